@@ -19,12 +19,14 @@ package apis
 
 import (
 	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
+	banv1alpha1 "github.com/rossigee/provider-discord/apis/ban/v1alpha1"
 	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
 	deduplicationv1alpha1 "github.com/rossigee/provider-discord/apis/deduplication/v1alpha1"
 	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
 	integrationv1alpha1 "github.com/rossigee/provider-discord/apis/integration/v1alpha1"
 	invitev1alpha1 "github.com/rossigee/provider-discord/apis/invite/v1alpha1"
 	memberv1alpha1 "github.com/rossigee/provider-discord/apis/member/v1alpha1"
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
 	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
 	userv1alpha1 "github.com/rossigee/provider-discord/apis/user/v1alpha1"
 	"github.com/rossigee/provider-discord/apis/v1alpha1"
@@ -49,6 +51,8 @@ func init() {
 		userv1alpha1.AddToScheme,
 		applicationv1alpha1.AddToScheme,
 		integrationv1alpha1.AddToScheme,
+		banv1alpha1.AddToScheme,
+		messagev1alpha1.AddToScheme,
 	)
 }
 
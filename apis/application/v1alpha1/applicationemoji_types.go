@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationEmojiParameters define an emoji owned by a Discord application.
+// +kubebuilder:validation:XValidation:rule="[has(self.image), has(self.imageSecretRef), has(self.imageUrl)].filter(x, x).size() == 1",message="exactly one of image, imageSecretRef and imageUrl is required"
+type ApplicationEmojiParameters struct {
+	// ApplicationID is the ID of the application that owns the emoji. Use
+	// "@me" for the bot's own application.
+	// +kubebuilder:validation:Required
+	ApplicationID string `json:"applicationId"`
+
+	// Name is the emoji's name, used as :name: in messages.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_]{2,32}$`
+	Name string `json:"name"`
+
+	// Image is the emoji image as a data URI, e.g.
+	// "data:image/png;base64,...". Discord cannot replace an emoji's image,
+	// so it cannot be changed once set.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="image is immutable"
+	Image string `json:"image,omitempty"`
+
+	// ImageSecretRef selects a key of a Secret in the resource's namespace
+	// that holds the emoji image, as a data URI or the raw image file. The
+	// emoji is uploaded again when the image in the Secret changes.
+	// +optional
+	ImageSecretRef *xpv1.LocalSecretKeySelector `json:"imageSecretRef,omitempty"`
+
+	// ImageURL is an https URL to download the emoji image from. The image
+	// is downloaded again at most hourly, and the emoji is uploaded again
+	// when it changes. Redirects must stay on https and public addresses.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https://`
+	ImageURL *string `json:"imageUrl,omitempty"`
+}
+
+// ApplicationEmojiObservation represents the observed state of an
+// application emoji.
+type ApplicationEmojiObservation struct {
+	// ID is the emoji's Discord ID.
+	ID string `json:"id,omitempty"`
+
+	// ApplicationID is the resolved ID of the application that owns the
+	// emoji.
+	ApplicationID string `json:"applicationId,omitempty"`
+
+	// Name is the emoji's name.
+	Name string `json:"name,omitempty"`
+
+	// Animated reports whether the emoji is animated.
+	Animated bool `json:"animated,omitempty"`
+
+	// Markdown is the message syntax that renders the emoji, e.g.
+	// "<:wave:123>", for use in command responses and messages.
+	Markdown string `json:"markdown,omitempty"`
+
+	// ImageSHA256 is the SHA-256 of the image last uploaded. Discord does
+	// not return an emoji's image, so it is compared with the SHA-256 of
+	// the declared image to tell whether the image changed.
+	ImageSHA256 string `json:"imageSha256,omitempty"`
+}
+
+// An ApplicationEmojiSpec defines the desired state of an ApplicationEmoji.
+type ApplicationEmojiSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider ApplicationEmojiParameters `json:"forProvider"`
+}
+
+// An ApplicationEmojiStatus represents the observed state of an
+// ApplicationEmoji.
+type ApplicationEmojiStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 ApplicationEmojiObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// An ApplicationEmoji is a managed resource that represents an emoji owned
+// by a Discord application. Application emojis can be used by the bot in
+// any guild without taking up guild emoji slots.
+// +kubebuilder:printcolumn:name="APP_ID",type="string",JSONPath=".spec.forProvider.applicationId"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".status.atProvider.name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type ApplicationEmoji struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationEmojiSpec   `json:"spec"`
+	Status ApplicationEmojiStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// ApplicationEmojiList contains a list of ApplicationEmojis.
+type ApplicationEmojiList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationEmoji `json:"items"`
+}
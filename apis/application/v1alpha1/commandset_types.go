@@ -0,0 +1,326 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CommandSetParameters define the complete set of an application's global
+// commands, or of its commands in a guild.
+type CommandSetParameters struct {
+	// ApplicationID is the ID of the application that owns the commands.
+	// Use "@me" for the bot's own application.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="applicationId is immutable"
+	ApplicationID string `json:"applicationId"`
+
+	// GuildID scopes the commands to a guild. Guild commands are available
+	// immediately and only in that guild; without it the set holds the
+	// application's global commands.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="guildId is immutable"
+	GuildID *string `json:"guildId,omitempty"`
+
+	// Commands are the application's commands in the scope. They replace
+	// the commands Discord has in a single bulk overwrite, so commands that
+	// are not listed are deleted.
+	// +optional
+	// +kubebuilder:validation:MaxItems=130
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=type
+	Commands []Command `json:"commands,omitempty"`
+}
+
+// CommandType is the type of an application command.
+// +kubebuilder:validation:Enum=ChatInput;User;Message
+type CommandType string
+
+// Command types.
+const (
+	CommandTypeChatInput CommandType = "ChatInput"
+	CommandTypeUser      CommandType = "User"
+	CommandTypeMessage   CommandType = "Message"
+)
+
+// A Command is a slash command, or a user or message context menu command.
+type Command struct {
+	// Type is the type of command: ChatInput for slash commands, User or
+	// Message for context menu commands.
+	// +optional
+	// +kubebuilder:default=ChatInput
+	Type CommandType `json:"type,omitempty"`
+
+	// Name is the command's name. Slash command names are lowercase.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+
+	// Description is shown with slash commands. Context menu commands have
+	// none.
+	// +optional
+	// +kubebuilder:validation:MaxLength=100
+	Description string `json:"description,omitempty"`
+
+	// Localizations translate the command's name and description, keyed by
+	// Discord locale, e.g. "de" or "pt-BR".
+	// +optional
+	// +kubebuilder:validation:MaxProperties=32
+	// +kubebuilder:validation:XValidation:rule="self.all(l, l in ['id', 'da', 'de', 'en-GB', 'en-US', 'es-ES', 'es-419', 'fr', 'hr', 'it', 'lt', 'hu', 'nl', 'no', 'pl', 'pt-BR', 'ro', 'fi', 'sv-SE', 'vi', 'tr', 'cs', 'el', 'bg', 'ru', 'uk', 'hi', 'th', 'zh-CN', 'ja', 'zh-TW', 'ko'])",message="localizations must be keyed by Discord locales"
+	Localizations map[string]CommandLocalization `json:"localizations,omitempty"`
+
+	// Options are the command's parameters, or its subcommands and
+	// subcommand groups.
+	// +optional
+	// +kubebuilder:validation:MaxItems=25
+	Options []CommandOption `json:"options,omitempty"`
+
+	// DefaultMemberPermissions is the permission bitset members need to use
+	// the command by default, e.g. "8" for administrators. "0" disables it
+	// for everyone but administrators.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	DefaultMemberPermissions *string `json:"defaultMemberPermissions,omitempty"`
+
+	// NSFW restricts the command to age-restricted channels.
+	// +optional
+	NSFW *bool `json:"nsfw,omitempty"`
+}
+
+// CommandOptionType is the type of a command option.
+// +kubebuilder:validation:Enum=SubCommand;SubCommandGroup;String;Integer;Boolean;User;Channel;Role;Mentionable;Number;Attachment
+type CommandOptionType string
+
+// Command option types.
+const (
+	CommandOptionSubCommand      CommandOptionType = "SubCommand"
+	CommandOptionSubCommandGroup CommandOptionType = "SubCommandGroup"
+	CommandOptionString          CommandOptionType = "String"
+	CommandOptionInteger         CommandOptionType = "Integer"
+	CommandOptionBoolean         CommandOptionType = "Boolean"
+	CommandOptionUser            CommandOptionType = "User"
+	CommandOptionChannel         CommandOptionType = "Channel"
+	CommandOptionRole            CommandOptionType = "Role"
+	CommandOptionMentionable     CommandOptionType = "Mentionable"
+	CommandOptionNumber          CommandOptionType = "Number"
+	CommandOptionAttachment      CommandOptionType = "Attachment"
+)
+
+// A CommandParameter is a parameter of a command or subcommand.
+type CommandParameter struct {
+	// Type is the type of the option.
+	// +kubebuilder:validation:Required
+	Type CommandOptionType `json:"type"`
+
+	// Name is the option's name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+
+	// Description is the option's description.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	Description string `json:"description"`
+
+	// Localizations translate the option's name and description, keyed by
+	// Discord locale.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=32
+	// +kubebuilder:validation:XValidation:rule="self.all(l, l in ['id', 'da', 'de', 'en-GB', 'en-US', 'es-ES', 'es-419', 'fr', 'hr', 'it', 'lt', 'hu', 'nl', 'no', 'pl', 'pt-BR', 'ro', 'fi', 'sv-SE', 'vi', 'tr', 'cs', 'el', 'bg', 'ru', 'uk', 'hi', 'th', 'zh-CN', 'ja', 'zh-TW', 'ko'])",message="localizations must be keyed by Discord locales"
+	Localizations map[string]CommandLocalization `json:"localizations,omitempty"`
+
+	// Required makes the parameter mandatory.
+	// +optional
+	Required *bool `json:"required,omitempty"`
+
+	// Choices are the values a String, Integer or Number parameter is
+	// limited to.
+	// +optional
+	// +kubebuilder:validation:MaxItems=25
+	Choices []CommandOptionChoice `json:"choices,omitempty"`
+
+	// ChannelTypes limits a Channel parameter to channels of these types.
+	// +optional
+	ChannelTypes []int `json:"channelTypes,omitempty"`
+
+	// MinValue is the smallest value of an Integer or Number parameter.
+	// +optional
+	MinValue *int64 `json:"minValue,omitempty"`
+
+	// MaxValue is the largest value of an Integer or Number parameter.
+	// +optional
+	MaxValue *int64 `json:"maxValue,omitempty"`
+
+	// MinLength is the shortest value of a String parameter.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=6000
+	MinLength *int `json:"minLength,omitempty"`
+
+	// MaxLength is the longest value of a String parameter.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=6000
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Autocomplete makes Discord ask the bot for suggestions as the
+	// parameter is typed. It cannot be combined with choices.
+	// +optional
+	Autocomplete *bool `json:"autocomplete,omitempty"`
+}
+
+// A CommandOptionChoice is a value a parameter is limited to.
+type CommandOptionChoice struct {
+	// Name is the choice's name shown to the user.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	Name string `json:"name"`
+
+	// Localizations translate the choice's name, keyed by Discord locale.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=32
+	// +kubebuilder:validation:XValidation:rule="self.all(l, l in ['id', 'da', 'de', 'en-GB', 'en-US', 'es-ES', 'es-419', 'fr', 'hr', 'it', 'lt', 'hu', 'nl', 'no', 'pl', 'pt-BR', 'ro', 'fi', 'sv-SE', 'vi', 'tr', 'cs', 'el', 'bg', 'ru', 'uk', 'hi', 'th', 'zh-CN', 'ja', 'zh-TW', 'ko'])",message="localizations must be keyed by Discord locales"
+	Localizations map[string]string `json:"localizations,omitempty"`
+
+	// Value is the choice's value, parsed as an integer or number for
+	// Integer and Number parameters.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=100
+	Value string `json:"value"`
+}
+
+// A CommandLocalization translates the name and description of a command
+// or option into a locale. Untranslated fields fall back to the default.
+type CommandLocalization struct {
+	// Name is the translated name.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Name *string `json:"name,omitempty"`
+
+	// Description is the translated description.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	Description *string `json:"description,omitempty"`
+}
+
+// A CommandSubOption is a parameter, or a subcommand of a subcommand group.
+type CommandSubOption struct {
+	CommandParameter `json:",inline"`
+
+	// Options are the parameters of a subcommand.
+	// +optional
+	// +kubebuilder:validation:MaxItems=25
+	Options []CommandParameter `json:"options,omitempty"`
+}
+
+// A CommandOption is a parameter, subcommand or subcommand group of a
+// command.
+type CommandOption struct {
+	CommandParameter `json:",inline"`
+
+	// Options are the parameters of a subcommand, or the subcommands of a
+	// subcommand group.
+	// +optional
+	// +kubebuilder:validation:MaxItems=25
+	Options []CommandSubOption `json:"options,omitempty"`
+}
+
+// CommandObservation is a command Discord has in the set's scope.
+type CommandObservation struct {
+	// ID is the command's Discord ID.
+	ID string `json:"id"`
+
+	// Name is the command's name.
+	Name string `json:"name"`
+
+	// Type is the command's type.
+	Type CommandType `json:"type,omitempty"`
+}
+
+// CommandSetObservation represents the observed commands of a CommandSet.
+type CommandSetObservation struct {
+	// ApplicationID is the resolved ID of the application that owns the
+	// commands.
+	ApplicationID string `json:"applicationId,omitempty"`
+
+	// Commands are the commands Discord has in the set's scope.
+	Commands []CommandObservation `json:"commands,omitempty"`
+
+	// CommandCount is the number of commands Discord has in the set's scope.
+	CommandCount int `json:"commandCount,omitempty"`
+}
+
+// A CommandSetSpec defines the desired state of a CommandSet.
+type CommandSetSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider CommandSetParameters `json:"forProvider"`
+}
+
+// A CommandSetStatus represents the observed state of a CommandSet.
+type CommandSetStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 CommandSetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A CommandSet is a managed resource that owns every command of a Discord
+// application, globally or in a guild, and replaces them in a single bulk
+// overwrite rather than one by one. Deleting a CommandSet deletes its
+// commands.
+// +kubebuilder:printcolumn:name="APP_ID",type="string",JSONPath=".spec.forProvider.applicationId"
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="COMMANDS",type="integer",JSONPath=".status.atProvider.commandCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type CommandSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CommandSetSpec   `json:"spec"`
+	Status CommandSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// CommandSetList contains a list of CommandSets.
+type CommandSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CommandSet `json:"items"`
+}
@@ -40,6 +40,10 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Application{},
 		&ApplicationList{},
+		&ApplicationEmoji{},
+		&ApplicationEmojiList{},
+		&CommandSet{},
+		&CommandSetList{},
 	)
 	return nil
 }
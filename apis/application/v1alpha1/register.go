@@ -29,3 +29,19 @@ var (
 	ApplicationKindAPIVersion   = ApplicationKind + "." + SchemeGroupVersion.String()
 	ApplicationGroupVersionKind = SchemeGroupVersion.WithKind(ApplicationKind)
 )
+
+// ApplicationEmoji type metadata.
+var (
+	ApplicationEmojiKind             = reflect.TypeOf(ApplicationEmoji{}).Name()
+	ApplicationEmojiGroupKind        = schema.GroupKind{Group: Group, Kind: ApplicationEmojiKind}
+	ApplicationEmojiKindAPIVersion   = ApplicationEmojiKind + "." + SchemeGroupVersion.String()
+	ApplicationEmojiGroupVersionKind = SchemeGroupVersion.WithKind(ApplicationEmojiKind)
+)
+
+// CommandSet type metadata.
+var (
+	CommandSetKind             = reflect.TypeOf(CommandSet{}).Name()
+	CommandSetGroupKind        = schema.GroupKind{Group: Group, Kind: CommandSetKind}
+	CommandSetKindAPIVersion   = CommandSetKind + "." + SchemeGroupVersion.String()
+	CommandSetGroupVersionKind = SchemeGroupVersion.WithKind(CommandSetKind)
+)
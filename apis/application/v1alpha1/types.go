@@ -73,6 +73,12 @@ type ApplicationParameters struct {
 	// Tags are tags describing the application
 	// +optional
 	Tags []string `json:"tags,omitempty"`
+
+	// InteractionsEndpointURL is the URL Discord sends interactions to
+	// instead of the gateway. Discord verifies the endpoint with PINGs
+	// before accepting it; an empty string removes it.
+	// +optional
+	InteractionsEndpointURL *string `json:"interactionsEndpointUrl,omitempty"`
 }
 
 // ApplicationObservation represents the observed state of a Discord application
@@ -157,13 +163,71 @@ type ApplicationObservation struct {
 
 	// CustomInstallURL is the custom URL for OAuth2 authorization
 	CustomInstallURL *string `json:"customInstallUrl,omitempty"`
+
+	// Owner is the user that owns the application. For team-owned
+	// applications Discord reports a placeholder user for the team.
+	Owner *ApplicationOwner `json:"owner,omitempty"`
+
+	// Team is the developer team that owns the application, if any
+	Team *ApplicationTeam `json:"team,omitempty"`
+}
+
+// ApplicationOwner identifies the user that owns an application
+type ApplicationOwner struct {
+	// ID is the owner's user ID
+	ID string `json:"id"`
+
+	// Username is the owner's username
+	Username string `json:"username,omitempty"`
+
+	// GlobalName is the owner's display name
+	GlobalName *string `json:"globalName,omitempty"`
+}
+
+// ApplicationTeam describes the developer team that owns an application
+type ApplicationTeam struct {
+	// ID is the team's ID
+	ID string `json:"id"`
+
+	// Name is the team's name
+	Name string `json:"name,omitempty"`
+
+	// OwnerUserID is the user ID of the team's owner
+	OwnerUserID string `json:"ownerUserId,omitempty"`
+
+	// Members are the team's members, including pending invitations
+	Members []ApplicationTeamMember `json:"members,omitempty"`
+}
+
+// ApplicationTeamMember is a member of an application's developer team
+type ApplicationTeamMember struct {
+	// UserID is the member's user ID
+	UserID string `json:"userId"`
+
+	// Username is the member's username
+	Username string `json:"username,omitempty"`
+
+	// Role is the member's role on the team: admin, developer or read_only
+	Role string `json:"role,omitempty"`
+
+	// MembershipState is Invited until the member accepts the invitation to
+	// the team, then Accepted
+	// +kubebuilder:validation:Enum=Invited;Accepted
+	MembershipState string `json:"membershipState,omitempty"`
 }
 
 // A ApplicationSpec defines the desired state of a Application.
 type ApplicationSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      ApplicationParameters `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider ApplicationParameters `json:"forProvider"`
 }
 
 // A ApplicationStatus represents the observed state of a Application.
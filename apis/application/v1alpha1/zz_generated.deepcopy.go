@@ -52,6 +52,149 @@ func (in *Application) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEmoji) DeepCopyInto(out *ApplicationEmoji) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEmoji.
+func (in *ApplicationEmoji) DeepCopy() *ApplicationEmoji {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationEmoji)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationEmoji) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEmojiList) DeepCopyInto(out *ApplicationEmojiList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationEmoji, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEmojiList.
+func (in *ApplicationEmojiList) DeepCopy() *ApplicationEmojiList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationEmojiList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationEmojiList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEmojiObservation) DeepCopyInto(out *ApplicationEmojiObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEmojiObservation.
+func (in *ApplicationEmojiObservation) DeepCopy() *ApplicationEmojiObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationEmojiObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEmojiParameters) DeepCopyInto(out *ApplicationEmojiParameters) {
+	*out = *in
+	if in.ImageSecretRef != nil {
+		in, out := &in.ImageSecretRef, &out.ImageSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	if in.ImageURL != nil {
+		in, out := &in.ImageURL, &out.ImageURL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEmojiParameters.
+func (in *ApplicationEmojiParameters) DeepCopy() *ApplicationEmojiParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationEmojiParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEmojiSpec) DeepCopyInto(out *ApplicationEmojiSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEmojiSpec.
+func (in *ApplicationEmojiSpec) DeepCopy() *ApplicationEmojiSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationEmojiSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEmojiStatus) DeepCopyInto(out *ApplicationEmojiStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEmojiStatus.
+func (in *ApplicationEmojiStatus) DeepCopy() *ApplicationEmojiStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationEmojiStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationList) DeepCopyInto(out *ApplicationList) {
 	*out = *in
@@ -187,6 +330,16 @@ func (in *ApplicationObservation) DeepCopyInto(out *ApplicationObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(ApplicationOwner)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Team != nil {
+		in, out := &in.Team, &out.Team
+		*out = new(ApplicationTeam)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationObservation.
@@ -199,6 +352,26 @@ func (in *ApplicationObservation) DeepCopy() *ApplicationObservation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationOwner) DeepCopyInto(out *ApplicationOwner) {
+	*out = *in
+	if in.GlobalName != nil {
+		in, out := &in.GlobalName, &out.GlobalName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationOwner.
+func (in *ApplicationOwner) DeepCopy() *ApplicationOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationParameters) DeepCopyInto(out *ApplicationParameters) {
 	*out = *in
@@ -257,6 +430,11 @@ func (in *ApplicationParameters) DeepCopyInto(out *ApplicationParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InteractionsEndpointURL != nil {
+		in, out := &in.InteractionsEndpointURL, &out.InteractionsEndpointURL
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationParameters.
@@ -278,6 +456,11 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 		*out = new(v2.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
@@ -307,3 +490,399 @@ func (in *ApplicationStatus) DeepCopy() *ApplicationStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationTeam) DeepCopyInto(out *ApplicationTeam) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]ApplicationTeamMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTeam.
+func (in *ApplicationTeam) DeepCopy() *ApplicationTeam {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationTeam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationTeamMember) DeepCopyInto(out *ApplicationTeamMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTeamMember.
+func (in *ApplicationTeamMember) DeepCopy() *ApplicationTeamMember {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationTeamMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Command) DeepCopyInto(out *Command) {
+	*out = *in
+	if in.Localizations != nil {
+		in, out := &in.Localizations, &out.Localizations
+		*out = make(map[string]CommandLocalization, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]CommandOption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultMemberPermissions != nil {
+		in, out := &in.DefaultMemberPermissions, &out.DefaultMemberPermissions
+		*out = new(string)
+		**out = **in
+	}
+	if in.NSFW != nil {
+		in, out := &in.NSFW, &out.NSFW
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Command.
+func (in *Command) DeepCopy() *Command {
+	if in == nil {
+		return nil
+	}
+	out := new(Command)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandLocalization) DeepCopyInto(out *CommandLocalization) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandLocalization.
+func (in *CommandLocalization) DeepCopy() *CommandLocalization {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandLocalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandObservation) DeepCopyInto(out *CommandObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandObservation.
+func (in *CommandObservation) DeepCopy() *CommandObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandOption) DeepCopyInto(out *CommandOption) {
+	*out = *in
+	in.CommandParameter.DeepCopyInto(&out.CommandParameter)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]CommandSubOption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandOption.
+func (in *CommandOption) DeepCopy() *CommandOption {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandOptionChoice) DeepCopyInto(out *CommandOptionChoice) {
+	*out = *in
+	if in.Localizations != nil {
+		in, out := &in.Localizations, &out.Localizations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandOptionChoice.
+func (in *CommandOptionChoice) DeepCopy() *CommandOptionChoice {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandOptionChoice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandParameter) DeepCopyInto(out *CommandParameter) {
+	*out = *in
+	if in.Localizations != nil {
+		in, out := &in.Localizations, &out.Localizations
+		*out = make(map[string]CommandLocalization, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Choices != nil {
+		in, out := &in.Choices, &out.Choices
+		*out = make([]CommandOptionChoice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ChannelTypes != nil {
+		in, out := &in.ChannelTypes, &out.ChannelTypes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinValue != nil {
+		in, out := &in.MinValue, &out.MinValue
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxValue != nil {
+		in, out := &in.MaxValue, &out.MaxValue
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinLength != nil {
+		in, out := &in.MinLength, &out.MinLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.Autocomplete != nil {
+		in, out := &in.Autocomplete, &out.Autocomplete
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandParameter.
+func (in *CommandParameter) DeepCopy() *CommandParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSet) DeepCopyInto(out *CommandSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSet.
+func (in *CommandSet) DeepCopy() *CommandSet {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CommandSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSetList) DeepCopyInto(out *CommandSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CommandSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSetList.
+func (in *CommandSetList) DeepCopy() *CommandSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CommandSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSetObservation) DeepCopyInto(out *CommandSetObservation) {
+	*out = *in
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]CommandObservation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSetObservation.
+func (in *CommandSetObservation) DeepCopy() *CommandSetObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSetObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSetParameters) DeepCopyInto(out *CommandSetParameters) {
+	*out = *in
+	if in.GuildID != nil {
+		in, out := &in.GuildID, &out.GuildID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]Command, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSetParameters.
+func (in *CommandSetParameters) DeepCopy() *CommandSetParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSetParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSetSpec) DeepCopyInto(out *CommandSetSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSetSpec.
+func (in *CommandSetSpec) DeepCopy() *CommandSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSetStatus) DeepCopyInto(out *CommandSetStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSetStatus.
+func (in *CommandSetStatus) DeepCopy() *CommandSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandSubOption) DeepCopyInto(out *CommandSubOption) {
+	*out = *in
+	in.CommandParameter.DeepCopyInto(&out.CommandParameter)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]CommandParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandSubOption.
+func (in *CommandSubOption) DeepCopy() *CommandSubOption {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandSubOption)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -12,3 +12,21 @@ func (l *ApplicationList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this ApplicationEmojiList.
+func (l *ApplicationEmojiList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this CommandSetList.
+func (l *CommandSetList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
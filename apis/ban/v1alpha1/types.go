@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BanListParameters defines the guild whose bans are observed
+type BanListParameters struct {
+	// GuildID is the ID of the Discord guild
+	// +kubebuilder:validation:Required
+	GuildID string `json:"guildId"`
+
+	// ExpectedUserIDs are the user IDs that are expected to be banned.
+	// Any of these missing from the guild's ban list are reported in
+	// status, e.g. to detect manual unbans.
+	// +optional
+	ExpectedUserIDs []string `json:"expectedUserIds,omitempty"`
+}
+
+// BanEntry is a single observed guild ban
+type BanEntry struct {
+	// UserID is the ID of the banned user
+	UserID string `json:"userId"`
+
+	// Username is the banned user's username
+	Username string `json:"username,omitempty"`
+
+	// Reason is the reason given for the ban
+	Reason *string `json:"reason,omitempty"`
+}
+
+// BanListObservation represents the observed bans of a Discord guild
+type BanListObservation struct {
+	// BanCount is the total number of bans in the guild
+	BanCount int `json:"banCount,omitempty"`
+
+	// Bans are the bans currently in place in the guild. They are omitted
+	// when the guild has more than 1000 bans, to keep the status within the
+	// size limit of a Kubernetes object.
+	Bans []BanEntry `json:"bans,omitempty"`
+
+	// BansOmitted is true when the guild has too many bans to list in
+	// bans. Such a guild's bans are read in full every poll.
+	BansOmitted bool `json:"bansOmitted,omitempty"`
+
+	// MissingUserIDs are expected user IDs that are not currently banned
+	MissingUserIDs []string `json:"missingUserIds,omitempty"`
+
+	// UnexpectedUserIDs are banned user IDs that are not listed in
+	// expectedUserIds. Only populated when expectedUserIds is set, and
+	// limited to the first 1000 found.
+	UnexpectedUserIDs []string `json:"unexpectedUserIds,omitempty"`
+
+	// LastAuditLogEntryID is the newest audit log entry reflected in bans.
+	// Later polls only read the ban and unban entries after it instead of
+	// listing every ban. Unset when the bot cannot view the audit log.
+	LastAuditLogEntryID string `json:"lastAuditLogEntryId,omitempty"`
+
+	// LastFullSyncTime is when every ban was last listed. The list is
+	// read in full again once a day, in case the audit log missed a change.
+	LastFullSyncTime *metav1.Time `json:"lastFullSyncTime,omitempty"`
+}
+
+// A BanListSpec defines the desired state of a BanList.
+type BanListSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider BanListParameters `json:"forProvider"`
+}
+
+// A BanListStatus represents the observed state of a BanList.
+type BanListStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 BanListObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A BanList is an observe-only managed resource that reports the bans of a
+// Discord guild. It never creates or removes bans.
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="BANS",type="integer",JSONPath=".status.atProvider.banCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type BanList struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BanListSpec   `json:"spec"`
+	Status BanListStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// BanListList contains a list of BanLists.
+type BanListList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BanList `json:"items"`
+}
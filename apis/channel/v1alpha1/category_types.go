@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CategoryParameters are the configurable fields of a Category.
+type CategoryParameters struct {
+	// Name is the name of the category channel.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	Name string `json:"name"`
+
+	// GuildID is the ID of the guild this category belongs to.
+	// +kubebuilder:validation:Required
+	GuildID string `json:"guildId"`
+
+	// Position is the sorting position of the category.
+	// +optional
+	Position *int `json:"position,omitempty"`
+
+	// PermissionOverwrites are applied to the category and every channel in
+	// it, keeping the channels synced with the category.
+	// +optional
+	PermissionOverwrites []PermissionOverwrite `json:"permissionOverwrites,omitempty"`
+
+	// Channels are the channels in the category, in display order.
+	// Uncategorized channels of the same name and type are moved in rather
+	// than duplicated. Channels in the category that are not listed are left
+	// alone.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Channels []CategoryChannel `json:"channels,omitempty"`
+
+	// AllowDelete allows deleting the category's channels when they have
+	// message history. Without it, deleting a Category whose channels have
+	// messages fails.
+	// +optional
+	AllowDelete *bool `json:"allowDelete,omitempty"`
+}
+
+// A CategoryChannel is a channel in a Category.
+type CategoryChannel struct {
+	// Name is the name of the channel.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	Name string `json:"name"`
+
+	// Type is the type of channel.
+	// 0 = Text, 2 = Voice, 5 = News, 13 = Stage Voice, 15 = Forum
+	// +optional
+	// +kubebuilder:validation:Enum=0;2;5;13;15
+	// +kubebuilder:default=0
+	Type int `json:"type,omitempty"`
+
+	// Topic is the channel topic (text channels only).
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Topic *string `json:"topic,omitempty"`
+
+	// NSFW indicates whether the channel is NSFW.
+	// +optional
+	NSFW *bool `json:"nsfw,omitempty"`
+}
+
+// CategoryObservation are the observable fields of a Category.
+type CategoryObservation struct {
+	// ID is the unique identifier of the category channel in Discord.
+	ID string `json:"id,omitempty"`
+
+	// Name is the current name of the category.
+	Name string `json:"name,omitempty"`
+
+	// Position is the sorting position of the category.
+	Position int `json:"position,omitempty"`
+
+	// Channels are the declared channels found in the category.
+	Channels []CategoryChannelObservation `json:"channels,omitempty"`
+}
+
+// CategoryChannelObservation is the observed state of a channel in a
+// Category.
+type CategoryChannelObservation struct {
+	// ID is the unique identifier of the channel in Discord.
+	ID string `json:"id"`
+
+	// Name is the current name of the channel.
+	Name string `json:"name"`
+
+	// Type is the type of channel.
+	Type int `json:"type,omitempty"`
+
+	// Position is the sorting position of the channel.
+	Position int `json:"position,omitempty"`
+}
+
+// A CategorySpec defines the desired state of a Category.
+type CategorySpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider CategoryParameters `json:"forProvider"`
+}
+
+// A CategoryStatus represents the observed state of a Category.
+type CategoryStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 CategoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A Category is a managed resource that represents a Discord category
+// channel together with the channels in it.
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="CATEGORY-ID",type="string",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type Category struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CategorySpec   `json:"spec"`
+	Status CategoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// CategoryList contains a list of Category
+type CategoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Category `json:"items"`
+}
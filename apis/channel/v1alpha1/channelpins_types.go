@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChannelPinsParameters declare the messages that must be pinned in a
+// channel.
+// +kubebuilder:validation:XValidation:rule="has(self.channelId) || has(self.channelRef)",message="channelId or channelRef is required"
+type ChannelPinsParameters struct {
+	// ChannelID is the ID of the channel.
+	// +optional
+	ChannelID *string `json:"channelId,omitempty"`
+
+	// ChannelRef names a Channel resource in the same namespace whose ID is
+	// used as ChannelID. Ignored when ChannelID is set.
+	// +optional
+	ChannelRef *xpv1.Reference `json:"channelRef,omitempty"`
+
+	// MessageIDs are the IDs of messages in the channel that must be pinned.
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=set
+	// +optional
+	MessageIDs []string `json:"messageIds,omitempty"`
+
+	// MessageRefs name ScheduledMessage resources in the same namespace
+	// whose posted messages must be pinned. Messages that have not been
+	// posted yet are pinned once they are.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	MessageRefs []xpv1.Reference `json:"messageRefs,omitempty"`
+
+	// Exclusive unpins every pinned message that is not declared here.
+	// +kubebuilder:default=false
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
+}
+
+// ChannelPinsObservation is the observed state of a channel's pins.
+type ChannelPinsObservation struct {
+	// ChannelID is the ID of the channel.
+	ChannelID string `json:"channelId,omitempty"`
+
+	// PinCount is the number of messages pinned in the channel.
+	PinCount int `json:"pinCount,omitempty"`
+
+	// PinLimit is the number of messages Discord allows to be pinned in a
+	// channel.
+	PinLimit int `json:"pinLimit,omitempty"`
+
+	// PinnedMessageIDs are the IDs of the messages pinned in the channel,
+	// most recently pinned first.
+	PinnedMessageIDs []string `json:"pinnedMessageIds,omitempty"`
+
+	// MissingMessageIDs are declared messages that are not pinned.
+	MissingMessageIDs []string `json:"missingMessageIds,omitempty"`
+
+	// UnexpectedMessageIDs are pinned messages that are not declared. They
+	// are unpinned when exclusive is true.
+	UnexpectedMessageIDs []string `json:"unexpectedMessageIds,omitempty"`
+
+	// UnavailableMessageIDs are declared messages that cannot be pinned
+	// because they were deleted or are in another channel.
+	UnavailableMessageIDs []string `json:"unavailableMessageIds,omitempty"`
+}
+
+// A ChannelPinsSpec defines the desired state of a ChannelPins.
+type ChannelPinsSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider ChannelPinsParameters `json:"forProvider"`
+}
+
+// A ChannelPinsStatus represents the observed state of a ChannelPins.
+type ChannelPinsStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 ChannelPinsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A ChannelPins is a managed resource that keeps declared messages pinned in
+// a Discord channel. Deleting it unpins the declared messages.
+// +kubebuilder:printcolumn:name="CHANNEL",type="string",JSONPath=".status.atProvider.channelId"
+// +kubebuilder:printcolumn:name="PINS",type="integer",JSONPath=".status.atProvider.pinCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type ChannelPins struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChannelPinsSpec   `json:"spec"`
+	Status ChannelPinsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// ChannelPinsList contains a list of ChannelPins
+type ChannelPinsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChannelPins `json:"items"`
+}
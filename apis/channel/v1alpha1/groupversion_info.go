@@ -40,6 +40,10 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Channel{},
 		&ChannelList{},
+		&Category{},
+		&CategoryList{},
+		&ChannelPins{},
+		&ChannelPinsList{},
 	)
 	return nil
 }
@@ -29,3 +29,19 @@ var (
 	ChannelKindAPIVersion   = ChannelKind + "." + SchemeGroupVersion.String()
 	ChannelGroupVersionKind = SchemeGroupVersion.WithKind(ChannelKind)
 )
+
+// Category type metadata.
+var (
+	CategoryKind             = reflect.TypeOf(Category{}).Name()
+	CategoryGroupKind        = schema.GroupKind{Group: Group, Kind: CategoryKind}
+	CategoryKindAPIVersion   = CategoryKind + "." + SchemeGroupVersion.String()
+	CategoryGroupVersionKind = SchemeGroupVersion.WithKind(CategoryKind)
+)
+
+// ChannelPins type metadata.
+var (
+	ChannelPinsKind             = reflect.TypeOf(ChannelPins{}).Name()
+	ChannelPinsGroupKind        = schema.GroupKind{Group: Group, Kind: ChannelPinsKind}
+	ChannelPinsKindAPIVersion   = ChannelPinsKind + "." + SchemeGroupVersion.String()
+	ChannelPinsGroupVersionKind = SchemeGroupVersion.WithKind(ChannelPinsKind)
+)
@@ -31,8 +31,11 @@ type ChannelParameters struct {
 
 	// Type is the type of channel.
 	// 0 = Text, 1 = DM, 2 = Voice, 3 = Group DM, 4 = Category, 5 = News, 10 = News Thread, 11 = Public Thread, 12 = Private Thread, 13 = Stage Voice, 15 = Forum
+	// Discord can only convert text and news channels into each other, so
+	// the type cannot otherwise be changed once set.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=0;2;4;5;13;15
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf || (self in [0, 5] && oldSelf in [0, 5])",message="type can only be changed between text (0) and news (5)"
 	Type int `json:"type"`
 
 	// GuildID is the ID of the guild this channel belongs to.
@@ -86,13 +89,94 @@ type ChannelParameters struct {
 	// +optional
 	PermissionOverwrites []PermissionOverwrite `json:"permissionOverwrites,omitempty"`
 
+	// Preset expands into the permission overwrites of a common layout,
+	// against @everyone and the preset roles:
+	// announcementsReadonly lets everyone read but only the preset roles post;
+	// staffOnly hides the channel from everyone but the preset roles;
+	// public makes the channel visible to everyone.
+	// Overwrites in permissionOverwrites for the same role or member replace
+	// the preset's.
+	// +optional
+	Preset *ChannelPreset `json:"preset,omitempty"`
+
+	// PresetRoleIDs are the IDs of the roles the preset grants access to.
+	// +optional
+	PresetRoleIDs []string `json:"presetRoleIds,omitempty"`
+
+	// PresetRoleRefs name the Roles the preset grants access to.
+	// +optional
+	PresetRoleRefs []xpv1.Reference `json:"presetRoleRefs,omitempty"`
+
 	// AllowDelete allows deletion of channels that have message history.
 	// Must be explicitly set to true when the channel has messages and an operator
 	// has reviewed and approved the deletion.
 	// +optional
 	AllowDelete *bool `json:"allowDelete,omitempty"`
+
+	// RecreatePolicy controls what happens when the channel is found to have
+	// been deleted in Discord. Recreate clears the external name and creates
+	// a new channel; MarkUnavailable leaves it deleted and reports the resource
+	// as unavailable until it is recreated manually or the external name is
+	// cleared.
+	// +optional
+	// +kubebuilder:validation:Enum=Recreate;MarkUnavailable
+	// +kubebuilder:default=Recreate
+	RecreatePolicy *RecreatePolicy `json:"recreatePolicy,omitempty"`
+
+	// ReportInvites lists the channel's active invites in
+	// status.atProvider.invites, for invite hygiene audits. Listing invites
+	// requires the bot to have the Manage Channels permission.
+	// +optional
+	ReportInvites *bool `json:"reportInvites,omitempty"`
+
+	// Flags are the channel flags to set. REQUIRE_TAG makes posts in a forum
+	// channel require a tag; HIDE_MEDIA_DOWNLOAD_OPTIONS hides the download
+	// options of media channel attachments. An empty list clears both flags;
+	// leaving it unset leaves the channel's flags alone.
+	// +optional
+	// +listType=set
+	Flags []ChannelFlag `json:"flags,omitempty"`
+
+	// SanitizeName transforms the name into one Discord accepts for every
+	// channel type before it is applied: lowercase ASCII letters, digits and
+	// dashes, with spaces, underscores, slashes and dots turned into dashes
+	// and other characters dropped. The applied name is reported in
+	// status.atProvider.sanitizedName when it differs from the declared one.
+	// +optional
+	SanitizeName *bool `json:"sanitizeName,omitempty"`
 }
 
+// ChannelFlag is a Discord channel flag.
+// +kubebuilder:validation:Enum=REQUIRE_TAG;HIDE_MEDIA_DOWNLOAD_OPTIONS
+type ChannelFlag string
+
+// Channel flags that can be managed.
+const (
+	ChannelFlagRequireTag               ChannelFlag = "REQUIRE_TAG"
+	ChannelFlagHideMediaDownloadOptions ChannelFlag = "HIDE_MEDIA_DOWNLOAD_OPTIONS"
+)
+
+// ChannelPreset is a common layout of channel permission overwrites.
+// +kubebuilder:validation:Enum=announcementsReadonly;staffOnly;public
+type ChannelPreset string
+
+// Channel presets.
+const (
+	ChannelPresetAnnouncementsReadonly ChannelPreset = "announcementsReadonly"
+	ChannelPresetStaffOnly             ChannelPreset = "staffOnly"
+	ChannelPresetPublic                ChannelPreset = "public"
+)
+
+// RecreatePolicy controls how a Channel that was deleted in Discord is
+// handled.
+type RecreatePolicy string
+
+// Channel recreate policies.
+const (
+	RecreatePolicyRecreate        RecreatePolicy = "Recreate"
+	RecreatePolicyMarkUnavailable RecreatePolicy = "MarkUnavailable"
+)
+
 // PermissionOverwrite represents a permission overwrite for a channel.
 type PermissionOverwrite struct {
 	// ID is the ID of the role or member to overwrite.
@@ -151,6 +235,9 @@ type ChannelObservation struct {
 	// DefaultAutoArchiveDuration is the default auto archive duration.
 	DefaultAutoArchiveDuration int `json:"defaultAutoArchiveDuration,omitempty"`
 
+	// Flags are the flags set on the channel.
+	Flags []string `json:"flags,omitempty"`
+
 	// LastMessageID is the ID of the last message sent in this channel.
 	LastMessageID string `json:"lastMessageId,omitempty"`
 
@@ -167,13 +254,54 @@ type ChannelObservation struct {
 	// Used to prevent accidental deletion of channels with valuable history.
 	// +optional
 	HasMessages *bool `json:"hasMessages,omitempty"`
+
+	// Invites are the channel's active invites, reported when
+	// spec.forProvider.reportInvites is true.
+	// +optional
+	Invites []ChannelInviteObservation `json:"invites,omitempty"`
+
+	// SanitizedName is the name applied in place of the declared name when
+	// spec.forProvider.sanitizeName transformed it.
+	// +optional
+	SanitizedName string `json:"sanitizedName,omitempty"`
+}
+
+// ChannelInviteObservation describes an active invite to a channel.
+type ChannelInviteObservation struct {
+	// Code is the invite code.
+	Code string `json:"code"`
+
+	// InviterID is the ID of the user who created the invite.
+	InviterID string `json:"inviterId,omitempty"`
+
+	// Uses is the number of times the invite has been used.
+	Uses int `json:"uses"`
+
+	// MaxUses is the number of uses allowed, or 0 for unlimited.
+	MaxUses int `json:"maxUses"`
+
+	// MaxAge is the invite's lifetime in seconds, or 0 for never expiring.
+	MaxAge int `json:"maxAge"`
+
+	// Temporary indicates whether the invite grants temporary membership.
+	Temporary bool `json:"temporary,omitempty"`
+
+	// ExpiresAt is when the invite expires, if it does.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 // A ChannelSpec defines the desired state of a Channel.
 type ChannelSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      ChannelParameters     `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider ChannelParameters `json:"forProvider"`
 }
 
 // A ChannelStatus represents the observed state of a Channel.
@@ -25,6 +25,208 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Category) DeepCopyInto(out *Category) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Category.
+func (in *Category) DeepCopy() *Category {
+	if in == nil {
+		return nil
+	}
+	out := new(Category)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Category) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryChannel) DeepCopyInto(out *CategoryChannel) {
+	*out = *in
+	if in.Topic != nil {
+		in, out := &in.Topic, &out.Topic
+		*out = new(string)
+		**out = **in
+	}
+	if in.NSFW != nil {
+		in, out := &in.NSFW, &out.NSFW
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryChannel.
+func (in *CategoryChannel) DeepCopy() *CategoryChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryChannelObservation) DeepCopyInto(out *CategoryChannelObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryChannelObservation.
+func (in *CategoryChannelObservation) DeepCopy() *CategoryChannelObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryChannelObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryList) DeepCopyInto(out *CategoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Category, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryList.
+func (in *CategoryList) DeepCopy() *CategoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CategoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryObservation) DeepCopyInto(out *CategoryObservation) {
+	*out = *in
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]CategoryChannelObservation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryObservation.
+func (in *CategoryObservation) DeepCopy() *CategoryObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryParameters) DeepCopyInto(out *CategoryParameters) {
+	*out = *in
+	if in.Position != nil {
+		in, out := &in.Position, &out.Position
+		*out = new(int)
+		**out = **in
+	}
+	if in.PermissionOverwrites != nil {
+		in, out := &in.PermissionOverwrites, &out.PermissionOverwrites
+		*out = make([]PermissionOverwrite, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]CategoryChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowDelete != nil {
+		in, out := &in.AllowDelete, &out.AllowDelete
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryParameters.
+func (in *CategoryParameters) DeepCopy() *CategoryParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategorySpec) DeepCopyInto(out *CategorySpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategorySpec.
+func (in *CategorySpec) DeepCopy() *CategorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CategorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryStatus) DeepCopyInto(out *CategoryStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryStatus.
+func (in *CategoryStatus) DeepCopy() *CategoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Channel) DeepCopyInto(out *Channel) {
 	*out = *in
@@ -52,6 +254,25 @@ func (in *Channel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelInviteObservation) DeepCopyInto(out *ChannelInviteObservation) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelInviteObservation.
+func (in *ChannelInviteObservation) DeepCopy() *ChannelInviteObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelInviteObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChannelList) DeepCopyInto(out *ChannelList) {
 	*out = *in
@@ -87,6 +308,11 @@ func (in *ChannelList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChannelObservation) DeepCopyInto(out *ChannelObservation) {
 	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CreatedAt != nil {
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = (*in).DeepCopy()
@@ -107,6 +333,13 @@ func (in *ChannelObservation) DeepCopyInto(out *ChannelObservation) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Invites != nil {
+		in, out := &in.Invites, &out.Invites
+		*out = make([]ChannelInviteObservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelObservation.
@@ -169,11 +402,48 @@ func (in *ChannelParameters) DeepCopyInto(out *ChannelParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Preset != nil {
+		in, out := &in.Preset, &out.Preset
+		*out = new(ChannelPreset)
+		**out = **in
+	}
+	if in.PresetRoleIDs != nil {
+		in, out := &in.PresetRoleIDs, &out.PresetRoleIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PresetRoleRefs != nil {
+		in, out := &in.PresetRoleRefs, &out.PresetRoleRefs
+		*out = make([]v2.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AllowDelete != nil {
 		in, out := &in.AllowDelete, &out.AllowDelete
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RecreatePolicy != nil {
+		in, out := &in.RecreatePolicy, &out.RecreatePolicy
+		*out = new(RecreatePolicy)
+		**out = **in
+	}
+	if in.ReportInvites != nil {
+		in, out := &in.ReportInvites, &out.ReportInvites
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]ChannelFlag, len(*in))
+		copy(*out, *in)
+	}
+	if in.SanitizeName != nil {
+		in, out := &in.SanitizeName, &out.SanitizeName
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelParameters.
@@ -186,6 +456,186 @@ func (in *ChannelParameters) DeepCopy() *ChannelParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPins) DeepCopyInto(out *ChannelPins) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPins.
+func (in *ChannelPins) DeepCopy() *ChannelPins {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChannelPins) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPinsList) DeepCopyInto(out *ChannelPinsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChannelPins, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPinsList.
+func (in *ChannelPinsList) DeepCopy() *ChannelPinsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPinsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChannelPinsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPinsObservation) DeepCopyInto(out *ChannelPinsObservation) {
+	*out = *in
+	if in.PinnedMessageIDs != nil {
+		in, out := &in.PinnedMessageIDs, &out.PinnedMessageIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingMessageIDs != nil {
+		in, out := &in.MissingMessageIDs, &out.MissingMessageIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnexpectedMessageIDs != nil {
+		in, out := &in.UnexpectedMessageIDs, &out.UnexpectedMessageIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnavailableMessageIDs != nil {
+		in, out := &in.UnavailableMessageIDs, &out.UnavailableMessageIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPinsObservation.
+func (in *ChannelPinsObservation) DeepCopy() *ChannelPinsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPinsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPinsParameters) DeepCopyInto(out *ChannelPinsParameters) {
+	*out = *in
+	if in.ChannelID != nil {
+		in, out := &in.ChannelID, &out.ChannelID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ChannelRef != nil {
+		in, out := &in.ChannelRef, &out.ChannelRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MessageIDs != nil {
+		in, out := &in.MessageIDs, &out.MessageIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MessageRefs != nil {
+		in, out := &in.MessageRefs, &out.MessageRefs
+		*out = make([]v2.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exclusive != nil {
+		in, out := &in.Exclusive, &out.Exclusive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPinsParameters.
+func (in *ChannelPinsParameters) DeepCopy() *ChannelPinsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPinsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPinsSpec) DeepCopyInto(out *ChannelPinsSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPinsSpec.
+func (in *ChannelPinsSpec) DeepCopy() *ChannelPinsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPinsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelPinsStatus) DeepCopyInto(out *ChannelPinsStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelPinsStatus.
+func (in *ChannelPinsStatus) DeepCopy() *ChannelPinsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelPinsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChannelSpec) DeepCopyInto(out *ChannelSpec) {
 	*out = *in
@@ -195,6 +645,11 @@ func (in *ChannelSpec) DeepCopyInto(out *ChannelSpec) {
 		*out = new(v2.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
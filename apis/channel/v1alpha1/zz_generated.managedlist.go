@@ -4,6 +4,15 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 
+// GetItems of this CategoryList.
+func (l *CategoryList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this ChannelList.
 func (l *ChannelList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -12,3 +21,12 @@ func (l *ChannelList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this ChannelPinsList.
+func (l *ChannelPinsList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -21,4 +21,6 @@ limitations under the License.
 
 //go:generate go run -tags generate github.com/crossplane/crossplane-tools/cmd/angryjet generate-methodsets --header-file=../hack/boilerplate.go.txt ./...
 
+//go:generate go run -tags generate sigs.k8s.io/controller-tools/cmd/controller-gen webhook paths=../internal/admission/... output:webhook:artifacts:config=../package/webhookconfigurations
+
 package apis
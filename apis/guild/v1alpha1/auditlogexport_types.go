@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditLogConfigMapSink writes each export to a new ConfigMap in the
+// AuditLogExport's namespace.
+type AuditLogConfigMapSink struct {
+	// NamePrefix prefixes the names of the ConfigMaps, which end with the ID
+	// of the newest entry they hold.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=200
+	NamePrefix string `json:"namePrefix"`
+
+	// MaxConfigMaps is how many ConfigMaps to keep. The oldest are deleted
+	// once an export writes more.
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	MaxConfigMaps int32 `json:"maxConfigMaps,omitempty"`
+}
+
+// AuditLogWebhookSink POSTs each export as JSON to an HTTP endpoint.
+type AuditLogWebhookSink struct {
+	// URLSecretRef selects the key of a Secret in the AuditLogExport's
+	// namespace that holds the endpoint URL.
+	URLSecretRef xpv1.LocalSecretKeySelector `json:"urlSecretRef"`
+}
+
+// AuditLogS3Sink uploads each export as an object to an S3-compatible
+// bucket.
+type AuditLogS3Sink struct {
+	// Endpoint is the base URL of the S3-compatible service, e.g.
+	// https://s3.eu-west-1.amazonaws.com. Buckets are addressed by path.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// Region is the region requests are signed for.
+	// +kubebuilder:default="us-east-1"
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// Bucket is the name of the bucket.
+	// +kubebuilder:validation:MinLength=3
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the object keys, which end with the ID of the
+	// newest entry they hold.
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+
+	// CredentialsSecretRef names a Secret in the AuditLogExport's namespace
+	// with accessKeyId and secretAccessKey keys.
+	CredentialsSecretRef xpv1.LocalSecretReference `json:"credentialsSecretRef"`
+}
+
+// AuditLogSink is where exported audit log entries are written. Exactly one
+// sink must be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.configMap) ? 1 : 0) + (has(self.webhook) ? 1 : 0) + (has(self.s3) ? 1 : 0) == 1",message="exactly one of configMap, webhook or s3 must be set"
+type AuditLogSink struct {
+	// ConfigMap writes exports to ConfigMaps, suited to small guilds.
+	// +optional
+	ConfigMap *AuditLogConfigMapSink `json:"configMap,omitempty"`
+
+	// Webhook POSTs exports to an HTTP endpoint.
+	// +optional
+	Webhook *AuditLogWebhookSink `json:"webhook,omitempty"`
+
+	// S3 uploads exports to an S3-compatible bucket.
+	// +optional
+	S3 *AuditLogS3Sink `json:"s3,omitempty"`
+}
+
+// AuditLogExportParameters define the guild whose audit log is exported and
+// where it is written.
+type AuditLogExportParameters struct {
+	// GuildID is the ID of the Discord guild.
+	// +kubebuilder:validation:Required
+	GuildID string `json:"guildId"`
+
+	// Interval is how often new audit log entries are exported.
+	// +kubebuilder:default="1h"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Sink is where the entries are written.
+	// +kubebuilder:validation:Required
+	Sink AuditLogSink `json:"sink"`
+}
+
+// AuditLogExportObservation is the observed progress of an audit log
+// export.
+type AuditLogExportObservation struct {
+	// LastEntryID is the ID of the newest exported entry. Later exports
+	// start after it.
+	LastEntryID string `json:"lastEntryId,omitempty"`
+
+	// LastExportTime is when the export last caught up with the audit log.
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+
+	// LastExportCount is the number of entries the last export wrote.
+	LastExportCount int `json:"lastExportCount,omitempty"`
+
+	// ExportedCount is the total number of entries written.
+	ExportedCount int64 `json:"exportedCount,omitempty"`
+
+	// LastObject names the ConfigMap or object key the last entries were
+	// written to, if any.
+	LastObject string `json:"lastObject,omitempty"`
+}
+
+// An AuditLogExportSpec defines the desired state of an AuditLogExport.
+type AuditLogExportSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider AuditLogExportParameters `json:"forProvider"`
+}
+
+// An AuditLogExportStatus represents the observed state of an
+// AuditLogExport.
+type AuditLogExportStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 AuditLogExportObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// An AuditLogExport is a managed resource that periodically copies new
+// entries from a guild's audit log to a sink, keeping them beyond the 45
+// days Discord retains them. Deleting it stops the export and leaves the
+// written entries in place.
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="EXPORTED",type="integer",JSONPath=".status.atProvider.exportedCount"
+// +kubebuilder:printcolumn:name="LAST-EXPORT",type="date",JSONPath=".status.atProvider.lastExportTime"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type AuditLogExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AuditLogExportSpec   `json:"spec"`
+	Status AuditLogExportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// AuditLogExportList contains a list of AuditLogExports.
+type AuditLogExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AuditLogExport `json:"items"`
+}
@@ -40,6 +40,10 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Guild{},
 		&GuildList{},
+		&AuditLogExport{},
+		&AuditLogExportList{},
+		&GuildSet{},
+		&GuildSetList{},
 	)
 	return nil
 }
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GuildSet labels set on the Channels and Roles a GuildSet stamps out.
+const (
+	// LabelGuildSet names the GuildSet that stamped a resource.
+	LabelGuildSet = "guild.discord.crossplane.io/guildset"
+
+	// LabelGuild names the Guild a resource was stamped into.
+	LabelGuild = "guild.discord.crossplane.io/guild"
+)
+
+// A GuildSetChannel is a Channel template stamped into every guild of a
+// GuildSet.
+type GuildSetChannel struct {
+	// Name identifies the template within the GuildSet. The Channel stamped
+	// into a guild is named <guildset>-<guild>-<name>, shortened and ending
+	// in a hash when that is too long for Kubernetes. A template is not
+	// stamped into a guild where its name is also another's.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// ForProvider is the Channel's spec.forProvider. Its guildId is set to
+	// the ID of each matching guild.
+	ForProvider channelv1alpha1.ChannelParameters `json:"forProvider"`
+}
+
+// A GuildSetRole is a Role template stamped into every guild of a GuildSet.
+type GuildSetRole struct {
+	// Name identifies the template within the GuildSet. The Role stamped
+	// into a guild is named <guildset>-<guild>-<name>, shortened and ending
+	// in a hash when that is too long for Kubernetes. A template is not
+	// stamped into a guild where its name is also another's.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// ForProvider is the Role's spec.forProvider. Its guildId is set to the
+	// ID of each matching guild.
+	ForProvider rolev1alpha1.RoleParameters `json:"forProvider"`
+}
+
+// A GuildSetSpec defines the guilds of a GuildSet and what to stamp into
+// each of them.
+type GuildSetSpec struct {
+	// GuildSelector selects the Guilds in the GuildSet's namespace to stamp
+	// the templates into. An empty selector selects every Guild.
+	GuildSelector metav1.LabelSelector `json:"guildSelector"`
+
+	// Channels are stamped into every selected guild.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Channels []GuildSetChannel `json:"channels,omitempty"`
+
+	// Roles are stamped into every selected guild.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Roles []GuildSetRole `json:"roles,omitempty"`
+}
+
+// A GuildSetStatus reports the guilds a GuildSet stamps into.
+type GuildSetStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// Guilds are the names of the selected Guilds the templates were
+	// stamped into.
+	// +optional
+	Guilds []string `json:"guilds,omitempty"`
+
+	// PendingGuilds are the names of selected Guilds that have no Discord
+	// guild ID yet. They are stamped once their guild exists, and keep the
+	// resources stamped into them meanwhile.
+	// +optional
+	PendingGuilds []string `json:"pendingGuilds,omitempty"`
+
+	// ResourceCount is the number of Channels and Roles the GuildSet owns.
+	// +optional
+	ResourceCount int `json:"resourceCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A GuildSet stamps Channel and Role templates into every Guild matching a
+// label selector, for organizations running many identical guilds. It owns
+// the Channels and Roles it creates: they follow template changes, appear
+// when a Guild starts matching, and are deleted when a Guild stops matching,
+// a template is removed or the GuildSet is deleted. They use the Guild's
+// ProviderConfig and credentials.
+// +kubebuilder:printcolumn:name="RESOURCES",type="integer",JSONPath=".status.resourceCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,discord}
+type GuildSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GuildSetSpec   `json:"spec"`
+	Status GuildSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// GuildSetList contains a list of GuildSets.
+type GuildSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GuildSet `json:"items"`
+}
@@ -29,3 +29,19 @@ var (
 	GuildKindAPIVersion   = GuildKind + "." + SchemeGroupVersion.String()
 	GuildGroupVersionKind = SchemeGroupVersion.WithKind(GuildKind)
 )
+
+// AuditLogExport type metadata.
+var (
+	AuditLogExportKind             = reflect.TypeOf(AuditLogExport{}).Name()
+	AuditLogExportGroupKind        = schema.GroupKind{Group: Group, Kind: AuditLogExportKind}
+	AuditLogExportKindAPIVersion   = AuditLogExportKind + "." + SchemeGroupVersion.String()
+	AuditLogExportGroupVersionKind = SchemeGroupVersion.WithKind(AuditLogExportKind)
+)
+
+// GuildSet type metadata.
+var (
+	GuildSetKind             = reflect.TypeOf(GuildSet{}).Name()
+	GuildSetGroupKind        = schema.GroupKind{Group: Group, Kind: GuildSetKind}
+	GuildSetKindAPIVersion   = GuildSetKind + "." + SchemeGroupVersion.String()
+	GuildSetGroupVersionKind = SchemeGroupVersion.WithKind(GuildSetKind)
+)
@@ -75,6 +75,177 @@ type GuildParameters struct {
 	// SystemChannelFlags are the system channel flags.
 	// +optional
 	SystemChannelFlags *int `json:"systemChannelFlags,omitempty"`
+
+	// MFALevel is the two-factor authentication requirement for moderation
+	// actions. Changing it requires the bot to own the guild.
+	// 0 = None, 1 = Elevated
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	MFALevel *int `json:"mfaLevel,omitempty"`
+
+	// PreferredLocale is the guild's preferred locale, used for its Discovery
+	// listing and for notices Discord sends to the community.
+	// +optional
+	// +kubebuilder:validation:Enum=id;da;de;en-GB;en-US;es-ES;es-419;fr;hr;it;lt;hu;nl;no;pl;pt-BR;ro;fi;sv-SE;vi;tr;cs;el;bg;ru;uk;hi;th;zh-CN;ja;zh-TW;ko
+	PreferredLocale *string `json:"preferredLocale,omitempty"`
+
+	// Description is the guild's description, shown in Discovery and on
+	// invites. Requires the COMMUNITY feature.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Banner is the guild's banner image as a data URI, e.g.
+	// data:image/png;base64,.... Requires the BANNER feature, unlocked at
+	// server boost level 2. The image is uploaded again only when this value
+	// changes or the banner is removed in Discord.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^data:image/(png|jpeg|gif);base64,`
+	Banner *string `json:"banner,omitempty"`
+
+	// DiscoverySplash is the guild's Discovery splash image as a data URI.
+	// Requires the DISCOVERABLE feature. The image is uploaded again only
+	// when this value changes or the splash is removed in Discord.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^data:image/(png|jpeg);base64,`
+	DiscoverySplash *string `json:"discoverySplash,omitempty"`
+
+	// PremiumProgressBarEnabled shows the server boost progress bar.
+	// +optional
+	PremiumProgressBarEnabled *bool `json:"premiumProgressBarEnabled,omitempty"`
+
+	// OwnerID is the ID of the user who should own the guild. Ownership can
+	// only be transferred by the current owner, so the bot must own the
+	// guild, and only when AllowOwnershipTransfer is true.
+	// +optional
+	OwnerID *string `json:"ownerId,omitempty"`
+
+	// OwnerRef names a User resource in the guild's namespace whose ID is
+	// used as OwnerID. Ignored when OwnerID is set.
+	// +optional
+	OwnerRef *xpv1.Reference `json:"ownerRef,omitempty"`
+
+	// AllowOwnershipTransfer confirms that the guild may be transferred to
+	// the owner set by OwnerID or OwnerRef. Transfers cannot be undone by the
+	// bot once it no longer owns the guild.
+	// +optional
+	AllowOwnershipTransfer *bool `json:"allowOwnershipTransfer,omitempty"`
+
+	// DeletionMode controls what deleting the resource does in Discord.
+	// Delete deletes the guild, which only its owner can do; Leave makes the
+	// bot leave the guild instead, for guilds the bot does not own.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Leave
+	// +kubebuilder:default=Delete
+	DeletionMode *DeletionMode `json:"deletionMode,omitempty"`
+
+	// PrimaryInvite configures a permanent invite managed with the guild and
+	// published as its join link when the guild has no vanity URL. A
+	// permanent invite the bot already created in the channel is adopted
+	// rather than another created. The invite is checked hourly, so one
+	// revoked in Discord may take up to an hour to be replaced.
+	// +optional
+	PrimaryInvite *PrimaryInviteParameters `json:"primaryInvite,omitempty"`
+
+	// MembershipScreening configures the form new members must complete
+	// before they can talk in the guild. Requires the COMMUNITY feature.
+	// +optional
+	MembershipScreening *MembershipScreeningParameters `json:"membershipScreening,omitempty"`
+
+	// AlertOnMemberCountBelow raises the MemberCountThreshold condition, with
+	// a warning event, when the guild's approximate member count drops below
+	// this value.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AlertOnMemberCountBelow *int `json:"alertOnMemberCountBelow,omitempty"`
+
+	// AlertOnMemberCountAbove raises the MemberCountThreshold condition, with
+	// a warning event, when the guild's approximate member count rises above
+	// this value.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AlertOnMemberCountAbove *int `json:"alertOnMemberCountAbove,omitempty"`
+
+	// RestoreFromTemplate compares the guild's roles and channels with a
+	// Discord guild template, reporting the changes that would restore them
+	// in status.atProvider.templateRestore. The changes are only made once
+	// their plan is approved.
+	// +optional
+	RestoreFromTemplate *TemplateRestoreParameters `json:"restoreFromTemplate,omitempty"`
+}
+
+// DeletionMode controls how a Guild is decommissioned.
+type DeletionMode string
+
+// Guild deletion modes.
+const (
+	DeletionModeDelete DeletionMode = "Delete"
+	DeletionModeLeave  DeletionMode = "Leave"
+)
+
+// PrimaryInviteParameters configure a guild's primary invite.
+type PrimaryInviteParameters struct {
+	// ChannelID is the ID of the channel the invite leads to.
+	// +kubebuilder:validation:Required
+	ChannelID string `json:"channelId"`
+}
+
+// MembershipScreeningParameters configure a guild's membership screening,
+// also known as the member verification gate.
+type MembershipScreeningParameters struct {
+	// Enabled makes new members complete the form before they can talk.
+	// +kubebuilder:validation:Required
+	Enabled bool `json:"enabled"`
+
+	// Description is shown to new members above the form.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// FormFields are the fields of the form, in order. When set they
+	// replace every field of the form in Discord.
+	// +optional
+	FormFields []MembershipScreeningField `json:"formFields,omitempty"`
+}
+
+// A MembershipScreeningField is a field of a membership screening form.
+type MembershipScreeningField struct {
+	// Type is the kind of field. TERMS asks new members to agree to Rules;
+	// MULTIPLE_CHOICE offers Choices.
+	// +kubebuilder:validation:Enum=TERMS;TEXT_INPUT;PARAGRAPH;MULTIPLE_CHOICE
+	Type string `json:"type"`
+
+	// Label is the field's title or question.
+	Label string `json:"label"`
+
+	// Rules are the server rules new members agree to in a TERMS field.
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+
+	// Choices are the answers offered by a MULTIPLE_CHOICE field.
+	// +optional
+	Choices []string `json:"choices,omitempty"`
+
+	// Required makes new members answer the field.
+	// +optional
+	// +kubebuilder:default=true
+	Required *bool `json:"required,omitempty"`
+}
+
+// TemplateRestoreParameters restore a guild's roles and channels from a
+// Discord guild template.
+type TemplateRestoreParameters struct {
+	// Code is the code of the guild template, as in
+	// https://discord.new/<code>.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Code string `json:"code"`
+
+	// ApprovedPlan is the planId reported in
+	// status.atProvider.templateRestore that may be applied. The plan ID
+	// changes with the template and the guild, so an approval never applies
+	// to changes that were not reviewed.
+	// +optional
+	ApprovedPlan *string `json:"approvedPlan,omitempty"`
 }
 
 // GuildObservation are the observable fields of a Guild.
@@ -121,6 +292,67 @@ type GuildObservation struct {
 	// SystemChannelFlags are the system channel flags.
 	SystemChannelFlags int `json:"systemChannelFlags,omitempty"`
 
+	// MFALevel is the two-factor authentication requirement for moderation.
+	MFALevel int `json:"mfaLevel,omitempty"`
+
+	// PreferredLocale is the guild's preferred locale.
+	PreferredLocale string `json:"preferredLocale,omitempty"`
+
+	// Description is the guild's description.
+	Description string `json:"description,omitempty"`
+
+	// Banner is the hash of the guild's banner image.
+	Banner string `json:"banner,omitempty"`
+
+	// BannerChecksum is the SHA-256 checksum of the banner image data last
+	// uploaded by the provider.
+	BannerChecksum string `json:"bannerChecksum,omitempty"`
+
+	// DiscoverySplash is the hash of the guild's Discovery splash image.
+	DiscoverySplash string `json:"discoverySplash,omitempty"`
+
+	// DiscoverySplashChecksum is the SHA-256 checksum of the Discovery
+	// splash image data last uploaded by the provider.
+	DiscoverySplashChecksum string `json:"discoverySplashChecksum,omitempty"`
+
+	// PremiumProgressBarEnabled indicates whether the server boost progress
+	// bar is shown.
+	PremiumProgressBarEnabled bool `json:"premiumProgressBarEnabled,omitempty"`
+
+	// VanityURLCode is the guild's vanity invite code, if it has one.
+	VanityURLCode string `json:"vanityUrlCode,omitempty"`
+
+	// PrimaryInviteCode is the code of the primary invite created for the
+	// guild.
+	PrimaryInviteCode string `json:"primaryInviteCode,omitempty"`
+
+	// InviteURL is the guild's canonical join link: its vanity URL if it has
+	// one, otherwise its primary invite.
+	InviteURL string `json:"inviteUrl,omitempty"`
+
+	// PremiumTier is the guild's server boost level, from 0 to 3.
+	PremiumTier int `json:"premiumTier,omitempty"`
+
+	// Emojis reports static custom emoji slot usage.
+	Emojis *SlotUsage `json:"emojis,omitempty"`
+
+	// AnimatedEmojis reports animated custom emoji slot usage.
+	AnimatedEmojis *SlotUsage `json:"animatedEmojis,omitempty"`
+
+	// Stickers reports custom sticker slot usage.
+	Stickers *SlotUsage `json:"stickers,omitempty"`
+
+	// Widget reports the guild's public widget, if it is enabled.
+	Widget *WidgetObservation `json:"widget,omitempty"`
+
+	// MembershipScreening reports the guild's membership screening, if it
+	// is configured.
+	MembershipScreening *MembershipScreeningObservation `json:"membershipScreening,omitempty"`
+
+	// TemplateRestore reports the changes restoring the guild from its
+	// template would make, if restoreFromTemplate is set.
+	TemplateRestore *TemplateRestoreObservation `json:"templateRestore,omitempty"`
+
 	// CreatedAt is the timestamp when the guild was created.
 	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
 
@@ -128,11 +360,71 @@ type GuildObservation struct {
 	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
 }
 
+// SlotUsage reports how many of a guild's slots for a kind of asset are used.
+// The limit depends on the guild's premium tier.
+type SlotUsage struct {
+	// Used is the number of slots in use.
+	Used int `json:"used"`
+
+	// Limit is the number of slots available at the guild's premium tier.
+	Limit int `json:"limit"`
+}
+
+// WidgetObservation reports the public values of a guild's widget, which
+// are readable without privileged intents.
+type WidgetObservation struct {
+	// PresenceCount is the number of members online.
+	PresenceCount int `json:"presenceCount"`
+
+	// InstantInvite is the widget's invite link, if its invite channel is
+	// set.
+	InstantInvite string `json:"instantInvite,omitempty"`
+
+	// ImageURL is the URL of the widget's PNG image.
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// MembershipScreeningObservation reports a guild's membership screening.
+type MembershipScreeningObservation struct {
+	// Enabled reports whether new members must complete the form.
+	Enabled bool `json:"enabled"`
+
+	// Version is when the form was last changed.
+	Version string `json:"version,omitempty"`
+
+	// FormFieldCount is the number of fields in the form.
+	FormFieldCount int `json:"formFieldCount,omitempty"`
+}
+
+// TemplateRestoreObservation reports the plan restoring a guild from its
+// template.
+type TemplateRestoreObservation struct {
+	// Code is the code of the template the plan was made from.
+	Code string `json:"code"`
+
+	// PlanID identifies the changes below. Set restoreFromTemplate's
+	// approvedPlan to it to apply them.
+	PlanID string `json:"planId,omitempty"`
+
+	// Changes are the changes the plan makes, in the order they are made.
+	Changes []string `json:"changes,omitempty"`
+
+	// LastAppliedPlanID is the ID of the plan last applied.
+	LastAppliedPlanID string `json:"lastAppliedPlanId,omitempty"`
+}
+
 // A GuildSpec defines the desired state of a Guild.
 type GuildSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      GuildParameters       `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider GuildParameters `json:"forProvider"`
 }
 
 // A GuildStatus represents the observed state of a Guild.
@@ -148,6 +440,7 @@ type GuildStatus struct {
 // +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
 // +kubebuilder:printcolumn:name="GUILD-ID",type="string",JSONPath=".status.atProvider.id"
 // +kubebuilder:printcolumn:name="MEMBERS",type="integer",JSONPath=".status.atProvider.memberCount"
+// +kubebuilder:printcolumn:name="INVITE",type="string",JSONPath=".status.atProvider.inviteUrl",priority=1
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
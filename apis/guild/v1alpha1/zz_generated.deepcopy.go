@@ -22,9 +22,241 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogConfigMapSink) DeepCopyInto(out *AuditLogConfigMapSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogConfigMapSink.
+func (in *AuditLogConfigMapSink) DeepCopy() *AuditLogConfigMapSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogConfigMapSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogExport) DeepCopyInto(out *AuditLogExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogExport.
+func (in *AuditLogExport) DeepCopy() *AuditLogExport {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuditLogExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogExportList) DeepCopyInto(out *AuditLogExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AuditLogExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogExportList.
+func (in *AuditLogExportList) DeepCopy() *AuditLogExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuditLogExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogExportObservation) DeepCopyInto(out *AuditLogExportObservation) {
+	*out = *in
+	if in.LastExportTime != nil {
+		in, out := &in.LastExportTime, &out.LastExportTime
+		*out = new(v1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogExportObservation.
+func (in *AuditLogExportObservation) DeepCopy() *AuditLogExportObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogExportObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogExportParameters) DeepCopyInto(out *AuditLogExportParameters) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.Sink.DeepCopyInto(&out.Sink)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogExportParameters.
+func (in *AuditLogExportParameters) DeepCopy() *AuditLogExportParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogExportParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogExportSpec) DeepCopyInto(out *AuditLogExportSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogExportSpec.
+func (in *AuditLogExportSpec) DeepCopy() *AuditLogExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogExportStatus) DeepCopyInto(out *AuditLogExportStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogExportStatus.
+func (in *AuditLogExportStatus) DeepCopy() *AuditLogExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogS3Sink) DeepCopyInto(out *AuditLogS3Sink) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogS3Sink.
+func (in *AuditLogS3Sink) DeepCopy() *AuditLogS3Sink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogS3Sink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogSink) DeepCopyInto(out *AuditLogSink) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(AuditLogConfigMapSink)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditLogWebhookSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(AuditLogS3Sink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogSink.
+func (in *AuditLogSink) DeepCopy() *AuditLogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogWebhookSink) DeepCopyInto(out *AuditLogWebhookSink) {
+	*out = *in
+	out.URLSecretRef = in.URLSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogWebhookSink.
+func (in *AuditLogWebhookSink) DeepCopy() *AuditLogWebhookSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogWebhookSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Guild) DeepCopyInto(out *Guild) {
 	*out = *in
@@ -92,6 +324,36 @@ func (in *GuildObservation) DeepCopyInto(out *GuildObservation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Emojis != nil {
+		in, out := &in.Emojis, &out.Emojis
+		*out = new(SlotUsage)
+		**out = **in
+	}
+	if in.AnimatedEmojis != nil {
+		in, out := &in.AnimatedEmojis, &out.AnimatedEmojis
+		*out = new(SlotUsage)
+		**out = **in
+	}
+	if in.Stickers != nil {
+		in, out := &in.Stickers, &out.Stickers
+		*out = new(SlotUsage)
+		**out = **in
+	}
+	if in.Widget != nil {
+		in, out := &in.Widget, &out.Widget
+		*out = new(WidgetObservation)
+		**out = **in
+	}
+	if in.MembershipScreening != nil {
+		in, out := &in.MembershipScreening, &out.MembershipScreening
+		*out = new(MembershipScreeningObservation)
+		**out = **in
+	}
+	if in.TemplateRestore != nil {
+		in, out := &in.TemplateRestore, &out.TemplateRestore
+		*out = new(TemplateRestoreObservation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CreatedAt != nil {
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = (*in).DeepCopy()
@@ -160,6 +422,81 @@ func (in *GuildParameters) DeepCopyInto(out *GuildParameters) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MFALevel != nil {
+		in, out := &in.MFALevel, &out.MFALevel
+		*out = new(int)
+		**out = **in
+	}
+	if in.PreferredLocale != nil {
+		in, out := &in.PreferredLocale, &out.PreferredLocale
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Banner != nil {
+		in, out := &in.Banner, &out.Banner
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiscoverySplash != nil {
+		in, out := &in.DiscoverySplash, &out.DiscoverySplash
+		*out = new(string)
+		**out = **in
+	}
+	if in.PremiumProgressBarEnabled != nil {
+		in, out := &in.PremiumProgressBarEnabled, &out.PremiumProgressBarEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OwnerID != nil {
+		in, out := &in.OwnerID, &out.OwnerID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OwnerRef != nil {
+		in, out := &in.OwnerRef, &out.OwnerRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowOwnershipTransfer != nil {
+		in, out := &in.AllowOwnershipTransfer, &out.AllowOwnershipTransfer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeletionMode != nil {
+		in, out := &in.DeletionMode, &out.DeletionMode
+		*out = new(DeletionMode)
+		**out = **in
+	}
+	if in.PrimaryInvite != nil {
+		in, out := &in.PrimaryInvite, &out.PrimaryInvite
+		*out = new(PrimaryInviteParameters)
+		**out = **in
+	}
+	if in.MembershipScreening != nil {
+		in, out := &in.MembershipScreening, &out.MembershipScreening
+		*out = new(MembershipScreeningParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertOnMemberCountBelow != nil {
+		in, out := &in.AlertOnMemberCountBelow, &out.AlertOnMemberCountBelow
+		*out = new(int)
+		**out = **in
+	}
+	if in.AlertOnMemberCountAbove != nil {
+		in, out := &in.AlertOnMemberCountAbove, &out.AlertOnMemberCountAbove
+		*out = new(int)
+		**out = **in
+	}
+	if in.RestoreFromTemplate != nil {
+		in, out := &in.RestoreFromTemplate, &out.RestoreFromTemplate
+		*out = new(TemplateRestoreParameters)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildParameters.
@@ -172,6 +509,153 @@ func (in *GuildParameters) DeepCopy() *GuildParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuildSet) DeepCopyInto(out *GuildSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildSet.
+func (in *GuildSet) DeepCopy() *GuildSet {
+	if in == nil {
+		return nil
+	}
+	out := new(GuildSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GuildSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuildSetChannel) DeepCopyInto(out *GuildSetChannel) {
+	*out = *in
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildSetChannel.
+func (in *GuildSetChannel) DeepCopy() *GuildSetChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(GuildSetChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuildSetList) DeepCopyInto(out *GuildSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GuildSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildSetList.
+func (in *GuildSetList) DeepCopy() *GuildSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(GuildSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GuildSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuildSetRole) DeepCopyInto(out *GuildSetRole) {
+	*out = *in
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildSetRole.
+func (in *GuildSetRole) DeepCopy() *GuildSetRole {
+	if in == nil {
+		return nil
+	}
+	out := new(GuildSetRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuildSetSpec) DeepCopyInto(out *GuildSetSpec) {
+	*out = *in
+	in.GuildSelector.DeepCopyInto(&out.GuildSelector)
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]GuildSetChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]GuildSetRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildSetSpec.
+func (in *GuildSetSpec) DeepCopy() *GuildSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuildSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuildSetStatus) DeepCopyInto(out *GuildSetStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Guilds != nil {
+		in, out := &in.Guilds, &out.Guilds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingGuilds != nil {
+		in, out := &in.PendingGuilds, &out.PendingGuilds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuildSetStatus.
+func (in *GuildSetStatus) DeepCopy() *GuildSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GuildSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GuildSpec) DeepCopyInto(out *GuildSpec) {
 	*out = *in
@@ -181,6 +665,11 @@ func (in *GuildSpec) DeepCopyInto(out *GuildSpec) {
 		*out = new(v2.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
@@ -210,3 +699,160 @@ func (in *GuildStatus) DeepCopy() *GuildStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MembershipScreeningField) DeepCopyInto(out *MembershipScreeningField) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Choices != nil {
+		in, out := &in.Choices, &out.Choices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MembershipScreeningField.
+func (in *MembershipScreeningField) DeepCopy() *MembershipScreeningField {
+	if in == nil {
+		return nil
+	}
+	out := new(MembershipScreeningField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MembershipScreeningObservation) DeepCopyInto(out *MembershipScreeningObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MembershipScreeningObservation.
+func (in *MembershipScreeningObservation) DeepCopy() *MembershipScreeningObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(MembershipScreeningObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MembershipScreeningParameters) DeepCopyInto(out *MembershipScreeningParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.FormFields != nil {
+		in, out := &in.FormFields, &out.FormFields
+		*out = make([]MembershipScreeningField, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MembershipScreeningParameters.
+func (in *MembershipScreeningParameters) DeepCopy() *MembershipScreeningParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MembershipScreeningParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryInviteParameters) DeepCopyInto(out *PrimaryInviteParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryInviteParameters.
+func (in *PrimaryInviteParameters) DeepCopy() *PrimaryInviteParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryInviteParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlotUsage) DeepCopyInto(out *SlotUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlotUsage.
+func (in *SlotUsage) DeepCopy() *SlotUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(SlotUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateRestoreObservation) DeepCopyInto(out *TemplateRestoreObservation) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRestoreObservation.
+func (in *TemplateRestoreObservation) DeepCopy() *TemplateRestoreObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateRestoreObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateRestoreParameters) DeepCopyInto(out *TemplateRestoreParameters) {
+	*out = *in
+	if in.ApprovedPlan != nil {
+		in, out := &in.ApprovedPlan, &out.ApprovedPlan
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRestoreParameters.
+func (in *TemplateRestoreParameters) DeepCopy() *TemplateRestoreParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateRestoreParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WidgetObservation) DeepCopyInto(out *WidgetObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WidgetObservation.
+func (in *WidgetObservation) DeepCopy() *WidgetObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(WidgetObservation)
+	in.DeepCopyInto(out)
+	return out
+}
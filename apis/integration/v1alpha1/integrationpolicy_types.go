@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IntegrationPolicyParameters define which bot applications may stay
+// integrated with a guild.
+type IntegrationPolicyParameters struct {
+	// GuildID is the ID of the guild whose integrations the policy enforces.
+	// +kubebuilder:validation:Required
+	GuildID string `json:"guildId"`
+
+	// AllowedApplicationIDs are the IDs of the applications whose bots may
+	// stay in the guild. Integrations of any other application are deleted,
+	// which removes the bot. Integrations without an application, such as
+	// Twitch or YouTube, and the provider's own application are never
+	// deleted.
+	// +optional
+	// +listType=set
+	AllowedApplicationIDs []string `json:"allowedApplicationIds,omitempty"`
+}
+
+// IntegrationPolicyObservation reports the guild's integrations and those
+// that break the policy.
+type IntegrationPolicyObservation struct {
+	// IntegrationCount is the number of integrations in the guild.
+	IntegrationCount int `json:"integrationCount,omitempty"`
+
+	// ViolatingIntegrationIDs are the IDs of integrations that break the
+	// policy and are deleted on the next update.
+	ViolatingIntegrationIDs []string `json:"violatingIntegrationIds,omitempty"`
+
+	// DeletedCount is the number of integrations the policy has deleted.
+	DeletedCount int `json:"deletedCount,omitempty"`
+}
+
+// An IntegrationPolicySpec defines the desired state of an IntegrationPolicy.
+type IntegrationPolicySpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider IntegrationPolicyParameters `json:"forProvider"`
+}
+
+// An IntegrationPolicyStatus represents the observed state of an
+// IntegrationPolicy.
+type IntegrationPolicyStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 IntegrationPolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// An IntegrationPolicy allows only approved bots in a Discord guild by
+// deleting the integrations of applications that are not allowed. Deleting
+// the policy stops enforcement but never deletes integrations.
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="INTEGRATIONS",type="integer",JSONPath=".status.atProvider.integrationCount"
+// +kubebuilder:printcolumn:name="DELETED",type="integer",JSONPath=".status.atProvider.deletedCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type IntegrationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IntegrationPolicySpec   `json:"spec"`
+	Status IntegrationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// IntegrationPolicyList contains a list of IntegrationPolicies.
+type IntegrationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IntegrationPolicy `json:"items"`
+}
@@ -29,3 +29,11 @@ var (
 	IntegrationKindAPIVersion   = IntegrationKind + "." + SchemeGroupVersion.String()
 	IntegrationGroupVersionKind = SchemeGroupVersion.WithKind(IntegrationKind)
 )
+
+// IntegrationPolicy type metadata.
+var (
+	IntegrationPolicyKind             = reflect.TypeOf(IntegrationPolicy{}).Name()
+	IntegrationPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: IntegrationPolicyKind}
+	IntegrationPolicyKindAPIVersion   = IntegrationPolicyKind + "." + SchemeGroupVersion.String()
+	IntegrationPolicyGroupVersionKind = SchemeGroupVersion.WithKind(IntegrationPolicyKind)
+)
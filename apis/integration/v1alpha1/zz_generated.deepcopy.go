@@ -179,6 +179,149 @@ func (in *IntegrationParameters) DeepCopy() *IntegrationParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPolicy) DeepCopyInto(out *IntegrationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPolicy.
+func (in *IntegrationPolicy) DeepCopy() *IntegrationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntegrationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPolicyList) DeepCopyInto(out *IntegrationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IntegrationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPolicyList.
+func (in *IntegrationPolicyList) DeepCopy() *IntegrationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntegrationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPolicyObservation) DeepCopyInto(out *IntegrationPolicyObservation) {
+	*out = *in
+	if in.ViolatingIntegrationIDs != nil {
+		in, out := &in.ViolatingIntegrationIDs, &out.ViolatingIntegrationIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPolicyObservation.
+func (in *IntegrationPolicyObservation) DeepCopy() *IntegrationPolicyObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPolicyObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPolicyParameters) DeepCopyInto(out *IntegrationPolicyParameters) {
+	*out = *in
+	if in.AllowedApplicationIDs != nil {
+		in, out := &in.AllowedApplicationIDs, &out.AllowedApplicationIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPolicyParameters.
+func (in *IntegrationPolicyParameters) DeepCopy() *IntegrationPolicyParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPolicyParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPolicySpec) DeepCopyInto(out *IntegrationPolicySpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPolicySpec.
+func (in *IntegrationPolicySpec) DeepCopy() *IntegrationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPolicyStatus) DeepCopyInto(out *IntegrationPolicyStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPolicyStatus.
+func (in *IntegrationPolicyStatus) DeepCopy() *IntegrationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 	*out = *in
@@ -188,6 +331,11 @@ func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 		*out = new(v2.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
 	out.ForProvider = in.ForProvider
 }
 
@@ -12,3 +12,12 @@ func (l *IntegrationList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this IntegrationPolicyList.
+func (l *IntegrationPolicyList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -40,6 +40,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Invite{},
 		&InviteList{},
+		&InvitePolicy{},
+		&InvitePolicyList{},
 	)
 	return nil
 }
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InvitePolicyParameters define which of a guild's invites are kept.
+type InvitePolicyParameters struct {
+	// GuildID is the ID of the guild whose invites the policy enforces.
+	// +kubebuilder:validation:Required
+	GuildID string `json:"guildId"`
+
+	// DeleteUnmanaged deletes invites that are not managed by an Invite
+	// resource, such as those created by hand in Discord. Primary invites
+	// created for Guild resources count as managed, as do invites created
+	// by the provider's bot and invites younger than one poll interval,
+	// whose codes may not be recorded yet.
+	// +optional
+	DeleteUnmanaged *bool `json:"deleteUnmanaged,omitempty"`
+
+	// MaxAge deletes invites older than this, e.g. "720h", whether or not
+	// they are managed. Managed invites are recreated by their Invite
+	// resource, which rotates them.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// ExemptCodes are invite codes the policy never deletes.
+	// +optional
+	// +listType=set
+	ExemptCodes []string `json:"exemptCodes,omitempty"`
+}
+
+// InvitePolicyObservation reports the guild's invites and those that break
+// the policy.
+type InvitePolicyObservation struct {
+	// InviteCount is the number of invites in the guild.
+	InviteCount int `json:"inviteCount,omitempty"`
+
+	// ManagedCount is the number of the guild's invites that are managed.
+	ManagedCount int `json:"managedCount,omitempty"`
+
+	// ViolatingCodes are the codes of invites that break the policy and are
+	// deleted on the next update.
+	ViolatingCodes []string `json:"violatingCodes,omitempty"`
+
+	// DeletedCount is the number of invites the policy has deleted.
+	DeletedCount int `json:"deletedCount,omitempty"`
+}
+
+// An InvitePolicySpec defines the desired state of an InvitePolicy.
+type InvitePolicySpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider InvitePolicyParameters `json:"forProvider"`
+}
+
+// An InvitePolicyStatus represents the observed state of an InvitePolicy.
+type InvitePolicyStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 InvitePolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// An InvitePolicy enforces invite hygiene in a Discord guild by deleting
+// invites that are unmanaged or too old. Deleting the policy stops
+// enforcement but never deletes invites.
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="INVITES",type="integer",JSONPath=".status.atProvider.inviteCount"
+// +kubebuilder:printcolumn:name="DELETED",type="integer",JSONPath=".status.atProvider.deletedCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type InvitePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InvitePolicySpec   `json:"spec"`
+	Status InvitePolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// InvitePolicyList contains a list of InvitePolicies.
+type InvitePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InvitePolicy `json:"items"`
+}
@@ -29,3 +29,11 @@ var (
 	InviteKindAPIVersion   = InviteKind + "." + SchemeGroupVersion.String()
 	InviteGroupVersionKind = SchemeGroupVersion.WithKind(InviteKind)
 )
+
+// InvitePolicy type metadata.
+var (
+	InvitePolicyKind             = reflect.TypeOf(InvitePolicy{}).Name()
+	InvitePolicyGroupKind        = schema.GroupKind{Group: Group, Kind: InvitePolicyKind}
+	InvitePolicyKindAPIVersion   = InvitePolicyKind + "." + SchemeGroupVersion.String()
+	InvitePolicyGroupVersionKind = SchemeGroupVersion.WithKind(InvitePolicyKind)
+)
@@ -21,14 +21,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// InviteParameters are the configurable fields of an Invite.
+// InviteParameters are the configurable fields of an Invite. Discord cannot
+// modify an invite, so they cannot be changed once set; to change them,
+// delete the Invite and create it again, which creates a new invite code.
+// +kubebuilder:validation:XValidation:rule="has(self.maxAge) == has(oldSelf.maxAge) && (!has(self.maxAge) || self.maxAge == oldSelf.maxAge)",message="maxAge is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.maxUses) == has(oldSelf.maxUses) && (!has(self.maxUses) || self.maxUses == oldSelf.maxUses)",message="maxUses is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.temporary) == has(oldSelf.temporary) && (!has(self.temporary) || self.temporary == oldSelf.temporary)",message="temporary is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.unique) == has(oldSelf.unique) && (!has(self.unique) || self.unique == oldSelf.unique)",message="unique is immutable"
 type InviteParameters struct {
-	// ChannelID is the ID of the channel this invite is for.
+	// ChannelID is the ID of the channel this invite is for. It cannot be
+	// changed once set.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="channelId is immutable"
 	ChannelID string `json:"channelId"`
 
 	// MaxAge is the duration of invite in seconds before expiry, or 0 for never.
-	// Default is 86400 (24 hours).
+	// Default is 86400 (24 hours). It cannot be changed once set.
 	// +optional
 	// +kubebuilder:default=86400
 	// +kubebuilder:validation:Minimum=0
@@ -36,7 +44,7 @@ type InviteParameters struct {
 	MaxAge *int `json:"maxAge,omitempty"`
 
 	// MaxUses is the max number of uses, or 0 for unlimited.
-	// Default is 0 (unlimited).
+	// Default is 0 (unlimited). It cannot be changed once set.
 	// +optional
 	// +kubebuilder:default=0
 	// +kubebuilder:validation:Minimum=0
@@ -44,14 +52,14 @@ type InviteParameters struct {
 	MaxUses *int `json:"maxUses,omitempty"`
 
 	// Temporary specifies whether this invite only grants temporary membership.
-	// Default is false.
+	// Default is false. It cannot be changed once set.
 	// +optional
 	// +kubebuilder:default=false
 	Temporary *bool `json:"temporary,omitempty"`
 
 	// Unique specifies whether this invite should be unique.
 	// If true, don't try to reuse a similar invite.
-	// Default is false.
+	// Default is false. It cannot be changed once set.
 	// +optional
 	// +kubebuilder:default=false
 	Unique *bool `json:"unique,omitempty"`
@@ -112,7 +120,14 @@ type InviteObservation struct {
 type InviteSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      InviteParameters      `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider InviteParameters `json:"forProvider"`
 }
 
 // An InviteStatus represents the observed state of an Invite.
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// forProviderSchema returns the structural schema of spec.forProvider from
+// the Invite CRD.
+func forProviderSchema(t *testing.T) *schema.Structural {
+	t.Helper()
+	data, err := os.ReadFile("../../../package/crds/invite.discord.crossplane.io_invites.yaml")
+	require.NoError(t, err)
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal(data, crd))
+
+	v1 := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"].Properties["forProvider"]
+	props := &apiextensions.JSONSchemaProps{}
+	require.NoError(t, apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&v1, props, nil))
+	s, err := schema.NewStructural(props)
+	require.NoError(t, err)
+	return s
+}
+
+func TestInviteParametersImmutable(t *testing.T) {
+	s := forProviderSchema(t)
+	v := cel.NewValidator(s, false, celconfig.PerCallLimit)
+
+	cases := map[string]struct {
+		old     map[string]interface{}
+		new     map[string]interface{}
+		wantErr string
+	}{
+		"Unchanged": {
+			old: map[string]interface{}{"channelId": "1", "maxAge": int64(3600), "unique": true},
+			new: map[string]interface{}{"channelId": "1", "maxAge": int64(3600), "unique": true},
+		},
+		"Changed": {
+			old:     map[string]interface{}{"channelId": "1", "maxAge": int64(3600)},
+			new:     map[string]interface{}{"channelId": "1", "maxAge": int64(60)},
+			wantErr: "maxAge is immutable",
+		},
+		"Added": {
+			old:     map[string]interface{}{"channelId": "1"},
+			new:     map[string]interface{}{"channelId": "1", "maxUses": int64(5)},
+			wantErr: "maxUses is immutable",
+		},
+		"Removed": {
+			old:     map[string]interface{}{"channelId": "1", "temporary": true},
+			new:     map[string]interface{}{"channelId": "1"},
+			wantErr: "temporary is immutable",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			errs, _ := v.Validate(context.Background(), field.NewPath("spec", "forProvider"), s, tc.new, tc.old, celconfig.RuntimeCELCostBudget)
+			if tc.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tc.wantErr)
+		})
+	}
+}
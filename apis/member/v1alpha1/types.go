@@ -25,14 +25,21 @@ import (
 //+kubebuilder:object:generate=true
 
 // MemberParameters defines the desired state of a Discord guild member
+// +kubebuilder:validation:XValidation:rule="has(self.userId) || has(self.username)",message="one of userId or username is required"
 type MemberParameters struct {
 	// GuildID is the ID of the Discord guild
 	// +kubebuilder:validation:Required
 	GuildID string `json:"guildId"`
 
 	// UserID is the ID of the Discord user to manage
-	// +kubebuilder:validation:Required
-	UserID string `json:"userId"`
+	// +optional
+	UserID string `json:"userId,omitempty"`
+
+	// Username identifies the user by their unique Discord username when
+	// UserID is not set. It is resolved to a user ID by searching the
+	// guild's members once, when the Member is first observed.
+	// +optional
+	Username *string `json:"username,omitempty"`
 
 	// Nick is the user's nickname in the guild
 	// +optional
@@ -59,6 +66,14 @@ type MemberParameters struct {
 	// +optional
 	CommunicationDisabledUntil *string `json:"communicationDisabledUntil,omitempty"`
 
+	// TimeoutDuration times the member out for the given duration (e.g. 24h).
+	// The timeout is renewed while the Member exists and cleared when the
+	// Member is deleted, in which case the user is not removed from the guild.
+	// Takes precedence over CommunicationDisabledUntil. Discord caps timeouts
+	// at 28 days.
+	// +optional
+	TimeoutDuration *metav1.Duration `json:"timeoutDuration,omitempty"`
+
 	// Flags represents guild member flags as a bit set
 	// +optional
 	Flags *int `json:"flags,omitempty"`
@@ -141,7 +156,14 @@ type MemberObservation struct {
 type MemberSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      MemberParameters      `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider MemberParameters `json:"forProvider"`
 }
 
 // A MemberStatus represents the observed state of a Member.
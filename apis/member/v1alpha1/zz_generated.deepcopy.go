@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -202,6 +203,11 @@ func (in *MemberObservation) DeepCopy() *MemberObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberParameters) DeepCopyInto(out *MemberParameters) {
 	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
 	if in.Nick != nil {
 		in, out := &in.Nick, &out.Nick
 		*out = new(string)
@@ -232,6 +238,11 @@ func (in *MemberParameters) DeepCopyInto(out *MemberParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TimeoutDuration != nil {
+		in, out := &in.TimeoutDuration, &out.TimeoutDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Flags != nil {
 		in, out := &in.Flags, &out.Flags
 		*out = new(int)
@@ -263,6 +274,11 @@ func (in *MemberSpec) DeepCopyInto(out *MemberSpec) {
 		*out = new(v2.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
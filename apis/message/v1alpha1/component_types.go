@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// LinkButton is a button that opens a URL. Link buttons need no
+// interaction handling.
+type LinkButton struct {
+	// Label is the text shown on the button
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=80
+	Label string `json:"label"`
+
+	// URL is the http(s) or discord:// URL the button opens
+	// +kubebuilder:validation:Pattern=`^(https?|discord)://`
+	// +kubebuilder:validation:MaxLength=512
+	URL string `json:"url"`
+}
+
+// RoleSelectMenu is a dropdown listing the guild's roles. The choices are
+// sent to the interactions endpoint of the bot's application under
+// CustomID, which must assign the selected roles.
+type RoleSelectMenu struct {
+	// CustomID identifies the menu in the interactions it sends
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	CustomID string `json:"customId"`
+
+	// Placeholder is shown when nothing is selected
+	// +kubebuilder:validation:MaxLength=150
+	// +optional
+	Placeholder *string `json:"placeholder,omitempty"`
+
+	// MinValues is the minimum number of roles that must be selected
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=25
+	// +optional
+	MinValues *int `json:"minValues,omitempty"`
+
+	// MaxValues is the maximum number of roles that can be selected
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=25
+	// +optional
+	MaxValues *int `json:"maxValues,omitempty"`
+
+	// DefaultRoleIDs are the roles selected when the menu is shown
+	// +kubebuilder:validation:MaxItems=25
+	// +optional
+	DefaultRoleIDs []string `json:"defaultRoleIds,omitempty"`
+}
+
+// ActionRow is a row of interactive components below a message. A row holds
+// either up to five link buttons or a single select menu.
+// +kubebuilder:validation:XValidation:rule="has(self.buttons) != has(self.roleSelect)",message="an action row holds either buttons or a roleSelect menu"
+type ActionRow struct {
+	// Buttons are link buttons shown side by side
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	Buttons []LinkButton `json:"buttons,omitempty"`
+
+	// RoleSelect is a role select menu filling the row
+	// +optional
+	RoleSelect *RoleSelectMenu `json:"roleSelect,omitempty"`
+}
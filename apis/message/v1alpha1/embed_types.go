@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// EmbedField is a name and value shown in an embed
+type EmbedField struct {
+	// Name is the field's heading
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+
+	// Value is the field's text
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Value string `json:"value"`
+
+	// Inline shows the field side by side with other inline fields
+	// +optional
+	Inline *bool `json:"inline,omitempty"`
+}
+
+// EmbedImage is an image shown in an embed
+type EmbedImage struct {
+	// URL is the image's http(s) URL
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+}
+
+// EmbedFooter is the small text shown at the bottom of an embed
+type EmbedFooter struct {
+	// Text is the footer's text
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Text string `json:"text"`
+
+	// IconURL is the http(s) URL of an icon shown next to the text
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	IconURL *string `json:"iconUrl,omitempty"`
+}
+
+// Embed is rich content attached to a message. It is shared by every spec
+// that posts messages. Discord also limits the combined length of the
+// title, description, field names and values and footer text of all embeds
+// in a message to 6000 characters, which the admission webhook enforces.
+type Embed struct {
+	// Title is the embed's title
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Title *string `json:"title,omitempty"`
+
+	// Description is the embed's main text
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// URL is the link opened by the embed's title
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Color is the embed's accent color as an RGB integer
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16777215
+	// +optional
+	Color *int `json:"color,omitempty"`
+
+	// Fields are the embed's name and value pairs
+	// +kubebuilder:validation:MaxItems=25
+	// +optional
+	Fields []EmbedField `json:"fields,omitempty"`
+
+	// Image is a large image shown below the description
+	// +optional
+	Image *EmbedImage `json:"image,omitempty"`
+
+	// Footer is shown at the bottom of the embed
+	// +optional
+	Footer *EmbedFooter `json:"footer,omitempty"`
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduledMessageParameters defines the message to post and when to post it
+// +kubebuilder:validation:XValidation:rule="has(self.channelId) || has(self.channelRef)",message="channelId or channelRef is required"
+// +kubebuilder:validation:XValidation:rule="has(self.content) || (has(self.embeds) && size(self.embeds) > 0) || (has(self.components) && size(self.components) > 0)",message="content, embeds or components is required"
+type ScheduledMessageParameters struct {
+	// ChannelID is the ID of the channel to post the message in
+	// +optional
+	ChannelID *string `json:"channelId,omitempty"`
+
+	// ChannelRef names a Channel resource in the message's namespace whose
+	// ID is used as ChannelID. Ignored when ChannelID is set.
+	// +optional
+	ChannelRef *xpv1.Reference `json:"channelRef,omitempty"`
+
+	// SendAt is when the message is posted. A time in the past posts the
+	// message as soon as the resource is created.
+	// +kubebuilder:validation:Required
+	SendAt metav1.Time `json:"sendAt"`
+
+	// Content is the text of the message
+	// +kubebuilder:validation:MaxLength=2000
+	// +optional
+	Content *string `json:"content,omitempty"`
+
+	// Embeds are rich content attached to the message
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Embeds []Embed `json:"embeds,omitempty"`
+
+	// Components are rows of link buttons and select menus shown below the
+	// message
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	Components []ActionRow `json:"components,omitempty"`
+
+	// Reactions are emojis the bot reacts to the message with once it is
+	// posted, e.g. for reaction roles or polls. Each is a Unicode emoji or
+	// a custom emoji as name:id or <:name:id>. Reactions that are cleared
+	// are added again.
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:MinLength=1
+	// +listType=set
+	// +optional
+	Reactions []string `json:"reactions,omitempty"`
+}
+
+// ScheduledMessageObservation represents the observed state of a
+// ScheduledMessage
+type ScheduledMessageObservation struct {
+	// MessageID is the ID of the posted message
+	MessageID string `json:"messageId,omitempty"`
+
+	// ChannelID is the ID of the channel the message was posted in
+	ChannelID string `json:"channelId,omitempty"`
+
+	// SentAt is when the message was posted
+	SentAt *metav1.Time `json:"sentAt,omitempty"`
+}
+
+// A ScheduledMessageSpec defines the desired state of a ScheduledMessage.
+type ScheduledMessageSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider ScheduledMessageParameters `json:"forProvider"`
+}
+
+// A ScheduledMessageStatus represents the observed state of a
+// ScheduledMessage.
+type ScheduledMessageStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 ScheduledMessageObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A ScheduledMessage is a managed resource that posts a message to a Discord
+// channel once its sendAt time has passed. The message is posted once;
+// later changes to the spec, or deleting the resource, leave it in place.
+// +kubebuilder:printcolumn:name="SEND-AT",type="date",JSONPath=".spec.forProvider.sendAt"
+// +kubebuilder:printcolumn:name="MESSAGE",type="string",JSONPath=".status.atProvider.messageId"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type ScheduledMessage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScheduledMessageSpec   `json:"spec"`
+	Status ScheduledMessageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// ScheduledMessageList contains a list of ScheduledMessages.
+type ScheduledMessageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScheduledMessage `json:"items"`
+}
@@ -28,6 +28,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Role{},
 		&RoleList{},
+		&RoleRollout{},
+		&RoleRolloutList{},
 	)
 	return nil
 }
@@ -29,3 +29,11 @@ var (
 	RoleKindAPIVersion   = RoleKind + "." + SchemeGroupVersion.String()
 	RoleGroupVersionKind = SchemeGroupVersion.WithKind(RoleKind)
 )
+
+// RoleRollout type metadata.
+var (
+	RoleRolloutKind             = reflect.TypeOf(RoleRollout{}).Name()
+	RoleRolloutGroupKind        = schema.GroupKind{Group: Group, Kind: RoleRolloutKind}
+	RoleRolloutKindAPIVersion   = RoleRolloutKind + "." + SchemeGroupVersion.String()
+	RoleRolloutGroupVersionKind = SchemeGroupVersion.WithKind(RoleRolloutKind)
+)
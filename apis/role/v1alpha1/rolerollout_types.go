@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoleRolloutParameters define a role to assign to, or remove from, every
+// guild member matching a selector.
+type RoleRolloutParameters struct {
+	// GuildID is the ID of the guild whose members the rollout changes.
+	// +kubebuilder:validation:Required
+	GuildID string `json:"guildId"`
+
+	// RoleID is the ID of the role to assign or remove.
+	// +kubebuilder:validation:Required
+	RoleID string `json:"roleId"`
+
+	// Action is Add to assign the role to matching members or Remove to
+	// take it away from them.
+	// +optional
+	// +kubebuilder:validation:Enum=Add;Remove
+	// +kubebuilder:default=Add
+	Action *RolloutAction `json:"action,omitempty"`
+
+	// Selector chooses the members the rollout applies to. Every member of
+	// the guild matches an empty selector.
+	// +optional
+	Selector RoleRolloutSelector `json:"selector,omitempty"`
+
+	// BatchSize is the most members changed per reconcile. Smaller batches
+	// spread the rollout over more reconciles and leave more of the bot's
+	// rate limit to other resources.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +kubebuilder:default=50
+	BatchSize *int `json:"batchSize,omitempty"`
+}
+
+// RolloutAction is what a RoleRollout does to matching members.
+type RolloutAction string
+
+// Role rollout actions.
+const (
+	RolloutActionAdd    RolloutAction = "Add"
+	RolloutActionRemove RolloutAction = "Remove"
+)
+
+// A RoleRolloutSelector matches guild members. A member must match every
+// criterion that is set.
+type RoleRolloutSelector struct {
+	// Query matches members whose username or nickname starts with it.
+	// Discord returns at most 1000 members for a query.
+	// +optional
+	Query *string `json:"query,omitempty"`
+
+	// HasRoles matches members holding all of these role IDs.
+	// +optional
+	// +listType=set
+	HasRoles []string `json:"hasRoles,omitempty"`
+
+	// JoinedBefore matches members who joined the guild before this time.
+	// +optional
+	JoinedBefore *metav1.Time `json:"joinedBefore,omitempty"`
+}
+
+// RoleRolloutObservation reports the rollout's progress.
+type RoleRolloutObservation struct {
+	// MatchedCount is the number of members matching the selector in the
+	// pages scanned so far. It covers the whole guild once a scan finishes.
+	MatchedCount int `json:"matchedCount,omitempty"`
+
+	// PendingCount is the number of matching members in the page being
+	// rolled out that the rollout has yet to change.
+	PendingCount int `json:"pendingCount,omitempty"`
+
+	// ChangedCount is the number of members the rollout has changed.
+	ChangedCount int `json:"changedCount,omitempty"`
+
+	// NextBatch are the IDs of the users changed on the next update.
+	NextBatch []string `json:"nextBatch,omitempty"`
+
+	// Cursor is the ID of the last member the scan in progress has passed.
+	// The next page is listed after it. It is empty between scans.
+	Cursor string `json:"cursor,omitempty"`
+
+	// ScannedAt is when the last scan of the guild's members finished.
+	ScannedAt *metav1.Time `json:"scannedAt,omitempty"`
+}
+
+// A RoleRolloutSpec defines the desired state of a RoleRollout.
+type RoleRolloutSpec struct {
+	xpv1.ManagedResourceSpec         `json:",inline"`
+	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider RoleRolloutParameters `json:"forProvider"`
+}
+
+// A RoleRolloutStatus represents the observed state of a RoleRollout.
+type RoleRolloutStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+	AtProvider                 RoleRolloutObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A RoleRollout assigns a role to, or removes it from, every member of a
+// Discord guild matching a selector, a batch per reconcile. Members are
+// scanned a page at a time. Once a scan finds nothing left to change the
+// guild is scanned again hourly, so members who match later are changed
+// too. Deleting the rollout stops it but never reverts the changes it made.
+// +kubebuilder:printcolumn:name="GUILD",type="string",JSONPath=".spec.forProvider.guildId"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.roleId"
+// +kubebuilder:printcolumn:name="MATCHED",type="integer",JSONPath=".status.atProvider.matchedCount"
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.atProvider.pendingCount"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,discord}
+type RoleRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleRolloutSpec   `json:"spec"`
+	Status RoleRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// RoleRolloutList contains a list of RoleRollouts.
+type RoleRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoleRollout `json:"items"`
+}
@@ -37,8 +37,68 @@ type RoleParameters struct {
 	// Position of the role in the role hierarchy
 	// +optional
 	Position *int `json:"position,omitempty"`
+
+	// IsEveryone manages the guild's @everyone role, whose ID is the guild
+	// ID, instead of a role of its own. Only its permissions are updated; the
+	// role is never created or deleted.
+	// +optional
+	IsEveryone *bool `json:"isEveryone,omitempty"`
+
+	// TrackMemberCount enables reporting the number of members holding this
+	// role in status. Counting pages through the full member list and
+	// requires the GUILD_MEMBERS privileged intent.
+	// +optional
+	TrackMemberCount *bool `json:"trackMemberCount,omitempty"`
+
+	// ConflictPolicy controls what happens when a role with the same name
+	// already exists in the guild at creation time. Adopt manages the
+	// existing role, Error refuses to create the role and CreateDuplicate
+	// creates another role with the same name. Roles owned by a bot or
+	// integration, or managed by another Role, are never adopted; the
+	// AdoptionRefused condition reports them.
+	// +optional
+	// +kubebuilder:validation:Enum=Adopt;Error;CreateDuplicate
+	// +kubebuilder:default=Adopt
+	ConflictPolicy *ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// RecreatePolicy controls what happens when the role is found to have
+	// been deleted in Discord. Recreate clears the external name and creates
+	// a new role; MarkUnavailable leaves it deleted and reports the resource
+	// as unavailable until it is recreated manually or the external name is
+	// cleared.
+	// +optional
+	// +kubebuilder:validation:Enum=Recreate;MarkUnavailable
+	// +kubebuilder:default=Recreate
+	RecreatePolicy *RecreatePolicy `json:"recreatePolicy,omitempty"`
+
+	// AllowDelete allows deletion of a role that members can pick in an
+	// onboarding prompt. Deleting such a role breaks the prompt, so it must
+	// be explicitly set to true once the prompt has been updated.
+	// +optional
+	AllowDelete *bool `json:"allowDelete,omitempty"`
 }
 
+// RecreatePolicy controls how a Role that was deleted in Discord is
+// handled.
+type RecreatePolicy string
+
+// Role recreate policies.
+const (
+	RecreatePolicyRecreate        RecreatePolicy = "Recreate"
+	RecreatePolicyMarkUnavailable RecreatePolicy = "MarkUnavailable"
+)
+
+// ConflictPolicy controls how a Role handles an existing role of the same
+// name.
+type ConflictPolicy string
+
+// Role conflict policies.
+const (
+	ConflictPolicyAdopt           ConflictPolicy = "Adopt"
+	ConflictPolicyError           ConflictPolicy = "Error"
+	ConflictPolicyCreateDuplicate ConflictPolicy = "CreateDuplicate"
+)
+
 // RoleObservation are the observable fields of a Role.
 type RoleObservation struct {
 	// ID of the role on Discord
@@ -46,13 +106,31 @@ type RoleObservation struct {
 
 	// Whether this role is managed by an integration
 	Managed bool `json:"managed,omitempty"`
+
+	// MemberCount is the number of guild members holding this role. Only
+	// populated when trackMemberCount is enabled.
+	MemberCount *int `json:"memberCount,omitempty"`
+
+	// Flags are the flags set on the role, e.g. IN_PROMPT when members can
+	// pick it in an onboarding prompt.
+	Flags []string `json:"flags,omitempty"`
+
+	// CreatedAt is when the role was created, derived from its ID.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
 }
 
 // A RoleSpec defines the desired state of a Role.
 type RoleSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      RoleParameters        `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider RoleParameters `json:"forProvider"`
 }
 
 // A RoleStatus represents the observed state of a Role.
@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -87,6 +88,20 @@ func (in *RoleList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
 	*out = *in
+	if in.MemberCount != nil {
+		in, out := &in.MemberCount, &out.MemberCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
@@ -127,6 +142,31 @@ func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.IsEveryone != nil {
+		in, out := &in.IsEveryone, &out.IsEveryone
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrackMemberCount != nil {
+		in, out := &in.TrackMemberCount, &out.TrackMemberCount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConflictPolicy != nil {
+		in, out := &in.ConflictPolicy, &out.ConflictPolicy
+		*out = new(ConflictPolicy)
+		**out = **in
+	}
+	if in.RecreatePolicy != nil {
+		in, out := &in.RecreatePolicy, &out.RecreatePolicy
+		*out = new(RecreatePolicy)
+		**out = **in
+	}
+	if in.AllowDelete != nil {
+		in, out := &in.AllowDelete, &out.AllowDelete
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
@@ -139,6 +179,189 @@ func (in *RoleParameters) DeepCopy() *RoleParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRollout) DeepCopyInto(out *RoleRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRollout.
+func (in *RoleRollout) DeepCopy() *RoleRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRolloutList) DeepCopyInto(out *RoleRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RoleRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRolloutList.
+func (in *RoleRolloutList) DeepCopy() *RoleRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRolloutObservation) DeepCopyInto(out *RoleRolloutObservation) {
+	*out = *in
+	if in.NextBatch != nil {
+		in, out := &in.NextBatch, &out.NextBatch
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScannedAt != nil {
+		in, out := &in.ScannedAt, &out.ScannedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRolloutObservation.
+func (in *RoleRolloutObservation) DeepCopy() *RoleRolloutObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRolloutObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRolloutParameters) DeepCopyInto(out *RoleRolloutParameters) {
+	*out = *in
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(RolloutAction)
+		**out = **in
+	}
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRolloutParameters.
+func (in *RoleRolloutParameters) DeepCopy() *RoleRolloutParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRolloutParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRolloutSelector) DeepCopyInto(out *RoleRolloutSelector) {
+	*out = *in
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(string)
+		**out = **in
+	}
+	if in.HasRoles != nil {
+		in, out := &in.HasRoles, &out.HasRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JoinedBefore != nil {
+		in, out := &in.JoinedBefore, &out.JoinedBefore
+		*out = new(v1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRolloutSelector.
+func (in *RoleRolloutSelector) DeepCopy() *RoleRolloutSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRolloutSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRolloutSpec) DeepCopyInto(out *RoleRolloutSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(v2.SecretReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRolloutSpec.
+func (in *RoleRolloutSpec) DeepCopy() *RoleRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRolloutStatus) DeepCopyInto(out *RoleRolloutStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRolloutStatus.
+func (in *RoleRolloutStatus) DeepCopy() *RoleRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 	*out = *in
@@ -148,6 +371,11 @@ func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 		*out = new(v2.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v2.LocalSecretKeySelector)
+		**out = **in
+	}
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
@@ -165,7 +393,7 @@ func (in *RoleSpec) DeepCopy() *RoleSpec {
 func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
 	*out = *in
 	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
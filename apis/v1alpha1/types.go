@@ -41,6 +41,69 @@ type ProviderConfigSpec struct {
 	// GarbageCollection configuration for autonomous cleanup.
 	// +optional
 	GarbageCollection *GarbageCollectionSpec `json:"garbageCollection,omitempty"`
+
+	// MaintenanceWindows restricts mutating Discord operations for resources
+	// using this ProviderConfig to the given windows. Outside every window,
+	// resources are only observed and the change they would make is reported
+	// in their Maintenance condition until the next window opens.
+	// If empty, changes are made as soon as they are observed.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// RequiresApproval holds back updates to resources using this
+	// ProviderConfig until they are approved. A resource with a pending
+	// update reports it, with its diff, in a PendingApproval condition, and
+	// the update proceeds once the resource is annotated with
+	// discord.crossplane.io/approve set to the approval ID in that condition.
+	// Creates and deletes are not affected.
+	// +optional
+	RequiresApproval *bool `json:"requiresApproval,omitempty"`
+
+	// AllowCredentialsOverride lets resources using this ProviderConfig
+	// authenticate with their own bot token, set by spec.credentialsSecretRef
+	// and read from a Secret in the resource's namespace, so one provider
+	// install can serve teams that each bring their own bot. Resources that
+	// do not set spec.credentialsSecretRef use this ProviderConfig's
+	// credentials.
+	// +optional
+	AllowCredentialsOverride *bool `json:"allowCredentialsOverride,omitempty"`
+
+	// StatusMessage keeps a message in a Discord channel up to date with the
+	// health of the resources using this ProviderConfig.
+	// +optional
+	StatusMessage *StatusMessageSpec `json:"statusMessage,omitempty"`
+}
+
+// A StatusMessageSpec configures the message that reports the health of a
+// ProviderConfig's resources in Discord.
+type StatusMessageSpec struct {
+	// ChannelID is the ID of the channel the status message is posted in.
+	// The bot needs the Send Messages permission there.
+	// +kubebuilder:validation:Pattern=`^[0-9]{17,20}$`
+	ChannelID string `json:"channelId"`
+
+	// Interval is how often the message is refreshed. Defaults to 5m.
+	// +optional
+	// +kubebuilder:default="5m"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// A MaintenanceWindow is a recurring period during which the provider may
+// change Discord resources.
+type MaintenanceWindow struct {
+	// Schedule is a five-field cron expression (minute, hour, day of month,
+	// month, day of week) for when the window opens, e.g. "0 3 * * 1-5".
+	// +kubebuilder:validation:MinLength=9
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open, e.g. "2h".
+	// Must be between one minute and seven days.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone Schedule is evaluated in, e.g.
+	// "Europe/London". Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -55,6 +118,11 @@ type ProviderCredentials struct {
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// StatusMessageID is the ID of the status message posted for
+	// spec.statusMessage.
+	// +optional
+	StatusMessageID string `json:"statusMessageId,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -36,6 +36,12 @@ type WebhookParameters struct {
 	// Avatar is the avatar image data for the webhook (base64 encoded image).
 	// +optional
 	Avatar *string `json:"avatar,omitempty"`
+
+	// Verify checks on every observation that the token published in the
+	// connection secret still works, reporting the result in the TokenValid
+	// condition.
+	// +optional
+	Verify *bool `json:"verify,omitempty"`
 }
 
 // WebhookObservation are the observable fields of a Webhook.
@@ -53,6 +59,15 @@ type WebhookObservation struct {
 	// Avatar is the webhook's avatar hash.
 	Avatar string `json:"avatar,omitempty"`
 
+	// AppliedAvatarSHA256 is the SHA-256 of the avatar image data last sent
+	// to Discord.
+	AppliedAvatarSHA256 string `json:"appliedAvatarSha256,omitempty"`
+
+	// AppliedAvatar is the avatar hash Discord reported after the avatar
+	// was last sent. An avatar hash that differs from it means the avatar
+	// was changed outside the provider.
+	AppliedAvatar string `json:"appliedAvatar,omitempty"`
+
 	// ChannelID is the ID of the channel this webhook posts to.
 	ChannelID string `json:"channelId,omitempty"`
 
@@ -81,7 +96,14 @@ type WebhookObservation struct {
 type WebhookSpec struct {
 	xpv1.ManagedResourceSpec         `json:",inline"`
 	WriteConnectionSecretToReference *xpv1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
-	ForProvider                      WebhookParameters     `json:"forProvider"`
+
+	// CredentialsSecretRef overrides the bot token of the ProviderConfig
+	// with one read from a Secret in the resource's namespace. The
+	// ProviderConfig must set allowCredentialsOverride.
+	// +optional
+	CredentialsSecretRef *xpv1.LocalSecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	ForProvider WebhookParameters `json:"forProvider"`
 }
 
 // A WebhookStatus represents the observed state of a Webhook.
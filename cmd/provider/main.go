@@ -21,7 +21,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
 	xpcontroller "github.com/crossplane/crossplane-runtime/v2/pkg/controller"
@@ -29,16 +30,27 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/rossigee/provider-discord/apis"
+	"github.com/rossigee/provider-discord/internal/admin"
+	"github.com/rossigee/provider-discord/internal/admission"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
 	"github.com/rossigee/provider-discord/internal/controller"
+	"github.com/rossigee/provider-discord/internal/diagnostics"
 	"github.com/rossigee/provider-discord/internal/features"
 	"github.com/rossigee/provider-discord/internal/metrics"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"github.com/rossigee/provider-discord/internal/selfcheck"
 	"github.com/rossigee/provider-discord/internal/tracing"
+	"github.com/rossigee/provider-discord/internal/tuning"
 	"github.com/rossigee/provider-discord/internal/version"
 	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	sigzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 func main() {
@@ -47,14 +59,82 @@ func main() {
 		debug                    = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		leaderElection           = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
 		leaderElectionNS         = app.Flag("leader-election-namespace", "Namespace to use for leader election.").Default("crossplane-system").OverrideDefaultFromEnvar("LEADER_ELECTION_NAMESPACE").String()
+		leaderElectionID         = app.Flag("leader-election-id", "Name of the lease used for leader election. Replica sets running different --kinds need different IDs.").Default("crossplane-leader-election-provider-discord").OverrideDefaultFromEnvar("LEADER_ELECTION_ID").String()
+		leaseDuration            = app.Flag("leader-election-lease-duration", "How long replicas wait before taking over leadership from a leader that stopped renewing its lease.").Default("60s").OverrideDefaultFromEnvar("LEADER_ELECTION_LEASE_DURATION").Duration()
+		renewDeadline            = app.Flag("leader-election-renew-deadline", "How long the leader keeps retrying to renew its lease before giving up leadership.").Default("50s").OverrideDefaultFromEnvar("LEADER_ELECTION_RENEW_DEADLINE").Duration()
+		retryPeriod              = app.Flag("leader-election-retry-period", "How often replicas try to acquire or renew the lease.").Default("2s").OverrideDefaultFromEnvar("LEADER_ELECTION_RETRY_PERIOD").Duration()
+		releaseOnCancel          = app.Flag("leader-election-release-on-cancel", "Release the lease when the provider shuts down, so another replica takes over without waiting for the lease to expire.").Default("true").OverrideDefaultFromEnvar("LEADER_ELECTION_RELEASE_ON_CANCEL").Bool()
+		kinds                    = app.Flag("kinds", "The kinds whose controllers run, e.g. guild,channel,role. All run when unset. Combine with --leader-election-id to spread leadership of kinds across replica sets.").OverrideDefaultFromEnvar("KINDS").String()
 		pollInterval             = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Short('p').Default("1m").Duration()
+		pollJitterFraction       = app.Flag("poll-jitter-fraction", "The share of the poll interval by which each resource's polls are randomly spread out. 0 disables jitter.").Default("0.1").OverrideDefaultFromEnvar("POLL_JITTER_FRACTION").Float64()
 		maxReconcileRate         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		maxConcurrentReconciles  = app.Flag("max-concurrent-reconciles", "The maximum number of resources of each kind reconciled at once. Defaults to --max-reconcile-rate.").Default("0").OverrideDefaultFromEnvar("MAX_CONCURRENT_RECONCILES").Int()
+		channelConcurrency       = app.Flag("channel-concurrency", "The maximum number of Channels reconciled at once. Defaults to --max-concurrent-reconciles.").Default("0").OverrideDefaultFromEnvar("CHANNEL_CONCURRENCY").Int()
+		roleConcurrency          = app.Flag("role-concurrency", "The maximum number of Roles reconciled at once. Defaults to --max-concurrent-reconciles.").Default("0").OverrideDefaultFromEnvar("ROLE_CONCURRENCY").Int()
+		memberConcurrency        = app.Flag("member-concurrency", "The maximum number of Members reconciled at once. Defaults to --max-concurrent-reconciles.").Default("0").OverrideDefaultFromEnvar("MEMBER_CONCURRENCY").Int()
+		guildConcurrency         = app.Flag("guild-concurrency", "The maximum number of Guilds reconciled at once. Guild operations are serialized by default.").Default("1").OverrideDefaultFromEnvar("GUILD_CONCURRENCY").Int()
+		backoffBase              = app.Flag("backoff-base", "How long to wait before retrying a failed reconcile. The delay doubles with each consecutive failure.").Default(tuning.DefaultBackoffBase.String()).OverrideDefaultFromEnvar("BACKOFF_BASE").Duration()
+		backoffMax               = app.Flag("backoff-max", "The maximum delay between retries of a resource that keeps failing to reconcile.").Default(tuning.DefaultBackoffMax.String()).OverrideDefaultFromEnvar("BACKOFF_MAX").Duration()
+		readMaxRetries           = app.Flag("read-max-retries", "How many times a failed or rate limited Discord read is retried.").Default(strconv.Itoa(resilience.DefaultMaxRetries)).OverrideDefaultFromEnvar("READ_MAX_RETRIES").Int()
+		readRetryBaseDelay       = app.Flag("read-retry-base-delay", "How long to wait before retrying a Discord read. The delay doubles with each attempt.").Default(resilience.DefaultBaseDelay.String()).OverrideDefaultFromEnvar("READ_RETRY_BASE_DELAY").Duration()
+		readRetryMaxDelay        = app.Flag("read-retry-max-delay", "The longest delay before retrying a Discord read. Reads that Discord asks to delay for longer fail instead.").Default(resilience.DefaultMaxDelay.String()).OverrideDefaultFromEnvar("READ_RETRY_MAX_DELAY").Duration()
+		writeMaxRetries          = app.Flag("write-max-retries", "How many times a failed or rate limited Discord write is retried.").Default(strconv.Itoa(resilience.DefaultMaxRetries)).OverrideDefaultFromEnvar("WRITE_MAX_RETRIES").Int()
+		writeRetryBaseDelay      = app.Flag("write-retry-base-delay", "How long to wait before retrying a Discord write. The delay doubles with each attempt.").Default(resilience.DefaultBaseDelay.String()).OverrideDefaultFromEnvar("WRITE_RETRY_BASE_DELAY").Duration()
+		writeRetryMaxDelay       = app.Flag("write-retry-max-delay", "The longest delay before retrying a Discord write. Writes that Discord asks to delay for longer fail instead.").Default(resilience.DefaultMaxDelay.String()).OverrideDefaultFromEnvar("WRITE_RETRY_MAX_DELAY").Duration()
+		retryBudget              = app.Flag("retry-budget", "How many Discord requests may be retried per --retry-budget-window. Once the budget is spent, failing requests are not retried and their resources report RetryBudgetExhausted. 0 disables the budget.").Default(strconv.Itoa(resilience.DefaultRetryBudget)).OverrideDefaultFromEnvar("RETRY_BUDGET").Int()
+		retryBudgetWindow        = app.Flag("retry-budget-window", "The window over which --retry-budget is counted.").Default(resilience.DefaultRetryBudgetWindow.String()).OverrideDefaultFromEnvar("RETRY_BUDGET_WINDOW").Duration()
+		breakerFailureThreshold  = app.Flag("circuit-breaker-failure-threshold", "How many consecutive Discord server errors for a resource type open its circuit breaker.").Default(strconv.Itoa(resilience.DefaultFailureThreshold)).OverrideDefaultFromEnvar("CIRCUIT_BREAKER_FAILURE_THRESHOLD").Int()
+		breakerRecoveryTimeout   = app.Flag("circuit-breaker-recovery-timeout", "How long an open circuit breaker fails requests before letting one through to test recovery.").Default(resilience.DefaultRecoveryTimeout.String()).OverrideDefaultFromEnvar("CIRCUIT_BREAKER_RECOVERY_TIMEOUT").Duration()
 		syncPeriod               = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for management policies.").Default("true").OverrideDefaultFromEnvar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		dryRun                   = app.Flag("dry-run", "Report the changes each resource would make in its DryRun condition instead of calling mutating Discord endpoints.").Default("false").OverrideDefaultFromEnvar("DRY_RUN").Bool()
+		webhookTLSCertDir        = app.Flag("webhook-tls-cert-dir", "The directory of TLS certificate that will be used by the webhook server. Admission webhooks are disabled when unset.").OverrideDefaultFromEnvar("WEBHOOK_TLS_CERT_DIR").String()
+		adminBindAddress         = app.Flag("admin-bind-address", "The address the admin endpoint for on-demand reconciles binds to. The endpoint is disabled when unset.").OverrideDefaultFromEnvar("ADMIN_BIND_ADDRESS").String()
+		adminToken               = app.Flag("admin-token", "The bearer token required by the admin endpoint.").OverrideDefaultFromEnvar("ADMIN_TOKEN").String()
+		diagnosticsBindAddress   = app.Flag("diagnostics-bind-address", "The address the unauthenticated pprof and expvar diagnostics endpoint binds to, e.g. localhost:6060. The endpoint is disabled when unset.").OverrideDefaultFromEnvar("DIAGNOSTICS_BIND_ADDRESS").String()
+		selfCheck                = app.Flag("self-check", "Check on startup that the provider's CRDs are installed and its RBAC allows what its controllers do.").Default("true").OverrideDefaultFromEnvar("SELF_CHECK").Bool()
 	)
 
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
+	if *maxConcurrentReconciles <= 0 {
+		*maxConcurrentReconciles = *maxReconcileRate
+	}
+	t := tuning.Options{
+		Concurrency: map[string]int{
+			"channel": *channelConcurrency,
+			"role":    *roleConcurrency,
+			"member":  *memberConcurrency,
+			"guild":   *guildConcurrency,
+		},
+		PollJitterFraction: *pollJitterFraction,
+		BackoffBase:        *backoffBase,
+		BackoffMax:         *backoffMax,
+		Kinds:              parseKinds(*kinds),
+	}
+	kingpin.FatalIfError(t.Validate(), "Invalid tuning settings")
+	if *retryPeriod <= 0 || *retryPeriod >= *renewDeadline || *renewDeadline >= *leaseDuration {
+		kingpin.Fatalf("leader election durations must satisfy 0 < retry period < renew deadline < lease duration")
+	}
+	retries := clients.DefaultRetryPolicy()
+	retries.Read.MaxRetries, retries.Read.BaseDelay, retries.Read.MaxDelay = *readMaxRetries, *readRetryBaseDelay, *readRetryMaxDelay
+	retries.Write.MaxRetries, retries.Write.BaseDelay, retries.Write.MaxDelay = *writeMaxRetries, *writeRetryBaseDelay, *writeRetryMaxDelay
+	kingpin.FatalIfError(retries.Read.Validate(), "Invalid read retry settings")
+	kingpin.FatalIfError(retries.Write.Validate(), "Invalid write retry settings")
+	if *retryBudget < 0 || *retryBudgetWindow <= 0 {
+		kingpin.Fatalf("retry budget must not be negative and its window must be positive")
+	}
+	breaker := resilience.DefaultCircuitBreakerConfig()
+	breaker.FailureThreshold, breaker.RecoveryTimeout = *breakerFailureThreshold, *breakerRecoveryTimeout
+	kingpin.FatalIfError(breaker.Validate(), "Invalid circuit breaker settings")
+	clients.SetRetryPolicy(retries)
+	clients.SetCircuitBreakerConfig(breaker)
+	clients.SetRetryBudget(*retryBudget, *retryBudgetWindow)
+	simulation, err := clients.SimulatedAPIFromEnv()
+	kingpin.FatalIfError(err, "Invalid simulated rate limits")
+	clients.SetSimulatedAPI(simulation)
+	conditions.SetDryRun(*dryRun)
+
 	var zl = sigzap.New(sigzap.UseDevMode(*debug), func(o *sigzap.Options) {
 		if *debug {
 			o.Level = zapcore.DebugLevel
@@ -68,6 +148,10 @@ func main() {
 	shutdownTracing := tracing.Init("provider-discord")
 	defer shutdownTracing(context.Background())
 
+	shutdownMetrics, err := tracing.InitMetrics("provider-discord", crmetrics.Registry)
+	kingpin.FatalIfError(err, "Cannot start OpenTelemetry metrics export")
+	defer shutdownMetrics(context.Background())
+
 	// Always set the controller-runtime logger to capture reconciliation events
 	// Use info level to avoid excessive verbosity while still showing important operations
 	ctrl.SetLogger(zl.WithName("controller-runtime"))
@@ -79,10 +163,16 @@ func main() {
 		"platform", runtime.GOOS+"/"+runtime.GOARCH,
 		"sync-period", syncPeriod.String(),
 		"poll-interval", pollInterval.String(),
+		"poll-jitter-fraction", *pollJitterFraction,
 		"max-reconcile-rate", *maxReconcileRate,
+		"max-concurrent-reconciles", *maxConcurrentReconciles,
+		"concurrency-overrides", t.Concurrency,
+		"backoff-base", backoffBase.String(),
+		"backoff-max", backoffMax.String(),
 		"leader-election", *leaderElection,
 		"leader-election-namespace", *leaderElectionNS,
 		"management-policies", *enableManagementPolicies,
+		"dry-run", *dryRun,
 		"debug-mode", *debug)
 
 	cfg, err := ctrl.GetConfig()
@@ -94,12 +184,17 @@ func main() {
 		Cache: cache.Options{
 			SyncPeriod: syncPeriod,
 		},
-		LeaderElection:             *leaderElection,
-		LeaderElectionID:           "crossplane-leader-election-provider-discord",
-		LeaderElectionNamespace:    *leaderElectionNS,
-		LeaderElectionResourceLock: "leases",
-		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
-		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		LeaderElection:                *leaderElection,
+		LeaderElectionID:              *leaderElectionID,
+		LeaderElectionNamespace:       *leaderElectionNS,
+		LeaderElectionResourceLock:    "leases",
+		LeaderElectionReleaseOnCancel: *releaseOnCancel,
+		LeaseDuration:                 leaseDuration,
+		RenewDeadline:                 renewDeadline,
+		RetryPeriod:                   retryPeriod,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *webhookTLSCertDir,
+		}),
 	})
 	if err != nil {
 		kingpin.FatalIfError(err, "Cannot create controller manager")
@@ -107,7 +202,7 @@ func main() {
 
 	o := xpcontroller.Options{
 		Logger:                  log,
-		MaxConcurrentReconciles: *maxReconcileRate,
+		MaxConcurrentReconciles: *maxConcurrentReconciles,
 		PollInterval:            *pollInterval,
 		GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
 		Features:                &feature.Flags{},
@@ -124,17 +219,54 @@ func main() {
 	}
 	log.Info("Successfully added Discord APIs to scheme")
 
+	if *selfCheck {
+		disc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		kingpin.FatalIfError(err, "Cannot create discovery client")
+		kingpin.FatalIfError(selfcheck.New(disc, mgr.GetClient(), mgr.GetScheme(), t.Enabled).Run(context.Background()), "Startup self-check failed")
+		log.Info("Startup self-check passed")
+	}
+
 	// Initialize metrics recorder for Discord API monitoring
 	metricsRecorder := metrics.NewMetricsRecorder()
 
 	log.Info("Setting up Discord controllers")
-	if err := controller.SetupWithMetrics(mgr, o, metricsRecorder); err != nil {
+	if err := controller.SetupWithMetrics(mgr, o, t, metricsRecorder); err != nil {
 		kingpin.FatalIfError(err, "Cannot setup Discord controllers")
 	}
 	log.Info("Successfully set up Discord controllers")
 
+	if *webhookTLSCertDir != "" {
+		kingpin.FatalIfError(admission.Setup(mgr), "Cannot setup admission webhooks")
+		log.Info("Admission webhooks enabled", "cert-dir", *webhookTLSCertDir)
+	}
+
+	if *adminBindAddress != "" {
+		if *adminToken == "" {
+			kingpin.Fatalf("--admin-token is required when --admin-bind-address is set")
+		}
+		kingpin.FatalIfError(mgr.Add(admin.NewServer(*adminBindAddress, *adminToken, mgr.GetClient(), mgr.GetScheme())), "Cannot add admin endpoint")
+		log.Info("Admin endpoint enabled", "address", *adminBindAddress)
+	}
+
+	if *diagnosticsBindAddress != "" {
+		kingpin.FatalIfError(mgr.Add(diagnostics.NewServer(*diagnosticsBindAddress)), "Cannot add diagnostics endpoint")
+		log.Info("Diagnostics endpoint enabled", "address", *diagnosticsBindAddress)
+	}
+
 	kingpin.FatalIfError(mgr.AddHealthzCheck("healthz", healthz.Ping), "Cannot add health check")
 	kingpin.FatalIfError(mgr.AddReadyzCheck("readyz", healthz.Ping), "Cannot add ready check")
 
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
+
+// parseKinds splits a comma separated list of kinds, ignoring case and
+// empty entries.
+func parseKinds(s string) []string {
+	var kinds []string
+	for _, kind := range strings.Split(s, ",") {
+		if kind = strings.ToLower(strings.TrimSpace(kind)); kind != "" {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
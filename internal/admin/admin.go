@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admin serves the provider's authenticated admin endpoint, which
+// lets operators force reconciliation without waiting for the poll interval.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/index"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ReconcilePath is the path of the on-demand reconcile endpoint.
+	ReconcilePath = "/reconcile"
+
+	// AnnotationKeyReconcileRequestedAt records when a reconcile was last
+	// requested through the admin endpoint. Changing it queues the resource
+	// for immediate reconciliation; setting it with kubectl works too.
+	AnnotationKeyReconcileRequestedAt = "discord.crossplane.io/reconcile-requested-at"
+
+	groupSuffix = ".discord.crossplane.io"
+	guildIDPath = "spec.forProvider.guildId"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// ReconcileResponse lists the resources queued for reconciliation.
+type ReconcileResponse struct {
+	Requested []string `json:"requested"`
+}
+
+// A Server serves the admin endpoint. Requests must carry the configured
+// token as a bearer token.
+type Server struct {
+	addr   string
+	token  string
+	kube   client.Client
+	scheme *runtime.Scheme
+	index  *index.Index
+	now    func() time.Time
+}
+
+// NewServer returns an admin server listening on addr that authenticates
+// requests with token and manages resources through kube.
+func NewServer(addr, token string, kube client.Client, scheme *runtime.Scheme) *Server {
+	return &Server{addr: addr, token: token, kube: kube, scheme: scheme, index: index.Default(), now: time.Now}
+}
+
+// NeedLeaderElection returns false so every replica serves the endpoint.
+// Requests only annotate resources, which is safe from any replica.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the admin endpoint until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReconcilePath, s.ServeReconcile)
+	srv := &http.Server{Addr: s.addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return errors.Wrap(err, "admin server failed")
+	case <-ctx.Done():
+		sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(sctx)
+	}
+}
+
+// ServeReconcile queues resources for immediate reconciliation. It accepts
+// either kind, namespace and name to select a single resource, guild to
+// select every managed resource of that guild, including the Guild itself,
+// or id to select the resources representing a Discord object, such as the
+// target of an audit log entry.
+func (s *Server) ServeReconcile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	var (
+		requested []string
+		err       error
+	)
+	switch {
+	case q.Get("guild") != "":
+		requested, err = s.reconcileGuild(r.Context(), q.Get("guild"))
+	case q.Get("id") != "":
+		requested, err = s.reconcileID(r.Context(), q.Get("id"))
+	case q.Get("kind") != "" && q.Get("name") != "":
+		requested, err = s.reconcileResource(r.Context(), q.Get("kind"), q.Get("namespace"), q.Get("name"))
+	default:
+		http.Error(w, "one of guild, id, or kind and name, must be set", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(ReconcileResponse{Requested: requested})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+type errUnknownKind string
+
+func (e errUnknownKind) Error() string {
+	return fmt.Sprintf("unknown kind %q", string(e))
+}
+
+func statusFor(err error) int {
+	var unknown errUnknownKind
+	switch {
+	case errors.As(err, &unknown):
+		return http.StatusBadRequest
+	case kerrors.IsNotFound(errors.Cause(err)):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// managedKinds returns the managed resource kinds of this provider known to
+// the scheme, sorted so guild-wide requests annotate in a stable order.
+func (s *Server) managedKinds() []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for gvk := range s.scheme.AllKnownTypes() {
+		if !strings.HasSuffix(gvk.Group, groupSuffix) || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		obj, err := s.scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		if _, ok := obj.(resource.Managed); ok {
+			kinds = append(kinds, gvk)
+		}
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+	return kinds
+}
+
+func (s *Server) reconcileResource(ctx context.Context, kind, namespace, name string) ([]string, error) {
+	for _, gvk := range s.managedKinds() {
+		if !strings.EqualFold(gvk.Kind, kind) {
+			continue
+		}
+		obj, err := s.scheme.New(gvk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create %s", gvk.Kind)
+		}
+		mg := obj.(resource.Managed)
+		if err := s.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, mg); err != nil {
+			return nil, errors.Wrapf(err, "cannot get %s %s", gvk.Kind, name)
+		}
+		if err := s.requestReconcile(ctx, mg); err != nil {
+			return nil, err
+		}
+		return []string{describe(gvk, mg)}, nil
+	}
+	return nil, errUnknownKind(kind)
+}
+
+func (s *Server) reconcileGuild(ctx context.Context, guildID string) ([]string, error) {
+	return s.reconcileMatching(ctx, func(gvk schema.GroupVersionKind, mg resource.Managed) bool {
+		return inGuild(gvk, mg, guildID)
+	})
+}
+
+// reconcileID requests reconciliation of the resources representing the
+// Discord object id. Resources are looked up in the index the controllers
+// keep of their observations, falling back to listing every kind when none
+// is found there, as on standby replicas and for resources not yet observed.
+func (s *Server) reconcileID(ctx context.Context, id string) ([]string, error) {
+	requested := []string{}
+	for _, ref := range s.index.Lookup(id) {
+		r, err := s.reconcileResource(ctx, ref.Kind, ref.Namespace, ref.Name)
+		if kerrors.IsNotFound(errors.Cause(err)) {
+			s.index.Forget(ref)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		requested = append(requested, r...)
+	}
+	if len(requested) > 0 {
+		return requested, nil
+	}
+	return s.reconcileMatching(ctx, func(_ schema.GroupVersionKind, mg resource.Managed) bool {
+		for _, i := range index.IDs(meta.GetExternalName(mg)) {
+			if i == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// reconcileMatching requests reconciliation of every managed resource
+// match selects.
+func (s *Server) reconcileMatching(ctx context.Context, match func(schema.GroupVersionKind, resource.Managed) bool) ([]string, error) {
+	requested := []string{}
+	for _, gvk := range s.managedKinds() {
+		obj, err := s.scheme.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			continue
+		}
+		l, ok := obj.(resource.ManagedList)
+		if !ok {
+			continue
+		}
+		if err := s.kube.List(ctx, l.(client.ObjectList)); err != nil {
+			return nil, errors.Wrapf(err, "cannot list %s", gvk.Kind)
+		}
+		for _, mg := range l.GetItems() {
+			if !match(gvk, mg) {
+				continue
+			}
+			if err := s.requestReconcile(ctx, mg); err != nil {
+				return nil, err
+			}
+			requested = append(requested, describe(gvk, mg))
+		}
+	}
+	return requested, nil
+}
+
+// inGuild reports whether mg is the guild itself or belongs to it.
+func inGuild(gvk schema.GroupVersionKind, mg resource.Managed, guildID string) bool {
+	if gvk.Group == guildv1alpha1.Group {
+		return meta.GetExternalName(mg) == guildID
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return false
+	}
+	id, err := fieldpath.Pave(u).GetString(guildIDPath)
+	return err == nil && id == guildID
+}
+
+// requestReconcile bumps the reconcile annotation of mg, which the
+// controllers' event filters treat as a change worth reconciling.
+func (s *Server) requestReconcile(ctx context.Context, mg resource.Managed) error {
+	patch := client.MergeFrom(mg.DeepCopyObject().(client.Object))
+	meta.AddAnnotations(mg, map[string]string{AnnotationKeyReconcileRequestedAt: s.now().UTC().Format(time.RFC3339Nano)})
+	return errors.Wrapf(s.kube.Patch(ctx, mg, patch), "cannot request reconcile of %s", mg.GetName())
+}
+
+func describe(gvk schema.GroupVersionKind, mg resource.Managed) string {
+	if ns := mg.GetNamespace(); ns != "" {
+		return strings.ToLower(gvk.Kind) + "/" + ns + "/" + mg.GetName()
+	}
+	return strings.ToLower(gvk.Kind) + "/" + mg.GetName()
+}
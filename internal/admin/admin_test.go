@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-discord/apis"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func TestServeReconcile(t *testing.T) {
+	const token = "secret"
+
+	newObjects := func() []client.Object {
+		g := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "guild", Namespace: "default"}}
+		meta.SetExternalName(g, "111")
+		return []client.Object{
+			g,
+			&channelv1alpha1.Channel{
+				ObjectMeta: metav1.ObjectMeta{Name: "general", Namespace: "default"},
+				Spec:       channelv1alpha1.ChannelSpec{ForProvider: channelv1alpha1.ChannelParameters{Name: "general", GuildID: "111"}},
+			},
+			&rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "default"},
+				Spec:       rolev1alpha1.RoleSpec{ForProvider: rolev1alpha1.RoleParameters{Name: "Admins", GuildID: "111"}},
+			},
+			&rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Spec:       rolev1alpha1.RoleSpec{ForProvider: rolev1alpha1.RoleParameters{Name: "Other", GuildID: "222"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		auth           string
+		query          string
+		expectedStatus int
+		expectedNames  []string
+	}{
+		{
+			name:           "single resource",
+			method:         http.MethodPost,
+			auth:           "Bearer " + token,
+			query:          "kind=channel&namespace=default&name=general",
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"channel/default/general"},
+		},
+		{
+			name:           "all resources of a guild",
+			method:         http.MethodPost,
+			auth:           "Bearer " + token,
+			query:          "guild=111",
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"channel/default/general", "guild/default/guild", "role/default/admins"},
+		},
+		{
+			name:           "missing token",
+			method:         http.MethodPost,
+			query:          "guild=111",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong token",
+			method:         http.MethodPost,
+			auth:           "Bearer nope",
+			query:          "guild=111",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			auth:           "Bearer " + token,
+			query:          "guild=111",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "unknown kind",
+			method:         http.MethodPost,
+			auth:           "Bearer " + token,
+			query:          "kind=spaceship&name=general",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown resource",
+			method:         http.MethodPost,
+			auth:           "Bearer " + token,
+			query:          "kind=channel&namespace=default&name=missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "no selector",
+			method:         http.MethodPost,
+			auth:           "Bearer " + token,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			require.NoError(t, apis.AddToScheme(s))
+			kube := fake.NewClientBuilder().WithScheme(s).WithObjects(newObjects()...).Build()
+
+			now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+			srv := NewServer("", token, kube, s)
+			srv.now = func() time.Time { return now }
+
+			req := httptest.NewRequest(tc.method, ReconcilePath+"?"+tc.query, nil)
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			w := httptest.NewRecorder()
+			srv.ServeReconcile(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp ReconcileResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.ElementsMatch(t, tc.expectedNames, resp.Requested)
+
+			other := &rolev1alpha1.Role{}
+			require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "other"}, other))
+			assert.NotContains(t, other.GetAnnotations(), AnnotationKeyReconcileRequestedAt)
+
+			ch := &channelv1alpha1.Channel{}
+			require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "general"}, ch))
+			assert.Equal(t, now.Format(time.RFC3339Nano), ch.GetAnnotations()[AnnotationKeyReconcileRequestedAt])
+		})
+	}
+}
+
+func TestServeReconcileByID(t *testing.T) {
+	const token = "secret"
+
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	ch := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{Name: "general", Namespace: "default"}}
+	meta.SetExternalName(ch, "333")
+	ro := &rolev1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "admins", Namespace: "default"}}
+	meta.SetExternalName(ro, "444")
+
+	// The index only knows the channel, and a role that has been deleted
+	idx := index.New()
+	idx.Observe(ch.DeepCopy(), true)
+	gone := &rolev1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "default"}}
+	meta.SetExternalName(gone, "555")
+	idx.Observe(gone, true)
+
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(ch, ro).Build()
+	srv := NewServer("", token, kube, s)
+	srv.index = idx
+
+	reconcile := func(id string) []string {
+		req := httptest.NewRequest(http.MethodPost, ReconcilePath+"?id="+id, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		srv.ServeReconcile(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp ReconcileResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp.Requested
+	}
+
+	assert.Equal(t, []string{"channel/default/general"}, reconcile("333"))
+
+	// Resources missing from the index are found by listing every kind
+	assert.Equal(t, []string{"role/default/admins"}, reconcile("444"))
+
+	// Index entries of deleted resources are dropped
+	assert.Empty(t, reconcile("555"))
+	assert.Empty(t, idx.Lookup("555"))
+}
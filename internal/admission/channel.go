@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-channel-discord-crossplane-io-v1alpha1-channel,mutating=true,failurePolicy=fail,sideEffects=None,groups=channel.discord.crossplane.io,resources=channels,verbs=create;update,versions=v1alpha1,name=channels.channel.discord.crossplane.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-channel-discord-crossplane-io-v1alpha1-channel,mutating=false,failurePolicy=fail,sideEffects=None,groups=channel.discord.crossplane.io,resources=channels,verbs=create;update,versions=v1alpha1,name=channels.channel.discord.crossplane.io,admissionReviewVersions=v1
+
+func setupChannel(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &channelv1alpha1.Channel{}).
+		WithDefaulter(&channelDefaulter{}).
+		WithValidator(&channelValidator{}).
+		Complete()
+}
+
+// channelDefaulter normalizes channel names the way Discord would, so the
+// declared name matches the observed one and does not cause update loops.
+// Names the controller sanitizes are left as declared.
+type channelDefaulter struct{}
+
+func (d *channelDefaulter) Default(_ context.Context, cr *channelv1alpha1.Channel) error {
+	p := &cr.Spec.ForProvider
+	if !sanitized(p) {
+		p.Name = clients.NormalizeChannelName(p.Name, p.Type)
+	}
+	return nil
+}
+
+// sanitized reports whether the controller sanitizes the channel's name,
+// which always yields a name Discord accepts.
+func sanitized(p *channelv1alpha1.ChannelParameters) bool {
+	return p.SanitizeName != nil && *p.SanitizeName
+}
+
+// channelValidator rejects channels Discord would refuse with a 50035 Invalid
+// Form Body error.
+type channelValidator struct{}
+
+func (v *channelValidator) ValidateCreate(_ context.Context, cr *channelv1alpha1.Channel) (admission.Warnings, error) {
+	return nil, validateChannel(cr)
+}
+
+func (v *channelValidator) ValidateUpdate(_ context.Context, _, cr *channelv1alpha1.Channel) (admission.Warnings, error) {
+	return nil, validateChannel(cr)
+}
+
+func (v *channelValidator) ValidateDelete(_ context.Context, _ *channelv1alpha1.Channel) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// errNotSnowflake describes an ID field that does not hold a Discord ID.
+const errNotSnowflake = "must be a Discord ID: a 17 to 20 digit snowflake whose creation time is not in the future"
+
+func validateChannel(cr *channelv1alpha1.Channel) error {
+	p := cr.Spec.ForProvider
+	path := field.NewPath("spec", "forProvider")
+	var errs field.ErrorList
+
+	name := clients.NormalizeChannelName(p.Name, p.Type)
+	switch n := utf8.RuneCountInString(name); {
+	case sanitized(&p):
+	case n == 0:
+		errs = append(errs, field.Invalid(path.Child("name"), p.Name, "must contain at least one character Discord allows in channel names"))
+	case n > clients.MaxChannelNameLength:
+		errs = append(errs, field.Invalid(path.Child("name"), n, fmt.Sprintf("must be no more than %d characters", clients.MaxChannelNameLength)))
+	case clients.IsTextChannelType(p.Type) && name != p.Name:
+		errs = append(errs, field.Invalid(path.Child("name"), p.Name, fmt.Sprintf("Discord stores this name as %q; use that instead", name)))
+	}
+
+	if !snowflake.Valid(p.GuildID) {
+		errs = append(errs, field.Invalid(path.Child("guildId"), p.GuildID, errNotSnowflake))
+	}
+	if p.ParentID != nil && *p.ParentID != "" && !snowflake.Valid(*p.ParentID) {
+		errs = append(errs, field.Invalid(path.Child("parentId"), *p.ParentID, errNotSnowflake))
+	}
+
+	if p.Topic != nil {
+		switch n := utf8.RuneCountInString(*p.Topic); {
+		case !clients.IsTextChannelType(p.Type):
+			errs = append(errs, field.Forbidden(path.Child("topic"), "only text, announcement and forum channels have a topic"))
+		case n > clients.MaxChannelTopicLength:
+			errs = append(errs, field.Invalid(path.Child("topic"), n, fmt.Sprintf("must be no more than %d characters", clients.MaxChannelTopicLength)))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(channelv1alpha1.ChannelGroupKind, cr.GetName(), errs)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func newChannel(name string, channelType int, topic *string) *channelv1alpha1.Channel {
+	cr := &channelv1alpha1.Channel{}
+	cr.SetName("test-channel")
+	cr.Spec.ForProvider = channelv1alpha1.ChannelParameters{
+		Name:    name,
+		Type:    channelType,
+		GuildID: "123456789012345678",
+		Topic:   topic,
+	}
+	return cr
+}
+
+func TestChannelDefaulter(t *testing.T) {
+	tests := []struct {
+		name         string
+		channelType  int
+		sanitize     bool
+		declared     string
+		expectedName string
+	}{
+		{name: "text channel", channelType: 0, declared: "  General Chat! ", expectedName: "general-chat"},
+		{name: "announcement channel", channelType: 5, declared: "Release -- Notes", expectedName: "release-notes"},
+		{name: "voice channel keeps case and spaces", channelType: 2, declared: "Team Voice", expectedName: "Team Voice"},
+		{name: "category keeps case and spaces", channelType: 4, declared: " Staff Area", expectedName: "Staff Area"},
+		{name: "sanitized name is left as declared", channelType: 0, sanitize: true, declared: "General Chat!", expectedName: "General Chat!"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newChannel(tc.declared, tc.channelType, nil)
+			cr.Spec.ForProvider.SanitizeName = &tc.sanitize
+			require.NoError(t, (&channelDefaulter{}).Default(context.Background(), cr))
+			assert.Equal(t, tc.expectedName, cr.Spec.ForProvider.Name)
+		})
+	}
+}
+
+func TestValidateChannel(t *testing.T) {
+	topic := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		cr          *channelv1alpha1.Channel
+		expectedErr string
+	}{
+		{
+			name: "valid text channel",
+			cr:   newChannel("general", 0, topic("Welcome!")),
+		},
+		{
+			name: "valid voice channel",
+			cr:   newChannel("Team Voice", 2, nil),
+		},
+		{
+			name:        "name not normalized",
+			cr:          newChannel("General Chat", 0, nil),
+			expectedErr: `Discord stores this name as "general-chat"`,
+		},
+		{
+			name:        "name with only disallowed characters",
+			cr:          newChannel("!!!", 0, nil),
+			expectedErr: "spec.forProvider.name",
+		},
+		{
+			name: "sanitized name",
+			cr: func() *channelv1alpha1.Channel {
+				cr := newChannel("!!!", 0, nil)
+				sanitize := true
+				cr.Spec.ForProvider.SanitizeName = &sanitize
+				return cr
+			}(),
+		},
+		{
+			name:        "name too long",
+			cr:          newChannel(strings.Repeat("a", 101), 0, nil),
+			expectedErr: "must be no more than 100 characters",
+		},
+		{
+			name:        "topic too long",
+			cr:          newChannel("general", 0, topic(strings.Repeat("a", 1025))),
+			expectedErr: "must be no more than 1024 characters",
+		},
+		{
+			name:        "topic on voice channel",
+			cr:          newChannel("Team Voice", 2, topic("Chat here")),
+			expectedErr: "only text, announcement and forum channels have a topic",
+		},
+		{
+			name: "guild ID is not a snowflake",
+			cr: func() *channelv1alpha1.Channel {
+				cr := newChannel("general", 0, nil)
+				cr.Spec.ForProvider.GuildID = "my-guild"
+				return cr
+			}(),
+			expectedErr: "spec.forProvider.guildId",
+		},
+		{
+			name: "parent ID is not a snowflake",
+			cr: func() *channelv1alpha1.Channel {
+				cr := newChannel("general", 0, nil)
+				cr.Spec.ForProvider.ParentID = topic("12345")
+				return cr
+			}(),
+			expectedErr: "spec.forProvider.parentId",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := (&channelValidator{}).ValidateCreate(context.Background(), tc.cr)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// +kubebuilder:webhook:path=/mutate-guild-discord-crossplane-io-v1alpha1-guild,mutating=true,failurePolicy=fail,sideEffects=None,groups=guild.discord.crossplane.io,resources=guilds,verbs=create,versions=v1alpha1,name=guilds.guild.discord.crossplane.io,admissionReviewVersions=v1
+
+// The settings Discord gives a new guild.
+const (
+	defaultVerificationLevel           = 0
+	defaultDefaultMessageNotifications = 0
+	defaultExplicitContentFilter       = 0
+	defaultAFKTimeout                  = 300
+)
+
+func setupGuild(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &guildv1alpha1.Guild{}).
+		WithDefaulter(&guildDefaulter{}).
+		Complete()
+}
+
+// guildDefaulter fills in the settings Discord gives a new guild, so the spec
+// of a guild the provider creates shows what Discord reports. It only runs on
+// create, and leaves guilds adopted by external name alone: defaulting those
+// would revert settings made in Discord.
+type guildDefaulter struct{}
+
+func (d *guildDefaulter) Default(_ context.Context, cr *guildv1alpha1.Guild) error {
+	if snowflake.Valid(meta.GetExternalName(cr)) {
+		return nil
+	}
+	p := &cr.Spec.ForProvider
+	p.VerificationLevel = defaultInt(p.VerificationLevel, defaultVerificationLevel)
+	p.DefaultMessageNotifications = defaultInt(p.DefaultMessageNotifications, defaultDefaultMessageNotifications)
+	p.ExplicitContentFilter = defaultInt(p.ExplicitContentFilter, defaultExplicitContentFilter)
+	p.AFKTimeout = defaultInt(p.AFKTimeout, defaultAFKTimeout)
+	return nil
+}
+
+// defaultInt returns v, or a pointer to def if v is nil.
+func defaultInt(v *int, def int) *int {
+	if v != nil {
+		return v
+	}
+	return &def
+}
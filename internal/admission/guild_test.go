@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGuildDefaulter(t *testing.T) {
+	level := 2
+
+	tests := []struct {
+		name         string
+		externalName string
+		declared     *int
+		expected     *guildv1alpha1.GuildParameters
+	}{
+		{
+			name: "new guild gets Discord defaults",
+			expected: &guildv1alpha1.GuildParameters{
+				Name:                        "community",
+				VerificationLevel:           intPtr(0),
+				DefaultMessageNotifications: intPtr(0),
+				ExplicitContentFilter:       intPtr(0),
+				AFKTimeout:                  intPtr(300),
+			},
+		},
+		{
+			name:     "declared settings are kept",
+			declared: &level,
+			expected: &guildv1alpha1.GuildParameters{
+				Name:                        "community",
+				VerificationLevel:           intPtr(2),
+				DefaultMessageNotifications: intPtr(0),
+				ExplicitContentFilter:       intPtr(0),
+				AFKTimeout:                  intPtr(300),
+			},
+		},
+		{
+			name:         "adopted guild is left alone",
+			externalName: "123456789012345678",
+			expected:     &guildv1alpha1.GuildParameters{Name: "community"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &guildv1alpha1.Guild{}
+			cr.SetName("community")
+			if tc.externalName != "" {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+			cr.Spec.ForProvider = guildv1alpha1.GuildParameters{Name: "community", VerificationLevel: tc.declared}
+
+			require.NoError(t, (&guildDefaulter{}).Default(context.Background(), cr))
+			assert.Equal(t, *tc.expected, cr.Spec.ForProvider)
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
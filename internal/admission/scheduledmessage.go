@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-message-discord-crossplane-io-v1alpha1-scheduledmessage,mutating=false,failurePolicy=fail,sideEffects=None,groups=message.discord.crossplane.io,resources=scheduledmessages,verbs=create;update,versions=v1alpha1,name=scheduledmessages.message.discord.crossplane.io,admissionReviewVersions=v1
+
+func setupScheduledMessage(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &messagev1alpha1.ScheduledMessage{}).
+		WithValidator(&scheduledMessageValidator{}).
+		Complete()
+}
+
+// scheduledMessageValidator rejects scheduled messages Discord would refuse
+// to post, which would otherwise only be noticed once sendAt passes.
+type scheduledMessageValidator struct{}
+
+func (v *scheduledMessageValidator) ValidateCreate(_ context.Context, cr *messagev1alpha1.ScheduledMessage) (admission.Warnings, error) {
+	return nil, validateScheduledMessage(cr)
+}
+
+func (v *scheduledMessageValidator) ValidateUpdate(_ context.Context, _, cr *messagev1alpha1.ScheduledMessage) (admission.Warnings, error) {
+	return nil, validateScheduledMessage(cr)
+}
+
+func (v *scheduledMessageValidator) ValidateDelete(_ context.Context, _ *messagev1alpha1.ScheduledMessage) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateScheduledMessage(cr *messagev1alpha1.ScheduledMessage) error {
+	p := cr.Spec.ForProvider
+	path := field.NewPath("spec", "forProvider")
+
+	var errs field.ErrorList
+	if p.ChannelID != nil && !snowflake.Valid(*p.ChannelID) {
+		errs = append(errs, field.Invalid(path.Child("channelId"), *p.ChannelID, errNotSnowflake))
+	}
+	errs = append(errs, validateEmbeds(path.Child("embeds"), p.Embeds)...)
+	errs = append(errs, validateComponents(path.Child("components"), p.Components)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(messagev1alpha1.ScheduledMessageGroupKind, cr.GetName(), errs)
+}
+
+// validateEmbeds checks the limits Discord applies to the embeds of a
+// message as a whole, which the CRD schema cannot express.
+func validateEmbeds(path *field.Path, embeds []messagev1alpha1.Embed) field.ErrorList {
+	var errs field.ErrorList
+	if len(embeds) > clients.MaxEmbeds {
+		errs = append(errs, field.TooMany(path, len(embeds), clients.MaxEmbeds))
+	}
+	for i, e := range embeds {
+		if len(e.Fields) > clients.MaxEmbedFields {
+			errs = append(errs, field.TooMany(path.Index(i).Child("fields"), len(e.Fields), clients.MaxEmbedFields))
+		}
+	}
+	if n := clients.EmbedsLength(clients.Embeds(embeds)); n > clients.MaxEmbedCharacters {
+		errs = append(errs, field.Invalid(path, n, fmt.Sprintf("titles, descriptions, field names and values and footers must total no more than %d characters", clients.MaxEmbedCharacters)))
+	}
+	return errs
+}
+
+// validateComponents checks the select menus of a message: their custom IDs
+// must be unique, and their defaults must be roles that fit the allowed
+// number of selections.
+func validateComponents(path *field.Path, rows []messagev1alpha1.ActionRow) field.ErrorList {
+	var errs field.ErrorList
+	seen := map[string]bool{}
+	for i, row := range rows {
+		m := row.RoleSelect
+		if m == nil {
+			continue
+		}
+		mp := path.Index(i).Child("roleSelect")
+		if seen[m.CustomID] {
+			errs = append(errs, field.Duplicate(mp.Child("customId"), m.CustomID))
+		}
+		seen[m.CustomID] = true
+
+		minValues, maxValues := 1, 1
+		if m.MinValues != nil {
+			minValues = *m.MinValues
+		}
+		if m.MaxValues != nil {
+			maxValues = *m.MaxValues
+		}
+		if minValues > maxValues {
+			errs = append(errs, field.Invalid(mp.Child("minValues"), minValues, fmt.Sprintf("must not exceed maxValues (%d)", maxValues)))
+		}
+		if len(m.DefaultRoleIDs) > maxValues {
+			errs = append(errs, field.TooMany(mp.Child("defaultRoleIds"), len(m.DefaultRoleIDs), maxValues))
+		}
+		for j, id := range m.DefaultRoleIDs {
+			if !snowflake.Valid(id) {
+				errs = append(errs, field.Invalid(mp.Child("defaultRoleIds").Index(j), id, errNotSnowflake))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduledMessage(embeds ...messagev1alpha1.Embed) *messagev1alpha1.ScheduledMessage {
+	channelID := "123456789012345678"
+	cr := &messagev1alpha1.ScheduledMessage{}
+	cr.SetName("launch")
+	cr.Spec.ForProvider = messagev1alpha1.ScheduledMessageParameters{
+		ChannelID: &channelID,
+		Embeds:    embeds,
+	}
+	return cr
+}
+
+func TestValidateScheduledMessage(t *testing.T) {
+	text := func(n int) *string { s := strings.Repeat("a", n); return &s }
+	fields := func(n int) []messagev1alpha1.EmbedField {
+		out := make([]messagev1alpha1.EmbedField, n)
+		for i := range out {
+			out[i] = messagev1alpha1.EmbedField{Name: "name", Value: "value"}
+		}
+		return out
+	}
+
+	three := 3
+
+	tests := []struct {
+		name        string
+		cr          *messagev1alpha1.ScheduledMessage
+		expectedErr string
+	}{
+		{
+			name: "valid embeds",
+			cr: newScheduledMessage(
+				messagev1alpha1.Embed{Title: text(256), Description: text(4096), Fields: fields(25)},
+				messagev1alpha1.Embed{Footer: &messagev1alpha1.EmbedFooter{Text: "footer"}},
+			),
+		},
+		{
+			name:        "too many fields",
+			cr:          newScheduledMessage(messagev1alpha1.Embed{Fields: fields(26)}),
+			expectedErr: "spec.forProvider.embeds[0].fields",
+		},
+		{
+			name: "too many characters across embeds",
+			cr: newScheduledMessage(
+				messagev1alpha1.Embed{Description: text(4096)},
+				messagev1alpha1.Embed{Description: text(1000), Footer: &messagev1alpha1.EmbedFooter{Text: *text(905)}},
+			),
+			expectedErr: "must total no more than 6000 characters",
+		},
+		{
+			name: "valid components",
+			cr: func() *messagev1alpha1.ScheduledMessage {
+				cr := newScheduledMessage()
+				cr.Spec.ForProvider.Components = []messagev1alpha1.ActionRow{
+					{Buttons: []messagev1alpha1.LinkButton{{Label: "Rules", URL: "https://example.com/rules"}}},
+					{RoleSelect: &messagev1alpha1.RoleSelectMenu{CustomID: "roles", MaxValues: &three, DefaultRoleIDs: []string{"123456789012345678"}}},
+				}
+				return cr
+			}(),
+		},
+		{
+			name: "duplicate select menu custom ID",
+			cr: func() *messagev1alpha1.ScheduledMessage {
+				cr := newScheduledMessage()
+				cr.Spec.ForProvider.Components = []messagev1alpha1.ActionRow{
+					{RoleSelect: &messagev1alpha1.RoleSelectMenu{CustomID: "roles"}},
+					{RoleSelect: &messagev1alpha1.RoleSelectMenu{CustomID: "roles"}},
+				}
+				return cr
+			}(),
+			expectedErr: "spec.forProvider.components[1].roleSelect.customId",
+		},
+		{
+			name: "more default roles than selections allowed",
+			cr: func() *messagev1alpha1.ScheduledMessage {
+				cr := newScheduledMessage()
+				cr.Spec.ForProvider.Components = []messagev1alpha1.ActionRow{
+					{RoleSelect: &messagev1alpha1.RoleSelectMenu{CustomID: "roles", DefaultRoleIDs: []string{"123456789012345678", "123456789012345679"}}},
+				}
+				return cr
+			}(),
+			expectedErr: "spec.forProvider.components[0].roleSelect.defaultRoleIds",
+		},
+		{
+			name: "min values above max values",
+			cr: func() *messagev1alpha1.ScheduledMessage {
+				cr := newScheduledMessage()
+				cr.Spec.ForProvider.Components = []messagev1alpha1.ActionRow{
+					{RoleSelect: &messagev1alpha1.RoleSelectMenu{CustomID: "roles", MinValues: &three}},
+				}
+				return cr
+			}(),
+			expectedErr: "must not exceed maxValues (1)",
+		},
+		{
+			name: "channel ID is not a snowflake",
+			cr: func() *messagev1alpha1.ScheduledMessage {
+				cr := newScheduledMessage()
+				cr.Spec.ForProvider.ChannelID = text(3)
+				return cr
+			}(),
+			expectedErr: "spec.forProvider.channelId",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := (&scheduledMessageValidator{}).ValidateCreate(context.Background(), tc.cr)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Application command types
+const (
+	ApplicationCommandTypeChatInput = 1
+	ApplicationCommandTypeUser      = 2
+	ApplicationCommandTypeMessage   = 3
+)
+
+// Application command option types
+const (
+	ApplicationCommandOptionSubCommand      = 1
+	ApplicationCommandOptionSubCommandGroup = 2
+	ApplicationCommandOptionString          = 3
+	ApplicationCommandOptionInteger         = 4
+	ApplicationCommandOptionBoolean         = 5
+	ApplicationCommandOptionUser            = 6
+	ApplicationCommandOptionChannel         = 7
+	ApplicationCommandOptionRole            = 8
+	ApplicationCommandOptionMentionable     = 9
+	ApplicationCommandOptionNumber          = 10
+	ApplicationCommandOptionAttachment      = 11
+)
+
+// Locales are the languages Discord's client is available in, and so the
+// locales commands can be translated into.
+var Locales = []string{
+	"id", "da", "de", "en-GB", "en-US", "es-ES", "es-419", "fr", "hr", "it", "lt",
+	"hu", "nl", "no", "pl", "pt-BR", "ro", "fi", "sv-SE", "vi", "tr", "cs",
+	"el", "bg", "ru", "uk", "hi", "th", "zh-CN", "ja", "zh-TW", "ko",
+}
+
+// IsLocale reports whether Discord knows the locale.
+func IsLocale(locale string) bool {
+	for _, l := range Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplicationCommandClient defines the interface for Discord application
+// command operations. An empty guild ID addresses the application's global
+// commands.
+type ApplicationCommandClient interface {
+	ListApplicationCommands(ctx context.Context, applicationID, guildID string) ([]ApplicationCommand, error)
+	BulkOverwriteApplicationCommands(ctx context.Context, applicationID, guildID string, commands []ApplicationCommand) ([]ApplicationCommand, error)
+}
+
+// ApplicationCommand represents a Discord slash, user or message command
+type ApplicationCommand struct {
+	ID                       string                     `json:"id,omitempty"`
+	Type                     int                        `json:"type,omitempty"`
+	Name                     string                     `json:"name"`
+	NameLocalizations        map[string]string          `json:"name_localizations,omitempty"`
+	Description              string                     `json:"description"`
+	DescriptionLocalizations map[string]string          `json:"description_localizations,omitempty"`
+	Options                  []ApplicationCommandOption `json:"options,omitempty"`
+	DefaultMemberPermissions *string                    `json:"default_member_permissions"`
+	NSFW                     bool                       `json:"nsfw,omitempty"`
+	Version                  string                     `json:"version,omitempty"`
+}
+
+// ApplicationCommandOption is a parameter, subcommand or subcommand group
+// of an application command
+type ApplicationCommandOption struct {
+	Type                     int                              `json:"type"`
+	Name                     string                           `json:"name"`
+	NameLocalizations        map[string]string                `json:"name_localizations,omitempty"`
+	Description              string                           `json:"description"`
+	DescriptionLocalizations map[string]string                `json:"description_localizations,omitempty"`
+	Required                 bool                             `json:"required,omitempty"`
+	Choices                  []ApplicationCommandOptionChoice `json:"choices,omitempty"`
+	Options                  []ApplicationCommandOption       `json:"options,omitempty"`
+	ChannelTypes             []int                            `json:"channel_types,omitempty"`
+	MinValue                 *float64                         `json:"min_value,omitempty"`
+	MaxValue                 *float64                         `json:"max_value,omitempty"`
+	MinLength                *int                             `json:"min_length,omitempty"`
+	MaxLength                *int                             `json:"max_length,omitempty"`
+	Autocomplete             bool                             `json:"autocomplete,omitempty"`
+}
+
+// ApplicationCommandOptionChoice is a predefined value of an option. The
+// value is a string, integer or number to match the option's type.
+type ApplicationCommandOptionChoice struct {
+	Name              string            `json:"name"`
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
+	Value             interface{}       `json:"value"`
+}
+
+func applicationCommandsEndpoint(applicationID, guildID string) string {
+	if guildID == "" {
+		return "/applications/" + applicationID + "/commands"
+	}
+	return "/applications/" + applicationID + "/guilds/" + guildID + "/commands"
+}
+
+// ListApplicationCommands lists an application's global commands, or its
+// commands in a guild, with all their translations
+func (c *DiscordClient) ListApplicationCommands(ctx context.Context, applicationID, guildID string) ([]ApplicationCommand, error) {
+	commands, err := doJSON[[]ApplicationCommand](ctx, c, "GET", applicationCommandsEndpoint(applicationID, guildID)+"?with_localizations=true", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list application commands")
+	}
+
+	return commands, nil
+}
+
+// BulkOverwriteApplicationCommands replaces an application's global
+// commands, or its commands in a guild, with commands in a single request.
+// Commands that are not listed are deleted.
+func (c *DiscordClient) BulkOverwriteApplicationCommands(ctx context.Context, applicationID, guildID string, commands []ApplicationCommand) ([]ApplicationCommand, error) {
+	if commands == nil {
+		commands = []ApplicationCommand{}
+	}
+	overwritten, err := doJSON[[]ApplicationCommand](ctx, c, "PUT", applicationCommandsEndpoint(applicationID, guildID), commands)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to overwrite application commands")
+	}
+
+	return overwritten, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBulkOverwriteApplicationCommands(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(data)
+		_, _ = w.Write([]byte(`[{"id": "1", "type": 1, "name": "ping", "description": "Check the bot", "version": "2"}]`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	commands, err := client.BulkOverwriteApplicationCommands(context.Background(), "42", "7", []ApplicationCommand{
+		{Type: ApplicationCommandTypeChatInput, Name: "ping", Description: "Check the bot"},
+	})
+	if err != nil {
+		t.Fatalf("BulkOverwriteApplicationCommands failed: %v", err)
+	}
+	if method != http.MethodPut || path != "/applications/42/guilds/7/commands" {
+		t.Errorf("Unexpected request %s %s", method, path)
+	}
+	if body != `[{"type":1,"name":"ping","description":"Check the bot","default_member_permissions":null}]` {
+		t.Errorf("Unexpected body %s", body)
+	}
+	if len(commands) != 1 || commands[0].ID != "1" {
+		t.Errorf("Unexpected commands %+v", commands)
+	}
+
+	// Overwriting with no commands deletes every global command
+	if _, err := client.BulkOverwriteApplicationCommands(context.Background(), "42", "", nil); err != nil {
+		t.Fatalf("BulkOverwriteApplicationCommands failed: %v", err)
+	}
+	if path != "/applications/42/commands" || body != `[]` {
+		t.Errorf("Unexpected request %s %s", path, body)
+	}
+}
+
+func TestListApplicationCommandsWithLocalizations(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[{"id": "1", "type": 1, "name": "ping", "name_localizations": {"de": "pingen"}, "description": "Check the bot"}]`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	commands, err := client.ListApplicationCommands(context.Background(), "42", "")
+	if err != nil {
+		t.Fatalf("ListApplicationCommands failed: %v", err)
+	}
+	if query != "with_localizations=true" {
+		t.Errorf("Unexpected query %q", query)
+	}
+	if len(commands) != 1 || commands[0].NameLocalizations["de"] != "pingen" {
+		t.Errorf("Unexpected commands %+v", commands)
+	}
+}
+
+func TestIsLocale(t *testing.T) {
+	for _, l := range []string{"en-US", "es-419", "zh-TW", "de"} {
+		if !IsLocale(l) {
+			t.Errorf("IsLocale(%q) = false", l)
+		}
+	}
+	for _, l := range []string{"", "en", "de-DE", "EN-US"} {
+		if IsLocale(l) {
+			t.Errorf("IsLocale(%q) = true", l)
+		}
+	}
+}
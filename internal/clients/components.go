@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+)
+
+// Discord message component types.
+const (
+	ComponentTypeActionRow  = 1
+	ComponentTypeButton     = 2
+	ComponentTypeRoleSelect = 6
+)
+
+// ButtonStyleLink is the style of buttons that open a URL.
+const ButtonStyleLink = 5
+
+// Components converts the action rows of a message spec to their Discord
+// representation.
+func Components(rows []messagev1alpha1.ActionRow) []Component {
+	if len(rows) == 0 {
+		return nil
+	}
+	out := make([]Component, 0, len(rows))
+	for _, row := range rows {
+		c := Component{Type: ComponentTypeActionRow}
+		for _, b := range row.Buttons {
+			c.Components = append(c.Components, Component{
+				Type:  ComponentTypeButton,
+				Style: ButtonStyleLink,
+				Label: b.Label,
+				URL:   b.URL,
+			})
+		}
+		if m := row.RoleSelect; m != nil {
+			c.Components = append(c.Components, roleSelect(m))
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func roleSelect(m *messagev1alpha1.RoleSelectMenu) Component {
+	c := Component{
+		Type:      ComponentTypeRoleSelect,
+		CustomID:  m.CustomID,
+		MinValues: m.MinValues,
+		MaxValues: m.MaxValues,
+	}
+	if m.Placeholder != nil {
+		c.Placeholder = *m.Placeholder
+	}
+	for _, id := range m.DefaultRoleIDs {
+		c.DefaultValues = append(c.DefaultValues, SelectDefaultValue{ID: id, Type: "role"})
+	}
+	return c
+}
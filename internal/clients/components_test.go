@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/json"
+	"testing"
+
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponents(t *testing.T) {
+	placeholder := "Pick your roles"
+	maxValues := 3
+	components := Components([]messagev1alpha1.ActionRow{
+		{Buttons: []messagev1alpha1.LinkButton{{Label: "Rules", URL: "https://example.com/rules"}}},
+		{RoleSelect: &messagev1alpha1.RoleSelectMenu{
+			CustomID:       "self-roles",
+			Placeholder:    &placeholder,
+			MaxValues:      &maxValues,
+			DefaultRoleIDs: []string{"123"},
+		}},
+	})
+
+	body, err := json.Marshal(components)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type": 1, "components": [{"type": 2, "style": 5, "label": "Rules", "url": "https://example.com/rules"}]},
+		{"type": 1, "components": [{"type": 6, "custom_id": "self-roles", "placeholder": "Pick your roles", "max_values": 3, "default_values": [{"id": "123", "type": "role"}]}]}
+	]`, string(body))
+	assert.Nil(t, Components(nil))
+}
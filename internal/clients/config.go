@@ -18,11 +18,13 @@ package clients
 
 import (
 	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-discord/apis/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strings"
@@ -80,8 +82,9 @@ func (c *ProviderCredentials) Extract(ctx context.Context, client client.Client)
 	return string(token), nil
 }
 
-// GetConfig extracts the Discord bot token from a ProviderConfig
-func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*string, error) {
+// GetProviderConfig returns the ProviderConfig referenced by a managed
+// resource.
+func GetProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*v1alpha1.ProviderConfig, error) {
 	// Get provider config reference from the managed resource's ResourceSpec
 	var pcRef *xpv1.ProviderConfigReference
 
@@ -103,6 +106,32 @@ func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*stri
 	if err := c.Get(ctx, types.NamespacedName{Name: pcRef.Name}, pc); err != nil {
 		return nil, errors.Wrap(err, errGetProviderConfig)
 	}
+	return pc, nil
+}
+
+// credentialsSecretRefPath is the field of a managed resource that selects
+// a bot token of its own.
+const credentialsSecretRefPath = "spec.credentialsSecretRef"
+
+// GetConfig extracts the Discord bot token for a managed resource: the one
+// its spec.credentialsSecretRef selects, if its ProviderConfig allows that,
+// otherwise the ProviderConfig's.
+func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*string, error) {
+	pc, err := GetProviderConfig(ctx, c, mg)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := credentialsSecretRef(mg)
+	if err != nil {
+		return nil, err
+	}
+	if ref != nil {
+		if pc.Spec.AllowCredentialsOverride == nil || !*pc.Spec.AllowCredentialsOverride {
+			return nil, errors.Errorf("ProviderConfig %s does not allow spec.credentialsSecretRef; set its spec.allowCredentialsOverride to true", pc.GetName())
+		}
+		return readToken(ctx, c, types.NamespacedName{Namespace: mg.GetNamespace(), Name: ref.Name}, ref.Key)
+	}
 
 	// Extract token from the credentials
 	if pc.Spec.Credentials.Source != xpv1.CredentialsSourceSecret {
@@ -113,17 +142,39 @@ func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*stri
 		return nil, errors.New("no secret reference provided")
 	}
 
-	secret := &corev1.Secret{}
-	if err := c.Get(ctx, types.NamespacedName{
+	return readToken(ctx, c, types.NamespacedName{
 		Namespace: pc.Spec.Credentials.SecretRef.Namespace,
 		Name:      pc.Spec.Credentials.SecretRef.Name,
-	}, secret); err != nil {
+	}, pc.Spec.Credentials.SecretRef.Key)
+}
+
+// credentialsSecretRef returns the Secret key a managed resource selects for
+// its own bot token, or nil if it uses its ProviderConfig's.
+func credentialsSecretRef(mg resource.Managed) (*xpv1.LocalSecretKeySelector, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert managed resource to unstructured")
+	}
+	ref := &xpv1.LocalSecretKeySelector{}
+	if err := fieldpath.Pave(u).GetValueInto(credentialsSecretRefPath, ref); err != nil {
+		if fieldpath.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "cannot read %s", credentialsSecretRefPath)
+	}
+	return ref, nil
+}
+
+// readToken reads a bot token from a key of a Secret.
+func readToken(ctx context.Context, c client.Client, nn types.NamespacedName, key string) (*string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, nn, secret); err != nil {
 		return nil, errors.Wrap(err, "cannot get credentials secret")
 	}
 
-	tokenBytes, ok := secret.Data[pc.Spec.Credentials.SecretRef.Key]
+	tokenBytes, ok := secret.Data[key]
 	if !ok {
-		return nil, errors.Errorf("credentials secret does not contain key %s", pc.Spec.Credentials.SecretRef.Key)
+		return nil, errors.Errorf("credentials secret does not contain key %s", key)
 	}
 
 	// Trim whitespace/newlines that sneak in from base64-encoded secrets or `echo`-style provisioning
@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-discord/apis/v1alpha1"
+	webhookv1alpha1 "github.com/rossigee/provider-discord/apis/webhook/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -262,6 +263,101 @@ func TestGetConfig(t *testing.T) {
 }
 
 // MockManaged is a mock implementation of resource.Managed
+func TestGetConfigCredentialsOverride(t *testing.T) {
+	allow := true
+	newWebhook := func(ref *xpv1.LocalSecretKeySelector) *webhookv1alpha1.Webhook {
+		cr := &webhookv1alpha1.Webhook{ObjectMeta: metav1.ObjectMeta{Name: "alerts", Namespace: "team-a"}}
+		cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Name: "shared"})
+		cr.Spec.CredentialsSecretRef = ref
+		return cr
+	}
+	teamRef := &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "team-bot"}, Key: testKey}
+
+	cases := map[string]struct {
+		mg      resource.Managed
+		allow   *bool
+		token   string
+		wantErr string
+	}{
+		"NoOverride": {
+			mg:    newWebhook(nil),
+			allow: &allow,
+			token: testToken,
+		},
+		"Override": {
+			mg:    newWebhook(teamRef),
+			allow: &allow,
+			token: "team-a-token",
+		},
+		"OverrideNotAllowed": {
+			mg:      newWebhook(teamRef),
+			wantErr: "ProviderConfig shared does not allow spec.credentialsSecretRef",
+		},
+		"OverrideSecretInOtherNamespace": {
+			mg: func() resource.Managed {
+				cr := newWebhook(teamRef)
+				cr.SetNamespace("team-b")
+				return cr
+			}(),
+			allow:   &allow,
+			wantErr: "cannot get credentials secret",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				Spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceSecret,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+							SecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{Name: testName, Namespace: testNamespace},
+								Key:             testKey,
+							},
+						},
+					},
+					AllowCredentialsOverride: tc.allow,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(
+					pc,
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+						Data:       map[string][]byte{testKey: []byte(testToken)},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "team-bot", Namespace: "team-a"},
+						Data:       map[string][]byte{testKey: []byte("team-a-token\n")},
+					},
+				).
+				Build()
+
+			token, err := GetConfig(context.Background(), fakeClient, tc.mg)
+
+			if tc.wantErr != "" {
+				if err == nil || !contains(err.Error(), tc.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.token, *token); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 type MockManaged struct {
 	providerConfigRef *xpv1.ProviderConfigReference
 }
@@ -24,11 +24,15 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-discord/internal/metrics"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"github.com/rossigee/provider-discord/internal/tracing"
 	"io"
 	"net/http"
+	"net/url"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -41,8 +45,10 @@ const (
 type RoleClient interface {
 	CreateRole(ctx context.Context, guildID string, req CreateRoleRequest) (*Role, error)
 	GetRole(ctx context.Context, guildID, roleID string) (*Role, error)
+	ListRoles(ctx context.Context, guildID string) ([]Role, error)
 	ModifyRole(ctx context.Context, guildID, roleID string, req ModifyRoleRequest) (*Role, error)
 	DeleteRole(ctx context.Context, guildID, roleID string) error
+	CountRoleMembers(ctx context.Context, guildID, roleID string) (int, error)
 }
 
 // GuildClient defines the interface for guild-related Discord operations
@@ -52,6 +58,7 @@ type GuildClient interface {
 	ModifyGuild(ctx context.Context, guildID string, req *ModifyGuildRequest) (*Guild, error)
 	DeleteGuild(ctx context.Context, guildID string) error
 	ListGuilds(ctx context.Context) ([]Guild, error)
+	ModifyGuildMFALevel(ctx context.Context, guildID string, level int) (int, error)
 }
 
 // ChannelClient defines the interface for channel-related Discord operations
@@ -61,6 +68,7 @@ type ChannelClient interface {
 	ModifyChannel(ctx context.Context, channelID string, req *ModifyChannelRequest) (*Channel, error)
 	DeleteChannel(ctx context.Context, channelID string) error
 	ListGuildChannels(ctx context.Context, guildID string) ([]Channel, error)
+	ModifyGuildChannelPositions(ctx context.Context, guildID string, positions []ChannelPosition) error
 	HasMessages(ctx context.Context, channelID string) (bool, error)
 }
 
@@ -68,6 +76,7 @@ type ChannelClient interface {
 type WebhookClient interface {
 	CreateWebhook(ctx context.Context, channelID string, req *CreateWebhookRequest) (*Webhook, error)
 	GetWebhook(ctx context.Context, webhookID string) (*Webhook, error)
+	GetWebhookWithToken(ctx context.Context, webhookID, token string) (*Webhook, error)
 	ModifyWebhook(ctx context.Context, webhookID string, req *ModifyWebhookRequest) (*Webhook, error)
 	DeleteWebhook(ctx context.Context, webhookID string) error
 	GetChannelWebhooks(ctx context.Context, channelID string) ([]Webhook, error)
@@ -87,6 +96,7 @@ type InviteClient interface {
 type MemberClient interface {
 	GetGuildMember(ctx context.Context, guildID, userID string) (*GuildMember, error)
 	ListGuildMembers(ctx context.Context, guildID string, req *ListGuildMembersRequest) ([]GuildMember, error)
+	ForEachGuildMember(ctx context.Context, guildID string, fn func(*GuildMember) error) error
 	SearchGuildMembers(ctx context.Context, guildID string, req *SearchGuildMembersRequest) ([]GuildMember, error)
 	AddGuildMember(ctx context.Context, guildID, userID string, req *AddGuildMemberRequest) (*GuildMember, error)
 	ModifyGuildMember(ctx context.Context, guildID, userID string, req *ModifyGuildMemberRequest) (*GuildMember, error)
@@ -94,6 +104,7 @@ type MemberClient interface {
 	RemoveGuildMember(ctx context.Context, guildID, userID string) error
 	AddGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error
 	RemoveGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error
+	ClearGuildMemberTimeout(ctx context.Context, guildID, userID string) error
 }
 
 // UserClient defines the interface for user-related Discord operations
@@ -112,12 +123,88 @@ type ApplicationClient interface {
 	ModifyCurrentApplication(ctx context.Context, req *ModifyCurrentApplicationRequest) (*DiscordApplication, error)
 }
 
+// ApplicationEmojiClient defines the interface for application-owned emoji Discord operations
+type ApplicationEmojiClient interface {
+	ListApplicationEmojis(ctx context.Context, applicationID string) ([]Emoji, error)
+	GetApplicationEmoji(ctx context.Context, applicationID, emojiID string) (*Emoji, error)
+	CreateApplicationEmoji(ctx context.Context, applicationID string, req *CreateApplicationEmojiRequest) (*Emoji, error)
+	ModifyApplicationEmoji(ctx context.Context, applicationID, emojiID string, req *ModifyApplicationEmojiRequest) (*Emoji, error)
+	DeleteApplicationEmoji(ctx context.Context, applicationID, emojiID string) error
+}
+
 // IntegrationClient defines the interface for integration-related Discord operations
 type IntegrationClient interface {
 	GetGuildIntegrations(ctx context.Context, guildID string) ([]GuildIntegration, error)
 	DeleteGuildIntegration(ctx context.Context, guildID, integrationID string) error
 }
 
+// BanClient defines the interface for ban-related Discord operations
+type BanClient interface {
+	GetGuildBans(ctx context.Context, guildID string, req *GetGuildBansRequest) ([]Ban, error)
+	ListAllGuildBans(ctx context.Context, guildID string) ([]Ban, error)
+	ForEachGuildBan(ctx context.Context, guildID string, fn func(*Ban) error) error
+}
+
+// ScheduledEventClient defines the interface for scheduled event Discord operations
+type ScheduledEventClient interface {
+	GetGuildScheduledEvent(ctx context.Context, guildID, eventID string, withUserCount bool) (*GuildScheduledEvent, error)
+	GetGuildScheduledEventUsers(ctx context.Context, guildID, eventID string, req *GetGuildScheduledEventUsersRequest) ([]GuildScheduledEventUser, error)
+	CountScheduledEventSubscribers(ctx context.Context, guildID, eventID string) (int, error)
+}
+
+// AutoModerationClient defines the interface for auto moderation Discord operations
+type AutoModerationClient interface {
+	ListAutoModerationRules(ctx context.Context, guildID string) ([]AutoModerationRule, error)
+	GetAutoModerationRule(ctx context.Context, guildID, ruleID string) (*AutoModerationRule, error)
+	CreateAutoModerationRule(ctx context.Context, guildID string, req *AutoModerationRuleRequest) (*AutoModerationRule, error)
+	ModifyAutoModerationRule(ctx context.Context, guildID, ruleID string, req *AutoModerationRuleRequest) (*AutoModerationRule, error)
+	DeleteAutoModerationRule(ctx context.Context, guildID, ruleID string) error
+}
+
+// OnboardingClient defines the interface for guild onboarding Discord operations
+type OnboardingClient interface {
+	GetGuildOnboarding(ctx context.Context, guildID string) (*GuildOnboarding, error)
+	ModifyGuildOnboarding(ctx context.Context, guildID string, req *ModifyGuildOnboardingRequest) (*GuildOnboarding, error)
+}
+
+// PermissionClient defines the interface for resolving the bot's own guild permissions
+type PermissionClient interface {
+	GetCurrentMemberPermissions(ctx context.Context, guildID string) (int64, error)
+}
+
+// WidgetClient defines the interface for reading a guild's public widget
+type WidgetClient interface {
+	GetGuildWidget(ctx context.Context, guildID string) (*GuildWidget, error)
+}
+
+// MemberVerificationClient defines the interface for a guild's membership
+// screening (member verification gate) Discord operations
+type MemberVerificationClient interface {
+	GetGuildMemberVerification(ctx context.Context, guildID string) (*MemberVerification, error)
+	ModifyGuildMemberVerification(ctx context.Context, guildID string, req *ModifyMemberVerificationRequest) (*MemberVerification, error)
+}
+
+// MessageClient defines the interface for posting channel messages and
+// reacting to them
+type MessageClient interface {
+	CreateMessage(ctx context.Context, channelID string, req *CreateMessageRequest) (*Message, error)
+	GetMessage(ctx context.Context, channelID, messageID string) (*Message, error)
+	EditMessage(ctx context.Context, channelID, messageID string, req *CreateMessageRequest) (*Message, error)
+	CreateReaction(ctx context.Context, channelID, messageID, emoji string) error
+}
+
+// PinClient defines the interface for channel pin Discord operations
+type PinClient interface {
+	GetPinnedMessages(ctx context.Context, channelID string) ([]Message, error)
+	PinMessage(ctx context.Context, channelID, messageID string) error
+	UnpinMessage(ctx context.Context, channelID, messageID string) error
+}
+
+// AuditLogClient defines the interface for reading a guild's audit log
+type AuditLogClient interface {
+	GetGuildAuditLog(ctx context.Context, guildID string, req *GetGuildAuditLogRequest) (*AuditLog, error)
+}
+
 // DiscordClient is a client for the Discord API
 type DiscordClient struct {
 	httpClient      *http.Client
@@ -125,6 +212,10 @@ type DiscordClient struct {
 	baseURL         string
 	logger          logr.Logger
 	metricsRecorder *metrics.MetricsRecorder
+	// transport sends requests once the middleware chain has handled them.
+	transport http.RoundTripper
+	// middlewares added with Use, outermost first.
+	middlewares []Middleware
 }
 
 // Ensure DiscordClient implements all client interfaces
@@ -136,23 +227,35 @@ var _ InviteClient = (*DiscordClient)(nil)
 var _ MemberClient = (*DiscordClient)(nil)
 var _ UserClient = (*DiscordClient)(nil)
 var _ ApplicationClient = (*DiscordClient)(nil)
+var _ ApplicationEmojiClient = (*DiscordClient)(nil)
+var _ ApplicationCommandClient = (*DiscordClient)(nil)
 var _ IntegrationClient = (*DiscordClient)(nil)
-
-var globalMetricsRecorder *metrics.MetricsRecorder
+var _ BanClient = (*DiscordClient)(nil)
+var _ ScheduledEventClient = (*DiscordClient)(nil)
+var _ AutoModerationClient = (*DiscordClient)(nil)
+var _ OnboardingClient = (*DiscordClient)(nil)
+var _ PermissionClient = (*DiscordClient)(nil)
+var _ WidgetClient = (*DiscordClient)(nil)
+var _ MemberVerificationClient = (*DiscordClient)(nil)
+var _ MessageClient = (*DiscordClient)(nil)
+var _ PinClient = (*DiscordClient)(nil)
+var _ AuditLogClient = (*DiscordClient)(nil)
+
+var globalMetricsRecorder atomic.Pointer[metrics.MetricsRecorder]
 
 // SetGlobalMetricsRecorder sets the global metrics recorder for all Discord clients
 func SetGlobalMetricsRecorder(recorder *metrics.MetricsRecorder) {
-	globalMetricsRecorder = recorder
+	globalMetricsRecorder.Store(recorder)
 }
 
 // NewDiscordClient creates a new Discord API client
 func NewDiscordClient(token string) *DiscordClient {
-	return NewDiscordClientWithMetrics(token, globalMetricsRecorder)
+	return NewDiscordClientWithMetrics(token, globalMetricsRecorder.Load())
 }
 
 // NewDiscordClientWithMetrics creates a new Discord API client with metrics recorder
 func NewDiscordClientWithMetrics(token string, metricsRecorder *metrics.MetricsRecorder) *DiscordClient {
-	return &DiscordClient{
+	c := &DiscordClient{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -160,7 +263,19 @@ func NewDiscordClientWithMetrics(token string, metricsRecorder *metrics.MetricsR
 		baseURL:         DiscordAPIBaseURL,
 		logger:          ctrl.Log.WithName("discord-client"),
 		metricsRecorder: metricsRecorder,
+		transport:       http.DefaultTransport,
+	}
+	if s := sharedSimulatedAPI.Load(); s != nil {
+		c.transport = s
 	}
+	c.Use()
+	return c
+}
+
+// SetBaseURL points the client at another Discord API endpoint, such as a
+// mock server.
+func (c *DiscordClient) SetBaseURL(baseURL string) {
+	c.baseURL = baseURL
 }
 
 // Guild represents a Discord guild
@@ -220,6 +335,7 @@ type Role struct {
 	Permissions  string `json:"permissions"`
 	Managed      bool   `json:"managed"`
 	Mentionable  bool   `json:"mentionable"`
+	Flags        int    `json:"flags,omitempty"`
 }
 
 // Emoji represents a Discord emoji
@@ -258,17 +374,89 @@ type Channel struct {
 	Name                 string                `json:"name"`
 	Position             int                   `json:"position,omitempty"`
 	ParentID             string                `json:"parent_id,omitempty"`
+	Topic                *string               `json:"topic,omitempty"`
+	NSFW                 bool                  `json:"nsfw,omitempty"`
+	Bitrate              int                   `json:"bitrate,omitempty"`
+	UserLimit            int                   `json:"user_limit,omitempty"`
+	RateLimitPerUser     int                   `json:"rate_limit_per_user,omitempty"`
+	Flags                int                   `json:"flags,omitempty"`
 	PermissionOverwrites []PermissionOverwrite `json:"permission_overwrites,omitempty"`
 }
 
 // Message represents a Discord message
 type Message struct {
-	ID        string `json:"id"`
-	ChannelID string `json:"channel_id"`
-	GuildID   string `json:"guild_id,omitempty"`
-	Author    User   `json:"author"`
-	Content   string `json:"content"`
-	Timestamp string `json:"timestamp"`
+	ID        string     `json:"id"`
+	ChannelID string     `json:"channel_id"`
+	GuildID   string     `json:"guild_id,omitempty"`
+	Author    User       `json:"author"`
+	Content   string     `json:"content"`
+	Timestamp string     `json:"timestamp"`
+	Reactions []Reaction `json:"reactions,omitempty"`
+}
+
+// Reaction represents the reactions to a message with a single emoji
+type Reaction struct {
+	Count int   `json:"count"`
+	Me    bool  `json:"me"`
+	Emoji Emoji `json:"emoji"`
+}
+
+// EmbedField represents a name and value shown in a message embed
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// EmbedImage represents an image shown in a message embed
+type EmbedImage struct {
+	URL string `json:"url"`
+}
+
+// EmbedFooter represents the footer of a message embed
+type EmbedFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// Embed represents rich content attached to a message
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Image       *EmbedImage  `json:"image,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+}
+
+// SelectDefaultValue represents a value preselected in an auto-populated
+// select menu
+type SelectDefaultValue struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Component represents an interactive message component: an action row, or
+// a button or select menu within one
+type Component struct {
+	Type          int                  `json:"type"`
+	Style         int                  `json:"style,omitempty"`
+	Label         string               `json:"label,omitempty"`
+	URL           string               `json:"url,omitempty"`
+	CustomID      string               `json:"custom_id,omitempty"`
+	Placeholder   string               `json:"placeholder,omitempty"`
+	MinValues     *int                 `json:"min_values,omitempty"`
+	MaxValues     *int                 `json:"max_values,omitempty"`
+	DefaultValues []SelectDefaultValue `json:"default_values,omitempty"`
+	Components    []Component          `json:"components,omitempty"`
+}
+
+// CreateMessageRequest represents a request to post a message in a channel
+type CreateMessageRequest struct {
+	Content    string      `json:"content,omitempty"`
+	Embeds     []Embed     `json:"embeds,omitempty"`
+	Components []Component `json:"components,omitempty"`
 }
 
 // ModifyGuildRequest represents a request to modify a guild
@@ -295,153 +483,493 @@ type ModifyGuildRequest struct {
 	PremiumProgressBarEnabled   *bool    `json:"premium_progress_bar_enabled,omitempty"`
 }
 
-// makeRequest performs an HTTP request to the Discord API
+// makeRequest performs an HTTP request to the Discord API. Authentication,
+// logging, metrics, tracing, rate limiting and retries are handled by the
+// client's middleware chain.
 func (c *DiscordClient) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
-	var bodyStr string
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			c.logger.Error(err, "Failed to marshal request body", "endpoint", endpoint)
+			c.logger.Error(err, "Failed to marshal request body", "endpoint", redactPath(endpoint))
 			return nil, errors.Wrap(err, "failed to marshal request body")
 		}
 		reqBody = bytes.NewReader(jsonBody)
-		bodyStr = string(jsonBody)
 	}
 
-	url := c.baseURL + endpoint
-	c.logger.Info("Making Discord API request",
-		"method", method,
-		"url", url,
-		"body", bodyStr)
-
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, reqBody)
 	if err != nil {
-		c.logger.Error(err, "Failed to create request", "url", url)
+		err = redactError(err, c.baseURL+redactPath(endpoint))
+		c.logger.Error(err, "Failed to create request", "endpoint", redactPath(endpoint))
 		return nil, errors.Wrap(err, "failed to create request")
 	}
 
-	req.Header.Set("Authorization", "Bot "+c.token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "Crossplane Discord Provider/1.0")
-
-	startTime := time.Now()
 	resp, err := c.httpClient.Do(req)
-	duration := time.Since(startTime)
-
 	if err != nil {
-		c.logger.Error(err, "Failed to perform request", "url", url)
-		// Record failed API operation if metrics recorder is available
-		if c.metricsRecorder != nil {
-			operation := c.mapHTTPMethodToOperation(method)
-			c.metricsRecorder.RecordAPIOperation("unknown", operation, "error", duration)
-		}
-		return nil, errors.Wrap(err, "failed to perform request")
-	}
-
-	c.logger.Info("Discord API response",
-		"method", method,
-		"url", url,
-		"status", resp.StatusCode)
-
-	// Record API operation and rate limit metrics if metrics recorder is available
-	if c.metricsRecorder != nil {
-		resourceType := c.extractResourceTypeFromEndpoint(endpoint)
-		operation := c.mapHTTPMethodToOperation(method)
-		status := c.mapHTTPStatusToStatus(resp.StatusCode)
-		c.metricsRecorder.RecordAPIOperation(resourceType, operation, status, duration)
-
-		// Parse and record rate limit information from headers
-		c.recordRateLimitMetrics(resourceType, endpoint, resp.Header)
+		return nil, errors.Wrap(redactError(err, redactURL(req)), "failed to perform request")
 	}
 
 	if resp.StatusCode >= 400 {
 		defer func() { _ = resp.Body.Close() }()
 		bodyBytes, _ := io.ReadAll(resp.Body)
+		apiErr := newAPIError(method, endpoint, resp.StatusCode, bodyBytes)
+		if resp.Request != nil {
+			apiErr.TraceID = tracing.TraceID(resp.Request.Context())
+		}
 		c.logger.Error(nil, "Discord API error",
 			"method", method,
-			"url", url,
+			"url", redactURL(req),
 			"status", resp.StatusCode,
-			"response", string(bodyBytes))
-		return nil, errors.Errorf("Discord API error: %d - %s", resp.StatusCode, string(bodyBytes))
+			"response", string(bodyBytes),
+			"traceID", apiErr.TraceID)
+		return nil, apiErr
 	}
 
 	return resp, nil
 }
 
-// GetGuild retrieves a guild by ID
-func (c *DiscordClient) GetGuild(ctx context.Context, guildID string) (*Guild, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"?with_counts=true", nil)
+// doJSON performs a request and decodes the JSON response into a T. A 204
+// No Content response, or any other empty body, yields the zero T, e.g. a
+// nil pointer, so callers of endpoints that may not answer with a body must
+// handle it.
+func doJSON[T any](ctx context.Context, c *DiscordClient, method, endpoint string, body interface{}) (T, error) {
+	var out T
+	resp, err := c.makeRequest(ctx, method, endpoint, body)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get guild")
+		return out, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	var guild Guild
-	if err := json.NewDecoder(resp.Body).Decode(&guild); err != nil {
-		return nil, errors.Wrap(err, "failed to decode guild response")
+	if resp.StatusCode == http.StatusNoContent || resp.ContentLength == 0 {
+		return out, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil && err != io.EOF {
+		return out, errors.Wrapf(err, "failed to decode response from %s %s", method, redactPath(endpoint))
 	}
 
-	return &guild, nil
+	return out, nil
 }
 
-// CreateGuild creates a new guild
-func (c *DiscordClient) CreateGuild(ctx context.Context, req *CreateGuildRequest) (*Guild, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/guilds", req)
+// streamJSON performs a request whose response is a JSON array and decodes
+// its elements one at a time, calling fn with each, so large listings are
+// never held in memory whole. It returns how many elements were decoded, and
+// stops at the first error fn returns.
+func streamJSON[T any](ctx context.Context, c *DiscordClient, method, endpoint string, fn func(*T) error) (int, error) {
+	resp, err := c.makeRequest(ctx, method, endpoint, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create guild")
+		return 0, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	var guild Guild
-	if err := json.NewDecoder(resp.Body).Decode(&guild); err != nil {
-		return nil, errors.Wrap(err, "failed to decode created guild response")
+	if resp.StatusCode == http.StatusNoContent || resp.ContentLength == 0 {
+		return 0, nil
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if tok, err := dec.Token(); err == io.EOF {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Wrapf(err, "failed to decode response from %s %s", method, redactPath(endpoint))
+	} else if tok != json.Delim('[') {
+		return 0, errors.Errorf("failed to decode response from %s %s: expected an array", method, redactPath(endpoint))
+	}
+
+	n := 0
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return n, errors.Wrapf(err, "failed to decode response from %s %s", method, redactPath(endpoint))
+		}
+		n++
+		if err := fn(&item); err != nil {
+			return n, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return n, errors.Wrapf(err, "failed to decode response from %s %s", method, redactPath(endpoint))
 	}
 
-	return &guild, nil
+	return n, nil
 }
 
-// ModifyGuild modifies an existing guild
-func (c *DiscordClient) ModifyGuild(ctx context.Context, guildID string, req *ModifyGuildRequest) (*Guild, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/guilds/"+guildID, req)
+// doNoContent performs a request whose response body, if any, is not
+// needed. The body is drained so the connection can be reused.
+func doNoContent(ctx context.Context, c *DiscordClient, method, endpoint string, body interface{}) error {
+	resp, err := c.makeRequest(ctx, method, endpoint, body)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to modify guild")
+		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	var guild Guild
-	if err := json.NewDecoder(resp.Body).Decode(&guild); err != nil {
-		return nil, errors.Wrap(err, "failed to decode modified guild response")
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Discord JSON error codes the provider reacts to. The full table of codes
+// and how they are classified lives in the resilience package.
+const (
+	ErrorCodeUnknownGuild          = resilience.ErrorCodeUnknownGuild
+	ErrorCodeUnknownMessage        = resilience.ErrorCodeUnknownMessage
+	ErrorCodeUnknownRole           = resilience.ErrorCodeUnknownRole
+	ErrorCodeBotsCannotUseEndpoint = resilience.ErrorCodeBotsCannotUseEndpoint
+	ErrorCodeMaxGuildsReached      = resilience.ErrorCodeMaxGuildsReached
+	ErrorCodeMaxRolesReached       = resilience.ErrorCodeMaxRolesReached
+	ErrorCodeMaxChannelsReached    = resilience.ErrorCodeMaxChannelsReached
+	ErrorCodeMissingAccess         = resilience.ErrorCodeMissingAccess
+	ErrorCodeWidgetDisabled        = resilience.ErrorCodeWidgetDisabled
+	ErrorCodeInvalidFormBody       = resilience.ErrorCodeInvalidFormBody
+	ErrorCodeUnknownWebhook        = resilience.ErrorCodeUnknownWebhook
+	ErrorCodeInvalidWebhookToken   = resilience.ErrorCodeInvalidWebhookToken
+)
+
+// MaxGuildsForBotGuildCreate is the number of guilds a bot may be a member of
+// before Discord refuses to let it create new ones.
+const MaxGuildsForBotGuildCreate = 10
+
+// An APIError is returned when Discord answers a request with an error
+// status. Its fields identify the failed request, so callers can act on or
+// report it without parsing the message.
+type APIError struct {
+	// Method and Endpoint of the failed request. Endpoint excludes the
+	// query string, and webhook and interaction tokens are redacted.
+	Method   string
+	Endpoint string
+
+	// GuildID and ChannelID addressed by the endpoint, if any.
+	GuildID   string
+	ChannelID string
+
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// Code and Message are Discord's JSON error code and message, if the
+	// response carried them.
+	Code    int
+	Message string
+
+	// Body is the raw response body.
+	Body string
+
+	// TraceID identifies the trace of the failed request, if it was
+	// sampled.
+	TraceID string
+}
+
+func newAPIError(method, endpoint string, status int, body []byte) *APIError {
+	e := &APIError{Method: method, StatusCode: status, Body: string(body)}
+	e.Endpoint, _, _ = strings.Cut(endpoint, "?")
+	e.Endpoint = redactPath(e.Endpoint)
+
+	parts := strings.Split(strings.TrimPrefix(e.Endpoint, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch parts[i] {
+		case "guilds":
+			e.GuildID = parts[i+1]
+		case "channels":
+			e.ChannelID = parts[i+1]
+		}
+	}
+
+	var apiErr struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil {
+		e.Code = apiErr.Code
+		e.Message = apiErr.Message
+	}
+	return e
+}
+
+// Error keeps the "Discord API error: <status> - <body>" form callers match
+// on, followed by the request that failed and its trace, so the error in a
+// resource's events can be looked up in the tracing backend.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Discord API error: %d - %s (%s %s)", e.StatusCode, e.Body, e.Method, e.Endpoint)
+	if e.TraceID != "" {
+		msg += " trace_id=" + e.TraceID
+	}
+	return msg
+}
+
+// DiscordStatus returns the HTTP status of the response.
+func (e *APIError) DiscordStatus() int {
+	return e.StatusCode
+}
+
+// DiscordCode returns the JSON error code in the response body, or 0 if it
+// had none.
+func (e *APIError) DiscordCode() int {
+	return e.Code
+}
+
+var _ resilience.CodedError = (*APIError)(nil)
+
+// AsAPIError returns the APIError in err's chain, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsNotFound reports whether err is a 404 response from the Discord API.
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ErrorCode returns the Discord JSON error code carried in the body of an API
+// error returned by makeRequest, or 0 if err carries none.
+func ErrorCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.Code
+	}
+	code, _ := resilience.ErrorCode(err)
+	return code
+}
+
+// IsBotNotInGuild reports whether err indicates the bot cannot access the
+// guild it addressed, typically because it has not been added to it.
+func IsBotNotInGuild(err error) bool {
+	switch ErrorCode(err) {
+	case ErrorCodeUnknownGuild, ErrorCodeMissingAccess:
+		return true
+	default:
+		return false
+	}
+}
+
+// GuildCreateNotAllowedError is returned when Discord will not let the bot
+// create a guild.
+type GuildCreateNotAllowedError struct {
+	// GuildCount is the number of guilds the bot is a member of, if known.
+	GuildCount int
+	// Err is Discord's refusal, if the guild create was attempted.
+	Err error
+}
+
+func (e *GuildCreateNotAllowedError) Error() string {
+	msg := fmt.Sprintf("GuildCreateNotAllowed: bots can only create guilds while they are members of fewer than %d guilds", MaxGuildsForBotGuildCreate)
+	if e.GuildCount > 0 {
+		msg += fmt.Sprintf(" (currently %d)", e.GuildCount)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *GuildCreateNotAllowedError) Unwrap() error {
+	return e.Err
+}
+
+// IsGuildCreateNotAllowed reports whether err indicates Discord refused, or
+// would refuse, to let the bot create a guild.
+func IsGuildCreateNotAllowed(err error) bool {
+	var notAllowed *GuildCreateNotAllowedError
+	return errors.As(err, &notAllowed)
+}
+
+// CheckGuildCreateAllowed returns a *GuildCreateNotAllowedError if the bot is
+// in too many guilds to create another.
+func CheckGuildCreateAllowed(ctx context.Context, c GuildClient) error {
+	guilds, err := c.ListGuilds(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to count bot guilds")
+	}
+	if len(guilds) >= MaxGuildsForBotGuildCreate {
+		return &GuildCreateNotAllowedError{GuildCount: len(guilds)}
+	}
+	return nil
+}
+
+// GetGuild retrieves a guild by ID
+func (c *DiscordClient) GetGuild(ctx context.Context, guildID string) (*Guild, error) {
+	guild, err := doJSON[*Guild](ctx, c, "GET", "/guilds/"+guildID+"?with_counts=true", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild")
+	}
+
+	return guild, nil
+}
+
+// CreateGuild creates a new guild
+func (c *DiscordClient) CreateGuild(ctx context.Context, req *CreateGuildRequest) (*Guild, error) {
+	guild, err := doJSON[*Guild](ctx, c, "POST", "/guilds", req)
+	if err != nil {
+		// These codes mean the bot may not create guilds only on this
+		// endpoint; elsewhere they are ordinary permission failures
+		switch ErrorCode(err) {
+		case ErrorCodeBotsCannotUseEndpoint, ErrorCodeMaxGuildsReached:
+			err = &GuildCreateNotAllowedError{Err: err}
+		}
+		return nil, errors.Wrap(err, "failed to create guild")
+	}
+
+	return guild, nil
+}
+
+// ModifyGuild modifies an existing guild
+func (c *DiscordClient) ModifyGuild(ctx context.Context, guildID string, req *ModifyGuildRequest) (*Guild, error) {
+	guild, err := doJSON[*Guild](ctx, c, "PATCH", "/guilds/"+guildID, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify guild")
 	}
 
-	return &guild, nil
+	return guild, nil
 }
 
 // DeleteGuild deletes a guild
 func (c *DiscordClient) DeleteGuild(ctx context.Context, guildID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/guilds/"+guildID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/guilds/"+guildID, nil); err != nil {
 		return errors.Wrap(err, "failed to delete guild")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
+// ModifyGuildMFALevel sets the moderation MFA requirement of a guild and
+// returns the resulting level. Only the guild owner may do this.
+func (c *DiscordClient) ModifyGuildMFALevel(ctx context.Context, guildID string, level int) (int, error) {
+	result, err := doJSON[struct {
+		Level int `json:"level"`
+	}](ctx, c, "POST", "/guilds/"+guildID+"/mfa", map[string]int{"level": level})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to modify guild MFA level")
+	}
+
+	return result.Level, nil
+}
+
 // ListGuilds lists all guilds the bot is a member of
 func (c *DiscordClient) ListGuilds(ctx context.Context) ([]Guild, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/users/@me/guilds", nil)
+	guilds, err := c.GetCurrentUserGuilds(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list guilds")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var guilds []Guild
-	if err := json.NewDecoder(resp.Body).Decode(&guilds); err != nil {
-		return nil, errors.Wrap(err, "failed to decode guilds response")
+	return guilds, nil
+}
+
+// GuildWidget represents the public widget of a guild
+type GuildWidget struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	InstantInvite *string              `json:"instant_invite"`
+	Channels      []GuildWidgetChannel `json:"channels"`
+	PresenceCount int                  `json:"presence_count"`
+}
+
+// GuildWidgetChannel represents a voice channel listed in a guild widget
+type GuildWidgetChannel struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+// WidgetImageURL returns the URL of a guild's widget image in the given
+// style, e.g. "shield" or "banner1". An empty style uses Discord's default.
+func WidgetImageURL(guildID, style string) string {
+	u := DiscordAPIBaseURL + "/guilds/" + guildID + "/widget.png"
+	if style != "" {
+		u += "?style=" + url.QueryEscape(style)
+	}
+	return u
+}
+
+// GetGuildWidget retrieves the public widget of a guild. Discord answers
+// with ErrorCodeWidgetDisabled when the guild's widget is disabled.
+func (c *DiscordClient) GetGuildWidget(ctx context.Context, guildID string) (*GuildWidget, error) {
+	widget, err := doJSON[*GuildWidget](ctx, c, "GET", "/guilds/"+guildID+"/widget.json", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild widget")
 	}
 
-	return guilds, nil
+	return widget, nil
+}
+
+// Membership screening form field types.
+const (
+	MemberVerificationFieldTerms          = "TERMS"
+	MemberVerificationFieldTextInput      = "TEXT_INPUT"
+	MemberVerificationFieldParagraph      = "PARAGRAPH"
+	MemberVerificationFieldMultipleChoice = "MULTIPLE_CHOICE"
+)
+
+// GuildFeatureMemberVerificationGate is the guild feature set while
+// membership screening is enabled.
+const GuildFeatureMemberVerificationGate = "MEMBER_VERIFICATION_GATE_ENABLED"
+
+// MemberVerification represents a guild's membership screening form, which
+// new members complete before they can talk
+type MemberVerification struct {
+	Version     string                    `json:"version"`
+	Description *string                   `json:"description"`
+	FormFields  []MemberVerificationField `json:"form_fields"`
+}
+
+// MemberVerificationField is a single field of a membership screening form.
+// Values holds the rules of a TERMS field and Choices the answers of a
+// MULTIPLE_CHOICE field.
+type MemberVerificationField struct {
+	FieldType   string   `json:"field_type"`
+	Label       string   `json:"label"`
+	Description *string  `json:"description,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
+	Placeholder *string  `json:"placeholder,omitempty"`
+	Required    bool     `json:"required"`
+}
+
+// ModifyMemberVerificationRequest represents a request to change a guild's
+// membership screening. FormFields replaces every field of the form.
+type ModifyMemberVerificationRequest struct {
+	Enabled     *bool
+	Description *string
+	FormFields  []MemberVerificationField
+}
+
+// GetGuildMemberVerification retrieves the membership screening form of a
+// guild. Whether screening is enabled is reported by the guild's
+// GuildFeatureMemberVerificationGate feature.
+func (c *DiscordClient) GetGuildMemberVerification(ctx context.Context, guildID string) (*MemberVerification, error) {
+	verification, err := doJSON[*MemberVerification](ctx, c, "GET", "/guilds/"+guildID+"/member-verification?with_guild=false", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild member verification")
+	}
+
+	return verification, nil
+}
+
+// ModifyGuildMemberVerification changes the membership screening of a
+// guild, which requires the COMMUNITY feature
+func (c *DiscordClient) ModifyGuildMemberVerification(ctx context.Context, guildID string, req *ModifyMemberVerificationRequest) (*MemberVerification, error) {
+	// Discord takes the form fields as a JSON-encoded string
+	body := struct {
+		Enabled     *bool   `json:"enabled,omitempty"`
+		Description *string `json:"description,omitempty"`
+		FormFields  *string `json:"form_fields,omitempty"`
+	}{Enabled: req.Enabled, Description: req.Description}
+	if req.FormFields != nil {
+		fields, err := json.Marshal(req.FormFields)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode member verification form fields")
+		}
+		encoded := string(fields)
+		body.FormFields = &encoded
+	}
+
+	verification, err := doJSON[*MemberVerification](ctx, c, "PATCH", "/guilds/"+guildID+"/member-verification", body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify guild member verification")
+	}
+
+	return verification, nil
 }
 
 // CreateRoleRequest represents a request to create a role
@@ -465,33 +993,44 @@ type ModifyRoleRequest struct {
 
 // CreateRole creates a new role in a guild
 func (c *DiscordClient) CreateRole(ctx context.Context, guildID string, req CreateRoleRequest) (*Role, error) {
-	resp, err := c.makeRequest(ctx, "POST", fmt.Sprintf("/guilds/%s/roles", guildID), req)
+	role, err := doJSON[*Role](ctx, c, "POST", fmt.Sprintf("/guilds/%s/roles", guildID), req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create role")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var role Role
-	if err := json.NewDecoder(resp.Body).Decode(&role); err != nil {
-		return nil, errors.Wrap(err, "failed to decode role response")
-	}
 
-	return &role, nil
+	return role, nil
 }
 
-// GetRole gets a role by ID
+// GetRole gets a role by ID. It fetches the single role, falling back to
+// listing the guild's roles where Discord does not serve the single-role
+// endpoint.
 func (c *DiscordClient) GetRole(ctx context.Context, guildID, roleID string) (*Role, error) {
-	resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/guilds/%s/roles", guildID), nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get roles")
+	role, err := doJSON[*Role](ctx, c, "GET", fmt.Sprintf("/guilds/%s/roles/%s", guildID, roleID), nil)
+	if err == nil && role != nil {
+		return role, nil
+	}
+	if apiErr, ok := AsAPIError(err); ok {
+		switch {
+		case apiErr.Code == ErrorCodeUnknownRole:
+			return nil, errors.New("role not found")
+		case apiErr.StatusCode == http.StatusMethodNotAllowed, apiErr.StatusCode == http.StatusNotFound && apiErr.Code == 0:
+			// Unknown route, so look the role up in the listing
+		default:
+			return nil, errors.Wrap(err, "failed to get role")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get role")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var roles []Role
-	if err := json.NewDecoder(resp.Body).Decode(&roles); err != nil {
-		return nil, errors.Wrap(err, "failed to decode roles response")
+	roles, err := c.ListRoles(ctx, guildID)
+	if err != nil {
+		return nil, err
 	}
 
+	return findRole(roles, roleID)
+}
+
+func findRole(roles []Role, roleID string) (*Role, error) {
 	for _, role := range roles {
 		if role.ID == roleID {
 			return &role, nil
@@ -501,33 +1040,47 @@ func (c *DiscordClient) GetRole(ctx context.Context, guildID, roleID string) (*R
 	return nil, errors.New("role not found")
 }
 
+// ListRoles lists all roles in a guild
+func (c *DiscordClient) ListRoles(ctx context.Context, guildID string) ([]Role, error) {
+	roles, err := doJSON[[]Role](ctx, c, "GET", fmt.Sprintf("/guilds/%s/roles", guildID), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get roles")
+	}
+
+	return roles, nil
+}
+
 // ModifyRole modifies an existing role
 func (c *DiscordClient) ModifyRole(ctx context.Context, guildID, roleID string, req ModifyRoleRequest) (*Role, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", fmt.Sprintf("/guilds/%s/roles/%s", guildID, roleID), req)
+	role, err := doJSON[*Role](ctx, c, "PATCH", fmt.Sprintf("/guilds/%s/roles/%s", guildID, roleID), req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to modify role")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var role Role
-	if err := json.NewDecoder(resp.Body).Decode(&role); err != nil {
-		return nil, errors.Wrap(err, "failed to decode modified role response")
+	if role == nil {
+		// Nothing changed, so Discord had no role to return
+		return c.GetRole(ctx, guildID, roleID)
 	}
 
-	return &role, nil
+	return role, nil
 }
 
 // DeleteRole deletes a role
 func (c *DiscordClient) DeleteRole(ctx context.Context, guildID, roleID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/guilds/%s/roles/%s", guildID, roleID), nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", fmt.Sprintf("/guilds/%s/roles/%s", guildID, roleID), nil); err != nil {
 		return errors.Wrap(err, "failed to delete role")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
+// ChannelPosition is a channel's place in a bulk move
+type ChannelPosition struct {
+	ID       string  `json:"id"`
+	Position *int    `json:"position,omitempty"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
 // CreateChannelRequest represents a request to create a channel
 type CreateChannelRequest struct {
 	Name                 string                `json:"name"`
@@ -554,6 +1107,7 @@ type ModifyChannelRequest struct {
 	Bitrate              *int                  `json:"bitrate,omitempty"`
 	UserLimit            *int                  `json:"user_limit,omitempty"`
 	ParentID             *string               `json:"parent_id,omitempty"`
+	Flags                *int                  `json:"flags,omitempty"`
 	PermissionOverwrites []PermissionOverwrite `json:"permission_overwrites,omitempty"`
 }
 
@@ -615,6 +1169,11 @@ type Invite struct {
 	CreatedAt                string               `json:"created_at"`
 }
 
+// InviteURL returns the join link for an invite or vanity URL code.
+func InviteURL(code string) string {
+	return "https://discord.gg/" + code
+}
+
 // CreateInviteRequest represents a request to create an invite
 type CreateInviteRequest struct {
 	MaxAge              *int    `json:"max_age,omitempty"`
@@ -682,21 +1241,76 @@ type GetCurrentUserGuildsRequest struct {
 	WithCounts *bool   `json:"with_counts,omitempty"`
 }
 
+// Ban-related request structures
+
+// GetGuildBansRequest represents a request to list guild bans
+type GetGuildBansRequest struct {
+	Limit  *int    `json:"limit,omitempty"`
+	Before *string `json:"before,omitempty"`
+	After  *string `json:"after,omitempty"`
+}
+
+// GetGuildAuditLogRequest represents the parameters for reading a page of
+// a guild's audit log
+type GetGuildAuditLogRequest struct {
+	Limit      *int    `json:"limit,omitempty"`
+	Before     *string `json:"before,omitempty"`
+	After      *string `json:"after,omitempty"`
+	ActionType *int    `json:"action_type,omitempty"`
+}
+
+// AuditLog represents a page of a guild's audit log
+type AuditLog struct {
+	Entries []AuditLogEntry `json:"audit_log_entries"`
+	Users   []User          `json:"users,omitempty"`
+}
+
+// Audit log action types the provider reacts to.
+const (
+	AuditLogActionMemberBanAdd    = 22
+	AuditLogActionMemberBanRemove = 23
+)
+
+// AuditLogEntry represents a single action recorded in a guild's audit
+// log. Changes and Options vary with the action type and are kept as
+// Discord sent them.
+type AuditLogEntry struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"user_id,omitempty"`
+	TargetID   string          `json:"target_id,omitempty"`
+	ActionType int             `json:"action_type"`
+	Reason     string          `json:"reason,omitempty"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	Options    json.RawMessage `json:"options,omitempty"`
+}
+
 // Application-related request structures
 
 // ModifyCurrentApplicationRequest represents a request to modify the current application
 type ModifyCurrentApplicationRequest struct {
-	Name                *string  `json:"name,omitempty"`
-	Description         *string  `json:"description,omitempty"`
-	Icon                *string  `json:"icon,omitempty"`
-	CoverImage          *string  `json:"cover_image,omitempty"`
-	RPCOrigins          []string `json:"rpc_origins,omitempty"`
-	BotPublic           *bool    `json:"bot_public,omitempty"`
-	BotRequireCodeGrant *bool    `json:"bot_require_code_grant,omitempty"`
-	TermsOfServiceURL   *string  `json:"terms_of_service_url,omitempty"`
-	PrivacyPolicyURL    *string  `json:"privacy_policy_url,omitempty"`
-	CustomInstallURL    *string  `json:"custom_install_url,omitempty"`
-	Tags                []string `json:"tags,omitempty"`
+	Name                    *string  `json:"name,omitempty"`
+	Description             *string  `json:"description,omitempty"`
+	Icon                    *string  `json:"icon,omitempty"`
+	CoverImage              *string  `json:"cover_image,omitempty"`
+	RPCOrigins              []string `json:"rpc_origins,omitempty"`
+	BotPublic               *bool    `json:"bot_public,omitempty"`
+	BotRequireCodeGrant     *bool    `json:"bot_require_code_grant,omitempty"`
+	TermsOfServiceURL       *string  `json:"terms_of_service_url,omitempty"`
+	PrivacyPolicyURL        *string  `json:"privacy_policy_url,omitempty"`
+	CustomInstallURL        *string  `json:"custom_install_url,omitempty"`
+	Tags                    []string `json:"tags,omitempty"`
+	InteractionsEndpointURL *string  `json:"interactions_endpoint_url,omitempty"`
+}
+
+// CreateApplicationEmojiRequest represents a request to create an application emoji
+type CreateApplicationEmojiRequest struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// ModifyApplicationEmojiRequest represents a request to rename an application emoji
+type ModifyApplicationEmojiRequest struct {
+	Name string `json:"name"`
 }
 
 // User represents a Discord user (basic fields for webhook/invite context)
@@ -709,91 +1323,147 @@ type User struct {
 
 // DiscordUser represents a full Discord user object
 type DiscordUser struct {
-	ID               string                 `json:"id"`
-	Username         string                 `json:"username"`
-	Discriminator    string                 `json:"discriminator"`
-	GlobalName       *string                `json:"global_name"`
-	Avatar           *string                `json:"avatar"`
-	Bot              *bool                  `json:"bot,omitempty"`
-	System           *bool                  `json:"system,omitempty"`
-	MFAEnabled       *bool                  `json:"mfa_enabled,omitempty"`
-	Banner           *string                `json:"banner"`
-	AccentColor      *int                   `json:"accent_color"`
-	Locale           *string                `json:"locale,omitempty"`
-	Verified         *bool                  `json:"verified,omitempty"`
-	Email            *string                `json:"email,omitempty"`
-	Flags            *int                   `json:"flags,omitempty"`
-	PremiumType      *int                   `json:"premium_type,omitempty"`
-	PublicFlags      *int                   `json:"public_flags,omitempty"`
-	AvatarDecoration map[string]interface{} `json:"avatar_decoration_data,omitempty"`
+	ID               string                `json:"id"`
+	Username         string                `json:"username"`
+	Discriminator    string                `json:"discriminator"`
+	GlobalName       *string               `json:"global_name"`
+	Avatar           *string               `json:"avatar"`
+	Bot              *bool                 `json:"bot,omitempty"`
+	System           *bool                 `json:"system,omitempty"`
+	MFAEnabled       *bool                 `json:"mfa_enabled,omitempty"`
+	Banner           *string               `json:"banner"`
+	AccentColor      *int                  `json:"accent_color"`
+	Locale           *string               `json:"locale,omitempty"`
+	Verified         *bool                 `json:"verified,omitempty"`
+	Email            *string               `json:"email,omitempty"`
+	Flags            *int                  `json:"flags,omitempty"`
+	PremiumType      *int                  `json:"premium_type,omitempty"`
+	PublicFlags      *int                  `json:"public_flags,omitempty"`
+	AvatarDecoration *AvatarDecorationData `json:"avatar_decoration_data,omitempty"`
+}
+
+// AvatarDecorationData represents the decoration shown around a user's avatar
+type AvatarDecorationData struct {
+	Asset string `json:"asset"`
+	SKUID string `json:"sku_id"`
 }
 
 // GuildMember represents a Discord guild member
 type GuildMember struct {
-	User                       *DiscordUser           `json:"user,omitempty"`
-	Nick                       *string                `json:"nick"`
-	Avatar                     *string                `json:"avatar"`
-	Banner                     *string                `json:"banner"`
-	Roles                      []string               `json:"roles"`
-	JoinedAt                   *string                `json:"joined_at"`
-	PremiumSince               *string                `json:"premium_since"`
-	Deaf                       bool                   `json:"deaf"`
-	Mute                       bool                   `json:"mute"`
-	Flags                      int                    `json:"flags"`
-	Pending                    *bool                  `json:"pending,omitempty"`
-	Permissions                *string                `json:"permissions,omitempty"`
-	CommunicationDisabledUntil *string                `json:"communication_disabled_until"`
-	AvatarDecorationData       map[string]interface{} `json:"avatar_decoration_data,omitempty"`
+	User                       *DiscordUser          `json:"user,omitempty"`
+	Nick                       *string               `json:"nick"`
+	Avatar                     *string               `json:"avatar"`
+	Banner                     *string               `json:"banner"`
+	Roles                      []string              `json:"roles"`
+	JoinedAt                   *string               `json:"joined_at"`
+	PremiumSince               *string               `json:"premium_since"`
+	Deaf                       bool                  `json:"deaf"`
+	Mute                       bool                  `json:"mute"`
+	Flags                      int                   `json:"flags"`
+	Pending                    *bool                 `json:"pending,omitempty"`
+	Permissions                *string               `json:"permissions,omitempty"`
+	CommunicationDisabledUntil *string               `json:"communication_disabled_until"`
+	AvatarDecorationData       *AvatarDecorationData `json:"avatar_decoration_data,omitempty"`
 }
 
 // DiscordApplication represents a Discord application
 type DiscordApplication struct {
-	ID                             string                 `json:"id"`
-	Name                           string                 `json:"name"`
-	Icon                           *string                `json:"icon"`
-	Description                    string                 `json:"description"`
-	RPCOrigins                     []string               `json:"rpc_origins"`
-	BotPublic                      bool                   `json:"bot_public"`
-	BotRequireCodeGrant            bool                   `json:"bot_require_code_grant"`
-	Bot                            map[string]interface{} `json:"bot,omitempty"`
-	TermsOfServiceURL              *string                `json:"terms_of_service_url"`
-	PrivacyPolicyURL               *string                `json:"privacy_policy_url"`
-	Owner                          map[string]interface{} `json:"owner,omitempty"`
-	Summary                        string                 `json:"summary"`
-	VerifyKey                      string                 `json:"verify_key"`
-	Team                           map[string]interface{} `json:"team"`
-	GuildID                        *string                `json:"guild_id"`
-	PrimarySkuID                   *string                `json:"primary_sku_id"`
-	Slug                           *string                `json:"slug"`
-	CoverImage                     *string                `json:"cover_image"`
-	Flags                          *int                   `json:"flags"`
-	ApproximateGuildCount          *int                   `json:"approximate_guild_count"`
-	RedirectURIs                   []string               `json:"redirect_uris"`
-	InteractionsEndpointURL        *string                `json:"interactions_endpoint_url"`
-	RoleConnectionsVerificationURL *string                `json:"role_connections_verification_url"`
-	Tags                           []string               `json:"tags"`
-	InstallParams                  map[string]interface{} `json:"install_params"`
-	CustomInstallURL               *string                `json:"custom_install_url"`
-}
+	ID                             string           `json:"id"`
+	Name                           string           `json:"name"`
+	Icon                           *string          `json:"icon"`
+	Description                    string           `json:"description"`
+	RPCOrigins                     []string         `json:"rpc_origins"`
+	BotPublic                      bool             `json:"bot_public"`
+	BotRequireCodeGrant            bool             `json:"bot_require_code_grant"`
+	Bot                            *DiscordUser     `json:"bot,omitempty"`
+	TermsOfServiceURL              *string          `json:"terms_of_service_url"`
+	PrivacyPolicyURL               *string          `json:"privacy_policy_url"`
+	Owner                          *DiscordUser     `json:"owner,omitempty"`
+	Summary                        string           `json:"summary"`
+	VerifyKey                      string           `json:"verify_key"`
+	Team                           *ApplicationTeam `json:"team"`
+	GuildID                        *string          `json:"guild_id"`
+	PrimarySkuID                   *string          `json:"primary_sku_id"`
+	Slug                           *string          `json:"slug"`
+	CoverImage                     *string          `json:"cover_image"`
+	Flags                          *int             `json:"flags"`
+	ApproximateGuildCount          *int             `json:"approximate_guild_count"`
+	RedirectURIs                   []string         `json:"redirect_uris"`
+	InteractionsEndpointURL        *string          `json:"interactions_endpoint_url"`
+	RoleConnectionsVerificationURL *string          `json:"role_connections_verification_url"`
+	Tags                           []string         `json:"tags"`
+	InstallParams                  *InstallParams   `json:"install_params"`
+	CustomInstallURL               *string          `json:"custom_install_url"`
+}
+
+// InstallParams represents the default scopes and permissions an application is installed with
+type InstallParams struct {
+	Scopes      []string `json:"scopes"`
+	Permissions string   `json:"permissions"`
+}
+
+// ApplicationTeam represents the developer team that owns a Discord application
+type ApplicationTeam struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Icon        *string      `json:"icon"`
+	OwnerUserID string       `json:"owner_user_id"`
+	Members     []TeamMember `json:"members"`
+}
+
+// TeamMember represents a member of an application team
+type TeamMember struct {
+	MembershipState int         `json:"membership_state"`
+	TeamID          string      `json:"team_id"`
+	User            DiscordUser `json:"user"`
+	Role            string      `json:"role"`
+}
+
+// Team membership states
+const (
+	TeamMembershipInvited  = 1
+	TeamMembershipAccepted = 2
+)
 
 // GuildIntegration represents a Discord guild integration
 type GuildIntegration struct {
-	ID                string                 `json:"id"`
-	Name              string                 `json:"name"`
-	Type              string                 `json:"type"`
-	Enabled           bool                   `json:"enabled"`
-	Syncing           *bool                  `json:"syncing,omitempty"`
-	RoleID            *string                `json:"role_id"`
-	EnableEmoticons   *bool                  `json:"enable_emoticons,omitempty"`
-	ExpireBehavior    *int                   `json:"expire_behavior,omitempty"`
-	ExpireGracePeriod *int                   `json:"expire_grace_period,omitempty"`
-	User              map[string]interface{} `json:"user,omitempty"`
-	Account           map[string]interface{} `json:"account"`
-	SyncedAt          *string                `json:"synced_at"`
-	SubscriberCount   *int                   `json:"subscriber_count,omitempty"`
-	Revoked           *bool                  `json:"revoked,omitempty"`
-	Application       map[string]interface{} `json:"application,omitempty"`
-	Scopes            []string               `json:"scopes,omitempty"`
+	ID                string                  `json:"id"`
+	Name              string                  `json:"name"`
+	Type              string                  `json:"type"`
+	Enabled           bool                    `json:"enabled"`
+	Syncing           *bool                   `json:"syncing,omitempty"`
+	RoleID            *string                 `json:"role_id"`
+	EnableEmoticons   *bool                   `json:"enable_emoticons,omitempty"`
+	ExpireBehavior    *int                    `json:"expire_behavior,omitempty"`
+	ExpireGracePeriod *int                    `json:"expire_grace_period,omitempty"`
+	User              *DiscordUser            `json:"user,omitempty"`
+	Account           *IntegrationAccount     `json:"account"`
+	SyncedAt          *string                 `json:"synced_at"`
+	SubscriberCount   *int                    `json:"subscriber_count,omitempty"`
+	Revoked           *bool                   `json:"revoked,omitempty"`
+	Application       *IntegrationApplication `json:"application,omitempty"`
+	Scopes            []string                `json:"scopes,omitempty"`
+}
+
+// IntegrationAccount represents the third-party account of a guild integration
+type IntegrationAccount struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// IntegrationApplication represents the bot application of a guild integration
+type IntegrationApplication struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Icon        *string      `json:"icon"`
+	Description string       `json:"description"`
+	Bot         *DiscordUser `json:"bot,omitempty"`
+}
+
+// Ban represents a Discord guild ban
+type Ban struct {
+	Reason *string     `json:"reason"`
+	User   DiscordUser `json:"user"`
 }
 
 // Application represents a Discord application (basic fields for invite context)
@@ -829,188 +1499,329 @@ type GuildScheduledEvent struct {
 	Status             int     `json:"status"`
 	EntityType         int     `json:"entity_type"`
 	EntityID           *string `json:"entity_id"`
+	UserCount          *int    `json:"user_count,omitempty"`
+}
+
+// AutoModerationRule represents a Discord auto moderation rule
+type AutoModerationRule struct {
+	ID              string                     `json:"id"`
+	GuildID         string                     `json:"guild_id"`
+	Name            string                     `json:"name"`
+	CreatorID       string                     `json:"creator_id"`
+	EventType       int                        `json:"event_type"`
+	TriggerType     int                        `json:"trigger_type"`
+	TriggerMetadata *AutoModerationTriggerMeta `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction     `json:"actions"`
+	Enabled         bool                       `json:"enabled"`
+	ExemptRoles     []string                   `json:"exempt_roles"`
+	ExemptChannels  []string                   `json:"exempt_channels"`
+}
+
+// AutoModerationTriggerMeta holds the trigger-specific settings of a rule
+type AutoModerationTriggerMeta struct {
+	KeywordFilter                []string `json:"keyword_filter,omitempty"`
+	RegexPatterns                []string `json:"regex_patterns,omitempty"`
+	Presets                      []int    `json:"presets,omitempty"`
+	AllowList                    []string `json:"allow_list,omitempty"`
+	MentionTotalLimit            *int     `json:"mention_total_limit,omitempty"`
+	MentionRaidProtectionEnabled *bool    `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+// AutoModerationAction is an action taken when a rule is triggered
+type AutoModerationAction struct {
+	Type     int                           `json:"type"`
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationActionMetadata holds the action-specific settings. ChannelID
+// is the alert channel for SEND_ALERT_MESSAGE actions.
+type AutoModerationActionMetadata struct {
+	ChannelID       *string `json:"channel_id,omitempty"`
+	DurationSeconds *int    `json:"duration_seconds,omitempty"`
+	CustomMessage   *string `json:"custom_message,omitempty"`
+}
+
+// AutoModerationRuleRequest represents a request to create or modify an auto
+// moderation rule
+type AutoModerationRuleRequest struct {
+	Name            *string                    `json:"name,omitempty"`
+	EventType       *int                       `json:"event_type,omitempty"`
+	TriggerType     *int                       `json:"trigger_type,omitempty"`
+	TriggerMetadata *AutoModerationTriggerMeta `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction     `json:"actions,omitempty"`
+	Enabled         *bool                      `json:"enabled,omitempty"`
+	ExemptRoles     []string                   `json:"exempt_roles,omitempty"`
+	ExemptChannels  []string                   `json:"exempt_channels,omitempty"`
+}
+
+// GuildOnboarding represents the onboarding flow of a guild
+type GuildOnboarding struct {
+	GuildID           string             `json:"guild_id"`
+	Prompts           []OnboardingPrompt `json:"prompts"`
+	DefaultChannelIDs []string           `json:"default_channel_ids"`
+	Enabled           bool               `json:"enabled"`
+	Mode              int                `json:"mode"`
+}
+
+// OnboardingPrompt is a single question of the onboarding flow
+type OnboardingPrompt struct {
+	ID           string                   `json:"id,omitempty"`
+	Type         int                      `json:"type"`
+	Options      []OnboardingPromptOption `json:"options"`
+	Title        string                   `json:"title"`
+	SingleSelect bool                     `json:"single_select"`
+	Required     bool                     `json:"required"`
+	InOnboarding bool                     `json:"in_onboarding"`
+}
+
+// OnboardingPromptOption is an answer to an onboarding prompt. Selecting it
+// grants RoleIDs and opts the member into ChannelIDs.
+type OnboardingPromptOption struct {
+	ID          string   `json:"id,omitempty"`
+	ChannelIDs  []string `json:"channel_ids"`
+	RoleIDs     []string `json:"role_ids"`
+	EmojiID     *string  `json:"emoji_id,omitempty"`
+	EmojiName   *string  `json:"emoji_name,omitempty"`
+	Title       string   `json:"title"`
+	Description *string  `json:"description"`
+}
+
+// ModifyGuildOnboardingRequest represents a request to replace the onboarding
+// flow of a guild
+type ModifyGuildOnboardingRequest struct {
+	Prompts           []OnboardingPrompt `json:"prompts,omitempty"`
+	DefaultChannelIDs []string           `json:"default_channel_ids,omitempty"`
+	Enabled           *bool              `json:"enabled,omitempty"`
+	Mode              *int               `json:"mode,omitempty"`
+}
+
+// GuildScheduledEventUser represents a user subscribed to a scheduled event
+type GuildScheduledEventUser struct {
+	GuildScheduledEventID string       `json:"guild_scheduled_event_id"`
+	User                  DiscordUser  `json:"user"`
+	Member                *GuildMember `json:"member,omitempty"`
+}
+
+// GetGuildScheduledEventUsersRequest represents a request to list the
+// subscribers of a scheduled event
+type GetGuildScheduledEventUsersRequest struct {
+	Limit      *int    `json:"limit,omitempty"`
+	WithMember *bool   `json:"with_member,omitempty"`
+	Before     *string `json:"before,omitempty"`
+	After      *string `json:"after,omitempty"`
 }
 
 // GetChannel retrieves a channel by ID
 func (c *DiscordClient) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/channels/"+channelID, nil)
+	channel, err := doJSON[*Channel](ctx, c, "GET", "/channels/"+channelID, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get channel")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var channel Channel
-	if err := json.NewDecoder(resp.Body).Decode(&channel); err != nil {
-		return nil, errors.Wrap(err, "failed to decode channel response")
-	}
 
-	return &channel, nil
+	return channel, nil
 }
 
 // CreateChannel creates a new channel in a guild
 func (c *DiscordClient) CreateChannel(ctx context.Context, req *CreateChannelRequest) (*Channel, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/guilds/"+req.GuildID+"/channels", req)
+	channel, err := doJSON[*Channel](ctx, c, "POST", "/guilds/"+req.GuildID+"/channels", req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create channel")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var channel Channel
-	if err := json.NewDecoder(resp.Body).Decode(&channel); err != nil {
-		return nil, errors.Wrap(err, "failed to decode created channel response")
-	}
 
-	return &channel, nil
+	return channel, nil
 }
 
 // ModifyChannel modifies an existing channel
 func (c *DiscordClient) ModifyChannel(ctx context.Context, channelID string, req *ModifyChannelRequest) (*Channel, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/channels/"+channelID, req)
+	channel, err := doJSON[*Channel](ctx, c, "PATCH", "/channels/"+channelID, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to modify channel")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var channel Channel
-	if err := json.NewDecoder(resp.Body).Decode(&channel); err != nil {
-		return nil, errors.Wrap(err, "failed to decode modified channel response")
+	return channel, nil
+}
+
+// ModifyGuildChannelPositions moves several channels of a guild in one
+// request, so Discord applies the moves together.
+func (c *DiscordClient) ModifyGuildChannelPositions(ctx context.Context, guildID string, positions []ChannelPosition) error {
+	if err := doNoContent(ctx, c, "PATCH", "/guilds/"+guildID+"/channels", positions); err != nil {
+		return errors.Wrap(err, "failed to modify channel positions")
 	}
 
-	return &channel, nil
+	return nil
 }
 
 // DeleteChannel deletes a channel
 func (c *DiscordClient) DeleteChannel(ctx context.Context, channelID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/channels/"+channelID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/channels/"+channelID, nil); err != nil {
 		return errors.Wrap(err, "failed to delete channel")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
 // ListGuildChannels lists all channels in a guild
 func (c *DiscordClient) ListGuildChannels(ctx context.Context, guildID string) ([]Channel, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/channels", nil)
+	channels, err := doJSON[[]Channel](ctx, c, "GET", "/guilds/"+guildID+"/channels", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list guild channels")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var channels []Channel
-	if err := json.NewDecoder(resp.Body).Decode(&channels); err != nil {
-		return nil, errors.Wrap(err, "failed to decode guild channels response")
-	}
 
 	return channels, nil
 }
 
 // HasMessages checks if a channel has any messages
 func (c *DiscordClient) HasMessages(ctx context.Context, channelID string) (bool, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/channels/"+channelID+"/messages?limit=1", nil)
+	messages, err := doJSON[[]Message](ctx, c, "GET", "/channels/"+channelID+"/messages?limit=1", nil)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check channel messages")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var messages []Message
-	if err := json.NewDecoder(resp.Body).Decode(&messages); err != nil {
-		return false, errors.Wrap(err, "failed to decode messages response")
-	}
 
 	return len(messages) > 0, nil
 }
 
-// Webhook methods
-
-// CreateWebhook creates a new webhook in a channel
-func (c *DiscordClient) CreateWebhook(ctx context.Context, channelID string, req *CreateWebhookRequest) (*Webhook, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/channels/"+channelID+"/webhooks", req)
+// CreateMessage posts a message in a channel
+func (c *DiscordClient) CreateMessage(ctx context.Context, channelID string, req *CreateMessageRequest) (*Message, error) {
+	message, err := doJSON[*Message](ctx, c, "POST", "/channels/"+channelID+"/messages", req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create webhook")
+		return nil, errors.Wrap(err, "failed to create message")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var webhook Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&webhook); err != nil {
-		return nil, errors.Wrap(err, "failed to decode created webhook response")
+	return message, nil
+}
+
+// GetMessage retrieves a message from a channel
+func (c *DiscordClient) GetMessage(ctx context.Context, channelID, messageID string) (*Message, error) {
+	message, err := doJSON[*Message](ctx, c, "GET", "/channels/"+channelID+"/messages/"+messageID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get message")
 	}
 
-	return &webhook, nil
+	return message, nil
 }
 
-// GetWebhook retrieves a webhook by ID
-func (c *DiscordClient) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/webhooks/"+webhookID, nil)
+// EditMessage replaces the content and embeds of a message the bot posted
+func (c *DiscordClient) EditMessage(ctx context.Context, channelID, messageID string, req *CreateMessageRequest) (*Message, error) {
+	message, err := doJSON[*Message](ctx, c, "PATCH", "/channels/"+channelID+"/messages/"+messageID, req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get webhook")
+		return nil, errors.Wrap(err, "failed to edit message")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var webhook Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&webhook); err != nil {
-		return nil, errors.Wrap(err, "failed to decode webhook response")
+	return message, nil
+}
+
+// CreateReaction reacts to a message as the bot. emoji is a Unicode emoji
+// or a custom emoji as name:id.
+func (c *DiscordClient) CreateReaction(ctx context.Context, channelID, messageID, emoji string) error {
+	endpoint := "/channels/" + channelID + "/messages/" + messageID + "/reactions/" + url.PathEscape(emoji) + "/@me"
+	if err := doNoContent(ctx, c, "PUT", endpoint, nil); err != nil {
+		return errors.Wrap(err, "failed to create reaction")
 	}
 
-	return &webhook, nil
+	return nil
+}
+
+// MaxPinnedMessages is the number of messages Discord allows to be pinned
+// in a channel.
+const MaxPinnedMessages = 50
+
+// GetPinnedMessages lists the messages pinned in a channel, most recently
+// pinned first
+func (c *DiscordClient) GetPinnedMessages(ctx context.Context, channelID string) ([]Message, error) {
+	messages, err := doJSON[[]Message](ctx, c, "GET", "/channels/"+channelID+"/pins", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pinned messages")
+	}
+
+	return messages, nil
+}
+
+// PinMessage pins a message in a channel
+func (c *DiscordClient) PinMessage(ctx context.Context, channelID, messageID string) error {
+	if err := doNoContent(ctx, c, "PUT", "/channels/"+channelID+"/pins/"+messageID, nil); err != nil {
+		return errors.Wrap(err, "failed to pin message")
+	}
+
+	return nil
+}
+
+// UnpinMessage unpins a message in a channel
+func (c *DiscordClient) UnpinMessage(ctx context.Context, channelID, messageID string) error {
+	if err := doNoContent(ctx, c, "DELETE", "/channels/"+channelID+"/pins/"+messageID, nil); err != nil {
+		return errors.Wrap(err, "failed to unpin message")
+	}
+
+	return nil
+}
+
+// Webhook methods
+
+// CreateWebhook creates a new webhook in a channel
+func (c *DiscordClient) CreateWebhook(ctx context.Context, channelID string, req *CreateWebhookRequest) (*Webhook, error) {
+	webhook, err := doJSON[*Webhook](ctx, c, "POST", "/channels/"+channelID+"/webhooks", req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook")
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (c *DiscordClient) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	webhook, err := doJSON[*Webhook](ctx, c, "GET", "/webhooks/"+webhookID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook")
+	}
+
+	return webhook, nil
+}
+
+// GetWebhookWithToken retrieves a webhook using its token, which fails once
+// the token is no longer valid
+func (c *DiscordClient) GetWebhookWithToken(ctx context.Context, webhookID, token string) (*Webhook, error) {
+	webhook, err := doJSON[*Webhook](ctx, c, "GET", "/webhooks/"+webhookID+"/"+token, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook with token")
+	}
+
+	return webhook, nil
 }
 
 // ModifyWebhook modifies an existing webhook
 func (c *DiscordClient) ModifyWebhook(ctx context.Context, webhookID string, req *ModifyWebhookRequest) (*Webhook, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/webhooks/"+webhookID, req)
+	webhook, err := doJSON[*Webhook](ctx, c, "PATCH", "/webhooks/"+webhookID, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to modify webhook")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var webhook Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&webhook); err != nil {
-		return nil, errors.Wrap(err, "failed to decode modified webhook response")
-	}
-
-	return &webhook, nil
+	return webhook, nil
 }
 
 // DeleteWebhook deletes a webhook
 func (c *DiscordClient) DeleteWebhook(ctx context.Context, webhookID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/webhooks/"+webhookID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/webhooks/"+webhookID, nil); err != nil {
 		return errors.Wrap(err, "failed to delete webhook")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
 // GetChannelWebhooks gets all webhooks for a channel
 func (c *DiscordClient) GetChannelWebhooks(ctx context.Context, channelID string) ([]Webhook, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/channels/"+channelID+"/webhooks", nil)
+	webhooks, err := doJSON[[]Webhook](ctx, c, "GET", "/channels/"+channelID+"/webhooks", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get channel webhooks")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var webhooks []Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&webhooks); err != nil {
-		return nil, errors.Wrap(err, "failed to decode channel webhooks response")
-	}
 
 	return webhooks, nil
 }
 
 // GetGuildWebhooks gets all webhooks for a guild
 func (c *DiscordClient) GetGuildWebhooks(ctx context.Context, guildID string) ([]Webhook, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/webhooks", nil)
+	webhooks, err := doJSON[[]Webhook](ctx, c, "GET", "/guilds/"+guildID+"/webhooks", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get guild webhooks")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var webhooks []Webhook
-	if err := json.NewDecoder(resp.Body).Decode(&webhooks); err != nil {
-		return nil, errors.Wrap(err, "failed to decode guild webhooks response")
-	}
 
 	return webhooks, nil
 }
@@ -1019,75 +1830,49 @@ func (c *DiscordClient) GetGuildWebhooks(ctx context.Context, guildID string) ([
 
 // CreateChannelInvite creates a new invite for a channel
 func (c *DiscordClient) CreateChannelInvite(ctx context.Context, channelID string, req *CreateInviteRequest) (*Invite, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/channels/"+channelID+"/invites", req)
+	invite, err := doJSON[*Invite](ctx, c, "POST", "/channels/"+channelID+"/invites", req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create channel invite")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var invite Invite
-	if err := json.NewDecoder(resp.Body).Decode(&invite); err != nil {
-		return nil, errors.Wrap(err, "failed to decode created invite response")
-	}
 
-	return &invite, nil
+	return invite, nil
 }
 
 // GetInvite retrieves an invite by code
 func (c *DiscordClient) GetInvite(ctx context.Context, inviteCode string) (*Invite, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/invites/"+inviteCode+"?with_counts=true&with_expiration=true", nil)
+	invite, err := doJSON[*Invite](ctx, c, "GET", "/invites/"+inviteCode+"?with_counts=true&with_expiration=true", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get invite")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var invite Invite
-	if err := json.NewDecoder(resp.Body).Decode(&invite); err != nil {
-		return nil, errors.Wrap(err, "failed to decode invite response")
-	}
 
-	return &invite, nil
+	return invite, nil
 }
 
 // DeleteInvite deletes an invite
 func (c *DiscordClient) DeleteInvite(ctx context.Context, inviteCode string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/invites/"+inviteCode, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/invites/"+inviteCode, nil); err != nil {
 		return errors.Wrap(err, "failed to delete invite")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
 // GetChannelInvites gets all invites for a channel
 func (c *DiscordClient) GetChannelInvites(ctx context.Context, channelID string) ([]Invite, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/channels/"+channelID+"/invites", nil)
+	invites, err := doJSON[[]Invite](ctx, c, "GET", "/channels/"+channelID+"/invites", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get channel invites")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var invites []Invite
-	if err := json.NewDecoder(resp.Body).Decode(&invites); err != nil {
-		return nil, errors.Wrap(err, "failed to decode channel invites response")
-	}
 
 	return invites, nil
 }
 
 // GetGuildInvites gets all invites for a guild
 func (c *DiscordClient) GetGuildInvites(ctx context.Context, guildID string) ([]Invite, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/invites", nil)
+	invites, err := doJSON[[]Invite](ctx, c, "GET", "/guilds/"+guildID+"/invites", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get guild invites")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var invites []Invite
-	if err := json.NewDecoder(resp.Body).Decode(&invites); err != nil {
-		return nil, errors.Wrap(err, "failed to decode guild invites response")
-	}
 
 	return invites, nil
 }
@@ -1096,18 +1881,55 @@ func (c *DiscordClient) GetGuildInvites(ctx context.Context, guildID string) ([]
 
 // GetGuildMember retrieves a guild member by user ID
 func (c *DiscordClient) GetGuildMember(ctx context.Context, guildID, userID string) (*GuildMember, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/members/"+userID, nil)
+	member, err := doJSON[*GuildMember](ctx, c, "GET", "/guilds/"+guildID+"/members/"+userID, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get guild member")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var member GuildMember
-	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
-		return nil, errors.Wrap(err, "failed to decode member response")
+	return member, nil
+}
+
+// GetCurrentMemberPermissions returns the bot's guild-level permissions,
+// combining the @everyone role with the bot's own roles. Guild owners and
+// administrators are granted every permission.
+func (c *DiscordClient) GetCurrentMemberPermissions(ctx context.Context, guildID string) (int64, error) {
+	member, err := c.GetGuildMember(ctx, guildID, "@me")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get bot guild member")
+	}
+
+	guild, err := c.GetGuild(ctx, guildID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get guild roles")
+	}
+
+	if member.User != nil && member.User.ID == guild.OwnerID {
+		return PermissionAll, nil
 	}
 
-	return &member, nil
+	memberRoles := make(map[string]bool, len(member.Roles))
+	for _, id := range member.Roles {
+		memberRoles[id] = true
+	}
+
+	var permissions int64
+	for _, role := range guild.Roles {
+		// The @everyone role shares its ID with the guild
+		if role.ID != guildID && !memberRoles[role.ID] {
+			continue
+		}
+		p, err := strconv.ParseInt(role.Permissions, 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid permissions on role %s", role.ID)
+		}
+		permissions |= p
+	}
+
+	if permissions&PermissionAdministrator != 0 {
+		return PermissionAll, nil
+	}
+
+	return permissions, nil
 }
 
 // ListGuildMembers lists guild members
@@ -1126,123 +1948,149 @@ func (c *DiscordClient) ListGuildMembers(ctx context.Context, guildID string, re
 		}
 	}
 
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/members"+query, nil)
+	members, err := doJSON[[]GuildMember](ctx, c, "GET", "/guilds/"+guildID+"/members"+query, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list guild members")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var members []GuildMember
-	if err := json.NewDecoder(resp.Body).Decode(&members); err != nil {
-		return nil, errors.Wrap(err, "failed to decode members response")
+	return members, nil
+}
+
+// ForEachGuildMember pages through the guild member list, calling fn with
+// each member as it is decoded. Only one member is held in memory at a time,
+// so it suits guilds of any size. It stops at the first error fn returns.
+// This requires the GUILD_MEMBERS privileged intent.
+func (c *DiscordClient) ForEachGuildMember(ctx context.Context, guildID string, fn func(*GuildMember) error) error {
+	const limit = 1000
+	after := ""
+
+	for {
+		endpoint := fmt.Sprintf("/guilds/%s/members?limit=%d", guildID, limit)
+		if after != "" {
+			endpoint += "&after=" + after
+		}
+		last := ""
+		n, err := streamJSON(ctx, c, "GET", endpoint, func(m *GuildMember) error {
+			if m.User != nil {
+				last = m.User.ID
+			}
+			return fn(m)
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list guild members")
+		}
+
+		if n < limit || last == "" {
+			return nil
+		}
+		after = last
 	}
+}
 
-	return members, nil
+// CountRoleMembers pages through the guild member list and counts the members
+// holding the given role. This requires the GUILD_MEMBERS privileged intent.
+func (c *DiscordClient) CountRoleMembers(ctx context.Context, guildID, roleID string) (int, error) {
+	count := 0
+	err := c.ForEachGuildMember(ctx, guildID, func(member *GuildMember) error {
+		for _, id := range member.Roles {
+			if id == roleID {
+				count++
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count role members")
+	}
+
+	return count, nil
 }
 
 // AddGuildMember adds a user to a guild (requires OAuth2 access token)
 func (c *DiscordClient) AddGuildMember(ctx context.Context, guildID, userID string, req *AddGuildMemberRequest) (*GuildMember, error) {
-	resp, err := c.makeRequest(ctx, "PUT", "/guilds/"+guildID+"/members/"+userID, req)
+	member, err := doJSON[*GuildMember](ctx, c, "PUT", "/guilds/"+guildID+"/members/"+userID, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to add guild member")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == 204 {
+	if member == nil {
 		// Member was already in the guild
 		return c.GetGuildMember(ctx, guildID, userID)
 	}
 
-	var member GuildMember
-	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
-		return nil, errors.Wrap(err, "failed to decode added member response")
-	}
-
-	return &member, nil
+	return member, nil
 }
 
 // ModifyGuildMember modifies a guild member
 func (c *DiscordClient) ModifyGuildMember(ctx context.Context, guildID, userID string, req *ModifyGuildMemberRequest) (*GuildMember, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/guilds/"+guildID+"/members/"+userID, req)
+	member, err := doJSON[*GuildMember](ctx, c, "PATCH", "/guilds/"+guildID+"/members/"+userID, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to modify guild member")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var member GuildMember
-	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
-		return nil, errors.Wrap(err, "failed to decode modified member response")
+	return member, nil
+}
+
+// ClearGuildMemberTimeout removes a member's timeout. ModifyGuildMemberRequest
+// omits nil fields, so the explicit null is sent separately.
+func (c *DiscordClient) ClearGuildMemberTimeout(ctx context.Context, guildID, userID string) error {
+	body := map[string]interface{}{"communication_disabled_until": nil}
+	if err := doNoContent(ctx, c, "PATCH", "/guilds/"+guildID+"/members/"+userID, body); err != nil {
+		return errors.Wrap(err, "failed to clear guild member timeout")
 	}
 
-	return &member, nil
+	return nil
 }
 
 // ModifyCurrentMember modifies the current user's member in a guild
 func (c *DiscordClient) ModifyCurrentMember(ctx context.Context, guildID string, req *ModifyCurrentMemberRequest) (*GuildMember, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/guilds/"+guildID+"/members/@me", req)
+	member, err := doJSON[*GuildMember](ctx, c, "PATCH", "/guilds/"+guildID+"/members/@me", req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to modify current member")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var member GuildMember
-	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
-		return nil, errors.Wrap(err, "failed to decode current member response")
-	}
 
-	return &member, nil
+	return member, nil
 }
 
 // AddGuildMemberRole adds a role to a guild member
 func (c *DiscordClient) AddGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error {
-	resp, err := c.makeRequest(ctx, "PUT", "/guilds/"+guildID+"/members/"+userID+"/roles/"+roleID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "PUT", "/guilds/"+guildID+"/members/"+userID+"/roles/"+roleID, nil); err != nil {
 		return errors.Wrap(err, "failed to add guild member role")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
 // RemoveGuildMemberRole removes a role from a guild member
 func (c *DiscordClient) RemoveGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/guilds/"+guildID+"/members/"+userID+"/roles/"+roleID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/guilds/"+guildID+"/members/"+userID+"/roles/"+roleID, nil); err != nil {
 		return errors.Wrap(err, "failed to remove guild member role")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
 // RemoveGuildMember removes/kicks a member from a guild
 func (c *DiscordClient) RemoveGuildMember(ctx context.Context, guildID, userID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/guilds/"+guildID+"/members/"+userID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/guilds/"+guildID+"/members/"+userID, nil); err != nil {
 		return errors.Wrap(err, "failed to remove guild member")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
 // SearchGuildMembers searches for guild members by username or nickname
 func (c *DiscordClient) SearchGuildMembers(ctx context.Context, guildID string, req *SearchGuildMembersRequest) ([]GuildMember, error) {
-	query := fmt.Sprintf("?query=%s", req.Query)
+	query := "?query=" + url.QueryEscape(req.Query)
 	if req.Limit != nil {
 		query += fmt.Sprintf("&limit=%d", *req.Limit)
 	}
 
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/members/search"+query, nil)
+	members, err := doJSON[[]GuildMember](ctx, c, "GET", "/guilds/"+guildID+"/members/search"+query, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to search guild members")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var members []GuildMember
-	if err := json.NewDecoder(resp.Body).Decode(&members); err != nil {
-		return nil, errors.Wrap(err, "failed to decode search members response")
-	}
 
 	return members, nil
 }
@@ -1251,80 +2099,94 @@ func (c *DiscordClient) SearchGuildMembers(ctx context.Context, guildID string,
 
 // GetUser retrieves a user by ID
 func (c *DiscordClient) GetUser(ctx context.Context, userID string) (*DiscordUser, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/users/"+userID, nil)
+	user, err := doJSON[*DiscordUser](ctx, c, "GET", "/users/"+userID, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get user")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var user DiscordUser
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, errors.Wrap(err, "failed to decode user response")
-	}
-
-	return &user, nil
+	return user, nil
 }
 
 // GetCurrentUser retrieves the current authenticated user
 func (c *DiscordClient) GetCurrentUser(ctx context.Context) (*DiscordUser, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/users/@me", nil)
+	user, err := doJSON[*DiscordUser](ctx, c, "GET", "/users/@me", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get current user")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var user DiscordUser
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, errors.Wrap(err, "failed to decode current user response")
-	}
 
-	return &user, nil
+	return user, nil
 }
 
 // ModifyCurrentUser modifies the current authenticated user
 func (c *DiscordClient) ModifyCurrentUser(ctx context.Context, req *ModifyCurrentUserRequest) (*DiscordUser, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/users/@me", req)
+	user, err := doJSON[*DiscordUser](ctx, c, "PATCH", "/users/@me", req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to modify current user")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var user DiscordUser
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, errors.Wrap(err, "failed to decode modified user response")
-	}
 
-	return &user, nil
+	return user, nil
 }
 
-// GetCurrentUserGuilds gets the current user's guilds
+// maxGuildsPerPage is the most guilds GET /users/@me/guilds returns at once.
+const maxGuildsPerPage = 200
+
+// GetCurrentUserGuilds gets the current user's guilds. A request with a Limit
+// fetches that single page; otherwise every guild is fetched, following the
+// Before or After cursor one page at a time.
 func (c *DiscordClient) GetCurrentUserGuilds(ctx context.Context, req *GetCurrentUserGuildsRequest) ([]Guild, error) {
-	query := ""
+	if req != nil && req.Limit != nil {
+		return c.getCurrentUserGuildsPage(ctx, req)
+	}
+
+	limit := maxGuildsPerPage
+	page := GetCurrentUserGuildsRequest{Limit: &limit}
 	if req != nil {
-		params := make([]string, 0)
-		if req.Before != nil {
-			params = append(params, fmt.Sprintf("before=%s", *req.Before))
-		}
-		if req.After != nil {
-			params = append(params, fmt.Sprintf("after=%s", *req.After))
+		page.Before, page.After, page.WithCounts = req.Before, req.After, req.WithCounts
+	}
+	var all []Guild
+
+	for {
+		guilds, err := c.getCurrentUserGuildsPage(ctx, &page)
+		if err != nil {
+			return nil, err
 		}
-		if req.Limit != nil {
-			params = append(params, fmt.Sprintf("limit=%d", *req.Limit))
+		all = append(all, guilds...)
+
+		if len(guilds) < limit {
+			return all, nil
 		}
-		if len(params) > 0 {
-			query = "?" + strings.Join(params, "&")
+		if page.Before != nil {
+			first := guilds[0].ID
+			page.Before = &first
+		} else {
+			last := guilds[len(guilds)-1].ID
+			page.After = &last
 		}
 	}
+}
 
-	resp, err := c.makeRequest(ctx, "GET", "/users/@me/guilds"+query, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get current user guilds")
+func (c *DiscordClient) getCurrentUserGuildsPage(ctx context.Context, req *GetCurrentUserGuildsRequest) ([]Guild, error) {
+	params := make([]string, 0)
+	if req.Before != nil {
+		params = append(params, fmt.Sprintf("before=%s", *req.Before))
+	}
+	if req.After != nil {
+		params = append(params, fmt.Sprintf("after=%s", *req.After))
+	}
+	if req.Limit != nil {
+		params = append(params, fmt.Sprintf("limit=%d", *req.Limit))
+	}
+	if req.WithCounts != nil {
+		params = append(params, fmt.Sprintf("with_counts=%t", *req.WithCounts))
+	}
+	query := ""
+	if len(params) > 0 {
+		query = "?" + strings.Join(params, "&")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var guilds []Guild
-	if err := json.NewDecoder(resp.Body).Decode(&guilds); err != nil {
-		return nil, errors.Wrap(err, "failed to decode current user guilds response")
+	guilds, err := doJSON[[]Guild](ctx, c, "GET", "/users/@me/guilds"+query, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current user guilds")
 	}
 
 	return guilds, nil
@@ -1332,11 +2194,9 @@ func (c *DiscordClient) GetCurrentUserGuilds(ctx context.Context, req *GetCurren
 
 // LeaveGuild leaves a guild
 func (c *DiscordClient) LeaveGuild(ctx context.Context, guildID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/users/@me/guilds/"+guildID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/users/@me/guilds/"+guildID, nil); err != nil {
 		return errors.Wrap(err, "failed to leave guild")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
@@ -1345,194 +2205,347 @@ func (c *DiscordClient) LeaveGuild(ctx context.Context, guildID string) error {
 
 // GetApplication retrieves an application by ID
 func (c *DiscordClient) GetApplication(ctx context.Context, applicationID string) (*DiscordApplication, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/applications/"+applicationID, nil)
+	application, err := doJSON[*DiscordApplication](ctx, c, "GET", "/applications/"+applicationID, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get application")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var application DiscordApplication
-	if err := json.NewDecoder(resp.Body).Decode(&application); err != nil {
-		return nil, errors.Wrap(err, "failed to decode application response")
-	}
 
-	return &application, nil
+	return application, nil
 }
 
 // GetCurrentApplication retrieves the current application
 func (c *DiscordClient) GetCurrentApplication(ctx context.Context) (*DiscordApplication, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/applications/@me", nil)
+	application, err := doJSON[*DiscordApplication](ctx, c, "GET", "/applications/@me", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get current application")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var application DiscordApplication
-	if err := json.NewDecoder(resp.Body).Decode(&application); err != nil {
-		return nil, errors.Wrap(err, "failed to decode current application response")
-	}
 
-	return &application, nil
+	return application, nil
 }
 
 // ModifyCurrentApplication modifies the current application
 func (c *DiscordClient) ModifyCurrentApplication(ctx context.Context, req *ModifyCurrentApplicationRequest) (*DiscordApplication, error) {
-	resp, err := c.makeRequest(ctx, "PATCH", "/applications/@me", req)
+	application, err := doJSON[*DiscordApplication](ctx, c, "PATCH", "/applications/@me", req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to edit current application")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	var application DiscordApplication
-	if err := json.NewDecoder(resp.Body).Decode(&application); err != nil {
-		return nil, errors.Wrap(err, "failed to decode edited application response")
+	return application, nil
+}
+
+// ListApplicationEmojis lists the emojis owned by an application
+func (c *DiscordClient) ListApplicationEmojis(ctx context.Context, applicationID string) ([]Emoji, error) {
+	resp, err := doJSON[struct {
+		Items []Emoji `json:"items"`
+	}](ctx, c, "GET", "/applications/"+applicationID+"/emojis", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list application emojis")
+	}
+
+	return resp.Items, nil
+}
+
+// GetApplicationEmoji retrieves an application emoji by ID
+func (c *DiscordClient) GetApplicationEmoji(ctx context.Context, applicationID, emojiID string) (*Emoji, error) {
+	emoji, err := doJSON[*Emoji](ctx, c, "GET", "/applications/"+applicationID+"/emojis/"+emojiID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get application emoji")
+	}
+
+	return emoji, nil
+}
+
+// CreateApplicationEmoji creates an emoji owned by an application
+func (c *DiscordClient) CreateApplicationEmoji(ctx context.Context, applicationID string, req *CreateApplicationEmojiRequest) (*Emoji, error) {
+	emoji, err := doJSON[*Emoji](ctx, c, "POST", "/applications/"+applicationID+"/emojis", req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create application emoji")
+	}
+
+	return emoji, nil
+}
+
+// ModifyApplicationEmoji renames an application emoji
+func (c *DiscordClient) ModifyApplicationEmoji(ctx context.Context, applicationID, emojiID string, req *ModifyApplicationEmojiRequest) (*Emoji, error) {
+	emoji, err := doJSON[*Emoji](ctx, c, "PATCH", "/applications/"+applicationID+"/emojis/"+emojiID, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify application emoji")
+	}
+
+	return emoji, nil
+}
+
+// DeleteApplicationEmoji deletes an application emoji
+func (c *DiscordClient) DeleteApplicationEmoji(ctx context.Context, applicationID, emojiID string) error {
+	if err := doNoContent(ctx, c, "DELETE", "/applications/"+applicationID+"/emojis/"+emojiID, nil); err != nil {
+		return errors.Wrap(err, "failed to delete application emoji")
 	}
 
-	return &application, nil
+	return nil
 }
 
 // Integration Client Methods
 
 // GetGuildIntegrations retrieves integrations for a guild
 func (c *DiscordClient) GetGuildIntegrations(ctx context.Context, guildID string) ([]GuildIntegration, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/guilds/"+guildID+"/integrations", nil)
+	integrations, err := doJSON[[]GuildIntegration](ctx, c, "GET", "/guilds/"+guildID+"/integrations", nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get guild integrations")
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var integrations []GuildIntegration
-	if err := json.NewDecoder(resp.Body).Decode(&integrations); err != nil {
-		return nil, errors.Wrap(err, "failed to decode integrations response")
-	}
 
 	return integrations, nil
 }
 
 // DeleteGuildIntegration deletes a guild integration
 func (c *DiscordClient) DeleteGuildIntegration(ctx context.Context, guildID, integrationID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", "/guilds/"+guildID+"/integrations/"+integrationID, nil)
-	if err != nil {
+	if err := doNoContent(ctx, c, "DELETE", "/guilds/"+guildID+"/integrations/"+integrationID, nil); err != nil {
 		return errors.Wrap(err, "failed to delete guild integration")
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
-// extractResourceTypeFromEndpoint extracts the resource type from a Discord API endpoint
-func (c *DiscordClient) extractResourceTypeFromEndpoint(endpoint string) string {
-	// Remove leading slash and query parameters
-	path := strings.TrimPrefix(endpoint, "/")
-	if idx := strings.Index(path, "?"); idx != -1 {
-		path = path[:idx]
-	}
-
-	parts := strings.Split(path, "/")
-	if len(parts) == 0 {
-		return "unknown"
-	}
-
-	// Map Discord API endpoints to resource types
-	switch parts[0] {
-	case "guilds":
-		if len(parts) >= 3 {
-			switch parts[2] {
-			case "channels":
-				return "channel"
-			case "roles":
-				return "role"
-			case "members":
-				return "member"
-			case "webhooks":
-				return "webhook"
-			case "invites":
-				return "invite"
-			case "integrations":
-				return "integration"
-			default:
-				return "guild"
-			}
+// Ban Client Methods
+
+// GetGuildAuditLog retrieves a single page of a guild's audit log
+func (c *DiscordClient) GetGuildAuditLog(ctx context.Context, guildID string, req *GetGuildAuditLogRequest) (*AuditLog, error) {
+	query := ""
+	if req != nil {
+		params := make([]string, 0)
+		if req.Limit != nil {
+			params = append(params, fmt.Sprintf("limit=%d", *req.Limit))
 		}
-		return "guild"
-	case "channels":
-		if len(parts) >= 3 {
-			switch parts[2] {
-			case "webhooks":
-				return "webhook"
-			case "invites":
-				return "invite"
-			default:
-				return "channel"
-			}
+		if req.Before != nil {
+			params = append(params, fmt.Sprintf("before=%s", *req.Before))
+		}
+		if req.After != nil {
+			params = append(params, fmt.Sprintf("after=%s", *req.After))
+		}
+		if req.ActionType != nil {
+			params = append(params, fmt.Sprintf("action_type=%d", *req.ActionType))
+		}
+		if len(params) > 0 {
+			query = "?" + strings.Join(params, "&")
 		}
-		return "channel"
-	case "users":
-		return "user"
-	case "applications":
-		return "application"
-	case "webhooks":
-		return "webhook"
-	case "invites":
-		return "invite"
-	default:
-		return "unknown"
 	}
+
+	log, err := doJSON[*AuditLog](ctx, c, "GET", "/guilds/"+guildID+"/audit-logs"+query, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild audit log")
+	}
+
+	return log, nil
 }
 
-// recordRateLimitMetrics parses rate limit headers and records metrics
-func (c *DiscordClient) recordRateLimitMetrics(resourceType, endpoint string, headers http.Header) {
-	// Discord rate limit headers
-	remaining := headers.Get("X-RateLimit-Remaining")
-	resetAfter := headers.Get("X-RateLimit-Reset-After")
-	limit := headers.Get("X-RateLimit-Limit")
+// GetGuildBans retrieves a single page of bans for a guild
+func (c *DiscordClient) GetGuildBans(ctx context.Context, guildID string, req *GetGuildBansRequest) ([]Ban, error) {
+	query := ""
+	if req != nil {
+		params := make([]string, 0)
+		if req.Limit != nil {
+			params = append(params, fmt.Sprintf("limit=%d", *req.Limit))
+		}
+		if req.Before != nil {
+			params = append(params, fmt.Sprintf("before=%s", *req.Before))
+		}
+		if req.After != nil {
+			params = append(params, fmt.Sprintf("after=%s", *req.After))
+		}
+		if len(params) > 0 {
+			query = "?" + strings.Join(params, "&")
+		}
+	}
+
+	bans, err := doJSON[[]Ban](ctx, c, "GET", "/guilds/"+guildID+"/bans"+query, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild bans")
+	}
 
-	if remaining != "" {
-		if remainingInt, err := strconv.Atoi(remaining); err == nil {
-			// Calculate reset time
-			var resetTime time.Time
-			if resetAfter != "" {
-				if resetAfterFloat, err := strconv.ParseFloat(resetAfter, 64); err == nil {
-					resetTime = time.Now().Add(time.Duration(resetAfterFloat) * time.Second)
-				}
-			}
+	return bans, nil
+}
 
-			c.metricsRecorder.RecordRateLimit(resourceType, endpoint, remainingInt, resetTime)
+// ForEachGuildBan pages through every ban in a guild using the after cursor,
+// calling fn with each ban as it is decoded. It stops at the first error fn
+// returns.
+func (c *DiscordClient) ForEachGuildBan(ctx context.Context, guildID string, fn func(*Ban) error) error {
+	const limit = 1000
+	after := ""
 
-			// Log rate limit information for debugging
-			c.logger.Info("Discord rate limit info",
-				"resourceType", resourceType,
-				"endpoint", endpoint,
-				"remaining", remainingInt,
-				"limit", limit,
-				"resetAfter", resetAfter)
+	for {
+		endpoint := fmt.Sprintf("/guilds/%s/bans?limit=%d", guildID, limit)
+		if after != "" {
+			endpoint += "&after=" + after
 		}
+		last := ""
+		n, err := streamJSON(ctx, c, "GET", endpoint, func(b *Ban) error {
+			last = b.User.ID
+			return fn(b)
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to get guild bans")
+		}
+
+		if n < limit {
+			return nil
+		}
+		after = last
 	}
 }
 
-// mapHTTPMethodToOperation maps HTTP methods to operation types
-func (c *DiscordClient) mapHTTPMethodToOperation(method string) string {
-	switch strings.ToUpper(method) {
-	case "POST":
-		return "create"
-	case "GET":
-		return "observe"
-	case "PATCH", "PUT":
-		return "update"
-	case "DELETE":
-		return "delete"
-	default:
-		return "unknown"
+// ListAllGuildBans pages through every ban in a guild using the after cursor
+func (c *DiscordClient) ListAllGuildBans(ctx context.Context, guildID string) ([]Ban, error) {
+	var all []Ban
+	err := c.ForEachGuildBan(ctx, guildID, func(b *Ban) error {
+		all = append(all, *b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// Auto Moderation Client Methods
+
+// ListAutoModerationRules lists the auto moderation rules of a guild
+func (c *DiscordClient) ListAutoModerationRules(ctx context.Context, guildID string) ([]AutoModerationRule, error) {
+	rules, err := doJSON[[]AutoModerationRule](ctx, c, "GET", "/guilds/"+guildID+"/auto-moderation/rules", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list auto moderation rules")
+	}
+
+	return rules, nil
+}
+
+// GetAutoModerationRule retrieves an auto moderation rule by ID
+func (c *DiscordClient) GetAutoModerationRule(ctx context.Context, guildID, ruleID string) (*AutoModerationRule, error) {
+	rule, err := doJSON[*AutoModerationRule](ctx, c, "GET", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get auto moderation rule")
+	}
+
+	return rule, nil
+}
+
+// CreateAutoModerationRule creates an auto moderation rule
+func (c *DiscordClient) CreateAutoModerationRule(ctx context.Context, guildID string, req *AutoModerationRuleRequest) (*AutoModerationRule, error) {
+	rule, err := doJSON[*AutoModerationRule](ctx, c, "POST", "/guilds/"+guildID+"/auto-moderation/rules", req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create auto moderation rule")
+	}
+
+	return rule, nil
+}
+
+// ModifyAutoModerationRule modifies an auto moderation rule
+func (c *DiscordClient) ModifyAutoModerationRule(ctx context.Context, guildID, ruleID string, req *AutoModerationRuleRequest) (*AutoModerationRule, error) {
+	rule, err := doJSON[*AutoModerationRule](ctx, c, "PATCH", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify auto moderation rule")
+	}
+
+	return rule, nil
+}
+
+// DeleteAutoModerationRule deletes an auto moderation rule
+func (c *DiscordClient) DeleteAutoModerationRule(ctx context.Context, guildID, ruleID string) error {
+	if err := doNoContent(ctx, c, "DELETE", "/guilds/"+guildID+"/auto-moderation/rules/"+ruleID, nil); err != nil {
+		return errors.Wrap(err, "failed to delete auto moderation rule")
+	}
+
+	return nil
+}
+
+// Onboarding Client Methods
+
+// GetGuildOnboarding retrieves the onboarding flow of a guild
+func (c *DiscordClient) GetGuildOnboarding(ctx context.Context, guildID string) (*GuildOnboarding, error) {
+	onboarding, err := doJSON[*GuildOnboarding](ctx, c, "GET", "/guilds/"+guildID+"/onboarding", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild onboarding")
+	}
+
+	return onboarding, nil
+}
+
+// ModifyGuildOnboarding replaces the onboarding flow of a guild
+func (c *DiscordClient) ModifyGuildOnboarding(ctx context.Context, guildID string, req *ModifyGuildOnboardingRequest) (*GuildOnboarding, error) {
+	onboarding, err := doJSON[*GuildOnboarding](ctx, c, "PUT", "/guilds/"+guildID+"/onboarding", req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify guild onboarding")
+	}
+
+	return onboarding, nil
+}
+
+// Scheduled Event Client Methods
+
+// GetGuildScheduledEvent retrieves a scheduled event, optionally including its
+// subscriber count
+func (c *DiscordClient) GetGuildScheduledEvent(ctx context.Context, guildID, eventID string, withUserCount bool) (*GuildScheduledEvent, error) {
+	endpoint := "/guilds/" + guildID + "/scheduled-events/" + eventID
+	if withUserCount {
+		endpoint += "?with_user_count=true"
+	}
+
+	event, err := doJSON[*GuildScheduledEvent](ctx, c, "GET", endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get scheduled event")
+	}
+
+	return event, nil
+}
+
+// GetGuildScheduledEventUsers retrieves a single page of users subscribed to a
+// scheduled event
+func (c *DiscordClient) GetGuildScheduledEventUsers(ctx context.Context, guildID, eventID string, req *GetGuildScheduledEventUsersRequest) ([]GuildScheduledEventUser, error) {
+	query := ""
+	if req != nil {
+		params := make([]string, 0)
+		if req.Limit != nil {
+			params = append(params, fmt.Sprintf("limit=%d", *req.Limit))
+		}
+		if req.WithMember != nil {
+			params = append(params, fmt.Sprintf("with_member=%t", *req.WithMember))
+		}
+		if req.Before != nil {
+			params = append(params, fmt.Sprintf("before=%s", *req.Before))
+		}
+		if req.After != nil {
+			params = append(params, fmt.Sprintf("after=%s", *req.After))
+		}
+		if len(params) > 0 {
+			query = "?" + strings.Join(params, "&")
+		}
 	}
+
+	users, err := doJSON[[]GuildScheduledEventUser](ctx, c, "GET", "/guilds/"+guildID+"/scheduled-events/"+eventID+"/users"+query, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get scheduled event users")
+	}
+
+	return users, nil
 }
 
-// mapHTTPStatusToStatus maps HTTP status codes to status types
-func (c *DiscordClient) mapHTTPStatusToStatus(statusCode int) string {
-	if statusCode == 429 {
-		return "rate_limited"
-	} else if statusCode >= 400 {
-		return "error"
-	} else {
-		return "success"
+// CountScheduledEventSubscribers pages through the subscribers of a scheduled
+// event and returns how many there are
+func (c *DiscordClient) CountScheduledEventSubscribers(ctx context.Context, guildID, eventID string) (int, error) {
+	limit := 100
+	var after *string
+	count := 0
+
+	for {
+		users, err := c.GetGuildScheduledEventUsers(ctx, guildID, eventID, &GetGuildScheduledEventUsersRequest{Limit: &limit, After: after})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to count scheduled event subscribers")
+		}
+		count += len(users)
+
+		if len(users) < limit {
+			return count, nil
+		}
+		lastID := users[len(users)-1].User.ID
+		after = &lastID
 	}
 }
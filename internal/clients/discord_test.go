@@ -19,9 +19,13 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -287,6 +291,167 @@ func TestListGuilds(t *testing.T) {
 	}
 }
 
+func TestListGuildsPagination(t *testing.T) {
+	var mockGuilds []Guild
+	for i := 1; i <= 450; i++ {
+		mockGuilds = append(mockGuilds, Guild{ID: strconv.Itoa(100000 + i), Name: fmt.Sprintf("Guild %d", i)})
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if limit := r.URL.Query().Get("limit"); limit != "200" {
+			t.Errorf("Expected limit=200, got %q", limit)
+		}
+
+		page := mockGuilds
+		if after := r.URL.Query().Get("after"); after != "" {
+			for i, g := range mockGuilds {
+				if g.ID == after {
+					page = mockGuilds[i+1:]
+				}
+			}
+		}
+		if len(page) > 200 {
+			page = page[:200]
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	guilds, err := client.ListGuilds(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if diff := cmp.Diff(mockGuilds, guilds); diff != "" {
+		t.Errorf("Guilds mismatch (-want +got):\n%s", diff)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestCountRoleMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/123456789/members" {
+			t.Errorf("Expected path /guilds/123456789/members, got %s", r.URL.Path)
+		}
+
+		var members []GuildMember
+		if r.URL.Query().Get("after") == "" {
+			// Full first page so the client requests the next one
+			for i := 0; i < 1000; i++ {
+				roles := []string{}
+				if i%10 == 0 {
+					roles = append(roles, "555")
+				}
+				members = append(members, GuildMember{User: &DiscordUser{ID: "1000"}, Roles: roles})
+			}
+		} else {
+			members = []GuildMember{
+				{User: &DiscordUser{ID: "2000"}, Roles: []string{"555", "666"}},
+				{User: &DiscordUser{ID: "2001"}, Roles: []string{"666"}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(members); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	count, err := client.CountRoleMembers(context.Background(), "123456789", "555")
+	if err != nil {
+		t.Fatalf("CountRoleMembers failed: %v", err)
+	}
+
+	if count != 101 {
+		t.Errorf("Expected 101 members, got %d", count)
+	}
+}
+
+func TestForEachGuildMember(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after") == "" {
+			// Stream a full first page so the client requests the next one
+			_, _ = w.Write([]byte("["))
+			for i := 0; i < 1000; i++ {
+				if i > 0 {
+					_, _ = w.Write([]byte(","))
+				}
+				_, _ = fmt.Fprintf(w, `{"user": {"id": "%d"}, "roles": []}`, 1000+i)
+			}
+			_, _ = w.Write([]byte("]"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"user": {"id": "3000"}, "roles": []}]`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	var ids []string
+	err := client.ForEachGuildMember(context.Background(), "123456789", func(m *GuildMember) error {
+		ids = append(ids, m.User.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachGuildMember failed: %v", err)
+	}
+	if len(ids) != 1001 || ids[999] != "1999" || ids[1000] != "3000" {
+		t.Errorf("Unexpected members: %d, last %s", len(ids), ids[len(ids)-1])
+	}
+	if len(requests) != 2 || requests[1] != "limit=1000&after=1999" {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+
+	// An error from fn stops the listing
+	requests = nil
+	stop := errors.New("stop")
+	seen := 0
+	err = client.ForEachGuildMember(context.Background(), "123456789", func(*GuildMember) error {
+		seen++
+		if seen == 10 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || seen != 10 || len(requests) != 1 {
+		t.Errorf("Expected listing to stop after 10 members, got %v after %d members and %d requests", err, seen, len(requests))
+	}
+}
+
+func TestStreamJSONRejectsObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"message": "not a list"}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	_, err := streamJSON(context.Background(), client, "GET", "/guilds/1/bans", func(*Ban) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "expected an array") {
+		t.Errorf("Expected an error for a non-array response, got %v", err)
+	}
+}
+
 func TestGetChannel(t *testing.T) {
 	mockChannel := Channel{
 		ID:      "123456789",
@@ -431,6 +596,43 @@ func TestModifyChannel(t *testing.T) {
 	}
 }
 
+func TestModifyGuildChannelPositions(t *testing.T) {
+	position, parentID := 3, "555"
+	mockRequest := []ChannelPosition{
+		{ID: "1", Position: &position, ParentID: &parentID},
+		{ID: "2", Position: &position},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Expected PATCH request, got %s", r.Method)
+		}
+
+		if r.URL.Path != "/guilds/987654321/channels" {
+			t.Errorf("Expected path /guilds/987654321/channels, got %s", r.URL.Path)
+		}
+
+		var receivedRequest []ChannelPosition
+		if err := json.NewDecoder(r.Body).Decode(&receivedRequest); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+
+		if diff := cmp.Diff(mockRequest, receivedRequest); diff != "" {
+			t.Errorf("Request mismatch (-want +got):\n%s", diff)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	if err := client.ModifyGuildChannelPositions(context.Background(), "987654321", mockRequest); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
 func TestDeleteChannel(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -546,6 +748,30 @@ func TestCreateGuildError(t *testing.T) {
 	}
 }
 
+func TestCreateGuildNotAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		if _, err := w.Write([]byte(`{"message": "Bots cannot use this endpoint", "code": 20001}`)); err != nil {
+			t.Errorf("Failed to write error response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	_, err := client.CreateGuild(context.Background(), &CreateGuildRequest{Name: "Test Guild"})
+	if !IsGuildCreateNotAllowed(err) {
+		t.Errorf("Expected GuildCreateNotAllowed, got %v", err)
+	}
+	if ErrorCode(err) != ErrorCodeBotsCannotUseEndpoint {
+		t.Errorf("Expected Discord's error to be kept, got %v", err)
+	}
+	if IsGuildCreateNotAllowed(&APIError{StatusCode: http.StatusForbidden, Code: ErrorCodeBotsCannotUseEndpoint}) {
+		t.Error("Expected a 20001 from another endpoint not to be GuildCreateNotAllowed")
+	}
+}
+
 func TestModifyGuildError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusForbidden)
@@ -674,3 +900,724 @@ func TestGetChannelError(t *testing.T) {
 		t.Error("Expected error for unknown channel, got nil")
 	}
 }
+
+func TestListAllGuildBans(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/guilds/123456789/bans" {
+			t.Errorf("Expected path /guilds/123456789/bans, got %s", r.URL.Path)
+		}
+
+		var bans []Ban
+		if after := r.URL.Query().Get("after"); after == "" {
+			for i := 0; i < 1000; i++ {
+				bans = append(bans, Ban{User: DiscordUser{ID: "100"}})
+			}
+		} else if after != "100" {
+			t.Errorf("Expected after cursor 100, got %s", after)
+		} else {
+			bans = []Ban{{User: DiscordUser{ID: "200"}}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(bans); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	bans, err := client.ListAllGuildBans(context.Background(), "123456789")
+	if err != nil {
+		t.Fatalf("ListAllGuildBans failed: %v", err)
+	}
+
+	if len(bans) != 1001 {
+		t.Errorf("Expected 1001 bans, got %d", len(bans))
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestCountScheduledEventSubscribers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/123456789/scheduled-events/555/users" {
+			t.Errorf("Expected path /guilds/123456789/scheduled-events/555/users, got %s", r.URL.Path)
+		}
+
+		var users []GuildScheduledEventUser
+		if r.URL.Query().Get("after") == "" {
+			for i := 0; i < 100; i++ {
+				users = append(users, GuildScheduledEventUser{User: DiscordUser{ID: "100"}})
+			}
+		} else {
+			users = []GuildScheduledEventUser{
+				{User: DiscordUser{ID: "200"}},
+				{User: DiscordUser{ID: "201"}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(users); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	count, err := client.CountScheduledEventSubscribers(context.Background(), "123456789", "555")
+	if err != nil {
+		t.Fatalf("CountScheduledEventSubscribers failed: %v", err)
+	}
+
+	if count != 102 {
+		t.Errorf("Expected 102 subscribers, got %d", count)
+	}
+}
+
+func TestCreateAutoModerationRule(t *testing.T) {
+	alertChannel := "222"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/guilds/123456789/auto-moderation/rules" {
+			t.Errorf("Expected path /guilds/123456789/auto-moderation/rules, got %s", r.URL.Path)
+		}
+
+		var req AutoModerationRuleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if len(req.Actions) != 1 || req.Actions[0].Metadata == nil || req.Actions[0].Metadata.ChannelID == nil || *req.Actions[0].Metadata.ChannelID != alertChannel {
+			t.Errorf("Expected alert action for channel %s, got %+v", alertChannel, req.Actions)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(AutoModerationRule{ID: "999", GuildID: "123456789", Name: *req.Name, Actions: req.Actions}); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	name := "Block invites"
+	rule, err := client.CreateAutoModerationRule(context.Background(), "123456789", &AutoModerationRuleRequest{
+		Name: &name,
+		Actions: []AutoModerationAction{
+			{Type: 2, Metadata: &AutoModerationActionMetadata{ChannelID: &alertChannel}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateAutoModerationRule failed: %v", err)
+	}
+
+	if rule.ID != "999" {
+		t.Errorf("Expected rule ID 999, got %s", rule.ID)
+	}
+}
+
+func TestModifyGuildOnboarding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/guilds/123456789/onboarding" {
+			t.Errorf("Expected path /guilds/123456789/onboarding, got %s", r.URL.Path)
+		}
+
+		var req ModifyGuildOnboardingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(GuildOnboarding{GuildID: "123456789", Prompts: req.Prompts, Enabled: true}); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	prompts := []OnboardingPrompt{{
+		Title: "Pick your interests",
+		Options: []OnboardingPromptOption{
+			{Title: "Go", RoleIDs: []string{"111"}, ChannelIDs: []string{"222"}},
+		},
+	}}
+	onboarding, err := client.ModifyGuildOnboarding(context.Background(), "123456789", &ModifyGuildOnboardingRequest{Prompts: prompts})
+	if err != nil {
+		t.Fatalf("ModifyGuildOnboarding failed: %v", err)
+	}
+
+	if diff := cmp.Diff(prompts, onboarding.Prompts); diff != "" {
+		t.Errorf("Onboarding prompts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsBotNotInGuild(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "missing access",
+			err:  errors.New("failed to get channel: Discord API error: 403 - {\"message\": \"Missing Access\", \"code\": 50001}"),
+			want: true,
+		},
+		{
+			name: "unknown guild",
+			err:  errors.New("Discord API error: 404 - {\"message\": \"Unknown Guild\", \"code\": 10004}"),
+			want: true,
+		},
+		{
+			name: "unknown channel",
+			err:  errors.New("Discord API error: 404 - {\"message\": \"Unknown Channel\", \"code\": 10003}"),
+			want: false,
+		},
+		{
+			name: "missing permissions",
+			err:  errors.New("Discord API error: 403 - {\"message\": \"Missing Permissions\", \"code\": 50013}"),
+			want: false,
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsBotNotInGuild(tc.err); got != tc.want {
+				t.Errorf("IsBotNotInGuild() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "not found",
+			err:  errors.Wrap(&APIError{StatusCode: http.StatusNotFound, Code: ErrorCodeUnknownRole}, "failed to get role"),
+			want: true,
+		},
+		{
+			name: "forbidden",
+			err:  &APIError{StatusCode: http.StatusForbidden, Code: ErrorCodeMissingAccess},
+			want: false,
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("Discord API error: 404 - not found"),
+			want: false,
+		},
+		{
+			name: "nil",
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsNotFound(tc.err); got != tc.want {
+				t.Errorf("IsNotFound() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetWebhookWithToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/webhooks/123456789/valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Invalid Webhook Token", "code": 50027}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(Webhook{ID: "123456789", Name: "CI"}); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	webhook, err := client.GetWebhookWithToken(context.Background(), "123456789", "valid-token")
+	if err != nil {
+		t.Fatalf("GetWebhookWithToken failed: %v", err)
+	}
+	if webhook.Name != "CI" {
+		t.Errorf("Expected webhook name CI, got %s", webhook.Name)
+	}
+
+	_, err = client.GetWebhookWithToken(context.Background(), "123456789", "stale-token")
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Expected API error for stale token, got %v", err)
+	}
+	if apiErr.Code != ErrorCodeInvalidWebhookToken {
+		t.Errorf("Expected code %d, got %d", ErrorCodeInvalidWebhookToken, apiErr.Code)
+	}
+	// The token authenticates the request on its own, so it must not
+	// reach errors, conditions or events
+	if strings.Contains(err.Error(), "stale-token") || apiErr.Endpoint != "/webhooks/123456789/REDACTED" {
+		t.Errorf("Expected the token to be redacted, got %q", err.Error())
+	}
+}
+
+func TestRedactPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"/webhooks/1":                         "/webhooks/1",
+		"/webhooks/1/secret":                  "/webhooks/1/REDACTED",
+		"/api/v10/webhooks/1/secret/messages": "/api/v10/webhooks/1/REDACTED/messages",
+		"/interactions/1/secret/callback":     "/interactions/1/REDACTED/callback",
+		"/channels/1/webhooks":                "/channels/1/webhooks",
+	} {
+		if got := redactPath(path); got != want {
+			t.Errorf("redactPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRequestErrorsRedactTokens(t *testing.T) {
+	client := NewDiscordClient("test-token")
+	client.baseURL = "https://discord.invalid"
+	client.transport = RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset by peer")
+	})
+	client.Use()
+
+	_, err := client.GetWebhookWithToken(context.Background(), "1", "webhook-secret")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if strings.Contains(err.Error(), "webhook-secret") {
+		t.Errorf("Expected the token to be redacted, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "/webhooks/1/REDACTED") {
+		t.Errorf("Expected the redacted URL, got %q", err.Error())
+	}
+}
+
+func TestDoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/roles/1":
+			_, _ = w.Write([]byte(`{"id": "1", "name": "Admins"}`))
+		case "/roles/2":
+			w.WriteHeader(http.StatusNoContent)
+		case "/roles/3":
+			_, _ = w.Write([]byte(`not json`))
+		case "/roles/4":
+			// An empty 200, as some endpoints send when nothing changed
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	role, err := doJSON[*Role](ctx, client, "GET", "/roles/1", nil)
+	if err != nil {
+		t.Fatalf("doJSON failed: %v", err)
+	}
+	if role == nil || role.Name != "Admins" {
+		t.Errorf("Expected role Admins, got %+v", role)
+	}
+
+	role, err = doJSON[*Role](ctx, client, "GET", "/roles/2", nil)
+	if err != nil {
+		t.Fatalf("doJSON failed on 204: %v", err)
+	}
+	if role != nil {
+		t.Errorf("Expected nil role for 204, got %+v", role)
+	}
+
+	role, err = doJSON[*Role](ctx, client, "GET", "/roles/4", nil)
+	if err != nil {
+		t.Fatalf("doJSON failed on empty body: %v", err)
+	}
+	if role != nil {
+		t.Errorf("Expected nil role for empty body, got %+v", role)
+	}
+
+	if err := doNoContent(ctx, client, "DELETE", "/roles/1", nil); err != nil {
+		t.Errorf("doNoContent failed: %v", err)
+	}
+
+	_, err = doJSON[*Role](ctx, client, "GET", "/roles/3", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "failed to decode response from GET /roles/3") {
+		t.Errorf("Expected decode error naming the endpoint, got %v", err)
+	}
+}
+
+func TestModifyRoleNoContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PATCH" && r.URL.Path == "/guilds/1/roles/2":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/guilds/1/roles/2":
+			_, _ = w.Write([]byte(`{"id": "2", "name": "Members"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	role, err := client.ModifyRole(context.Background(), "1", "2", ModifyRoleRequest{})
+	if err != nil {
+		t.Fatalf("ModifyRole failed: %v", err)
+	}
+	if role.Name != "Members" {
+		t.Errorf("Expected role to be read back after 204, got %+v", role)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Missing Permissions", "code": 50013}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	_, err := client.ModifyChannel(context.Background(), "456", &ModifyChannelRequest{})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Expected an APIError in %v", err)
+	}
+	want := &APIError{
+		Method:     "PATCH",
+		Endpoint:   "/channels/456",
+		ChannelID:  "456",
+		StatusCode: http.StatusForbidden,
+		Code:       50013,
+		Message:    "Missing Permissions",
+		Body:       `{"message": "Missing Permissions", "code": 50013}`,
+	}
+	if diff := cmp.Diff(want, apiErr); diff != "" {
+		t.Errorf("APIError: -want, +got:\n%s", diff)
+	}
+	if !strings.Contains(err.Error(), "Discord API error: 403 - ") || !strings.HasSuffix(err.Error(), "(PATCH /channels/456)") {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+	if code := ErrorCode(err); code != 50013 {
+		t.Errorf("Expected error code 50013, got %d", code)
+	}
+}
+
+func TestNewAPIErrorGuild(t *testing.T) {
+	e := newAPIError("GET", "/guilds/123/members?limit=1000", http.StatusNotFound, []byte(`not json`))
+	if e.GuildID != "123" || e.Endpoint != "/guilds/123/members" || e.Code != 0 {
+		t.Errorf("Unexpected APIError %+v", e)
+	}
+}
+
+func TestGetGuildWidget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/guilds/123/widget.json":
+			_, _ = w.Write([]byte(`{"id": "123", "name": "Test", "instant_invite": null, "channels": [], "presence_count": 7}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	widget, err := client.GetGuildWidget(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("GetGuildWidget failed: %v", err)
+	}
+	if widget.PresenceCount != 7 || widget.InstantInvite != nil {
+		t.Errorf("Unexpected widget %+v", widget)
+	}
+
+	if got := WidgetImageURL("123", "banner2"); got != "https://discord.com/api/v10/guilds/123/widget.png?style=banner2" {
+		t.Errorf("Unexpected widget image URL %s", got)
+	}
+}
+
+func TestClearGuildMemberTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/guilds/123/members/456" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if until, ok := body["communication_disabled_until"]; !ok || until != nil {
+			t.Errorf("Expected communication_disabled_until to be null, got %v", body)
+		}
+		_, _ = w.Write([]byte(`{"user": {"id": "456"}, "communication_disabled_until": null}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	if err := client.ClearGuildMemberTimeout(context.Background(), "123", "456"); err != nil {
+		t.Fatalf("ClearGuildMemberTimeout failed: %v", err)
+	}
+}
+
+func TestModifyGuildMemberVerification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/guilds/123/member-verification" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		fields, ok := body["form_fields"].(string)
+		if !ok {
+			t.Fatalf("Expected form_fields to be a JSON string, got %T", body["form_fields"])
+		}
+		if fields != `[{"field_type":"TERMS","label":"Read the rules","values":["Be nice"],"required":true}]` {
+			t.Errorf("Unexpected form fields %s", fields)
+		}
+		if body["enabled"] != true {
+			t.Errorf("Expected enabled, got %v", body["enabled"])
+		}
+		_, _ = w.Write([]byte(`{"version": "2025-01-01T00:00:00+00:00", "description": null, "form_fields": [{"field_type": "TERMS", "label": "Read the rules", "values": ["Be nice"], "required": true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	enabled := true
+	verification, err := client.ModifyGuildMemberVerification(context.Background(), "123", &ModifyMemberVerificationRequest{
+		Enabled: &enabled,
+		FormFields: []MemberVerificationField{{
+			FieldType: MemberVerificationFieldTerms,
+			Label:     "Read the rules",
+			Values:    []string{"Be nice"},
+			Required:  true,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("ModifyGuildMemberVerification failed: %v", err)
+	}
+	if len(verification.FormFields) != 1 || verification.FormFields[0].Values[0] != "Be nice" {
+		t.Errorf("Unexpected verification %+v", verification)
+	}
+}
+
+func TestListApplicationEmojis(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/42/emojis" {
+			t.Errorf("Expected path /applications/42/emojis, got %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"items": [{"id": "1", "name": "wave"}, {"id": "2", "name": "spin", "animated": true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	emojis, err := client.ListApplicationEmojis(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("ListApplicationEmojis failed: %v", err)
+	}
+	if len(emojis) != 2 || emojis[0].Name != "wave" || !emojis[1].Animated {
+		t.Errorf("Unexpected emojis %+v", emojis)
+	}
+}
+
+func TestDecodeTypedApplicationFields(t *testing.T) {
+	var app DiscordApplication
+	body := `{"id": "1", "bot": {"id": "2", "username": "bot"}, "install_params": {"scopes": ["bot", "applications.commands"], "permissions": "8"}}`
+	if err := json.Unmarshal([]byte(body), &app); err != nil {
+		t.Fatalf("Failed to decode application: %v", err)
+	}
+	if app.Bot == nil || app.Bot.ID != "2" {
+		t.Errorf("Unexpected bot %+v", app.Bot)
+	}
+	if app.InstallParams == nil || len(app.InstallParams.Scopes) != 2 || app.InstallParams.Permissions != "8" {
+		t.Errorf("Unexpected install params %+v", app.InstallParams)
+	}
+
+	var integration GuildIntegration
+	body = `{"id": "3", "user": {"id": "4"}, "account": {"id": "acc", "name": "Streamer"}, "application": {"id": "5", "name": "App", "bot": {"id": "6"}}}`
+	if err := json.Unmarshal([]byte(body), &integration); err != nil {
+		t.Fatalf("Failed to decode integration: %v", err)
+	}
+	if integration.User.ID != "4" || integration.Account.Name != "Streamer" || integration.Application.Bot.ID != "6" {
+		t.Errorf("Unexpected integration %+v", integration)
+	}
+}
+
+func TestCreateMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/channels/123/messages" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req CreateMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Content != "Hello" || len(req.Embeds) != 1 || req.Embeds[0].Fields[0].Name != "When" {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"id": "456", "channel_id": "123", "content": "Hello", "timestamp": "2025-01-01T00:00:00.000000+00:00"}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	message, err := client.CreateMessage(context.Background(), "123", &CreateMessageRequest{
+		Content: "Hello",
+		Embeds:  []Embed{{Title: "Launch", Fields: []EmbedField{{Name: "When", Value: "Now"}}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateMessage failed: %v", err)
+	}
+	if message.ID != "456" || message.ChannelID != "123" {
+		t.Errorf("Unexpected message %+v", message)
+	}
+}
+
+func TestEditMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/channels/123/messages/456" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req CreateMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if len(req.Embeds) != 1 || req.Embeds[0].Title != "Status" {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"id": "456", "channel_id": "123", "timestamp": "2025-01-01T00:00:00.000000+00:00"}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	message, err := client.EditMessage(context.Background(), "123", "456", &CreateMessageRequest{
+		Embeds: []Embed{{Title: "Status"}},
+	})
+	if err != nil {
+		t.Fatalf("EditMessage failed: %v", err)
+	}
+	if message.ID != "456" {
+		t.Errorf("Unexpected message %+v", message)
+	}
+}
+
+func TestCreateReaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.EscapedPath() != "/channels/123/messages/456/reactions/%E2%9C%85/@me" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	if err := client.CreateReaction(context.Background(), "123", "456", "✅"); err != nil {
+		t.Fatalf("CreateReaction failed: %v", err)
+	}
+}
+
+func TestPins(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" {
+			_, _ = w.Write([]byte(`[{"id": "2", "channel_id": "123"}, {"id": "1", "channel_id": "123"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	pins, err := client.GetPinnedMessages(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("GetPinnedMessages failed: %v", err)
+	}
+	if len(pins) != 2 || pins[0].ID != "2" {
+		t.Errorf("Unexpected pins %+v", pins)
+	}
+	if err := client.PinMessage(context.Background(), "123", "3"); err != nil {
+		t.Fatalf("PinMessage failed: %v", err)
+	}
+	if err := client.UnpinMessage(context.Background(), "123", "1"); err != nil {
+		t.Fatalf("UnpinMessage failed: %v", err)
+	}
+
+	expected := []string{"GET /channels/123/pins", "PUT /channels/123/pins/3", "DELETE /channels/123/pins/1"}
+	if strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestGetGuildAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/123/audit-logs" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "limit=100&after=0" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"audit_log_entries": [{"id": "2", "user_id": "9", "action_type": 22, "changes": [{"key": "nick"}]}], "users": [{"id": "9", "username": "mod"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	limit := 100
+	after := "0"
+	log, err := client.GetGuildAuditLog(context.Background(), "123", &GetGuildAuditLogRequest{Limit: &limit, After: &after})
+	if err != nil {
+		t.Fatalf("GetGuildAuditLog failed: %v", err)
+	}
+	if len(log.Entries) != 1 || log.Entries[0].ActionType != 22 || string(log.Entries[0].Changes) != `[{"key": "nick"}]` {
+		t.Errorf("Unexpected entries %+v", log.Entries)
+	}
+	if len(log.Users) != 1 || log.Users[0].Username != "mod" {
+		t.Errorf("Unexpected users %+v", log.Users)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"unicode/utf8"
+
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+)
+
+// Discord's limits on message embeds.
+const (
+	MaxEmbeds          = 10
+	MaxEmbedFields     = 25
+	MaxEmbedCharacters = 6000
+)
+
+// EmbedsLength returns the number of characters Discord counts against
+// MaxEmbedCharacters for embeds: their titles, descriptions, field names
+// and values and footer texts.
+func EmbedsLength(embeds []Embed) int {
+	n := 0
+	for _, e := range embeds {
+		n += utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+		for _, f := range e.Fields {
+			n += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+		}
+		if e.Footer != nil {
+			n += utf8.RuneCountInString(e.Footer.Text)
+		}
+	}
+	return n
+}
+
+// Embeds converts the embeds of a message spec to their Discord
+// representation.
+func Embeds(in []messagev1alpha1.Embed) []Embed {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Embed, 0, len(in))
+	for _, e := range in {
+		out = append(out, toEmbed(e))
+	}
+	return out
+}
+
+func toEmbed(in messagev1alpha1.Embed) Embed {
+	out := Embed{}
+	if in.Title != nil {
+		out.Title = *in.Title
+	}
+	if in.Description != nil {
+		out.Description = *in.Description
+	}
+	if in.URL != nil {
+		out.URL = *in.URL
+	}
+	if in.Color != nil {
+		out.Color = *in.Color
+	}
+	for _, f := range in.Fields {
+		out.Fields = append(out.Fields, EmbedField{
+			Name:   f.Name,
+			Value:  f.Value,
+			Inline: f.Inline != nil && *f.Inline,
+		})
+	}
+	if in.Image != nil {
+		out.Image = &EmbedImage{URL: in.Image.URL}
+	}
+	if in.Footer != nil {
+		out.Footer = &EmbedFooter{Text: in.Footer.Text}
+		if in.Footer.IconURL != nil {
+			out.Footer.IconURL = *in.Footer.IconURL
+		}
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeds(t *testing.T) {
+	title := "Release"
+	icon := "https://example.com/icon.png"
+	inline := true
+	embeds := Embeds([]messagev1alpha1.Embed{{
+		Title:  &title,
+		Fields: []messagev1alpha1.EmbedField{{Name: "Version", Value: "1.0", Inline: &inline}},
+		Image:  &messagev1alpha1.EmbedImage{URL: "https://example.com/banner.png"},
+		Footer: &messagev1alpha1.EmbedFooter{Text: "Team", IconURL: &icon},
+	}})
+
+	require.Len(t, embeds, 1)
+	assert.Equal(t, "Release", embeds[0].Title)
+	assert.Equal(t, []EmbedField{{Name: "Version", Value: "1.0", Inline: true}}, embeds[0].Fields)
+	assert.Equal(t, "https://example.com/banner.png", embeds[0].Image.URL)
+	assert.Equal(t, &EmbedFooter{Text: "Team", IconURL: icon}, embeds[0].Footer)
+	assert.Nil(t, Embeds(nil))
+}
+
+func TestEmbedsLength(t *testing.T) {
+	embeds := []Embed{
+		{Title: "héllo", Description: "ab", URL: "https://example.com", Fields: []EmbedField{{Name: "n", Value: "vv"}}},
+		{Footer: &EmbedFooter{Text: "foot", IconURL: "https://example.com/icon.png"}},
+	}
+	// URLs are not counted and characters are runes, not bytes
+	assert.Equal(t, 5+2+1+2+4, EmbedsLength(embeds))
+}
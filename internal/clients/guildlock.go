@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+)
+
+// guildLocks serializes the changes to a guild whose outcome depends on
+// their order, such as moving channels to positions computed from the
+// guild's current order. Without it, controllers reconciling different
+// resources of one guild at once could compute their moves from the same
+// order, interleave them and leave an ordering neither asked for.
+type guildLocks struct {
+	mu    sync.Mutex
+	locks map[string]*guildLock
+}
+
+// A guildLock is held by whoever has sent a value to it. refs counts the
+// callers holding or waiting for it, so it is dropped once unused.
+type guildLock struct {
+	ch   chan struct{}
+	refs int
+}
+
+// sharedGuildLocks is shared by all clients, since clients are created per
+// reconcile but the changes they serialize affect the whole guild.
+var sharedGuildLocks = &guildLocks{locks: map[string]*guildLock{}}
+
+// lock waits until the caller holds the guild's lock, or ctx is done. The
+// returned function releases it.
+func (g *guildLocks) lock(ctx context.Context, guildID string) (func(), error) {
+	g.mu.Lock()
+	l, ok := g.locks[guildID]
+	if !ok {
+		l = &guildLock{ch: make(chan struct{}, 1)}
+		g.locks[guildID] = l
+	}
+	l.refs++
+	g.mu.Unlock()
+
+	select {
+	case l.ch <- struct{}{}:
+		return func() {
+			<-l.ch
+			g.release(guildID, l)
+		}, nil
+	case <-ctx.Done():
+		g.release(guildID, l)
+		return nil, ctx.Err()
+	}
+}
+
+func (g *guildLocks) release(guildID string, l *guildLock) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	l.refs--
+	if l.refs == 0 {
+		delete(g.locks, guildID)
+	}
+}
+
+// LockGuild waits until the caller holds the guild's ordering lock, or ctx
+// is done, and returns the function that releases it. A single request is
+// applied by Discord as a whole, so the lock is held across a sequence:
+// reading the guild's order, computing the moves from it and sending them.
+func LockGuild(ctx context.Context, guildID string) (func(), error) {
+	return sharedGuildLocks.lock(ctx, guildID)
+}
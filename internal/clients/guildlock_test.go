@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGuildLocks(t *testing.T) {
+	g := &guildLocks{locks: map[string]*guildLock{}}
+
+	unlock, err := g.lock(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+
+	// Another guild is not held up
+	other, err := g.lock(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("lock of another guild failed: %v", err)
+	}
+	other()
+
+	// A second caller for the same guild waits until the first releases it
+	acquired := make(chan struct{})
+	go func() {
+		second, err := g.lock(context.Background(), "1")
+		if err != nil {
+			t.Errorf("second lock failed: %v", err)
+			return
+		}
+		close(acquired)
+		second()
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("Expected second caller to wait for the lock")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// A caller whose context ends gives up waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.lock(ctx, "1"); err == nil {
+		t.Error("Expected lock to fail once its context ended")
+	}
+
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Expected second caller to get the lock once released")
+	}
+
+	// Unused locks are dropped
+	time.Sleep(10 * time.Millisecond)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.locks) != 0 {
+		t.Errorf("Expected no locks left, got %d", len(g.locks))
+	}
+}
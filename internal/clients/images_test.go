@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageChecksum(t *testing.T) {
+	a := ImageChecksum("data:image/png;base64,iVBORw0KGgo=")
+	assert.Len(t, a, 64)
+	assert.Equal(t, a, ImageChecksum("data:image/png;base64,iVBORw0KGgo="))
+	assert.NotEqual(t, a, ImageChecksum("data:image/png;base64,AAAA"))
+}
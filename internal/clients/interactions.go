@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// An InteractionsEndpointRejectedError is returned when an application's
+// interactions endpoint URL fails the pre-flight check, or Discord refuses
+// it because its verification PING failed.
+type InteractionsEndpointRejectedError struct {
+	URL    string
+	Reason string
+}
+
+func (e *InteractionsEndpointRejectedError) Error() string {
+	return fmt.Sprintf("InteractionsEndpointRejected: %s: %s", e.URL, e.Reason)
+}
+
+// IsInteractionsEndpointRejected reports whether err indicates the
+// interactions endpoint URL failed the pre-flight check or was refused by
+// Discord.
+func IsInteractionsEndpointRejected(err error) bool {
+	var rejected *InteractionsEndpointRejectedError
+	if errors.As(err, &rejected) {
+		return true
+	}
+	if ErrorCode(err) != ErrorCodeInvalidFormBody {
+		return false
+	}
+	return strings.Contains(err.Error(), "interactions_endpoint_url")
+}
+
+// CheckInteractionsEndpoint returns an *InteractionsEndpointRejectedError if
+// endpoint would fail Discord's verification. Discord verifies an endpoint
+// by sending it PINGs and requires requests with an invalid signature to be
+// refused with 401 Unauthorized, so an unsigned PING is sent and anything
+// but a 401 is rejected. Only Discord can send the signed PING, so passing
+// the check does not guarantee Discord accepts the endpoint.
+func CheckInteractionsEndpoint(ctx context.Context, hc *http.Client, endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return &InteractionsEndpointRejectedError{URL: endpoint, Reason: "the URL must be an absolute https URL"}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(`{"type":1}`))
+	if err != nil {
+		return errors.Wrap(err, "failed to build interactions endpoint check")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return &InteractionsEndpointRejectedError{URL: endpoint, Reason: "the endpoint is unreachable: " + err.Error()}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return &InteractionsEndpointRejectedError{
+			URL:    endpoint,
+			Reason: fmt.Sprintf("the endpoint answered an unsigned PING with %d; it must verify request signatures and answer 401", resp.StatusCode),
+		}
+	}
+	return nil
+}
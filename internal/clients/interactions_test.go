@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckInteractionsEndpoint(t *testing.T) {
+	verifying := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Signature-Ed25519") == "" {
+			http.Error(w, "invalid request signature", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"type":1}`))
+	}))
+	defer verifying.Close()
+
+	trusting := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"type":1}`))
+	}))
+	defer trusting.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		rejected bool
+	}{
+		{name: "verifies signatures", url: verifying.URL},
+		{name: "accepts unsigned requests", url: trusting.URL, rejected: true},
+		{name: "not https", url: "http://example.com/interactions", rejected: true},
+		{name: "not absolute", url: "/interactions", rejected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckInteractionsEndpoint(context.Background(), verifying.Client(), tc.url)
+			if got := IsInteractionsEndpointRejected(err); got != tc.rejected {
+				t.Errorf("IsInteractionsEndpointRejected() = %v, want %v (err %v)", got, tc.rejected, err)
+			}
+		})
+	}
+}
+
+func TestIsInteractionsEndpointRejectedByDiscord(t *testing.T) {
+	err := newAPIError("PATCH", "/applications/@me", http.StatusBadRequest,
+		[]byte(`{"code": 50035, "errors": {"interactions_endpoint_url": {"_errors": [{"code": "APPLICATION_INTERACTIONS_ENDPOINT_URL_INVALID"}]}}, "message": "Invalid Form Body"}`))
+	if !IsInteractionsEndpointRejected(err) {
+		t.Errorf("Expected Discord's refusal to be recognized")
+	}
+
+	other := newAPIError("PATCH", "/applications/@me", http.StatusBadRequest,
+		[]byte(`{"code": 50035, "errors": {"name": {}}, "message": "Invalid Form Body"}`))
+	if IsInteractionsEndpointRejected(other) {
+		t.Errorf("Expected unrelated form errors to be ignored")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// Limits Discord applies to every guild, whatever its premium tier.
+const (
+	// MaxGuildChannels is the number of channels, categories included, a
+	// guild can hold.
+	MaxGuildChannels = 500
+	// MaxGuildRoles is the number of roles a guild can hold.
+	MaxGuildRoles = 250
+)
+
+// GuildLimitError is returned when creating resources would take a guild
+// past one of Discord's per-guild limits, before Discord is asked to create
+// them.
+type GuildLimitError struct {
+	GuildID  string
+	Resource string
+	Count    int
+	Adding   int
+	Limit    int
+}
+
+func (e *GuildLimitError) Error() string {
+	return fmt.Sprintf("guild %s has %d of at most %d %s, so %d more cannot be created", e.GuildID, e.Count, e.Limit, e.Resource, e.Adding)
+}
+
+// IsGuildLimitReached reports whether err is, or wraps, a GuildLimitError, or
+// whether Discord refused to create a channel or role because the guild
+// holds as many as it can.
+func IsGuildLimitReached(err error) bool {
+	var e *GuildLimitError
+	if errors.As(err, &e) {
+		return true
+	}
+	switch ErrorCode(err) {
+	case ErrorCodeMaxChannelsReached, ErrorCodeMaxRolesReached:
+		return true
+	default:
+		return false
+	}
+}
+
+// GuildChannelLister lists a guild's channels, which is all channel limit
+// checks need.
+type GuildChannelLister interface {
+	ListGuildChannels(ctx context.Context, guildID string) ([]Channel, error)
+}
+
+// CheckChannelCount verifies the guild can hold adding more channels.
+func CheckChannelCount(ctx context.Context, c GuildChannelLister, guildID string, adding int) error {
+	channels, err := c.ListGuildChannels(ctx, guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to count guild channels")
+	}
+	if len(channels)+adding > MaxGuildChannels {
+		return &GuildLimitError{GuildID: guildID, Resource: "channels", Count: len(channels), Adding: adding, Limit: MaxGuildChannels}
+	}
+	return nil
+}
+
+// RoleLister lists a guild's roles, which is all role limit checks need.
+type RoleLister interface {
+	ListRoles(ctx context.Context, guildID string) ([]Role, error)
+}
+
+// CheckRoleCount verifies the guild can hold adding more roles.
+func CheckRoleCount(ctx context.Context, c RoleLister, guildID string, adding int) error {
+	roles, err := c.ListRoles(ctx, guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to count guild roles")
+	}
+	if len(roles)+adding > MaxGuildRoles {
+		return &GuildLimitError{GuildID: guildID, Resource: "roles", Count: len(roles), Adding: adding, Limit: MaxGuildRoles}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"testing"
+)
+
+type fakeLister struct {
+	channels []Channel
+	roles    []Role
+	err      error
+}
+
+func (f *fakeLister) ListGuildChannels(ctx context.Context, guildID string) ([]Channel, error) {
+	return f.channels, f.err
+}
+
+func (f *fakeLister) ListRoles(ctx context.Context, guildID string) ([]Role, error) {
+	return f.roles, f.err
+}
+
+func TestCheckGuildLimits(t *testing.T) {
+	ctx := context.Background()
+	full := &fakeLister{channels: make([]Channel, MaxGuildChannels-1), roles: make([]Role, MaxGuildRoles)}
+
+	if err := CheckChannelCount(ctx, full, "1", 1); err != nil {
+		t.Errorf("Expected room for the 500th channel, got %v", err)
+	}
+	err := CheckChannelCount(ctx, full, "1", 2)
+	if !IsGuildLimitReached(err) {
+		t.Errorf("Expected guild limit error, got %v", err)
+	}
+	if want := "guild 1 has 499 of at most 500 channels, so 2 more cannot be created"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+	if err := CheckRoleCount(ctx, full, "1", 1); !IsGuildLimitReached(err) {
+		t.Errorf("Expected guild limit error, got %v", err)
+	}
+
+	failing := &fakeLister{err: errors.New("Discord API error: 503")}
+	if err := CheckRoleCount(ctx, failing, "1", 1); err == nil || IsGuildLimitReached(err) {
+		t.Errorf("Expected listing error, got %v", err)
+	}
+}
+
+func TestIsGuildLimitReached(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{errors.New(`Discord API error: 400 - {"message": "Maximum number of guild roles reached (250)", "code": 30005}`), true},
+		{errors.New(`Discord API error: 400 - {"message": "Maximum number of guild channels reached (500)", "code": 30013}`), true},
+		{errors.New(`Discord API error: 400 - {"message": "Maximum number of guilds reached (100)", "code": 30001}`), false},
+		{nil, false},
+	} {
+		if got := IsGuildLimitReached(tc.err); got != tc.want {
+			t.Errorf("IsGuildLimitReached(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
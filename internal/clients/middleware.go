@@ -0,0 +1,689 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/internal/metrics"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"github.com/rossigee/provider-discord/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A Middleware wraps the transport Discord API requests are sent through.
+// Cross-cutting behaviour such as authentication, logging, metrics and
+// retries lives in middlewares rather than in each client method.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps base in the supplied middlewares. The first middleware is the
+// outermost, so it sees a request first and its response last.
+func Chain(base http.RoundTripper, mws ...Middleware) http.RoundTripper {
+	for i := len(mws) - 1; i >= 0; i-- {
+		base = mws[i](base)
+	}
+	return base
+}
+
+// Use adds middlewares to the client. They wrap the built-in chain, so they
+// see each request once, before it is authenticated, rate limited or
+// retried.
+func (c *DiscordClient) Use(mws ...Middleware) {
+	c.middlewares = append(c.middlewares, mws...)
+	c.httpClient.Transport = Chain(c.transport, append(append([]Middleware{}, c.middlewares...), c.builtinMiddlewares()...)...)
+}
+
+// builtinMiddlewares returns the middlewares every client sends requests
+// through, outermost first.
+func (c *DiscordClient) builtinMiddlewares() []Middleware {
+	return []Middleware{
+		TracingMiddleware(),
+		RequestOptionsMiddleware(),
+		CircuitBreakerMiddleware(),
+		RetryPolicyMiddleware(c.logger, *retryPolicy.Load()),
+		RateLimitMiddleware(botKey(c.token)),
+		LoggingMiddleware(c.logger),
+		MetricsMiddleware(c.logger, c.metricsRecorder),
+		AuthMiddleware(c.token),
+	}
+}
+
+// botKey identifies a bot token without revealing it.
+func botKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// apiVersionPrefix matches the versioned API prefix of a request path.
+var apiVersionPrefix = regexp.MustCompile(`^/api/v\d+`)
+
+// apiPath returns the path of req relative to the API base URL, with any
+// webhook or interaction token redacted.
+func apiPath(req *http.Request) string {
+	return redactPath(apiVersionPrefix.ReplaceAllString(req.URL.Path, ""))
+}
+
+// redactedToken replaces webhook and interaction tokens in logged paths.
+const redactedToken = "REDACTED"
+
+// redactPath replaces the token segment of webhook and interaction paths,
+// such as /webhooks/{id}/{token}, which authenticates the request on its
+// own, so paths can be logged and reported in errors.
+func redactPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if (parts[i] == "webhooks" || parts[i] == "interactions") && parts[i+2] != "" {
+			parts[i+2] = redactedToken
+			i += 2
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// redactURL returns req's URL, with any webhook or interaction token
+// redacted.
+func redactURL(req *http.Request) string {
+	u := *req.URL
+	u.Path = redactPath(u.Path)
+	u.RawPath = ""
+	return u.String()
+}
+
+// redactError replaces the URL of the *url.Error in err's chain, which the
+// HTTP client reports in full, with its redacted form.
+func redactError(err error, redacted string) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redacted
+	}
+	return err
+}
+
+// AuthMiddleware authenticates requests as the bot with the supplied token.
+func AuthMiddleware(token string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bot "+token)
+			req.Header.Set("User-Agent", "Crossplane Discord Provider/1.0")
+			if req.Body != nil && req.Header.Get("Content-Type") == "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LoggingMiddleware logs each request sent and response received.
+func LoggingMiddleware(log logr.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var body string
+			if req.GetBody != nil {
+				if rc, err := req.GetBody(); err == nil {
+					b, _ := io.ReadAll(rc)
+					_ = rc.Close()
+					body = string(b)
+				}
+			}
+			log.Info("Making Discord API request",
+				"method", req.Method,
+				"url", redactURL(req),
+				"body", body)
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				log.Error(err, "Failed to perform request", "url", redactURL(req))
+				return nil, err
+			}
+
+			log.Info("Discord API response",
+				"method", req.Method,
+				"url", redactURL(req),
+				"status", resp.StatusCode)
+			return resp, nil
+		})
+	}
+}
+
+// MetricsMiddleware records the outcome, duration and rate limit headers of
+// each request. Requests pass straight through when recorder is nil.
+func MetricsMiddleware(log logr.Logger, recorder *metrics.MetricsRecorder) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if recorder == nil {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint := apiPath(req)
+			resourceType := resourceTypeFromEndpoint(endpoint)
+			operation := operationFromMethod(req.Method)
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			duration := time.Since(start)
+			if err != nil {
+				recorder.RecordAPIOperation(resourceType, operation, "error", duration)
+				return nil, err
+			}
+
+			recorder.RecordAPIOperation(resourceType, operation, statusFromCode(resp.StatusCode), duration)
+			recordRateLimitMetrics(log, recorder, resourceType, endpoint, resp.Header)
+			return resp, nil
+		})
+	}
+}
+
+// recordRateLimitMetrics parses rate limit headers and records metrics
+func recordRateLimitMetrics(log logr.Logger, recorder *metrics.MetricsRecorder, resourceType, endpoint string, headers http.Header) {
+	// Discord rate limit headers
+	remaining := headers.Get(resilience.DiscordRateLimitHeader)
+	resetAfter := headers.Get(resilience.DiscordRateLimitReset)
+	limit := headers.Get("X-RateLimit-Limit")
+
+	if remaining == "" {
+		return
+	}
+	remainingInt, err := strconv.Atoi(remaining)
+	if err != nil {
+		return
+	}
+
+	// Calculate reset time
+	var resetTime time.Time
+	if d, ok := parseSeconds(resetAfter); ok {
+		resetTime = time.Now().Add(d)
+	}
+
+	recorder.RecordRateLimit(resourceType, endpoint, remainingInt, resetTime)
+
+	// Log rate limit information for debugging
+	log.Info("Discord rate limit info",
+		"resourceType", resourceType,
+		"endpoint", endpoint,
+		"remaining", remainingInt,
+		"limit", limit,
+		"resetAfter", resetAfter)
+}
+
+// TracingMiddleware wraps each request in a span and propagates its trace
+// context in a traceparent header, which Discord ignores but egress proxies
+// can record. The response's Request carries the span's context, so errors
+// built from the response can name the trace.
+func TracingMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracing.StartSpan(req.Context(), "Discord "+req.Method,
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.path", apiPath(req)),
+				attribute.String("crossplane.resource.type", resourceTypeFromEndpoint(apiPath(req))),
+			)
+			defer span.End()
+
+			req = req.Clone(ctx)
+			tracing.Inject(ctx, req.Header)
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				err = redactError(err, redactURL(req))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+			resp.Request = req
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			return resp, nil
+		})
+	}
+}
+
+// circuitBreakers holds a circuit breaker per resource type, so that an
+// outage of one part of the Discord API does not stop calls to the rest.
+type circuitBreakers struct {
+	mu       sync.Mutex
+	config   *resilience.CircuitBreakerConfig
+	breakers map[string]*resilience.CircuitBreaker
+}
+
+// sharedCircuitBreakers is shared by all clients, since clients are created
+// per reconcile but outages affect every resource.
+var sharedCircuitBreakers = &circuitBreakers{
+	config:   resilience.DefaultCircuitBreakerConfig(),
+	breakers: map[string]*resilience.CircuitBreaker{},
+}
+
+func (b *circuitBreakers) get(resourceType string) *resilience.CircuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cb, ok := b.breakers[resourceType]
+	if !ok {
+		cb = resilience.NewCircuitBreaker(b.config, resourceType)
+		b.breakers[resourceType] = cb
+	}
+	return cb
+}
+
+// errServerError marks a 5xx response as a failure for the circuit breaker.
+var errServerError = errors.New("Discord API server error")
+
+// CircuitBreakerMiddleware stops sending requests for a resource type once
+// Discord keeps failing them with network errors or 5xx responses, until the
+// breaker's recovery timeout has passed. Requests rejected by an open breaker
+// fail with an error for which resilience.IsCircuitOpen is true.
+func CircuitBreakerMiddleware() Middleware {
+	return circuitBreakerMiddleware(sharedCircuitBreakers)
+}
+
+func circuitBreakerMiddleware(b *circuitBreakers) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			cb := b.get(resourceTypeFromEndpoint(apiPath(req)))
+			var resp *http.Response
+			err := cb.Call(req.Context(), req.Method, func() error {
+				var err error
+				if resp, err = next.RoundTrip(req); err != nil {
+					return err
+				}
+				if resp.StatusCode >= http.StatusInternalServerError {
+					return errServerError
+				}
+				return nil
+			})
+			if err != nil && !errors.Is(err, errServerError) {
+				return nil, err
+			}
+			return resp, nil
+		})
+	}
+}
+
+// rateLimits remembers exhausted rate limit buckets, so requests wait for
+// the bucket to reset instead of being rejected with a 429.
+type rateLimits struct {
+	mu sync.Mutex
+	// resets holds when each exhausted route, or a bot's global limit,
+	// becomes available again.
+	resets map[string]time.Time
+}
+
+// sharedRateLimits is shared by all clients, since clients are created per
+// reconcile but Discord limits apply per bot.
+var sharedRateLimits = &rateLimits{resets: map[string]time.Time{}}
+
+func (l *rateLimits) wait(ctx context.Context, keys ...string) error {
+	l.mu.Lock()
+	var until time.Time
+	for _, k := range keys {
+		if t, ok := l.resets[k]; ok {
+			if time.Now().After(t) {
+				delete(l.resets, k)
+				continue
+			}
+			if t.After(until) {
+				until = t
+			}
+		}
+	}
+	l.mu.Unlock()
+
+	if until.IsZero() {
+		return nil
+	}
+	return sleep(ctx, time.Until(until))
+}
+
+func (l *rateLimits) exhaust(key string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resets[key] = time.Now().Add(d)
+}
+
+// RateLimitMiddleware delays requests to routes whose rate limit bucket the
+// bot has exhausted, and all requests while the bot's global limit is hit.
+func RateLimitMiddleware(bot string) Middleware {
+	return rateLimitMiddleware(sharedRateLimits, bot)
+}
+
+func rateLimitMiddleware(l *rateLimits, bot string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			global := bot + " global"
+			route := bot + " " + req.Method + " " + apiPath(req)
+			if err := l.wait(req.Context(), global, route); err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+
+			if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("X-RateLimit-Global") == "true" {
+				if d, ok := parseSeconds(resp.Header.Get(resilience.DiscordRetryAfterHeader)); ok {
+					l.exhaust(global, d)
+				}
+			}
+			if resp.Header.Get(resilience.DiscordRateLimitHeader) == "0" {
+				if d, ok := parseSeconds(resp.Header.Get(resilience.DiscordRateLimitReset)); ok {
+					l.exhaust(route, d)
+				}
+			}
+			return resp, nil
+		})
+	}
+}
+
+// RetryMiddleware retries requests Discord rejected without acting on them:
+// rate limited requests, and idempotent requests that hit a gateway error.
+// Waits longer than the configured maximum delay are left to the caller, so
+// a reconcile is requeued rather than blocked, as are requests made
+// WithNoRetry. Retries are spent from a budget shared by all clients; once it
+// is exhausted, requests that would be retried fail with an error for which
+// resilience.IsRetryBudgetExhausted is true.
+func RetryMiddleware(log logr.Logger, cfg *resilience.RetryConfig) Middleware {
+	return retryMiddleware(log, cfg, sharedRetryBudget.Load())
+}
+
+// sharedRetryBudget is shared by all clients, since clients are created per
+// reconcile but a flapping API affects every resource.
+var sharedRetryBudget atomic.Pointer[resilience.RetryBudget]
+
+func init() {
+	sharedRetryBudget.Store(resilience.NewRetryBudget(resilience.DefaultRetryBudget, resilience.DefaultRetryBudgetWindow))
+	p := DefaultRetryPolicy()
+	retryPolicy.Store(&p)
+}
+
+// SetRetryBudget replaces the retry budget shared by all clients. It is
+// called at startup, before any request is sent.
+func SetRetryBudget(limit int, window time.Duration) {
+	sharedRetryBudget.Store(resilience.NewRetryBudget(limit, window))
+}
+
+func retryMiddleware(log logr.Logger, cfg *resilience.RetryConfig, budget *resilience.RetryBudget) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			for attempt := 0; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if err != nil || attempt >= cfg.MaxRetries || requestOptionsFrom(req.Context()).noRetry || !retryable(req, resp) {
+					return resp, err
+				}
+
+				delay := backoff(cfg, attempt)
+				if resp.StatusCode == http.StatusTooManyRequests {
+					if d, ok := parseSeconds(resp.Header.Get(resilience.DiscordRetryAfterHeader)); ok {
+						delay = d
+					}
+				}
+				if delay > cfg.MaxDelay {
+					return resp, nil
+				}
+				if err := budget.Spend(resourceTypeFromEndpoint(apiPath(req))); err != nil {
+					body, _ := io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					log.Info("Not retrying Discord API request", "method", req.Method, "url", redactURL(req), "status", resp.StatusCode, "reason", err.Error())
+					// Keep the response that was not retried, so callers
+					// can still act on Discord's error.
+					var de *resilience.DiscordError
+					if errors.As(err, &de) {
+						apiErr := newAPIError(req.Method, apiPath(req), resp.StatusCode, body)
+						apiErr.TraceID = tracing.TraceID(req.Context())
+						de.StatusCode, de.Cause = resp.StatusCode, apiErr
+					}
+					return nil, err
+				}
+
+				// Rewind the body for the next attempt
+				if req.Body != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return resp, nil
+					}
+					req = req.Clone(req.Context())
+					req.Body = body
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+
+				log.Info("Retrying Discord API request",
+					"method", req.Method,
+					"url", redactURL(req),
+					"status", resp.StatusCode,
+					"attempt", attempt+1,
+					"delay", delay)
+				if err := sleep(req.Context(), delay); err != nil {
+					return nil, err
+				}
+			}
+		})
+	}
+}
+
+// A RetryPolicy configures retries separately for reads, which are cheap to
+// repeat, and writes, which spend the budget of Discord's mutating routes.
+type RetryPolicy struct {
+	Read  *resilience.RetryConfig
+	Write *resilience.RetryConfig
+}
+
+// DefaultRetryPolicy retries reads and writes alike, with the resilience
+// package's defaults.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Read:  resilience.DefaultRetryConfig(),
+		Write: resilience.DefaultRetryConfig(),
+	}
+}
+
+// retryPolicy is used by every client, since clients are created per
+// reconcile from a bot token alone.
+var retryPolicy atomic.Pointer[RetryPolicy]
+
+// SetRetryPolicy replaces the retry policy of clients created afterwards.
+// It is called at startup, before any client is created.
+func SetRetryPolicy(p RetryPolicy) {
+	retryPolicy.Store(&p)
+}
+
+// SetCircuitBreakerConfig replaces the settings of the circuit breakers
+// shared by all clients. It is called once at startup, before any request
+// is sent.
+func SetCircuitBreakerConfig(cfg *resilience.CircuitBreakerConfig) {
+	sharedCircuitBreakers.mu.Lock()
+	defer sharedCircuitBreakers.mu.Unlock()
+	sharedCircuitBreakers.config = cfg
+	sharedCircuitBreakers.breakers = map[string]*resilience.CircuitBreaker{}
+}
+
+// RetryPolicyMiddleware retries reads as p.Read and writes as p.Write
+// configure, as RetryMiddleware does.
+func RetryPolicyMiddleware(log logr.Logger, p RetryPolicy) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		read := RetryMiddleware(log, p.Read)(next)
+		write := RetryMiddleware(log, p.Write)(next)
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet || req.Method == http.MethodHead {
+				return read.RoundTrip(req)
+			}
+			return write.RoundTrip(req)
+		})
+	}
+}
+
+// retryable reports whether a request can safely be sent again.
+func retryable(req *http.Request, resp *http.Response) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+func backoff(cfg *resilience.RetryConfig, attempt int) time.Duration {
+	d := time.Duration(float64(cfg.BaseDelay) * math.Pow(cfg.Multiplier, float64(attempt)))
+	return min(d, cfg.MaxDelay)
+}
+
+// parseSeconds parses Discord's fractional seconds rate limit headers.
+func parseSeconds(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return time.Duration(f * float64(time.Second)), true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// resourceTypeFromEndpoint extracts the resource type from a Discord API endpoint
+func resourceTypeFromEndpoint(endpoint string) string {
+	// Remove leading slash and query parameters
+	path := strings.TrimPrefix(endpoint, "/")
+	if idx := strings.Index(path, "?"); idx != -1 {
+		path = path[:idx]
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 {
+		return "unknown"
+	}
+
+	// Map Discord API endpoints to resource types
+	switch parts[0] {
+	case "guilds":
+		if len(parts) >= 3 {
+			switch parts[2] {
+			case "channels":
+				return "channel"
+			case "roles":
+				return "role"
+			case "members":
+				return "member"
+			case "webhooks":
+				return "webhook"
+			case "invites":
+				return "invite"
+			case "integrations":
+				return "integration"
+			case "bans":
+				return "ban"
+			case "scheduled-events":
+				return "scheduled_event"
+			case "auto-moderation":
+				return "auto_moderation"
+			case "onboarding":
+				return "onboarding"
+			default:
+				return "guild"
+			}
+		}
+		return "guild"
+	case "channels":
+		if len(parts) >= 3 {
+			switch parts[2] {
+			case "webhooks":
+				return "webhook"
+			case "invites":
+				return "invite"
+			default:
+				return "channel"
+			}
+		}
+		return "channel"
+	case "users":
+		return "user"
+	case "applications":
+		return "application"
+	case "webhooks":
+		return "webhook"
+	case "invites":
+		return "invite"
+	default:
+		return "unknown"
+	}
+}
+
+// operationFromMethod maps HTTP methods to operation types
+func operationFromMethod(method string) string {
+	switch strings.ToUpper(method) {
+	case "POST":
+		return "create"
+	case "GET":
+		return "observe"
+	case "PATCH", "PUT":
+		return "update"
+	case "DELETE":
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// statusFromCode maps HTTP status codes to status types
+func statusFromCode(statusCode int) string {
+	if statusCode == 429 {
+		return "rate_limited"
+	} else if statusCode >= 400 {
+		return "error"
+	} else {
+		return "success"
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/@me", nil)
+	if _, err := Chain(base, mw("outer"), mw("inner")).RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip failed: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "outer,inner,base" {
+		t.Errorf("Expected outer,inner,base, got %s", got)
+	}
+}
+
+func TestClientMiddleware(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if got := r.Header.Get("Authorization"); got != "Bot test-token" {
+			t.Errorf("Expected bot authorization, got %q", got)
+		}
+		if got := r.Header.Get("X-Test"); got != "yes" {
+			t.Errorf("Expected header added by custom middleware, got %q", got)
+		}
+		switch {
+		case r.URL.Path == "/users/@me" && n == 1:
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.01}`))
+		case r.URL.Path == "/users/@me":
+			_, _ = w.Write([]byte(`{"id": "1", "username": "bot"}`))
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"name":"general"}` {
+				t.Errorf("Unexpected body %q", body)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+	client.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Test", "yes")
+			return next.RoundTrip(req)
+		})
+	})
+
+	t.Run("rate limited request is retried", func(t *testing.T) {
+		user, err := client.GetCurrentUser(context.Background())
+		if err != nil {
+			t.Fatalf("GetCurrentUser failed: %v", err)
+		}
+		if user.ID != "1" {
+			t.Errorf("Expected user 1, got %s", user.ID)
+		}
+		if n := requests.Load(); n != 2 {
+			t.Errorf("Expected 2 requests, got %d", n)
+		}
+	})
+
+	t.Run("non-idempotent request is not retried on gateway error", func(t *testing.T) {
+		requests.Store(0)
+		resp, err := client.makeRequest(context.Background(), http.MethodPost, "/guilds/1/channels", map[string]string{"name": "general"})
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err == nil || !strings.Contains(err.Error(), "Discord API error: 503") {
+			t.Errorf("Expected 503 error, got %v", err)
+		}
+		if n := requests.Load(); n != 1 {
+			t.Errorf("Expected 1 request, got %d", n)
+		}
+	})
+}
+
+func TestLoggingMiddlewareRedactsTokens(t *testing.T) {
+	var logged strings.Builder
+	log := funcr.New(func(prefix, args string) { logged.WriteString(args + "\n") }, funcr.Options{})
+	rt := Chain(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}), LoggingMiddleware(log))
+
+	req := httptest.NewRequest(http.MethodGet, "https://discord.com/api/v10/webhooks/1/secret-token?wait=true", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if strings.Contains(logged.String(), "secret-token") {
+		t.Errorf("Webhook token was logged: %s", logged.String())
+	}
+	if !strings.Contains(logged.String(), "/webhooks/1/REDACTED?wait=true") {
+		t.Errorf("Expected redacted URL in log: %s", logged.String())
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limits := &rateLimits{resets: map[string]time.Time{}}
+	var sent []time.Time
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = append(sent, time.Now())
+		h := http.Header{}
+		if len(sent) == 1 {
+			h.Set("X-RateLimit-Remaining", "0")
+			h.Set("X-RateLimit-Reset-After", "0.05")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: h, Body: http.NoBody}, nil
+	})
+	rt := rateLimitMiddleware(limits, "bot")(base)
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/api/v10/guilds/1/roles", nil)
+		if _, err := rt.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip failed: %v", err)
+		}
+	}
+	if gap := sent[1].Sub(sent[0]); gap < 40*time.Millisecond {
+		t.Errorf("Expected second request to wait for the bucket to reset, waited %s", gap)
+	}
+
+	// A cancelled request does not wait out an exhausted bucket
+	limits.exhaust("bot global", time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/v10/guilds/1/roles", nil).WithContext(ctx)
+	if _, err := rt.RoundTrip(req); err == nil {
+		t.Error("Expected cancelled request to fail")
+	}
+}
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	breakers := &circuitBreakers{
+		config:   &resilience.CircuitBreakerConfig{FailureThreshold: 2, RecoveryTimeout: time.Hour, SuccessThreshold: 1},
+		breakers: map[string]*resilience.CircuitBreaker{},
+	}
+	var calls int
+	status := http.StatusServiceUnavailable
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+	})
+	rt := circuitBreakerMiddleware(breakers)(base)
+	roundTrip := func(path string) (*http.Response, error) {
+		return rt.RoundTrip(httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Server errors are passed back to the caller while the circuit is closed
+	for range 2 {
+		resp, err := roundTrip("/api/v10/guilds/1/roles")
+		if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("Expected the 503 response, got %v, %v", resp, err)
+		}
+	}
+
+	// The circuit is now open for roles, without sending the request
+	if _, err := roundTrip("/api/v10/guilds/1/roles/2"); !resilience.IsCircuitOpen(err) {
+		t.Errorf("Expected circuit open error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 requests to reach Discord, got %d", calls)
+	}
+
+	// Other resource types are unaffected, and client errors do not count as
+	// failures
+	status = http.StatusNotFound
+	for range 3 {
+		if _, err := roundTrip("/api/v10/guilds/1/channels"); err != nil {
+			t.Errorf("Expected channel requests to pass, got %v", err)
+		}
+	}
+}
+
+func TestRetryPolicyMiddleware(t *testing.T) {
+	policy := RetryPolicy{
+		Read:  &resilience.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second, Multiplier: 2},
+		Write: &resilience.RetryConfig{MaxRetries: 0, BaseDelay: time.Millisecond, MaxDelay: time.Second, Multiplier: 2},
+	}
+	var calls int
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: http.NoBody}, nil
+	})
+	rt := RetryPolicyMiddleware(logr.Discard(), policy)(base)
+
+	for _, tc := range []struct {
+		method string
+		want   int
+	}{
+		{http.MethodGet, 3},
+		{http.MethodPatch, 1},
+	} {
+		calls = 0
+		req, _ := http.NewRequest(tc.method, "https://discord.com/api/v10/guilds/1/roles/2", nil)
+		resp, err := rt.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("%s: expected the 429 response, got %v, %v", tc.method, resp, err)
+		}
+		if calls != tc.want {
+			t.Errorf("%s: expected %d requests, got %d", tc.method, tc.want, calls)
+		}
+	}
+}
+
+func TestRetryMiddlewareBudget(t *testing.T) {
+	cfg := &resilience.RetryConfig{MaxRetries: 5, BaseDelay: time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+	var calls int
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: http.NoBody}, nil
+	})
+	rt := retryMiddleware(logr.Discard(), cfg, resilience.NewRetryBudget(2, time.Hour))(base)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://discord.com/api/v10/guilds/1/roles", nil)
+	_, err := rt.RoundTrip(req)
+	if !resilience.IsRetryBudgetExhausted(err) {
+		t.Errorf("Expected retry budget exhausted error, got %v", err)
+	}
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Endpoint != "/guilds/1/roles" {
+		t.Errorf("Expected the 429 response that was not retried, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected the request and 2 retries, got %d requests", calls)
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 10003, "message": "Unknown Channel"}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	_, err := client.GetChannel(context.Background(), "123")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	// traceparent is version-traceid-spanid-flags
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 {
+		t.Fatalf("Unexpected traceparent %q", traceparent)
+	}
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if apiErr.TraceID != parts[1] {
+		t.Errorf("Expected trace ID %s, got %s", parts[1], apiErr.TraceID)
+	}
+	if !strings.HasSuffix(err.Error(), "trace_id="+parts[1]) {
+		t.Errorf("Expected the error to name the trace, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Discord API error: 404") {
+		t.Errorf("Expected the error to keep its form, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"unicode"
+)
+
+// Discord channel types.
+const (
+	ChannelTypeText         = 0
+	ChannelTypeVoice        = 2
+	ChannelTypeCategory     = 4
+	ChannelTypeAnnouncement = 5
+	ChannelTypeStage        = 13
+	ChannelTypeForum        = 15
+)
+
+// Discord channel flag bits.
+const (
+	ChannelFlagPinned                   = 1 << 1
+	ChannelFlagRequireTag               = 1 << 4
+	ChannelFlagHideMediaDownloadOptions = 1 << 15
+)
+
+// channelFlagNames lists the channel flags above in the order they are
+// reported.
+var channelFlagNames = []struct {
+	bit  int
+	name string
+}{
+	{ChannelFlagPinned, "PINNED"},
+	{ChannelFlagRequireTag, "REQUIRE_TAG"},
+	{ChannelFlagHideMediaDownloadOptions, "HIDE_MEDIA_DOWNLOAD_OPTIONS"},
+}
+
+// ChannelFlagNames returns the names of the known channel flags set in bits.
+func ChannelFlagNames(bits int) []string {
+	var names []string
+	for _, f := range channelFlagNames {
+		if bits&f.bit != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// ChannelFlagBits returns the bits of the named channel flags. Unknown names
+// are ignored.
+func ChannelFlagBits(names []string) int {
+	bits := 0
+	for _, n := range names {
+		for _, f := range channelFlagNames {
+			if f.name == n {
+				bits |= f.bit
+			}
+		}
+	}
+	return bits
+}
+
+// RoleFlagInPrompt is set on roles that can be selected by members in an
+// onboarding prompt.
+const RoleFlagInPrompt = 1 << 0
+
+// RoleFlagNames returns the names of the known role flags set in bits.
+func RoleFlagNames(bits int) []string {
+	if bits&RoleFlagInPrompt != 0 {
+		return []string{"IN_PROMPT"}
+	}
+	return nil
+}
+
+// Discord limits on channel fields.
+const (
+	MaxChannelNameLength  = 100
+	MaxChannelTopicLength = 1024
+)
+
+// textChannelNameStrip holds the characters Discord removes from the names of
+// text-like channels.
+const textChannelNameStrip = "~!@#$%^&*()+=[]{}|\\;:'\",<.>/?`"
+
+// IsTextChannelType reports whether channels of type t follow Discord's text
+// channel naming rules and accept a topic.
+func IsTextChannelType(t int) bool {
+	return t == ChannelTypeText || t == ChannelTypeAnnouncement || t == ChannelTypeForum
+}
+
+// NormalizeChannelName returns name as Discord would store it for a channel of
+// type t. Text, announcement and forum channel names are lowercased, runs of
+// whitespace become a single dash and disallowed punctuation is dropped. Other
+// channel types keep their name as declared.
+func NormalizeChannelName(name string, t int) string {
+	name = strings.TrimSpace(name)
+	if !IsTextChannelType(t) {
+		return name
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsSpace(r) || r == '-':
+			if !dash {
+				b.WriteRune('-')
+			}
+			dash = true
+		case strings.ContainsRune(textChannelNameStrip, r):
+		default:
+			b.WriteRune(r)
+			dash = false
+		}
+	}
+	return b.String()
+}
+
+// SanitizeChannelName returns name transformed into lowercase ASCII letters,
+// digits and dashes, which Discord accepts for every channel type. Spaces,
+// underscores, slashes and dots become dashes, other characters are dropped,
+// as are leading and trailing dashes, and the result is cut to
+// MaxChannelNameLength. These are the rules the introspect tool uses to
+// name the resources it generates. A name with nothing left becomes
+// "unnamed".
+func SanitizeChannelName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == ' ' || r == '_' || r == '/' || r == '.':
+			b.WriteRune('-')
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-':
+			b.WriteRune(r)
+		}
+	}
+	s := b.String()
+	if len(s) > MaxChannelNameLength {
+		s = s[:MaxChannelNameLength]
+	}
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return "unnamed"
+	}
+	return s
+}
+
+// ChannelMention returns the message syntax that links to the channel with
+// the given ID, e.g. <#123>.
+func ChannelMention(id string) string {
+	return "<#" + id + ">"
+}
+
+// RoleMention returns the message syntax that mentions the role with the
+// given ID, e.g. <@&123>.
+func RoleMention(id string) string {
+	return "<@&" + id + ">"
+}
+
+// CreatedAt returns the creation time encoded in the Discord ID id, or nil if
+// id is not a valid snowflake.
+func CreatedAt(id string) *metav1.Time {
+	t, err := snowflake.CreatedAt(id)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatedAt(t *testing.T) {
+	at := CreatedAt("175928847299117063")
+	require.NotNil(t, at)
+	assert.Equal(t, time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC), at.UTC())
+
+	assert.Nil(t, CreatedAt(""))
+	assert.Nil(t, CreatedAt("not-a-snowflake"))
+}
+
+func TestSanitizeChannelName(t *testing.T) {
+	tests := map[string]string{
+		"General Chat":    "general-chat",
+		"dev_ops/alerts":  "dev-ops-alerts",
+		"v1.2 Release!":   "v1-2-release",
+		"🎮 Gaming Lounge": "gaming-lounge",
+		"already-valid":   "already-valid",
+		"!!!":             "unnamed",
+		"":                "unnamed",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, SanitizeChannelName(in), in)
+	}
+	assert.Len(t, SanitizeChannelName(strings.Repeat("a", 150)), MaxChannelNameLength)
+}
+
+func TestChannelFlags(t *testing.T) {
+	bits := ChannelFlagBits([]string{"REQUIRE_TAG", "HIDE_MEDIA_DOWNLOAD_OPTIONS", "UNKNOWN"})
+	assert.Equal(t, ChannelFlagRequireTag|ChannelFlagHideMediaDownloadOptions, bits)
+	assert.Equal(t, []string{"PINNED", "REQUIRE_TAG"}, ChannelFlagNames(ChannelFlagPinned|ChannelFlagRequireTag|1<<20))
+	assert.Empty(t, ChannelFlagNames(0))
+}
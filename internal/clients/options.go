@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxAuditReasonLength is the longest audit log reason Discord accepts.
+const maxAuditReasonLength = 512
+
+// A RequestOption tunes the Discord API requests made with a context.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	auditReason string
+	timeout     time.Duration
+	noRetry     bool
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context whose Discord API requests are made
+// with the supplied options, on top of any already set on ctx. Options apply
+// to every client call made with the context, so controllers can tune an
+// operation without each client method taking options.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	o := requestOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, o)
+}
+
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	o, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return o
+}
+
+// WithAuditReason records reason in the guild audit log entries the
+// requests create.
+func WithAuditReason(reason string) RequestOption {
+	return func(o *requestOptions) {
+		if r := []rune(reason); len(r) > maxAuditReasonLength {
+			reason = string(r[:maxAuditReasonLength])
+		}
+		o.auditReason = reason
+	}
+}
+
+// WithTimeout bounds each request, including any retries, to d.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// WithNoRetry makes requests fail on the first rate limit or gateway error
+// instead of being retried, for callers that would rather requeue.
+func WithNoRetry() RequestOption {
+	return func(o *requestOptions) {
+		o.noRetry = true
+	}
+}
+
+// RequestOptionsMiddleware applies the audit reason and timeout set on a
+// request's context. Retries are skipped by RetryMiddleware itself.
+func RequestOptionsMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			o := requestOptionsFrom(req.Context())
+			if o.auditReason != "" {
+				req = req.Clone(req.Context())
+				req.Header.Set("X-Audit-Log-Reason", url.PathEscape(o.auditReason))
+			}
+			if o.timeout <= 0 {
+				return next.RoundTrip(req)
+			}
+
+			ctx, cancel := context.WithTimeout(req.Context(), o.timeout)
+			resp, err := next.RoundTrip(req.WithContext(ctx))
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			// The body is read after the request returns, so the timeout
+			// ends when the caller closes it
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		})
+	}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestOptions(t *testing.T) {
+	var requests atomic.Int32
+	var reason atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		reason.Store(r.Header.Get("X-Audit-Log-Reason"))
+		switch r.URL.Path {
+		case "/users/@me":
+			_, _ = w.Write([]byte(`{"id": "1", "username": "bot"}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/limited":
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	t.Run("audit reason is sent url-encoded", func(t *testing.T) {
+		ctx := WithRequestOptions(context.Background(), WithAuditReason("Crossplane update of default/général"))
+		if _, err := client.GetCurrentUser(ctx); err != nil {
+			t.Fatalf("GetCurrentUser failed: %v", err)
+		}
+		if got := reason.Load(); got != "Crossplane%20update%20of%20default%2Fg%C3%A9n%C3%A9ral" {
+			t.Errorf("Unexpected audit reason %q", got)
+		}
+	})
+
+	t.Run("options accumulate", func(t *testing.T) {
+		ctx := WithRequestOptions(context.Background(), WithAuditReason("first"))
+		ctx = WithRequestOptions(ctx, WithNoRetry())
+		o := requestOptionsFrom(ctx)
+		if o.auditReason != "first" || !o.noRetry {
+			t.Errorf("Expected options to accumulate, got %+v", o)
+		}
+	})
+
+	t.Run("timeout bounds the request", func(t *testing.T) {
+		ctx := WithRequestOptions(context.Background(), WithTimeout(20*time.Millisecond))
+		resp, err := client.makeRequest(ctx, http.MethodGet, "/slow", nil)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	})
+
+	t.Run("no retry fails on the first rate limit", func(t *testing.T) {
+		requests.Store(0)
+		ctx := WithRequestOptions(context.Background(), WithNoRetry())
+		resp, err := client.makeRequest(ctx, http.MethodGet, "/limited", nil)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err == nil || !strings.Contains(err.Error(), "Discord API error: 429") {
+			t.Errorf("Expected 429 error, got %v", err)
+		}
+		if n := requests.Load(); n != 1 {
+			t.Errorf("Expected 1 request, got %d", n)
+		}
+	})
+}
+
+func TestWithAuditReasonTruncates(t *testing.T) {
+	var o requestOptions
+	WithAuditReason(strings.Repeat("é", 600))(&o)
+	if n := len([]rune(o.auditReason)); n != maxAuditReasonLength {
+		t.Errorf("Expected %d characters, got %d", maxAuditReasonLength, n)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// Discord permission bits used by the provider's pre-flight checks.
+const (
+	PermissionCreateInstantInvite   int64 = 1 << 0
+	PermissionKickMembers           int64 = 1 << 1
+	PermissionBanMembers            int64 = 1 << 2
+	PermissionAdministrator         int64 = 1 << 3
+	PermissionManageChannels        int64 = 1 << 4
+	PermissionManageGuild           int64 = 1 << 5
+	PermissionViewAuditLog          int64 = 1 << 7
+	PermissionViewChannel           int64 = 1 << 10
+	PermissionSendMessages          int64 = 1 << 11
+	PermissionReadMessageHistory    int64 = 1 << 16
+	PermissionMuteMembers           int64 = 1 << 22
+	PermissionDeafenMembers         int64 = 1 << 23
+	PermissionMoveMembers           int64 = 1 << 24
+	PermissionManageNicknames       int64 = 1 << 27
+	PermissionManageRoles           int64 = 1 << 28
+	PermissionManageWebhooks        int64 = 1 << 29
+	PermissionCreatePublicThreads   int64 = 1 << 35
+	PermissionCreatePrivateThreads  int64 = 1 << 36
+	PermissionSendMessagesInThreads int64 = 1 << 38
+	PermissionModerateMembers       int64 = 1 << 40
+
+	// PermissionAll is granted to guild owners and administrators.
+	PermissionAll int64 = -1
+)
+
+// permissionNames lists the permissions above in the order they are reported.
+var permissionNames = []struct {
+	bit  int64
+	name string
+}{
+	{PermissionCreateInstantInvite, "CREATE_INSTANT_INVITE"},
+	{PermissionKickMembers, "KICK_MEMBERS"},
+	{PermissionBanMembers, "BAN_MEMBERS"},
+	{PermissionAdministrator, "ADMINISTRATOR"},
+	{PermissionManageChannels, "MANAGE_CHANNELS"},
+	{PermissionManageGuild, "MANAGE_GUILD"},
+	{PermissionViewAuditLog, "VIEW_AUDIT_LOG"},
+	{PermissionViewChannel, "VIEW_CHANNEL"},
+	{PermissionSendMessages, "SEND_MESSAGES"},
+	{PermissionReadMessageHistory, "READ_MESSAGE_HISTORY"},
+	{PermissionMuteMembers, "MUTE_MEMBERS"},
+	{PermissionDeafenMembers, "DEAFEN_MEMBERS"},
+	{PermissionMoveMembers, "MOVE_MEMBERS"},
+	{PermissionManageNicknames, "MANAGE_NICKNAMES"},
+	{PermissionManageRoles, "MANAGE_ROLES"},
+	{PermissionManageWebhooks, "MANAGE_WEBHOOKS"},
+	{PermissionCreatePublicThreads, "CREATE_PUBLIC_THREADS"},
+	{PermissionCreatePrivateThreads, "CREATE_PRIVATE_THREADS"},
+	{PermissionSendMessagesInThreads, "SEND_MESSAGES_IN_THREADS"},
+	{PermissionModerateMembers, "MODERATE_MEMBERS"},
+}
+
+// PermissionNames returns the names of the known permissions set in bits.
+func PermissionNames(bits int64) []string {
+	names := make([]string, 0)
+	for _, p := range permissionNames {
+		if bits&p.bit != 0 {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// MissingPermissionsError is returned when the bot lacks permissions an
+// operation requires.
+type MissingPermissionsError struct {
+	GuildID     string
+	Permissions []string
+}
+
+func (e *MissingPermissionsError) Error() string {
+	return "MissingPermission: " + strings.Join(e.Permissions, ", ")
+}
+
+// IsMissingPermissions reports whether err was caused by a
+// MissingPermissionsError, returning it if so.
+func IsMissingPermissions(err error) (*MissingPermissionsError, bool) {
+	var missing *MissingPermissionsError
+	if errors.As(err, &missing) {
+		return missing, true
+	}
+	return nil, false
+}
+
+// RequirePermissions returns a *MissingPermissionsError if the bot lacks any
+// of the required permissions in the guild.
+func RequirePermissions(ctx context.Context, c PermissionClient, guildID string, required int64) error {
+	have, err := c.GetCurrentMemberPermissions(ctx, guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve bot permissions")
+	}
+	if missing := required &^ have; missing != 0 {
+		return &MissingPermissionsError{GuildID: guildID, Permissions: PermissionNames(missing)}
+	}
+	return nil
+}
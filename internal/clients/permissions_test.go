@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestRequirePermissions(t *testing.T) {
+	const guildID = "123456789"
+
+	tests := []struct {
+		name        string
+		ownerID     string
+		roles       []Role
+		required    int64
+		wantMissing []string
+	}{
+		{
+			name:    "granted by bot role",
+			ownerID: "1",
+			roles: []Role{
+				{ID: guildID, Permissions: "0"},
+				{ID: "555", Permissions: strconv.FormatInt(PermissionManageChannels|PermissionManageRoles, 10)},
+			},
+			required: PermissionManageChannels | PermissionManageRoles,
+		},
+		{
+			name:    "granted by @everyone",
+			ownerID: "1",
+			roles: []Role{
+				{ID: guildID, Permissions: strconv.FormatInt(PermissionCreateInstantInvite, 10)},
+			},
+			required: PermissionCreateInstantInvite,
+		},
+		{
+			name:    "missing permissions",
+			ownerID: "1",
+			roles: []Role{
+				{ID: guildID, Permissions: "0"},
+				{ID: "555", Permissions: strconv.FormatInt(PermissionManageChannels, 10)},
+				{ID: "666", Permissions: strconv.FormatInt(PermissionManageRoles, 10)}, // Not held by the bot
+			},
+			required:    PermissionManageChannels | PermissionManageRoles | PermissionManageGuild,
+			wantMissing: []string{"MANAGE_GUILD", "MANAGE_ROLES"},
+		},
+		{
+			name:    "administrator",
+			ownerID: "1",
+			roles: []Role{
+				{ID: "555", Permissions: strconv.FormatInt(PermissionAdministrator, 10)},
+			},
+			required: PermissionManageGuild,
+		},
+		{
+			name:     "guild owner",
+			ownerID:  "999",
+			roles:    []Role{{ID: guildID, Permissions: "0"}},
+			required: PermissionManageGuild,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				var body interface{}
+				switch r.URL.Path {
+				case "/guilds/" + guildID + "/members/@me":
+					body = GuildMember{User: &DiscordUser{ID: "999"}, Roles: []string{"555"}}
+				case "/guilds/" + guildID:
+					body = Guild{ID: guildID, OwnerID: tc.ownerID, Roles: tc.roles}
+				default:
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				if err := json.NewEncoder(w).Encode(body); err != nil {
+					t.Errorf("Failed to encode mock response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			client := NewDiscordClient("test-token")
+			client.baseURL = server.URL
+
+			err := RequirePermissions(context.Background(), client, guildID, tc.required)
+			if tc.wantMissing == nil {
+				if err != nil {
+					t.Fatalf("RequirePermissions failed: %v", err)
+				}
+				return
+			}
+
+			missing, ok := IsMissingPermissions(err)
+			if !ok {
+				t.Fatalf("Expected MissingPermissionsError, got %v", err)
+			}
+			if diff := cmp.Diff(tc.wantMissing, missing.Permissions); diff != "" {
+				t.Errorf("Missing permissions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
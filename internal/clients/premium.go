@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// Guild features that raise premium tier limits.
+const (
+	GuildFeatureMoreEmoji    = "MORE_EMOJI"
+	GuildFeatureMoreStickers = "MORE_STICKERS"
+	GuildFeatureVIPRegions   = "VIP_REGIONS"
+)
+
+// MinBitrate is the lowest bitrate Discord accepts for a voice channel.
+const MinBitrate = 8000
+
+// PremiumTierLimits are the limits Discord applies to a guild, which grow
+// with its premium (server boost) tier.
+type PremiumTierLimits struct {
+	// EmojiSlots is the number of static, and separately animated, custom
+	// emojis the guild can hold.
+	EmojiSlots int
+	// StickerSlots is the number of custom stickers the guild can hold.
+	StickerSlots int
+	// MaxBitrate is the highest voice channel bitrate, in bits per second.
+	MaxBitrate int
+}
+
+// premiumTierLimits is indexed by premium tier.
+var premiumTierLimits = [...]PremiumTierLimits{
+	{EmojiSlots: 50, StickerSlots: 5, MaxBitrate: 96000},
+	{EmojiSlots: 100, StickerSlots: 15, MaxBitrate: 128000},
+	{EmojiSlots: 150, StickerSlots: 30, MaxBitrate: 256000},
+	{EmojiSlots: 250, StickerSlots: 60, MaxBitrate: 384000},
+}
+
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// LimitsForTier returns the limits of a guild with the supplied premium tier
+// and features. Tiers outside those Discord documents are clamped to them.
+func LimitsForTier(premiumTier int, features []string) PremiumTierLimits {
+	l := premiumTierLimits[min(max(premiumTier, 0), len(premiumTierLimits)-1)]
+	if hasFeature(features, GuildFeatureMoreEmoji) {
+		l.EmojiSlots = max(l.EmojiSlots, 200)
+	}
+	if hasFeature(features, GuildFeatureMoreStickers) {
+		l.StickerSlots = max(l.StickerSlots, 60)
+	}
+	if hasFeature(features, GuildFeatureVIPRegions) {
+		l.MaxBitrate = max(l.MaxBitrate, 384000)
+	}
+	return l
+}
+
+// LimitsForGuild returns the limits of guild.
+func LimitsForGuild(guild *Guild) PremiumTierLimits {
+	return LimitsForTier(guild.PremiumTier, guild.Features)
+}
+
+// PremiumLimitError is returned when a setting exceeds what the guild's
+// premium tier allows, before Discord is asked to apply it.
+type PremiumLimitError struct {
+	GuildID     string
+	Field       string
+	Value       int
+	Limit       int
+	PremiumTier int
+}
+
+func (e *PremiumLimitError) Error() string {
+	return fmt.Sprintf("%s %d exceeds the limit of %d for guild %s at premium tier %d", e.Field, e.Value, e.Limit, e.GuildID, e.PremiumTier)
+}
+
+// IsPremiumLimit reports whether err is, or wraps, a PremiumLimitError.
+func IsPremiumLimit(err error) bool {
+	var e *PremiumLimitError
+	return errors.As(err, &e)
+}
+
+// GuildGetter gets a guild, which is all tier limit checks need.
+type GuildGetter interface {
+	GetGuild(ctx context.Context, guildID string) (*Guild, error)
+}
+
+// CheckBitrate verifies a voice channel bitrate is allowed in the guild.
+func CheckBitrate(ctx context.Context, c GuildGetter, guildID string, bitrate int) error {
+	guild, err := c.GetGuild(ctx, guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get guild premium tier")
+	}
+	if limit := LimitsForGuild(guild).MaxBitrate; bitrate > limit {
+		return &PremiumLimitError{GuildID: guildID, Field: "bitrate", Value: bitrate, Limit: limit, PremiumTier: guild.PremiumTier}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+)
+
+func TestLimitsForTier(t *testing.T) {
+	tests := []struct {
+		name     string
+		tier     int
+		features []string
+		expected PremiumTierLimits
+	}{
+		{
+			name:     "unboosted",
+			tier:     0,
+			expected: PremiumTierLimits{EmojiSlots: 50, StickerSlots: 5, MaxBitrate: 96000},
+		},
+		{
+			name:     "tier 3",
+			tier:     3,
+			expected: PremiumTierLimits{EmojiSlots: 250, StickerSlots: 60, MaxBitrate: 384000},
+		},
+		{
+			name:     "unknown tiers are clamped",
+			tier:     7,
+			expected: PremiumTierLimits{EmojiSlots: 250, StickerSlots: 60, MaxBitrate: 384000},
+		},
+		{
+			name:     "features raise limits",
+			tier:     1,
+			features: []string{GuildFeatureMoreEmoji, GuildFeatureMoreStickers, GuildFeatureVIPRegions},
+			expected: PremiumTierLimits{EmojiSlots: 200, StickerSlots: 60, MaxBitrate: 384000},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := LimitsForTier(tc.tier, tc.features); got != tc.expected {
+				t.Errorf("LimitsForTier(%d, %v) = %+v, want %+v", tc.tier, tc.features, got, tc.expected)
+			}
+		})
+	}
+}
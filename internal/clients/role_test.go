@@ -119,11 +119,11 @@ func TestGetRole(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
-		assert.Equal(t, "/guilds/"+guildID+"/roles", r.URL.Path)
+		assert.Equal(t, "/guilds/"+guildID+"/roles/"+roleID, r.URL.Path)
 		assert.Equal(t, "Bot test-token", r.Header.Get("Authorization"))
 
 		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(roles); err != nil {
+		if err := json.NewEncoder(w).Encode(roles[0]); err != nil {
 			t.Errorf("Failed to encode mock response: %v", err)
 		}
 	}))
@@ -139,21 +139,27 @@ func TestGetRole(t *testing.T) {
 	assert.Equal(t, roles[0].Color, role.Color)
 }
 
-func TestGetRoleNotFound(t *testing.T) {
+func TestGetRoleFallsBackToList(t *testing.T) {
 	guildID := "123456789"
-	roleID := "nonexistent"
+	roleID := "987654321"
 
 	roles := []Role{
-		{
-			ID:   "987654321",
-			Name: "Test Role",
-		},
+		{ID: "111111111", Name: "Other Role"},
+		{ID: roleID, Name: "Test Role"},
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(roles); err != nil {
-			t.Errorf("Failed to encode mock response: %v", err)
+		switch r.URL.Path {
+		case "/guilds/" + guildID + "/roles/" + roleID:
+			// Route not served, as answered for endpoints Discord lacks
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "404: Not Found", "code": 0}`))
+		case "/guilds/" + guildID + "/roles":
+			if err := json.NewEncoder(w).Encode(roles); err != nil {
+				t.Errorf("Failed to encode mock response: %v", err)
+			}
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
 		}
 	}))
 	defer server.Close()
@@ -161,6 +167,26 @@ func TestGetRoleNotFound(t *testing.T) {
 	client := NewDiscordClient("test-token")
 	client.baseURL = server.URL
 
+	role, err := client.GetRole(context.Background(), guildID, roleID)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Role", role.Name)
+}
+
+func TestGetRoleNotFound(t *testing.T) {
+	guildID := "123456789"
+	roleID := "nonexistent"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/guilds/"+guildID+"/roles/"+roleID, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Unknown Role", "code": 10011}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
 	role, err := client.GetRole(context.Background(), guildID, roleID)
 	assert.Error(t, err)
 	assert.Nil(t, role)
@@ -281,7 +307,7 @@ func TestRoleErrorHandling(t *testing.T) {
 			name:       "GetRole 404 error",
 			statusCode: http.StatusNotFound,
 			method:     "GET",
-			endpoint:   "/guilds/" + guildID + "/roles",
+			endpoint:   "/guilds/" + guildID + "/roles/" + roleID,
 			operation: func(c *DiscordClient) error {
 				_, err := c.GetRole(context.Background(), guildID, roleID)
 				return err
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+)
+
+// SimulateRateLimitsEnv is the environment variable that switches clients to
+// a simulated Discord API. Set it to "true" for the default limits, or to
+// "<requests>/<window>", e.g. "5/1s", for a route limit of its own. No
+// request then reaches Discord, so load tests can exercise the rate limit
+// and retry middlewares in CI.
+const SimulateRateLimitsEnv = "DISCORD_SIMULATE_RATE_LIMITS"
+
+const (
+	// DefaultSimulatedRouteLimit is how many requests a simulated route
+	// allows per DefaultSimulatedWindow.
+	DefaultSimulatedRouteLimit = 50
+	// DefaultSimulatedWindow is how often a simulated route's bucket
+	// resets.
+	DefaultSimulatedWindow = time.Second
+	// DefaultSimulatedGlobalLimit is how many requests the simulated API
+	// allows per second across all routes, matching Discord's global limit.
+	DefaultSimulatedGlobalLimit = 50
+)
+
+// A SimulatedAPI answers Discord API requests locally. It keeps a bucket per
+// route and a global bucket, and answers like Discord does: rate limit
+// headers on every response, and a 429 with Retry-After once a bucket is
+// exhausted. Successful requests get a minimal body: an object carrying the
+// ID the path ends with, or an empty list for collections.
+type SimulatedAPI struct {
+	// RouteLimit is how many requests each route allows per Window.
+	RouteLimit int
+	// Window is how often route buckets reset.
+	Window time.Duration
+	// GlobalLimit is how many requests are allowed per second across all
+	// routes. Zero disables the global limit.
+	GlobalLimit int
+
+	now     func() time.Time
+	mu      sync.Mutex
+	buckets map[string]*simulatedBucket
+}
+
+type simulatedBucket struct {
+	used  int
+	reset time.Time
+}
+
+// NewSimulatedAPI returns a simulated Discord API with the default limits.
+func NewSimulatedAPI() *SimulatedAPI {
+	return &SimulatedAPI{
+		RouteLimit:  DefaultSimulatedRouteLimit,
+		Window:      DefaultSimulatedWindow,
+		GlobalLimit: DefaultSimulatedGlobalLimit,
+		now:         time.Now,
+		buckets:     map[string]*simulatedBucket{},
+	}
+}
+
+// ParseSimulatedAPI returns the simulated API a SimulateRateLimitsEnv value
+// asks for, or nil if it is empty or "false".
+func ParseSimulatedAPI(v string) (*SimulatedAPI, error) {
+	switch v {
+	case "", "false":
+		return nil, nil
+	case "true":
+		return NewSimulatedAPI(), nil
+	}
+
+	limit, window, ok := strings.Cut(v, "/")
+	if !ok {
+		return nil, errors.Errorf("%s must be true, false or <requests>/<window>, not %q", SimulateRateLimitsEnv, v)
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 1 {
+		return nil, errors.Errorf("%s: invalid request count %q", SimulateRateLimitsEnv, limit)
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return nil, errors.Errorf("%s: invalid window %q", SimulateRateLimitsEnv, window)
+	}
+
+	s := NewSimulatedAPI()
+	s.RouteLimit = n
+	s.Window = d
+	return s, nil
+}
+
+// sharedSimulatedAPI is shared by all clients, since clients are created per
+// reconcile but Discord limits apply per bot. It is nil unless
+// SetSimulatedAPI installs a simulation.
+var sharedSimulatedAPI atomic.Pointer[SimulatedAPI]
+
+// SetSimulatedAPI makes clients created afterwards answer requests with s,
+// or send them to Discord if s is nil. It is called once at startup, before
+// any client is created.
+func SetSimulatedAPI(s *SimulatedAPI) {
+	sharedSimulatedAPI.Store(s)
+}
+
+// SimulatedAPIFromEnv returns the simulated API SimulateRateLimitsEnv asks
+// for, or nil if it is unset.
+func SimulatedAPIFromEnv() (*SimulatedAPI, error) {
+	return ParseSimulatedAPI(os.Getenv(SimulateRateLimitsEnv))
+}
+
+// take uses a request from the named bucket, returning how many requests
+// it has left and when it resets, or false if it is exhausted.
+func (s *SimulatedAPI) take(key string, limit int, window time.Duration) (int, time.Duration, bool) {
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok || !now.Before(b.reset) {
+		b = &simulatedBucket{reset: now.Add(window)}
+		s.buckets[key] = b
+	}
+	resetAfter := b.reset.Sub(now)
+	if b.used >= limit {
+		return 0, resetAfter, false
+	}
+	b.used++
+	return limit - b.used, resetAfter, true
+}
+
+// RoundTrip answers req without sending it anywhere.
+func (s *SimulatedAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+
+	route := req.Method + " " + apiPath(req)
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	s.mu.Lock()
+	if s.GlobalLimit > 0 {
+		if _, resetAfter, ok := s.take("global", s.GlobalLimit, time.Second); !ok {
+			s.mu.Unlock()
+			header.Set("X-RateLimit-Global", "true")
+			header.Set("X-RateLimit-Scope", "global")
+			return simulatedRateLimited(req, header, resetAfter), nil
+		}
+	}
+	remaining, resetAfter, ok := s.take(route, s.RouteLimit, s.Window)
+	s.mu.Unlock()
+
+	header.Set("X-RateLimit-Limit", strconv.Itoa(s.RouteLimit))
+	header.Set(resilience.DiscordRateLimitHeader, strconv.Itoa(remaining))
+	header.Set(resilience.DiscordRateLimitReset, seconds(resetAfter))
+	header.Set("X-RateLimit-Bucket", route)
+	if !ok {
+		header.Set("X-RateLimit-Scope", "user")
+		return simulatedRateLimited(req, header, resetAfter), nil
+	}
+
+	if req.Method == http.MethodDelete {
+		return simulatedResponse(req, http.StatusNoContent, header, ""), nil
+	}
+	return simulatedResponse(req, http.StatusOK, header, simulatedBody(req)), nil
+}
+
+// ServeHTTP answers r like RoundTrip, so the simulated API can also back an
+// in-process server clients reach through SetBaseURL.
+func (s *SimulatedAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp, _ := s.RoundTrip(r)
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// simulatedBody returns a minimal JSON body for a successful request.
+func simulatedBody(req *http.Request) string {
+	segments := strings.Split(strings.Trim(apiPath(req), "/"), "/")
+	last := segments[len(segments)-1]
+	if snowflake.Valid(last) {
+		b, _ := json.Marshal(map[string]string{"id": last})
+		return string(b)
+	}
+	if req.Method == http.MethodGet {
+		return "[]"
+	}
+	return `{"id": "100000000000000000"}`
+}
+
+func simulatedRateLimited(req *http.Request, header http.Header, retryAfter time.Duration) *http.Response {
+	header.Set(resilience.DiscordRetryAfterHeader, seconds(retryAfter))
+	body := fmt.Sprintf(`{"message": "You are being rate limited.", "retry_after": %s, "global": %t}`,
+		seconds(retryAfter), header.Get("X-RateLimit-Global") == "true")
+	return simulatedResponse(req, http.StatusTooManyRequests, header, body)
+}
+
+func simulatedResponse(req *http.Request, code int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// seconds formats d the way Discord's rate limit headers do.
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
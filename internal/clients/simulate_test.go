@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseSimulatedAPI(t *testing.T) {
+	for v, want := range map[string]*SimulatedAPI{
+		"":      nil,
+		"false": nil,
+		"true":  {RouteLimit: DefaultSimulatedRouteLimit, Window: DefaultSimulatedWindow},
+		"5/2s":  {RouteLimit: 5, Window: 2 * time.Second},
+	} {
+		got, err := ParseSimulatedAPI(v)
+		if err != nil {
+			t.Fatalf("ParseSimulatedAPI(%q) failed: %v", v, err)
+		}
+		if (got == nil) != (want == nil) {
+			t.Fatalf("ParseSimulatedAPI(%q) = %v, want %v", v, got, want)
+		}
+		if got != nil && (got.RouteLimit != want.RouteLimit || got.Window != want.Window) {
+			t.Errorf("ParseSimulatedAPI(%q) = %d/%s, want %d/%s", v, got.RouteLimit, got.Window, want.RouteLimit, want.Window)
+		}
+	}
+
+	for _, v := range []string{"yes", "0/1s", "5/soon", "5/-1s"} {
+		if _, err := ParseSimulatedAPI(v); err == nil {
+			t.Errorf("ParseSimulatedAPI(%q) succeeded, want error", v)
+		}
+	}
+}
+
+func TestSimulatedAPI(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSimulatedAPI()
+	s.RouteLimit = 2
+	s.GlobalLimit = 3
+	s.now = func() time.Time { return now }
+
+	get := func(path string) *http.Response {
+		t.Helper()
+		resp, err := s.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/v10"+path, nil))
+		if err != nil {
+			t.Fatalf("RoundTrip failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := get("/guilds/100000000000000001")
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != `{"id":"100000000000000001"}` {
+		t.Errorf("Unexpected response %d %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "1" || resp.Header.Get("X-RateLimit-Reset-After") != "1.000" {
+		t.Errorf("Unexpected rate limit headers %v", resp.Header)
+	}
+
+	if resp := get("/guilds/100000000000000001"); resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("Expected bucket to be exhausted, got %v", resp.Header)
+	}
+
+	now = now.Add(250 * time.Millisecond)
+	resp = get("/guilds/100000000000000001")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "0.750" || resp.Header.Get("X-RateLimit-Scope") != "user" {
+		t.Errorf("Expected route rate limit, got %d %v", resp.StatusCode, resp.Header)
+	}
+
+	// The global bucket counts every request, even rate limited ones
+	resp = get("/users/@me/guilds")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("X-RateLimit-Global") != "true" {
+		t.Errorf("Expected global rate limit, got %d %v", resp.StatusCode, resp.Header)
+	}
+
+	now = now.Add(time.Second)
+	resp = get("/users/@me/guilds")
+	body, _ = io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "[]" {
+		t.Errorf("Expected buckets to reset, got %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestClientWithSimulatedAPI(t *testing.T) {
+	s := NewSimulatedAPI()
+	s.RouteLimit = 2
+	s.Window = 50 * time.Millisecond
+
+	client := NewDiscordClient("simulated-client-test-token")
+	client.transport = s
+	client.Use()
+
+	start := time.Now()
+	for range 5 {
+		guild, err := client.GetGuild(context.Background(), "100000000000000001")
+		if err != nil {
+			t.Fatalf("GetGuild failed: %v", err)
+		}
+		if guild.ID != "100000000000000001" {
+			t.Errorf("Unexpected guild %+v", guild)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected requests to wait for the bucket to reset, took %s", elapsed)
+	}
+}
+
+func TestSimulatedAPIServer(t *testing.T) {
+	server := httptest.NewServer(NewSimulatedAPI())
+	defer server.Close()
+
+	client := NewDiscordClient("simulated-server-test-token")
+	client.SetBaseURL(server.URL + "/api/v10")
+
+	guilds, err := client.ListGuilds(context.Background())
+	if err != nil {
+		t.Fatalf("ListGuilds failed: %v", err)
+	}
+	if len(guilds) != 0 {
+		t.Errorf("Unexpected guilds %+v", guilds)
+	}
+}
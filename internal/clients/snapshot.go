@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultSnapshotTTL is how long a guild snapshot serves reads. It covers a
+// burst of reconciles, such as a GitOps sync, without masking drift for
+// longer than a fraction of a poll interval.
+const DefaultSnapshotTTL = 5 * time.Second
+
+// A GuildSnapshotCache holds short-lived snapshots of guild-wide listings,
+// so that many resources of the same guild reconciled in a burst share one
+// API call. Concurrent requests for the same snapshot wait for a single
+// fetch.
+type GuildSnapshotCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*snapshot
+	// channelGuilds remembers the guild of every channel seen, keyed by
+	// bot and channel ID, so channel reads can be served from snapshots.
+	channelGuilds map[string]string
+}
+
+type snapshot struct {
+	done    chan struct{}
+	fetched time.Time
+	value   any
+	err     error
+}
+
+// NewGuildSnapshotCache returns a cache whose snapshots serve reads for ttl.
+func NewGuildSnapshotCache(ttl time.Duration) *GuildSnapshotCache {
+	return &GuildSnapshotCache{
+		ttl:           ttl,
+		now:           time.Now,
+		entries:       make(map[string]*snapshot),
+		channelGuilds: make(map[string]string),
+	}
+}
+
+// guildSnapshots is shared by all controllers so resources of different kinds
+// in the same guild benefit too.
+var guildSnapshots = NewGuildSnapshotCache(DefaultSnapshotTTL)
+
+// get returns the snapshot stored under key, calling fetch if there is none
+// or it has expired. Failed fetches are not cached.
+func (c *GuildSnapshotCache) get(key string, fetch func() (any, error)) (any, error) {
+	c.mu.Lock()
+	s, ok := c.entries[key]
+	if ok {
+		select {
+		case <-s.done:
+			if c.now().Sub(s.fetched) >= c.ttl {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		s = &snapshot{done: make(chan struct{})}
+		c.entries[key] = s
+		c.mu.Unlock()
+
+		s.value, s.err = fetch()
+		c.mu.Lock()
+		s.fetched = c.now()
+		if s.err != nil && c.entries[key] == s {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		close(s.done)
+		return s.value, s.err
+	}
+	c.mu.Unlock()
+
+	<-s.done
+	return s.value, s.err
+}
+
+// invalidate drops the snapshot stored under key, so the next read fetches
+// the result of a write.
+func (c *GuildSnapshotCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *GuildSnapshotCache) rememberChannels(bot string, channels ...Channel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ch := range channels {
+		if ch.GuildID != "" {
+			c.channelGuilds[bot+"/"+ch.ID] = ch.GuildID
+		}
+	}
+}
+
+func (c *GuildSnapshotCache) channelGuild(bot, channelID string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.channelGuilds[bot+"/"+channelID]
+}
+
+func (c *GuildSnapshotCache) forgetChannel(bot, channelID string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	guildID := c.channelGuilds[bot+"/"+channelID]
+	delete(c.channelGuilds, bot+"/"+channelID)
+	return guildID
+}
+
+// A SnapshotClient is a DiscordClient that serves guild, channel and role
+// reads from shared guild snapshots, and invalidates them when it writes.
+type SnapshotClient struct {
+	*DiscordClient
+
+	cache *GuildSnapshotCache
+	// bot identifies the bot token, so bots that see different parts of a
+	// guild never share snapshots.
+	bot string
+}
+
+var _ ChannelClient = (*SnapshotClient)(nil)
+var _ RoleClient = (*SnapshotClient)(nil)
+var _ GuildGetter = (*SnapshotClient)(nil)
+
+// NewSnapshotClient wraps c so its channel and role reads are served from the
+// snapshots shared by all controllers.
+func NewSnapshotClient(c *DiscordClient) *SnapshotClient {
+	return newSnapshotClient(c, guildSnapshots)
+}
+
+func newSnapshotClient(c *DiscordClient, cache *GuildSnapshotCache) *SnapshotClient {
+	return &SnapshotClient{DiscordClient: c, cache: cache, bot: botKey(c.token)}
+}
+
+func (s *SnapshotClient) channelsKey(guildID string) string {
+	return "channels/" + s.bot + "/" + guildID
+}
+
+func (s *SnapshotClient) rolesKey(guildID string) string {
+	return "roles/" + s.bot + "/" + guildID
+}
+
+func (s *SnapshotClient) guildKey(guildID string) string {
+	return "guild/" + s.bot + "/" + guildID
+}
+
+// GetGuild gets a guild from its snapshot, so that the premium tier checks
+// of many channels share one read.
+func (s *SnapshotClient) GetGuild(ctx context.Context, guildID string) (*Guild, error) {
+	v, err := s.cache.get(s.guildKey(guildID), func() (any, error) {
+		return s.DiscordClient.GetGuild(ctx, guildID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Guild), nil
+}
+
+// ListGuildChannels lists the channels in a guild from its snapshot.
+func (s *SnapshotClient) ListGuildChannels(ctx context.Context, guildID string) ([]Channel, error) {
+	v, err := s.cache.get(s.channelsKey(guildID), func() (any, error) {
+		return s.DiscordClient.ListGuildChannels(ctx, guildID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	channels := v.([]Channel)
+	s.cache.rememberChannels(s.bot, channels...)
+	return channels, nil
+}
+
+// GetChannel gets a channel from its guild's snapshot once the guild is
+// known, falling back to Discord for channels the snapshot does not hold.
+func (s *SnapshotClient) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	if guildID := s.cache.channelGuild(s.bot, channelID); guildID != "" {
+		channels, err := s.ListGuildChannels(ctx, guildID)
+		if err == nil {
+			for i := range channels {
+				if channels[i].ID == channelID {
+					ch := channels[i]
+					return &ch, nil
+				}
+			}
+		}
+	}
+
+	ch, err := s.DiscordClient.GetChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if ch != nil {
+		s.cache.rememberChannels(s.bot, *ch)
+	}
+	return ch, nil
+}
+
+// CreateChannel creates a channel and invalidates its guild's snapshot.
+func (s *SnapshotClient) CreateChannel(ctx context.Context, req *CreateChannelRequest) (*Channel, error) {
+	defer s.cache.invalidate(s.channelsKey(req.GuildID))
+	return s.DiscordClient.CreateChannel(ctx, req)
+}
+
+// ModifyChannel modifies a channel and invalidates its guild's snapshot.
+func (s *SnapshotClient) ModifyChannel(ctx context.Context, channelID string, req *ModifyChannelRequest) (*Channel, error) {
+	if guildID := s.cache.channelGuild(s.bot, channelID); guildID != "" {
+		defer s.cache.invalidate(s.channelsKey(guildID))
+	}
+	return s.DiscordClient.ModifyChannel(ctx, channelID, req)
+}
+
+// DeleteChannel deletes a channel and invalidates its guild's snapshot.
+func (s *SnapshotClient) DeleteChannel(ctx context.Context, channelID string) error {
+	if guildID := s.cache.forgetChannel(s.bot, channelID); guildID != "" {
+		defer s.cache.invalidate(s.channelsKey(guildID))
+	}
+	return s.DiscordClient.DeleteChannel(ctx, channelID)
+}
+
+// ModifyGuildChannelPositions moves channels and invalidates their guild's
+// snapshot.
+func (s *SnapshotClient) ModifyGuildChannelPositions(ctx context.Context, guildID string, positions []ChannelPosition) error {
+	defer s.cache.invalidate(s.channelsKey(guildID))
+	return s.DiscordClient.ModifyGuildChannelPositions(ctx, guildID, positions)
+}
+
+// ListRoles lists the roles in a guild from its snapshot.
+func (s *SnapshotClient) ListRoles(ctx context.Context, guildID string) ([]Role, error) {
+	v, err := s.cache.get(s.rolesKey(guildID), func() (any, error) {
+		return s.DiscordClient.ListRoles(ctx, guildID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]Role), nil
+}
+
+// GetRole gets a role from its guild's snapshot. A role the snapshot does
+// not hold drops it, so a role created since the listing is found by the
+// next read.
+func (s *SnapshotClient) GetRole(ctx context.Context, guildID, roleID string) (*Role, error) {
+	roles, err := s.ListRoles(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := findRole(roles, roleID)
+	if err != nil {
+		s.cache.invalidate(s.rolesKey(guildID))
+	}
+	return role, err
+}
+
+// CreateRole creates a role and invalidates its guild's snapshot.
+func (s *SnapshotClient) CreateRole(ctx context.Context, guildID string, req CreateRoleRequest) (*Role, error) {
+	defer s.cache.invalidate(s.rolesKey(guildID))
+	return s.DiscordClient.CreateRole(ctx, guildID, req)
+}
+
+// ModifyRole modifies a role and invalidates its guild's snapshot.
+func (s *SnapshotClient) ModifyRole(ctx context.Context, guildID, roleID string, req ModifyRoleRequest) (*Role, error) {
+	defer s.cache.invalidate(s.rolesKey(guildID))
+	return s.DiscordClient.ModifyRole(ctx, guildID, roleID, req)
+}
+
+// DeleteRole deletes a role and invalidates its guild's snapshot.
+func (s *SnapshotClient) DeleteRole(ctx context.Context, guildID, roleID string) error {
+	defer s.cache.invalidate(s.rolesKey(guildID))
+	return s.DiscordClient.DeleteRole(ctx, guildID, roleID)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSnapshotClient(t *testing.T) {
+	const guildID = "123456789"
+
+	var roleLists, roleGets, channelLists, channelGets, guildGets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body interface{}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/guilds/"+guildID+"/roles":
+			roleLists.Add(1)
+			body = []Role{{ID: "1", Name: "Admins"}, {ID: "2", Name: "Members"}}
+		case r.Method == "GET" && r.URL.Path == "/guilds/"+guildID+"/roles/2":
+			roleGets.Add(1)
+			body = Role{ID: "2", Name: "Members"}
+		case r.Method == "PATCH" && r.URL.Path == "/guilds/"+guildID+"/roles/1":
+			body = Role{ID: "1", Name: "Owners"}
+		case r.Method == "GET" && r.URL.Path == "/guilds/"+guildID+"/channels":
+			channelLists.Add(1)
+			body = []Channel{{ID: "10", GuildID: guildID, Name: "general"}, {ID: "11", GuildID: guildID, Name: "random"}}
+		case r.Method == "GET" && r.URL.Path == "/guilds/"+guildID:
+			guildGets.Add(1)
+			body = Guild{ID: guildID, PremiumTier: 2}
+		case r.Method == "GET" && r.URL.Path == "/channels/10":
+			channelGets.Add(1)
+			body = Channel{ID: "10", GuildID: guildID, Name: "general"}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(cache *GuildSnapshotCache) *SnapshotClient {
+		c := NewDiscordClient("test-token")
+		c.baseURL = server.URL
+		return newSnapshotClient(c, cache)
+	}
+	ctx := context.Background()
+
+	t.Run("concurrent role reads share one fetch", func(t *testing.T) {
+		cache := NewGuildSnapshotCache(time.Minute)
+		roleLists.Store(0)
+		roleGets.Store(0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Every resource gets its own client, as controllers do
+				if _, err := newClient(cache).GetRole(ctx, guildID, "2"); err != nil {
+					t.Errorf("GetRole failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if n := roleLists.Load(); n != 1 {
+			t.Errorf("Expected 1 role listing, got %d", n)
+		}
+		if n := roleGets.Load(); n != 0 {
+			t.Errorf("Expected no single role reads, got %d", n)
+		}
+	})
+
+	t.Run("a missing role drops the snapshot", func(t *testing.T) {
+		cache := NewGuildSnapshotCache(time.Minute)
+		roleLists.Store(0)
+		c := newClient(cache)
+
+		if _, err := c.GetRole(ctx, guildID, "3"); err == nil || err.Error() != "role not found" {
+			t.Fatalf("Expected role not found, got %v", err)
+		}
+		if _, err := c.GetRole(ctx, guildID, "2"); err != nil {
+			t.Fatalf("GetRole failed: %v", err)
+		}
+
+		if n := roleLists.Load(); n != 2 {
+			t.Errorf("Expected 2 role listings, got %d", n)
+		}
+	})
+
+	t.Run("writes invalidate the snapshot", func(t *testing.T) {
+		cache := NewGuildSnapshotCache(time.Minute)
+		roleLists.Store(0)
+		c := newClient(cache)
+
+		if _, err := c.ListRoles(ctx, guildID); err != nil {
+			t.Fatalf("ListRoles failed: %v", err)
+		}
+		name := "Owners"
+		if _, err := c.ModifyRole(ctx, guildID, "1", ModifyRoleRequest{Name: &name}); err != nil {
+			t.Fatalf("ModifyRole failed: %v", err)
+		}
+		if _, err := c.ListRoles(ctx, guildID); err != nil {
+			t.Fatalf("ListRoles failed: %v", err)
+		}
+
+		if n := roleLists.Load(); n != 2 {
+			t.Errorf("Expected 2 role listings, got %d", n)
+		}
+	})
+
+	t.Run("snapshots expire", func(t *testing.T) {
+		cache := NewGuildSnapshotCache(time.Minute)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		roleLists.Store(0)
+		c := newClient(cache)
+
+		if _, err := c.ListRoles(ctx, guildID); err != nil {
+			t.Fatalf("ListRoles failed: %v", err)
+		}
+		now = now.Add(time.Minute)
+		if _, err := c.ListRoles(ctx, guildID); err != nil {
+			t.Fatalf("ListRoles failed: %v", err)
+		}
+
+		if n := roleLists.Load(); n != 2 {
+			t.Errorf("Expected 2 role listings, got %d", n)
+		}
+	})
+
+	t.Run("channels are read from the guild snapshot once their guild is known", func(t *testing.T) {
+		cache := NewGuildSnapshotCache(time.Minute)
+		channelLists.Store(0)
+		channelGets.Store(0)
+		c := newClient(cache)
+
+		// The first read learns the channel's guild
+		if _, err := c.GetChannel(ctx, "10"); err != nil {
+			t.Fatalf("GetChannel failed: %v", err)
+		}
+		for i := 0; i < 5; i++ {
+			ch, err := c.GetChannel(ctx, "10")
+			if err != nil {
+				t.Fatalf("GetChannel failed: %v", err)
+			}
+			if ch.Name != "general" {
+				t.Errorf("Expected channel general, got %s", ch.Name)
+			}
+		}
+
+		if n := channelGets.Load(); n != 1 {
+			t.Errorf("Expected 1 channel read, got %d", n)
+		}
+		if n := channelLists.Load(); n != 1 {
+			t.Errorf("Expected 1 channel listing, got %d", n)
+		}
+	})
+
+	t.Run("guild reads share one fetch", func(t *testing.T) {
+		cache := NewGuildSnapshotCache(time.Minute)
+		guildGets.Store(0)
+
+		for i := 0; i < 5; i++ {
+			guild, err := newClient(cache).GetGuild(ctx, guildID)
+			if err != nil {
+				t.Fatalf("GetGuild failed: %v", err)
+			}
+			if guild.PremiumTier != 2 {
+				t.Errorf("Expected premium tier 2, got %d", guild.PremiumTier)
+			}
+		}
+
+		if n := guildGets.Load(); n != 1 {
+			t.Errorf("Expected 1 guild read, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/url"
+)
+
+// GuildTemplateClient defines the interface for reading Discord guild
+// templates
+type GuildTemplateClient interface {
+	GetGuildTemplate(ctx context.Context, code string) (*GuildTemplate, error)
+}
+
+// GuildTemplate represents a Discord guild template: a snapshot of a guild's
+// roles, channels and settings that new guilds can be created from
+type GuildTemplate struct {
+	Code                  string        `json:"code"`
+	Name                  string        `json:"name"`
+	Description           *string       `json:"description"`
+	SourceGuildID         string        `json:"source_guild_id"`
+	SerializedSourceGuild TemplateGuild `json:"serialized_source_guild"`
+	IsDirty               *bool         `json:"is_dirty"`
+}
+
+// TemplateGuild is the guild a template was taken from. Its roles and
+// channels are identified by integers local to the template rather than
+// Discord IDs; the role with ID 0 is @everyone.
+type TemplateGuild struct {
+	Name     string            `json:"name"`
+	Roles    []TemplateRole    `json:"roles"`
+	Channels []TemplateChannel `json:"channels"`
+}
+
+// TemplateRole is a role of a guild template
+type TemplateRole struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Permissions string `json:"permissions"`
+	Color       int    `json:"color"`
+	Hoist       bool   `json:"hoist"`
+	Mentionable bool   `json:"mentionable"`
+}
+
+// TemplateChannel is a channel of a guild template. ParentID is the
+// template ID of its category.
+type TemplateChannel struct {
+	ID               int     `json:"id"`
+	Type             int     `json:"type"`
+	Name             string  `json:"name"`
+	Position         int     `json:"position"`
+	Topic            *string `json:"topic"`
+	NSFW             bool    `json:"nsfw"`
+	Bitrate          int     `json:"bitrate"`
+	UserLimit        int     `json:"user_limit"`
+	RateLimitPerUser int     `json:"rate_limit_per_user"`
+	ParentID         *int    `json:"parent_id"`
+}
+
+// GetGuildTemplate retrieves a guild template by its code
+func (c *DiscordClient) GetGuildTemplate(ctx context.Context, code string) (*GuildTemplate, error) {
+	template, err := doJSON[*GuildTemplate](ctx, c, "GET", "/guilds/templates/"+url.PathEscape(code), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get guild template")
+	}
+
+	return template, nil
+}
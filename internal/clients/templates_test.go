@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetGuildTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/templates/hgM48av5Q69A" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{
+			"code": "hgM48av5Q69A",
+			"name": "Community",
+			"source_guild_id": "123",
+			"serialized_source_guild": {
+				"name": "Community",
+				"roles": [
+					{"id": 0, "name": "@everyone", "permissions": "104324673", "color": 0, "hoist": false, "mentionable": false},
+					{"id": 1, "name": "Moderator", "permissions": "8", "color": 3447003, "hoist": true, "mentionable": true}
+				],
+				"channels": [
+					{"id": 2, "type": 4, "name": "Text", "position": 0, "parent_id": null},
+					{"id": 3, "type": 0, "name": "general", "position": 0, "topic": "Chat", "parent_id": 2, "rate_limit_per_user": 5}
+				]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient("test-token")
+	client.baseURL = server.URL
+
+	template, err := client.GetGuildTemplate(context.Background(), "hgM48av5Q69A")
+	if err != nil {
+		t.Fatalf("GetGuildTemplate failed: %v", err)
+	}
+	source := template.SerializedSourceGuild
+	if len(source.Roles) != 2 || source.Roles[1].Name != "Moderator" || !source.Roles[1].Hoist {
+		t.Errorf("Unexpected roles %+v", source.Roles)
+	}
+	if len(source.Channels) != 2 || source.Channels[0].ParentID != nil {
+		t.Fatalf("Unexpected channels %+v", source.Channels)
+	}
+	general := source.Channels[1]
+	if general.ParentID == nil || *general.ParentID != 2 || general.Topic == nil || *general.Topic != "Chat" || general.RateLimitPerUser != 5 {
+		t.Errorf("Unexpected channel %+v", general)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conditions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AnnotationKeyApprove approves the pending update of a resource whose
+// ProviderConfig requires approval. Its value must be the approval ID
+// reported in the resource's PendingApproval condition, so an approval only
+// ever lets through the update it was given for.
+const AnnotationKeyApprove = "discord.crossplane.io/approve"
+
+// TypePendingApproval reports whether an update to a resource is waiting for
+// approval.
+const TypePendingApproval xpv1.ConditionType = "PendingApproval"
+
+// PendingApproval condition reasons.
+const (
+	ReasonAwaitingApproval xpv1.ConditionReason = "AwaitingApproval"
+	ReasonApproved         xpv1.ConditionReason = "Approved"
+	ReasonNoUpdatePending  xpv1.ConditionReason = "NoUpdatePending"
+)
+
+// AwaitingApproval returns a condition indicating an update to a resource is
+// waiting for approval.
+func AwaitingApproval(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePendingApproval,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAwaitingApproval,
+		Message:            msg,
+	}
+}
+
+// Approved returns a condition indicating the pending update to a resource
+// has been approved.
+func Approved(id string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePendingApproval,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonApproved,
+		Message:            fmt.Sprintf("update %s was approved", id),
+	}
+}
+
+// NoUpdatePending returns a condition indicating a resource has no update
+// waiting for approval.
+func NoUpdatePending() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePendingApproval,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoUpdatePending,
+	}
+}
+
+// approvalID identifies the update the observation o calls for on mg. It
+// changes whenever the spec or the detected diff does, so a stale approval
+// never applies to a different update. Kinds that report no diff are
+// identified by their observed state instead, so drift after an approval
+// needs approving again.
+func approvalID(mg resource.Managed, o managed.ExternalObservation) string {
+	change := o.Diff
+	if change == "" {
+		change = observedState(mg)
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d/%s", mg.GetNamespace(), mg.GetName(), mg.GetGeneration(), change)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// observedState returns the JSON of mg's status.atProvider, or "" if it has
+// none.
+func observedState(mg resource.Managed) string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return ""
+	}
+	v, err := fieldpath.Pave(u).GetValue("status.atProvider")
+	if err != nil {
+		return ""
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// review records whether the update the observation o calls for on mg has
+// been approved, and returns the observation to report to the managed
+// reconciler along with the verdict. Until the update is approved the
+// resource is reported as up to date, so the reconciler does not attempt it.
+func review(mg resource.Managed, o managed.ExternalObservation) (managed.ExternalObservation, bool) {
+	if meta.WasDeleted(mg) || !o.ResourceExists || o.ResourceUpToDate {
+		if mg.GetCondition(TypePendingApproval).Status == corev1.ConditionTrue {
+			mg.SetConditions(NoUpdatePending().WithObservedGeneration(mg.GetGeneration()))
+		}
+		return o, false
+	}
+
+	id := approvalID(mg, o)
+	if mg.GetAnnotations()[AnnotationKeyApprove] == id {
+		mg.SetConditions(Approved(id).WithObservedGeneration(mg.GetGeneration()))
+		return o, true
+	}
+
+	msg := fmt.Sprintf("update of the Discord resource for %s awaits approval; annotate it with %s=%s to apply it", mg.GetName(), AnnotationKeyApprove, id)
+	if o.Diff != "" {
+		msg += ": " + o.Diff
+	}
+	mg.SetConditions(AwaitingApproval(msg).WithObservedGeneration(mg.GetGeneration()))
+	o.ResourceUpToDate = true
+	return o, false
+}
+
+// errPendingApproval is returned instead of updating a resource whose update
+// has not been approved.
+func errPendingApproval(mg resource.Managed) error {
+	return errors.Errorf("update of the Discord resource for %s awaits approval", mg.GetName())
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conditions
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	required := true
+	pc := &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       v1alpha1.ProviderConfigSpec{RequiresApproval: &required},
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(pc).Build()
+
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild", Generation: 2}}
+	cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Name: "default"})
+
+	observation := managed.ExternalObservation{ResourceExists: true, Diff: "name: old -> new"}
+	updated := false
+	c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				return observation, nil
+			},
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				updated = true
+				return managed.ExternalUpdate{}, nil
+			},
+		}, nil
+	}), WithProviderConfig(kube))
+
+	// Without approval the update is reported and held back.
+	ec, err := c.Connect(ctx, cr)
+	require.NoError(t, err)
+	o, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, o.ResourceUpToDate)
+	cond := cr.GetCondition(TypePendingApproval)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Contains(t, cond.Message, "name: old -> new")
+	id := approvalID(cr, observation)
+	assert.Contains(t, cond.Message, AnnotationKeyApprove+"="+id)
+	_, err = ec.Update(ctx, cr)
+	assert.Error(t, err)
+	assert.False(t, updated)
+
+	// An approval for a different update is ignored.
+	cr.SetAnnotations(map[string]string{AnnotationKeyApprove: "stale"})
+	ec, err = c.Connect(ctx, cr)
+	require.NoError(t, err)
+	o, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, o.ResourceUpToDate)
+
+	// Approving the reported ID lets the update through.
+	cr.SetAnnotations(map[string]string{AnnotationKeyApprove: id})
+	ec, err = c.Connect(ctx, cr)
+	require.NoError(t, err)
+	o, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, o.ResourceUpToDate)
+	assert.Equal(t, ReasonApproved, cr.GetCondition(TypePendingApproval).Reason)
+	_, err = ec.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// Once applied, the condition is cleared.
+	observation = managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	cr.SetConditions(AwaitingApproval("pending"))
+	ec, err = c.Connect(ctx, cr)
+	require.NoError(t, err)
+	_, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, ReasonNoUpdatePending, cr.GetCondition(TypePendingApproval).Reason)
+}
+
+func TestApprovalIDChangesWithSpec(t *testing.T) {
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild", Generation: 1}}
+	o := managed.ExternalObservation{ResourceExists: true, Diff: "name: old -> new"}
+	first := approvalID(cr, o)
+
+	cr.SetGeneration(2)
+	assert.NotEqual(t, first, approvalID(cr, o))
+	assert.NotEqual(t, first, approvalID(cr, managed.ExternalObservation{Diff: "name: old -> other"}))
+}
+
+func TestApprovalIDWithoutDiffChangesWithObservedState(t *testing.T) {
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild", Generation: 1}}
+	cr.Status.AtProvider.Name = "old"
+	o := managed.ExternalObservation{ResourceExists: true}
+	first := approvalID(cr, o)
+	assert.Equal(t, first, approvalID(cr, o))
+
+	cr.Status.AtProvider.Name = "drifted"
+	assert.NotEqual(t, first, approvalID(cr, o))
+}
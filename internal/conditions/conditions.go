@@ -0,0 +1,864 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conditions provides Discord-specific status conditions that let
+// automation tell transient rate-limit waits apart from permission failures.
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/apis"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/index"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"time"
+)
+
+// Condition types.
+const (
+	// TypeRateLimited indicates whether the last Discord API call was
+	// rejected because a rate limit was hit.
+	TypeRateLimited xpv1.ConditionType = "RateLimited"
+
+	// TypePermissionDenied indicates whether the last Discord API call was
+	// rejected because the bot lacks permissions.
+	TypePermissionDenied xpv1.ConditionType = "PermissionDenied"
+
+	// TypeChildPending indicates whether the resource is waiting on
+	// resources it depends on or owns.
+	TypeChildPending xpv1.ConditionType = "ChildPending"
+
+	// TypeBotNotInGuild indicates whether the bot was refused access to the
+	// guild the resource belongs to.
+	TypeBotNotInGuild xpv1.ConditionType = "BotNotInGuild"
+
+	// TypeNearExhaustion indicates whether an invite has run out of, or is
+	// about to run out of, uses or lifetime and should be rotated.
+	TypeNearExhaustion xpv1.ConditionType = "NearExhaustion"
+
+	// TypeGuildCreateNotAllowed indicates whether Discord refused to let the
+	// bot create a guild.
+	TypeGuildCreateNotAllowed xpv1.ConditionType = "GuildCreateNotAllowed"
+
+	// TypeTokenValid indicates whether a webhook's published token is still
+	// accepted by Discord.
+	TypeTokenValid xpv1.ConditionType = "TokenValid"
+
+	// TypeMemberCountThreshold indicates whether a guild's approximate member
+	// count is outside the thresholds set on it.
+	TypeMemberCountThreshold xpv1.ConditionType = "MemberCountThreshold"
+
+	// TypeDiscordAPIUnavailable indicates whether calls for the resource are
+	// held back because the Discord API it uses keeps failing.
+	TypeDiscordAPIUnavailable xpv1.ConditionType = "DiscordAPIUnavailable"
+
+	// TypeManagedRole indicates whether a role owned by a bot or integration
+	// differs from its spec. Discord refuses changes to such roles, so the
+	// provider does not attempt them.
+	TypeManagedRole xpv1.ConditionType = "ManagedRole"
+
+	// TypeInteractionsEndpointRejected indicates whether an application's
+	// interactions endpoint URL failed the pre-flight check or was refused
+	// by Discord.
+	TypeInteractionsEndpointRejected xpv1.ConditionType = "InteractionsEndpointRejected"
+
+	// TypeGuildLimitReached indicates whether creating the resource would
+	// take its guild past Discord's limit of channels or roles.
+	TypeGuildLimitReached xpv1.ConditionType = "GuildLimitReached"
+
+	// TypeRetryBudgetExhausted indicates whether the resource's last Discord
+	// API call failed without being retried because the provider's retry
+	// budget was spent.
+	TypeRetryBudgetExhausted xpv1.ConditionType = "RetryBudgetExhausted"
+
+	// TypeTemplateDrift indicates whether a guild's roles and channels
+	// differ from the template it is restored from.
+	TypeTemplateDrift xpv1.ConditionType = "TemplateDrift"
+
+	// TypeAdoptionRefused indicates whether an existing Discord resource of
+	// the same name was left alone rather than adopted, because it belongs
+	// to a bot, an integration or another managed resource.
+	TypeAdoptionRefused xpv1.ConditionType = "AdoptionRefused"
+
+	// TypeMessagesUnavailable indicates whether declared messages cannot be
+	// acted on because they were deleted or are in another channel.
+	TypeMessagesUnavailable xpv1.ConditionType = "MessagesUnavailable"
+)
+
+// Condition reasons.
+const (
+	ReasonRateLimited           xpv1.ConditionReason = "RateLimited"
+	ReasonNotRateLimited        xpv1.ConditionReason = "NotRateLimited"
+	ReasonPermissionDenied      xpv1.ConditionReason = "PermissionDenied"
+	ReasonMissingPermission     xpv1.ConditionReason = "MissingPermission"
+	ReasonPermitted             xpv1.ConditionReason = "Permitted"
+	ReasonChildPending          xpv1.ConditionReason = "ChildPending"
+	ReasonChildrenReady         xpv1.ConditionReason = "ChildrenReady"
+	ReasonBotNotInGuild         xpv1.ConditionReason = "BotNotInGuild"
+	ReasonBotInGuild            xpv1.ConditionReason = "BotInGuild"
+	ReasonNearMaxUses           xpv1.ConditionReason = "NearMaxUses"
+	ReasonMaxUsesReached        xpv1.ConditionReason = "MaxUsesReached"
+	ReasonNearExpiry            xpv1.ConditionReason = "NearExpiry"
+	ReasonExpired               xpv1.ConditionReason = "Expired"
+	ReasonUsable                xpv1.ConditionReason = "Usable"
+	ReasonTokenVerified         xpv1.ConditionReason = "TokenVerified"
+	ReasonTokenRejected         xpv1.ConditionReason = "TokenRejected"
+	ReasonDeletedExternally     xpv1.ConditionReason = "DeletedExternally"
+	ReasonGuildCreateNotAllowed xpv1.ConditionReason = "GuildCreateNotAllowed"
+	ReasonGuildCreateAllowed    xpv1.ConditionReason = "GuildCreateAllowed"
+	ReasonMemberCountBelow      xpv1.ConditionReason = "MemberCountBelow"
+	ReasonMemberCountAbove      xpv1.ConditionReason = "MemberCountAbove"
+	ReasonMemberCountInRange    xpv1.ConditionReason = "MemberCountInRange"
+	ReasonCircuitOpen           xpv1.ConditionReason = "CircuitOpen"
+	ReasonDiscordAPIAvailable   xpv1.ConditionReason = "DiscordAPIAvailable"
+	ReasonManagedRoleDrift      xpv1.ConditionReason = "ManagedRoleDrift"
+	ReasonManagedRoleInSync     xpv1.ConditionReason = "ManagedRoleInSync"
+	ReasonRetryBudgetExhausted  xpv1.ConditionReason = "RetryBudgetExhausted"
+	ReasonGuildLimitReached     xpv1.ConditionReason = "GuildLimitReached"
+	ReasonWithinGuildLimit      xpv1.ConditionReason = "WithinGuildLimit"
+	ReasonRetryBudgetAvailable  xpv1.ConditionReason = "RetryBudgetAvailable"
+	ReasonTemplateDrifted       xpv1.ConditionReason = "TemplateDrifted"
+	ReasonMatchesTemplate       xpv1.ConditionReason = "MatchesTemplate"
+	ReasonAdoptionRefused       xpv1.ConditionReason = "AdoptionRefused"
+	ReasonNoAdoptionConflict    xpv1.ConditionReason = "NoAdoptionConflict"
+	ReasonMessagesUnavailable   xpv1.ConditionReason = "MessagesUnavailable"
+	ReasonMessagesAvailable     xpv1.ConditionReason = "MessagesAvailable"
+
+	ReasonInteractionsEndpointRejected xpv1.ConditionReason = "InteractionsEndpointRejected"
+	ReasonInteractionsEndpointAccepted xpv1.ConditionReason = "InteractionsEndpointAccepted"
+)
+
+// RateLimited returns a condition indicating Discord rate limited the
+// resource's last API call.
+func RateLimited(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRateLimited,
+		Message:            msg,
+	}
+}
+
+// NotRateLimited returns a condition indicating the resource's last API call
+// was not rate limited.
+func NotRateLimited() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotRateLimited,
+	}
+}
+
+// PermissionDenied returns a condition indicating Discord rejected the
+// resource's last API call because the bot lacks permissions.
+func PermissionDenied(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePermissionDenied,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPermissionDenied,
+		Message:            msg,
+	}
+}
+
+// MissingPermission returns a condition indicating a pre-flight check found
+// the bot lacks permissions the operation requires, before calling Discord.
+func MissingPermission(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePermissionDenied,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMissingPermission,
+		Message:            msg,
+	}
+}
+
+// Permitted returns a condition indicating the resource's last API call was
+// not rejected for lack of permissions.
+func Permitted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePermissionDenied,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPermitted,
+	}
+}
+
+// ChildPending returns a condition indicating the resource is waiting on
+// resources it depends on or owns.
+func ChildPending(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeChildPending,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChildPending,
+		Message:            msg,
+	}
+}
+
+// ChildrenReady returns a condition indicating the resource is no longer
+// waiting on resources it depends on or owns.
+func ChildrenReady() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeChildPending,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChildrenReady,
+	}
+}
+
+// BotNotInGuild returns a condition indicating Discord refused the bot access
+// to the resource's guild.
+func BotNotInGuild(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBotNotInGuild,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBotNotInGuild,
+		Message:            msg,
+	}
+}
+
+// BotInGuild returns a condition indicating the bot can access the resource's
+// guild again.
+func BotInGuild() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBotNotInGuild,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBotInGuild,
+	}
+}
+
+// GuildCreateNotAllowed returns a condition indicating Discord refused to let
+// the bot create a guild.
+func GuildCreateNotAllowed(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGuildCreateNotAllowed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGuildCreateNotAllowed,
+		Message:            msg,
+	}
+}
+
+// GuildCreateAllowed returns a condition indicating the bot was able to
+// create its guild.
+func GuildCreateAllowed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGuildCreateNotAllowed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGuildCreateAllowed,
+	}
+}
+
+// NearExhaustion returns a condition indicating an invite should be rotated
+// for the supplied reason.
+func NearExhaustion(reason xpv1.ConditionReason, msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeNearExhaustion,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}
+}
+
+// Usable returns a condition indicating an invite has plenty of uses and
+// lifetime left.
+func Usable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeNearExhaustion,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUsable,
+	}
+}
+
+// MemberCountOutOfRange returns a condition indicating a guild's member
+// count crossed one of its thresholds for the supplied reason.
+func MemberCountOutOfRange(reason xpv1.ConditionReason, msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMemberCountThreshold,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}
+}
+
+// MemberCountInRange returns a condition indicating a guild's member count is
+// within its thresholds.
+func MemberCountInRange() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMemberCountThreshold,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMemberCountInRange,
+	}
+}
+
+// TemplateDrifted returns a condition indicating a guild's roles and
+// channels differ from its template.
+func TemplateDrifted(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTemplateDrift,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTemplateDrifted,
+		Message:            msg,
+	}
+}
+
+// MatchesTemplate returns a condition indicating a guild's roles and
+// channels match its template.
+func MatchesTemplate() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTemplateDrift,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMatchesTemplate,
+	}
+}
+
+// AdoptionRefused returns a condition indicating an existing Discord
+// resource of the same name was not adopted.
+func AdoptionRefused(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAdoptionRefused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAdoptionRefused,
+		Message:            msg,
+	}
+}
+
+// NoAdoptionConflict returns a condition indicating a resource no longer
+// conflicts with an existing Discord resource it may not adopt.
+func NoAdoptionConflict() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAdoptionRefused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoAdoptionConflict,
+	}
+}
+
+// MessagesUnavailable returns a condition indicating declared messages were
+// deleted or are in another channel.
+func MessagesUnavailable(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMessagesUnavailable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMessagesUnavailable,
+		Message:            msg,
+	}
+}
+
+// MessagesAvailable returns a condition indicating every declared message
+// is in its channel.
+func MessagesAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMessagesUnavailable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMessagesAvailable,
+	}
+}
+
+// ManagedRoleDrift returns a condition indicating a role owned by a bot or
+// integration differs from its spec and will not be updated.
+func ManagedRoleDrift(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeManagedRole,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonManagedRoleDrift,
+		Message:            msg,
+	}
+}
+
+// ManagedRoleInSync returns a condition indicating a role owned by a bot or
+// integration no longer differs from its spec.
+func ManagedRoleInSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeManagedRole,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonManagedRoleInSync,
+	}
+}
+
+// DiscordAPIUnavailable returns a condition indicating calls for a resource
+// are held back by an open circuit breaker.
+func DiscordAPIUnavailable(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDiscordAPIUnavailable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitOpen,
+		Message:            msg,
+	}
+}
+
+// DiscordAPIAvailable returns a condition indicating Discord is serving a
+// resource's calls again.
+func DiscordAPIAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDiscordAPIUnavailable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDiscordAPIAvailable,
+	}
+}
+
+// GuildLimitReached returns a condition indicating the resource cannot be
+// created because its guild holds as many channels or roles as it can.
+func GuildLimitReached(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGuildLimitReached,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGuildLimitReached,
+		Message:            msg,
+	}
+}
+
+// WithinGuildLimit returns a condition indicating the resource's guild had
+// room for it.
+func WithinGuildLimit() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGuildLimitReached,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWithinGuildLimit,
+	}
+}
+
+// RetryBudgetExhausted returns a condition indicating a resource's call failed
+// and was not retried because the retry budget was spent.
+func RetryBudgetExhausted(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRetryBudgetExhausted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetryBudgetExhausted,
+		Message:            msg,
+	}
+}
+
+// RetryBudgetAvailable returns a condition indicating a resource's calls
+// succeed again after the retry budget was spent.
+func RetryBudgetAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRetryBudgetExhausted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetryBudgetAvailable,
+	}
+}
+
+// TokenValid returns a condition indicating Discord accepted a webhook's token.
+func TokenValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTokenValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokenVerified,
+	}
+}
+
+// TokenInvalid returns a condition indicating Discord rejected a webhook's
+// token.
+func TokenInvalid(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTokenValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokenRejected,
+		Message:            msg,
+	}
+}
+
+// InteractionsEndpointRejected returns a condition indicating an
+// application's interactions endpoint URL failed the pre-flight check or was
+// refused by Discord.
+func InteractionsEndpointRejected(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInteractionsEndpointRejected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInteractionsEndpointRejected,
+		Message:            msg,
+	}
+}
+
+// InteractionsEndpointAccepted returns a condition indicating an
+// application's interactions endpoint URL was accepted.
+func InteractionsEndpointAccepted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInteractionsEndpointRejected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInteractionsEndpointAccepted,
+	}
+}
+
+// DeletedExternally returns a Ready condition indicating the resource was
+// deleted in Discord and will not be recreated.
+func DeletedExternally(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletedExternally,
+		Message:            msg,
+	}
+}
+
+// botNotInGuildHint explains how to resolve a BotNotInGuild condition.
+const botNotInGuildHint = "The bot cannot access this guild. Check that the bot has been added to the guild " +
+	"(OAuth2 URL with the bot scope) and that its roles can view the channels involved. Discord said: "
+
+// guildCreateNotAllowedHint explains a GuildCreateNotAllowed condition.
+var guildCreateNotAllowedHint = fmt.Sprintf("Discord only lets bots create guilds while they are members of fewer than %d guilds. "+
+	"Remove the bot from unused guilds or create the guild manually and import it by setting the external name. Discord said: ", clients.MaxGuildsForBotGuildCreate)
+
+// interactionsEndpointRejectedHint explains an InteractionsEndpointRejected
+// condition.
+const interactionsEndpointRejectedHint = "Discord verifies an interactions endpoint by sending it PINGs: it must be " +
+	"reachable over https, answer signed PINGs with a PONG and refuse requests with an invalid signature with 401. Error: "
+
+// circuitOpenHint explains a DiscordAPIUnavailable condition.
+const circuitOpenHint = "Recent calls to this part of the Discord API kept failing, so calls are paused and retried " +
+	"once the circuit breaker's recovery timeout passes. Check https://discordstatus.com. Error: "
+
+// guildLimitReachedHint explains a GuildLimitReached condition.
+var guildLimitReachedHint = fmt.Sprintf("Discord limits every guild to %d channels, categories included, and %d roles. "+
+	"Delete unused channels or roles in the guild, or move resources to another guild. Error: ",
+	clients.MaxGuildChannels, clients.MaxGuildRoles)
+
+// retryBudgetExhaustedHint explains a RetryBudgetExhausted condition.
+const retryBudgetExhaustedHint = "Discord API calls across the provider keep failing and being retried, so " +
+	"retries are paused until the retry budget's window passes. Look for flapping resources or a rate limit budget " +
+	"shared with other bots, or raise --retry-budget. Error: "
+
+// A ChildPendingError is returned by controllers whose resource cannot make
+// progress until the resources it depends on or owns are ready.
+type ChildPendingError struct {
+	msg string
+}
+
+// NewChildPendingError returns an error that sets the ChildPending condition.
+func NewChildPendingError(msg string) error {
+	return &ChildPendingError{msg: msg}
+}
+
+func (e *ChildPendingError) Error() string {
+	return e.msg
+}
+
+// IsChildPending reports whether err was caused by a ChildPendingError.
+func IsChildPending(err error) bool {
+	var pending *ChildPendingError
+	return errors.As(err, &pending)
+}
+
+// ForError returns the conditions describing the outcome of an operation
+// that returned err. A nil error clears the RateLimited and PermissionDenied
+// conditions. Errors that are neither rate limits nor permission failures
+// leave the existing conditions untouched.
+func ForError(err error) []xpv1.Condition {
+	if err == nil {
+		return []xpv1.Condition{NotRateLimited(), Permitted()}
+	}
+
+	if IsChildPending(err) {
+		return []xpv1.Condition{ChildPending(err.Error())}
+	}
+
+	if missing, ok := clients.IsMissingPermissions(err); ok {
+		return []xpv1.Condition{MissingPermission(missing.Error())}
+	}
+
+	if clients.IsGuildCreateNotAllowed(err) {
+		return []xpv1.Condition{NotRateLimited(), GuildCreateNotAllowed(guildCreateNotAllowedHint + err.Error())}
+	}
+
+	if clients.IsGuildLimitReached(err) {
+		return []xpv1.Condition{NotRateLimited(), GuildLimitReached(guildLimitReachedHint + err.Error())}
+	}
+
+	if clients.IsInteractionsEndpointRejected(err) {
+		return []xpv1.Condition{NotRateLimited(), InteractionsEndpointRejected(interactionsEndpointRejectedHint + err.Error())}
+	}
+
+	if clients.IsBotNotInGuild(err) {
+		return []xpv1.Condition{NotRateLimited(), BotNotInGuild(botNotInGuildHint + err.Error())}
+	}
+
+	if resilience.IsCircuitOpen(err) {
+		return []xpv1.Condition{DiscordAPIUnavailable(circuitOpenHint + err.Error())}
+	}
+
+	if resilience.IsRetryBudgetExhausted(err) {
+		return []xpv1.Condition{RetryBudgetExhausted(retryBudgetExhaustedHint + err.Error())}
+	}
+
+	switch resilience.ParseDiscordError(err, "", "").ErrorType {
+	case resilience.ErrorTypeRateLimit:
+		return []xpv1.Condition{RateLimited(err.Error())}
+	case resilience.ErrorTypePermission:
+		return []xpv1.Condition{NotRateLimited(), PermissionDenied(err.Error())}
+	default:
+		return nil
+	}
+}
+
+// Record sets the conditions describing the outcome of an operation on mg,
+// tagged with the generation they were observed at. ChildPending,
+// BotNotInGuild, GuildCreateNotAllowed, GuildLimitReached and
+// InteractionsEndpointRejected are only cleared on success if they were
+// previously set, so resources that never hit them never report them.
+func Record(mg resource.Managed, err error) {
+	for _, c := range ForError(err) {
+		mg.SetConditions(c.WithObservedGeneration(mg.GetGeneration()))
+	}
+	if err != nil {
+		deferWhileUnavailable(mg, err)
+		return
+	}
+	if mg.GetCondition(TypeDiscordAPIUnavailable).Status == corev1.ConditionTrue {
+		mg.SetConditions(DiscordAPIAvailable().WithObservedGeneration(mg.GetGeneration()))
+	}
+	if mg.GetCondition(TypeRetryBudgetExhausted).Status == corev1.ConditionTrue {
+		mg.SetConditions(RetryBudgetAvailable().WithObservedGeneration(mg.GetGeneration()))
+	}
+	if mg.GetCondition(TypeChildPending).Status == corev1.ConditionTrue {
+		mg.SetConditions(ChildrenReady().WithObservedGeneration(mg.GetGeneration()))
+	}
+	if mg.GetCondition(TypeBotNotInGuild).Status == corev1.ConditionTrue {
+		mg.SetConditions(BotInGuild().WithObservedGeneration(mg.GetGeneration()))
+	}
+	if mg.GetCondition(TypeGuildCreateNotAllowed).Status == corev1.ConditionTrue {
+		mg.SetConditions(GuildCreateAllowed().WithObservedGeneration(mg.GetGeneration()))
+	}
+	if mg.GetCondition(TypeInteractionsEndpointRejected).Status == corev1.ConditionTrue {
+		mg.SetConditions(InteractionsEndpointAccepted().WithObservedGeneration(mg.GetGeneration()))
+	}
+	if mg.GetCondition(TypeGuildLimitReached).Status == corev1.ConditionTrue {
+		mg.SetConditions(WithinGuildLimit().WithObservedGeneration(mg.GetGeneration()))
+	}
+}
+
+// deferWhileUnavailable holds back retries of mg until the circuit breaker
+// that rejected its call lets calls through again, or the retry budget is
+// refilled, instead of retrying, and logging, on the usual backoff.
+func deferWhileUnavailable(mg resource.Managed, err error) {
+	var de *resilience.DiscordError
+	if !resilience.IsCircuitOpen(err) && !resilience.IsRetryBudgetExhausted(err) {
+		return
+	}
+	if !errors.As(err, &de) || de.RetryAfter <= 0 {
+		return
+	}
+	gvk, err := apiutil.GVKForObject(mg, scheme)
+	if err != nil {
+		return
+	}
+	tuning.DeferRetry(gvk.GroupKind(), types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()}, time.Now().Add(de.RetryAfter))
+}
+
+// scheme maps the provider's managed resources to their kinds, which objects
+// read from the cache do not carry.
+var scheme = func() *runtime.Scheme {
+	s := runtime.NewScheme()
+	_ = apis.AddToScheme(s)
+	return s
+}()
+
+// NewConnector wraps c so that the external clients it produces record
+// Discord-specific conditions after every operation and, once the resource
+// has been observed, its status.observedGeneration. Resources in dry-run mode
+// report the change they would make instead of making it.
+func NewConnector(c managed.ExternalConnector, opts ...ConnectorOption) managed.ExternalConnector {
+	cn := &connector{wrapped: c, now: time.Now}
+	for _, o := range opts {
+		o(cn)
+	}
+	return cn
+}
+
+// A ConnectorOption configures a connector returned by NewConnector.
+type ConnectorOption func(*connector)
+
+// WithProviderConfig applies the change controls of each resource's
+// ProviderConfig: changes outside its maintenance windows are held back, and
+// updates wait for approval when it requires them.
+func WithProviderConfig(kube client.Client) ConnectorOption {
+	return func(c *connector) {
+		c.kube = kube
+	}
+}
+
+type connector struct {
+	wrapped managed.ExternalConnector
+	kube    client.Client
+	now     func() time.Time
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.wrapped.Connect(ctx, mg)
+	if err != nil {
+		Record(mg, err)
+		return nil, err
+	}
+	e := &external{wrapped: ec}
+	if c.kube == nil {
+		return e, nil
+	}
+	pc, err := clients.GetProviderConfig(ctx, c.kube, mg)
+	if err == nil {
+		e.held, e.next, err = outsideWindow(pc, c.now())
+	}
+	if err != nil {
+		Record(mg, err)
+		return nil, err
+	}
+	e.requiresApproval = pc.Spec.RequiresApproval != nil && *pc.Spec.RequiresApproval
+	return e, nil
+}
+
+type external struct {
+	wrapped managed.ExternalClient
+
+	// held is true when the resource's ProviderConfig has maintenance
+	// windows and none of them is open. next is when the next one opens.
+	held bool
+	next time.Time
+
+	// requiresApproval is true when the resource's ProviderConfig requires
+	// updates to be approved. approved is set by Observe once the pending
+	// update has been approved.
+	requiresApproval bool
+	approved         bool
+
+	// drifted is set by Observe when the Discord resource no longer matches
+	// a spec that has not changed since the last reconcile, so a successful
+	// Update can be counted as a correction.
+	drifted bool
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.wrapped.Observe(ctx, mg)
+	Record(mg, err)
+	if err != nil {
+		return o, err
+	}
+	observed := observedGeneration(mg)
+	if ro, ok := mg.(resource.ReconciliationObserver); ok {
+		ro.SetObservedGeneration(mg.GetGeneration())
+	}
+	index.Default().Observe(mg, o.ResourceExists)
+	if e.drifted = drifted(mg, o, observed); !e.drifted {
+		drifts.forget(mg)
+	} else if drifts.first(mg, o.Diff) {
+		recordDrift(mg, false)
+	}
+	o = plan(mg, o)
+	if IsDryRun(mg) {
+		return o, nil
+	}
+	o = queue(mg, o, e.held, e.next)
+	if !e.requiresApproval || e.held {
+		return o, nil
+	}
+	o, e.approved = review(mg, o)
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if IsDryRun(mg) {
+		return managed.ExternalCreation{}, errDryRun(mg, "create")
+	}
+	if e.held {
+		return managed.ExternalCreation{}, errQueued(mg, "create", e.next)
+	}
+	c, err := e.wrapped.Create(auditContext(ctx, mg, "create"), mg)
+	Record(mg, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if IsDryRun(mg) {
+		return managed.ExternalUpdate{}, errDryRun(mg, "update")
+	}
+	if e.held {
+		return managed.ExternalUpdate{}, errQueued(mg, "update", e.next)
+	}
+	if e.requiresApproval && !e.approved {
+		return managed.ExternalUpdate{}, errPendingApproval(mg)
+	}
+	u, err := e.wrapped.Update(auditContext(ctx, mg, "update"), mg)
+	Record(mg, err)
+	if err == nil && e.drifted {
+		recordDrift(mg, true)
+		drifts.forget(mg)
+	}
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if IsDryRun(mg) {
+		return managed.ExternalDelete{}, errDryRun(mg, "delete")
+	}
+	if e.held {
+		return managed.ExternalDelete{}, errQueued(mg, "delete", e.next)
+	}
+	d, err := e.wrapped.Delete(auditContext(ctx, mg, "delete"), mg)
+	Record(mg, err)
+	return d, err
+}
+
+// auditContext records the managed resource behind a change in the guild
+// audit log, so moderators can tell provider changes from manual ones.
+func auditContext(ctx context.Context, mg resource.Managed, action string) context.Context {
+	name := mg.GetName()
+	if ns := mg.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+	return clients.WithRequestOptions(ctx, clients.WithAuditReason(fmt.Sprintf("Crossplane %s of %s", action, name)))
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.wrapped.Disconnect(ctx)
+}
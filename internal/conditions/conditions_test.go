@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/index"
+	"github.com/rossigee/provider-discord/internal/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectRateLimited  corev1.ConditionStatus
+		expectPermission   corev1.ConditionStatus
+		expectChildPending corev1.ConditionStatus
+	}{
+		{
+			name:               "success",
+			err:                nil,
+			expectRateLimited:  corev1.ConditionFalse,
+			expectPermission:   corev1.ConditionFalse,
+			expectChildPending: corev1.ConditionUnknown,
+		},
+		{
+			name:               "rate limited",
+			err:                errors.New("failed to get guild: Discord API error: 429 - {\"message\": \"You are being rate limited.\"}"),
+			expectRateLimited:  corev1.ConditionTrue,
+			expectPermission:   corev1.ConditionUnknown,
+			expectChildPending: corev1.ConditionUnknown,
+		},
+		{
+			name:               "missing permissions",
+			err:                errors.New("failed to update guild: Discord API error: 403 - {\"message\": \"Missing Permissions\", \"code\": 50013}"),
+			expectRateLimited:  corev1.ConditionFalse,
+			expectPermission:   corev1.ConditionTrue,
+			expectChildPending: corev1.ConditionUnknown,
+		},
+		{
+			name:               "pre-flight missing permission",
+			err:                &clients.MissingPermissionsError{GuildID: "123", Permissions: []string{"MANAGE_CHANNELS"}},
+			expectRateLimited:  corev1.ConditionUnknown,
+			expectPermission:   corev1.ConditionTrue,
+			expectChildPending: corev1.ConditionUnknown,
+		},
+		{
+			name:               "child pending",
+			err:                errors.Wrap(NewChildPendingError("waiting for 2 channels"), "cannot create bundle"),
+			expectRateLimited:  corev1.ConditionUnknown,
+			expectPermission:   corev1.ConditionUnknown,
+			expectChildPending: corev1.ConditionTrue,
+		},
+		{
+			name:               "other error",
+			err:                errors.New("some random error"),
+			expectRateLimited:  corev1.ConditionUnknown,
+			expectPermission:   corev1.ConditionUnknown,
+			expectChildPending: corev1.ConditionUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+
+			Record(cr, tc.err)
+
+			assert.Equal(t, tc.expectRateLimited, cr.GetCondition(TypeRateLimited).Status)
+			assert.Equal(t, tc.expectPermission, cr.GetCondition(TypePermissionDenied).Status)
+			assert.Equal(t, tc.expectChildPending, cr.GetCondition(TypeChildPending).Status)
+			if tc.expectRateLimited != corev1.ConditionUnknown {
+				assert.Equal(t, int64(3), cr.GetCondition(TypeRateLimited).ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestRecordBotNotInGuild(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+
+	Record(cr, errors.New("failed to get channel: Discord API error: 403 - {\"message\": \"Missing Access\", \"code\": 50001}"))
+
+	c := cr.GetCondition(TypeBotNotInGuild)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Contains(t, c.Message, "added to the guild")
+	// Lack of guild access is not reported as a permission failure
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(TypePermissionDenied).Status)
+
+	Record(cr, nil)
+
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeBotNotInGuild).Status)
+}
+
+func TestRecordGuildCreateNotAllowed(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+
+	refused := &clients.APIError{StatusCode: 403, Code: clients.ErrorCodeBotsCannotUseEndpoint, Message: "Bots cannot use this endpoint"}
+	Record(cr, errors.Wrap(&clients.GuildCreateNotAllowedError{Err: refused}, "failed to create guild"))
+
+	c := cr.GetCondition(TypeGuildCreateNotAllowed)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Contains(t, c.Message, fmt.Sprintf("fewer than %d guilds", clients.MaxGuildsForBotGuildCreate))
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(TypePermissionDenied).Status)
+
+	Record(cr, nil)
+
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeGuildCreateNotAllowed).Status)
+
+	// The same code from any other endpoint is a permission failure
+	Record(cr, refused)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeGuildCreateNotAllowed).Status)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(TypePermissionDenied).Status)
+}
+
+func TestRecordInteractionsEndpointRejected(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+
+	Record(cr, &clients.InteractionsEndpointRejectedError{URL: "https://example.com/interactions", Reason: "the endpoint is unreachable"})
+
+	c := cr.GetCondition(TypeInteractionsEndpointRejected)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Contains(t, c.Message, "invalid signature with 401")
+
+	Record(cr, nil)
+
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeInteractionsEndpointRejected).Status)
+}
+
+func TestRecordDiscordAPIUnavailable(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+	open := &resilience.DiscordError{StatusCode: 503, Message: "Circuit breaker is open", ErrorType: resilience.ErrorTypeUnavailable, RetryAfter: time.Minute}
+
+	Record(cr, errors.Wrap(open, "failed to get guild"))
+
+	c := cr.GetCondition(TypeDiscordAPIUnavailable)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Equal(t, ReasonCircuitOpen, c.Reason)
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(TypeRateLimited).Status)
+
+	Record(cr, nil)
+
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeDiscordAPIUnavailable).Status)
+}
+
+func TestRecordGuildLimitReached(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+
+	Record(cr, errors.Wrap(&clients.GuildLimitError{GuildID: "1", Resource: "roles", Count: 250, Adding: 1, Limit: 250}, "cannot create role"))
+
+	c := cr.GetCondition(TypeGuildLimitReached)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Equal(t, ReasonGuildLimitReached, c.Reason)
+	assert.Contains(t, c.Message, "500 channels, categories included, and 250 roles")
+
+	Record(cr, nil)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeGuildLimitReached).Status)
+
+	// Discord's own refusal sets it too
+	Record(cr, errors.New("failed to create channel: Discord API error: 400 - {\"message\": \"Maximum number of guild channels reached (500)\", \"code\": 30013}"))
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(TypeGuildLimitReached).Status)
+}
+
+func TestRecordRetryBudgetExhausted(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+	exhausted := &resilience.DiscordError{Message: "Retry budget of 100 retries per 1m0s is exhausted", ErrorType: resilience.ErrorTypeRetryBudget, RetryAfter: time.Minute}
+
+	Record(cr, errors.Wrap(exhausted, "failed to get guild"))
+
+	c := cr.GetCondition(TypeRetryBudgetExhausted)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Equal(t, ReasonRetryBudgetExhausted, c.Reason)
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(TypeDiscordAPIUnavailable).Status)
+
+	Record(cr, nil)
+
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeRetryBudgetExhausted).Status)
+}
+
+func TestRecordClearsChildPending(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+	cr.SetConditions(ChildPending("waiting"))
+
+	Record(cr, nil)
+
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeChildPending).Status)
+	assert.Equal(t, ReasonChildrenReady, cr.GetCondition(TypeChildPending).Reason)
+}
+
+func TestConnector(t *testing.T) {
+	ctx := context.Background()
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "connector-test", Generation: 5}}
+	meta.SetExternalName(cr, "987654321")
+
+	c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true}, nil
+			},
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, errors.New("Discord API error: 403 - Missing Permissions")
+			},
+		}, nil
+	}))
+
+	ec, err := c.Connect(ctx, cr)
+	require.NoError(t, err)
+
+	_, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), cr.GetObservedGeneration())
+	assert.Equal(t, []index.Ref{{Kind: "Guild", Name: "connector-test"}}, index.Default().Lookup("987654321"))
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypePermissionDenied).Status)
+
+	_, err = ec.Update(ctx, cr)
+	require.Error(t, err)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(TypePermissionDenied).Status)
+}
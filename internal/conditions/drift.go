@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conditions
+
+import (
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/metrics"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// drifted reports whether the observation o found an existing Discord
+// resource that no longer matches the spec of mg. A spec edited since the
+// last reconcile, whose generation is not the one observed before this
+// one, calls for an update that is not drift.
+func drifted(mg resource.Managed, o managed.ExternalObservation, observed int64) bool {
+	return o.ResourceExists && !o.ResourceUpToDate && !meta.WasDeleted(mg) && mg.GetGeneration() == observed
+}
+
+// observedGeneration returns the generation of mg the last reconcile
+// observed, from status.observedGeneration or, for kinds without it, the
+// Synced condition.
+func observedGeneration(mg resource.Managed) int64 {
+	if ro, ok := mg.(resource.ReconciliationObserver); ok {
+		if g := ro.GetObservedGeneration(); g != 0 {
+			return g
+		}
+	}
+	return mg.GetCondition(xpv1.TypeSynced).ObservedGeneration
+}
+
+// A driftLog remembers the drift last counted for each resource, so a
+// change held back by dry-run mode, a maintenance window or a pending
+// approval is counted once rather than on every poll.
+type driftLog struct {
+	mu      sync.Mutex
+	counted map[string]string
+}
+
+// drifts is shared by all connectors, since they are created per controller
+// and external clients per reconcile.
+var drifts = &driftLog{counted: map[string]string{}}
+
+func driftKey(mg resource.Managed) string {
+	return kindOf(mg) + "/" + mg.GetNamespace() + "/" + mg.GetName()
+}
+
+// first records change as the drift of mg and reports whether it was not
+// already counted.
+func (d *driftLog) first(mg resource.Managed, change string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := driftKey(mg)
+	if last, ok := d.counted[key]; ok && last == change {
+		return false
+	}
+	d.counted[key] = change
+	return true
+}
+
+// forget drops the drift recorded for mg once it no longer drifts.
+func (d *driftLog) forget(mg resource.Managed) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.counted, driftKey(mg))
+}
+
+// recordDrift counts a drift of mg in the drift metrics.
+func recordDrift(mg resource.Managed, corrected bool) {
+	kind, guild := kindOf(mg), guildOf(mg)
+	if corrected {
+		metrics.GetMetricsRecorder().RecordDriftCorrected(kind, guild)
+		return
+	}
+	metrics.GetMetricsRecorder().RecordDriftDetected(kind, guild)
+}
+
+// kindOf returns the lower case kind of mg. The type name is used because
+// objects read from the cache do not carry their TypeMeta.
+func kindOf(mg resource.Managed) string {
+	t := reflect.TypeOf(mg)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return strings.ToLower(t.Name())
+}
+
+// guildOf returns the ID of the guild mg is, or belongs to, or "" for kinds
+// that are not scoped to a guild.
+func guildOf(mg resource.Managed) string {
+	if _, ok := mg.(*guildv1alpha1.Guild); ok {
+		return meta.GetExternalName(mg)
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return ""
+	}
+	id, _ := fieldpath.Pave(u).GetString("spec.forProvider.guildId")
+	return id
+}
+
+// Change describes a field an update would change, from its observed to its
+// desired value. Controllers report their changes as the Diff of an
+// observation, which dry-run, maintenance window and approval conditions
+// show.
+func Change(field string, observed, desired interface{}) string {
+	return fmt.Sprintf("%s: %v -> %v", field, observed, desired)
+}
+
+// Diff joins changes into the Diff of an observation.
+func Diff(changes []string) string {
+	return strings.Join(changes, "; ")
+}
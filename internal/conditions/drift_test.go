@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conditions
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestKindAndGuildOf(t *testing.T) {
+	g := &guildv1alpha1.Guild{}
+	meta.SetExternalName(g, "111")
+	assert.Equal(t, "guild", kindOf(g))
+	assert.Equal(t, "111", guildOf(g))
+
+	c := &channelv1alpha1.Channel{}
+	c.Spec.ForProvider.GuildID = "222"
+	assert.Equal(t, "channel", kindOf(c))
+	assert.Equal(t, "222", guildOf(c))
+}
+
+func TestDrifted(t *testing.T) {
+	now := metav1.Now()
+	cr := &guildv1alpha1.Guild{}
+
+	assert.True(t, drifted(cr, managed.ExternalObservation{ResourceExists: true}, 0))
+	assert.False(t, drifted(cr, managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, 0))
+	assert.False(t, drifted(cr, managed.ExternalObservation{ResourceExists: false}, 0))
+
+	// An edited spec calls for an update that is not drift
+	cr.SetGeneration(2)
+	assert.False(t, drifted(cr, managed.ExternalObservation{ResourceExists: true}, 1))
+	assert.True(t, drifted(cr, managed.ExternalObservation{ResourceExists: true}, 2))
+
+	cr.SetDeletionTimestamp(&now)
+	assert.False(t, drifted(cr, managed.ExternalObservation{ResourceExists: true}, 2))
+}
+
+func TestObservedGeneration(t *testing.T) {
+	// Kinds with status.observedGeneration report it
+	c := &channelv1alpha1.Channel{}
+	c.SetConditions(xpv1.ReconcileSuccess().WithObservedGeneration(1))
+	c.SetObservedGeneration(3)
+	assert.Equal(t, int64(3), observedGeneration(c))
+
+	// Others fall back to the Synced condition
+	g := &guildv1alpha1.Guild{}
+	g.SetConditions(xpv1.ReconcileSuccess().WithObservedGeneration(2))
+	assert.Equal(t, int64(2), observedGeneration(g))
+}
+
+func TestDriftCountedOncePerChange(t *testing.T) {
+	d := &driftLog{counted: map[string]string{}}
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild"}}
+	other := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "other-guild"}}
+
+	assert.True(t, d.first(cr, "name: a -> b"))
+	// Held back and observed again
+	assert.False(t, d.first(cr, "name: a -> b"))
+	assert.True(t, d.first(other, "name: a -> b"))
+	// Drifted further
+	assert.True(t, d.first(cr, "name: c -> b"))
+
+	d.forget(cr)
+	assert.True(t, d.first(cr, "name: c -> b"))
+}
+
+func TestDriftCorrectedOnlyAfterDrift(t *testing.T) {
+	ctx := context.Background()
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild"}}
+	observation := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				return observation, nil
+			},
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, nil
+			},
+		}, nil
+	}))
+
+	ec, err := c.Connect(ctx, cr)
+	require.NoError(t, err)
+	_, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, ec.(*external).drifted)
+
+	observation.ResourceUpToDate = false
+	_, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, ec.(*external).drifted)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sync/atomic"
+)
+
+// AnnotationKeyDryRun puts a single resource in dry-run mode when set to
+// "true", regardless of the provider-wide setting.
+const AnnotationKeyDryRun = "discord.crossplane.io/dry-run"
+
+// TypeDryRun reports the action a resource in dry-run mode would take.
+const TypeDryRun xpv1.ConditionType = "DryRun"
+
+// Dry-run condition reasons.
+const (
+	ReasonWouldCreate    xpv1.ConditionReason = "WouldCreate"
+	ReasonWouldUpdate    xpv1.ConditionReason = "WouldUpdate"
+	ReasonWouldDelete    xpv1.ConditionReason = "WouldDelete"
+	ReasonNoChanges      xpv1.ConditionReason = "NoChanges"
+	ReasonDryRunDisabled xpv1.ConditionReason = "DryRunDisabled"
+)
+
+// dryRun is read by every reconcile, so it is safe to set at any time.
+var dryRun atomic.Bool
+
+// SetDryRun puts every resource in dry-run mode. It is called at startup,
+// before any controller is set up.
+func SetDryRun(enabled bool) {
+	dryRun.Store(enabled)
+}
+
+// DryRunEnabled reports whether the provider runs in dry-run mode, for
+// controllers of objects that are not managed resources.
+func DryRunEnabled() bool {
+	return dryRun.Load()
+}
+
+// IsDryRun reports whether mg is in dry-run mode, either provider-wide or
+// through its dry-run annotation.
+func IsDryRun(mg resource.Managed) bool {
+	return dryRun.Load() || mg.GetAnnotations()[AnnotationKeyDryRun] == "true"
+}
+
+// Planned returns a DryRun condition describing the action a resource would
+// take if it were not in dry-run mode.
+func Planned(reason xpv1.ConditionReason, msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDryRun,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}
+}
+
+// DryRunDisabled returns a condition indicating a resource is no longer in
+// dry-run mode.
+func DryRunDisabled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDryRun,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDryRunDisabled,
+	}
+}
+
+// plan records the action the observation o calls for on a resource in
+// dry-run mode, and returns the observation to report to the managed
+// reconciler. Resources that would be created or updated are reported as
+// existing and up to date, so the reconciler neither attempts the change nor
+// emits events claiming it happened.
+func plan(mg resource.Managed, o managed.ExternalObservation) managed.ExternalObservation {
+	if !IsDryRun(mg) {
+		if mg.GetCondition(TypeDryRun).Status == corev1.ConditionTrue {
+			mg.SetConditions(DryRunDisabled().WithObservedGeneration(mg.GetGeneration()))
+		}
+		return o
+	}
+
+	name := mg.GetName()
+	var c xpv1.Condition
+	switch {
+	case meta.WasDeleted(mg):
+		if !o.ResourceExists {
+			return o
+		}
+		c = Planned(ReasonWouldDelete, fmt.Sprintf("would delete the Discord resource for %s", name))
+	case !o.ResourceExists:
+		c = Planned(ReasonWouldCreate, fmt.Sprintf("would create a Discord resource for %s", name))
+	case !o.ResourceUpToDate:
+		msg := fmt.Sprintf("would update the Discord resource for %s", name)
+		if o.Diff != "" {
+			msg += ": " + o.Diff
+		}
+		c = Planned(ReasonWouldUpdate, msg)
+	default:
+		c = Planned(ReasonNoChanges, "the Discord resource matches the spec")
+	}
+	mg.SetConditions(c.WithObservedGeneration(mg.GetGeneration()))
+
+	if meta.WasDeleted(mg) {
+		return o
+	}
+	o.ResourceExists = true
+	o.ResourceUpToDate = true
+	return o
+}
+
+// errDryRun is returned instead of performing action on a resource in dry-run
+// mode. Deletions are held back this way, keeping the finalizer until dry-run
+// mode is turned off.
+func errDryRun(mg resource.Managed, action string) error {
+	return errors.Errorf("dry run: would %s the Discord resource for %s", action, mg.GetName())
+}
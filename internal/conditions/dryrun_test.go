@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	now := metav1.Now()
+
+	tests := []struct {
+		name             string
+		deleting         bool
+		observation      managed.ExternalObservation
+		expectedReason   xpv1.ConditionReason
+		expectedExists   bool
+		expectedUpToDate bool
+	}{
+		{
+			name:             "would create",
+			observation:      managed.ExternalObservation{ResourceExists: false},
+			expectedReason:   ReasonWouldCreate,
+			expectedExists:   true,
+			expectedUpToDate: true,
+		},
+		{
+			name:             "would update",
+			observation:      managed.ExternalObservation{ResourceExists: true, Diff: "name: old -> new"},
+			expectedReason:   ReasonWouldUpdate,
+			expectedExists:   true,
+			expectedUpToDate: true,
+		},
+		{
+			name:             "no changes",
+			observation:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			expectedReason:   ReasonNoChanges,
+			expectedExists:   true,
+			expectedUpToDate: true,
+		},
+		{
+			name:             "would delete",
+			deleting:         true,
+			observation:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			expectedReason:   ReasonWouldDelete,
+			expectedExists:   true,
+			expectedUpToDate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-guild",
+				Annotations: map[string]string{AnnotationKeyDryRun: "true"},
+			}}
+			if tc.deleting {
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			mutated := false
+			c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+						return tc.observation, nil
+					},
+					CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+						mutated = true
+						return managed.ExternalCreation{}, nil
+					},
+					UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+						mutated = true
+						return managed.ExternalUpdate{}, nil
+					},
+					DeleteFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+						mutated = true
+						return managed.ExternalDelete{}, nil
+					},
+				}, nil
+			}))
+
+			ec, err := c.Connect(ctx, cr)
+			require.NoError(t, err)
+
+			o, err := ec.Observe(ctx, cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExists, o.ResourceExists)
+			assert.Equal(t, tc.expectedUpToDate, o.ResourceUpToDate)
+			assert.Equal(t, tc.expectedReason, cr.GetCondition(TypeDryRun).Reason)
+			if tc.observation.Diff != "" {
+				assert.Contains(t, cr.GetCondition(TypeDryRun).Message, tc.observation.Diff)
+			}
+
+			_, err = ec.Create(ctx, cr)
+			assert.Error(t, err)
+			_, err = ec.Update(ctx, cr)
+			assert.Error(t, err)
+			_, err = ec.Delete(ctx, cr)
+			assert.Error(t, err)
+			assert.False(t, mutated)
+		})
+	}
+}
+
+func TestDryRunDisabled(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+	cr.SetConditions(Planned(ReasonWouldCreate, "would create"))
+
+	o := plan(cr, managed.ExternalObservation{ResourceExists: false})
+
+	assert.False(t, o.ResourceExists)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeDryRun).Status)
+	assert.Equal(t, ReasonDryRunDisabled, cr.GetCondition(TypeDryRun).Reason)
+}
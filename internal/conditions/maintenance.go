@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conditions
+
+import (
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/apis/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/maintenance"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// TypeMaintenance reports whether a change to a resource is queued until its
+// ProviderConfig's next maintenance window.
+const TypeMaintenance xpv1.ConditionType = "Maintenance"
+
+// Maintenance condition reasons.
+const (
+	ReasonChangeQueued   xpv1.ConditionReason = "ChangeQueued"
+	ReasonNoChangeQueued xpv1.ConditionReason = "NoChangeQueued"
+)
+
+// ChangeQueued returns a condition indicating a change to a resource is
+// waiting for the next maintenance window.
+func ChangeQueued(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMaintenance,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChangeQueued,
+		Message:            msg,
+	}
+}
+
+// NoChangeQueued returns a condition indicating a resource has no change
+// waiting for a maintenance window.
+func NoChangeQueued() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMaintenance,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoChangeQueued,
+	}
+}
+
+// outsideWindow reports whether changes at now must wait for a maintenance
+// window of pc, and when the next one opens.
+func outsideWindow(pc *v1alpha1.ProviderConfig, now time.Time) (bool, time.Time, error) {
+	windows, err := maintenance.NewWindows(pc.Spec.MaintenanceWindows)
+	if err != nil {
+		return false, time.Time{}, errors.Wrapf(err, "invalid maintenance windows in ProviderConfig %s", pc.GetName())
+	}
+	allowed, next := maintenance.Allowed(windows, now)
+	return !allowed, next, nil
+}
+
+// queue records the change the observation o calls for on a resource that is
+// held until its next maintenance window, and returns the observation to
+// report to the managed reconciler. As in dry-run mode, resources that would
+// be created or updated are reported as existing and up to date, so the
+// change is picked up by the first poll once a window opens.
+func queue(mg resource.Managed, o managed.ExternalObservation, held bool, next time.Time) managed.ExternalObservation {
+	action := ""
+	switch {
+	case !held:
+	case meta.WasDeleted(mg):
+		if o.ResourceExists {
+			action = "delete"
+		}
+	case !o.ResourceExists:
+		action = "create"
+	case !o.ResourceUpToDate:
+		action = "update"
+	}
+
+	if action == "" {
+		if mg.GetCondition(TypeMaintenance).Status == corev1.ConditionTrue {
+			mg.SetConditions(NoChangeQueued().WithObservedGeneration(mg.GetGeneration()))
+		}
+		return o
+	}
+
+	msg := fmt.Sprintf("will %s the Discord resource for %s in the next maintenance window", action, mg.GetName())
+	if !next.IsZero() {
+		msg += ", opening at " + next.UTC().Format(time.RFC3339)
+	}
+	if o.Diff != "" {
+		msg += ": " + o.Diff
+	}
+	mg.SetConditions(ChangeQueued(msg).WithObservedGeneration(mg.GetGeneration()))
+
+	if meta.WasDeleted(mg) {
+		return o
+	}
+	o.ResourceExists = true
+	o.ResourceUpToDate = true
+	return o
+}
+
+// errQueued is returned instead of performing action on a resource outside
+// its maintenance windows. Deletions are held back this way, keeping the
+// finalizer until a window opens.
+func errQueued(mg resource.Managed, action string, next time.Time) error {
+	if next.IsZero() {
+		return errors.Errorf("outside maintenance windows: will %s the Discord resource for %s in the next window", action, mg.GetName())
+	}
+	return errors.Errorf("outside maintenance windows: will %s the Discord resource for %s at %s", action, mg.GetName(), next.UTC().Format(time.RFC3339))
+}
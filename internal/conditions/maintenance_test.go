@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conditions
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	// Windows open at 03:00 UTC for two hours every day.
+	windows := []v1alpha1.MaintenanceWindow{{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}}}
+	inside := time.Date(2025, 6, 4, 4, 0, 0, 0, time.UTC)
+	outside := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		windows          []v1alpha1.MaintenanceWindow
+		now              time.Time
+		observation      managed.ExternalObservation
+		expectedExists   bool
+		expectedUpToDate bool
+		expectedStatus   corev1.ConditionStatus
+		expectedMutation bool
+	}{
+		{
+			name:             "no windows",
+			now:              outside,
+			observation:      managed.ExternalObservation{ResourceExists: true},
+			expectedExists:   true,
+			expectedStatus:   corev1.ConditionUnknown,
+			expectedMutation: true,
+		},
+		{
+			name:             "inside a window",
+			windows:          windows,
+			now:              inside,
+			observation:      managed.ExternalObservation{ResourceExists: true},
+			expectedExists:   true,
+			expectedStatus:   corev1.ConditionUnknown,
+			expectedMutation: true,
+		},
+		{
+			name:             "update outside windows",
+			windows:          windows,
+			now:              outside,
+			observation:      managed.ExternalObservation{ResourceExists: true, Diff: "name: old -> new"},
+			expectedExists:   true,
+			expectedUpToDate: true,
+			expectedStatus:   corev1.ConditionTrue,
+		},
+		{
+			name:             "create outside windows",
+			windows:          windows,
+			now:              outside,
+			observation:      managed.ExternalObservation{ResourceExists: false},
+			expectedExists:   true,
+			expectedUpToDate: true,
+			expectedStatus:   corev1.ConditionTrue,
+		},
+		{
+			name:             "up to date outside windows",
+			windows:          windows,
+			now:              outside,
+			observation:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			expectedExists:   true,
+			expectedUpToDate: true,
+			expectedStatus:   corev1.ConditionUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := runtime.NewScheme()
+			require.NoError(t, apis.AddToScheme(s))
+			pc := &v1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       v1alpha1.ProviderConfigSpec{MaintenanceWindows: tc.windows},
+			}
+			kube := fake.NewClientBuilder().WithScheme(s).WithObjects(pc).Build()
+
+			cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild"}}
+			cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Name: "default"})
+
+			mutated := false
+			c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+						return tc.observation, nil
+					},
+					UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+						mutated = true
+						return managed.ExternalUpdate{}, nil
+					},
+				}, nil
+			}), WithProviderConfig(kube))
+			c.(*connector).now = func() time.Time { return tc.now }
+
+			ec, err := c.Connect(ctx, cr)
+			require.NoError(t, err)
+
+			o, err := ec.Observe(ctx, cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExists, o.ResourceExists)
+			assert.Equal(t, tc.expectedUpToDate, o.ResourceUpToDate)
+			assert.Equal(t, tc.expectedStatus, cr.GetCondition(TypeMaintenance).Status)
+			if tc.expectedStatus == corev1.ConditionTrue {
+				assert.Contains(t, cr.GetCondition(TypeMaintenance).Message, "2025-06-05T03:00:00Z")
+				assert.Contains(t, cr.GetCondition(TypeMaintenance).Message, tc.observation.Diff)
+			}
+
+			_, err = ec.Update(ctx, cr)
+			assert.Equal(t, tc.expectedMutation, err == nil)
+			assert.Equal(t, tc.expectedMutation, mutated)
+		})
+	}
+}
+
+func TestMaintenanceWindowsInvalid(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	pc := &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1alpha1.ProviderConfigSpec{MaintenanceWindows: []v1alpha1.MaintenanceWindow{
+			{Schedule: "nightly", Duration: metav1.Duration{Duration: time.Hour}},
+		}},
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(pc).Build()
+	cr := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "test-guild"}}
+	cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Name: "default"})
+
+	c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{}, nil
+	}), WithProviderConfig(kube))
+
+	_, err := c.Connect(context.Background(), cr)
+	assert.ErrorContains(t, err, "invalid maintenance windows")
+}
+
+func TestQueueCleared(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+	cr.SetConditions(ChangeQueued("will update"))
+
+	o := queue(cr, managed.ExternalObservation{ResourceExists: true}, false, time.Time{})
+
+	assert.False(t, o.ResourceUpToDate)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(TypeMaintenance).Status)
+	assert.Equal(t, ReasonNoChangeQueued, cr.GetCondition(TypeMaintenance).Reason)
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/pkg/errors"
 	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
-	v1alpha1 "github.com/rossigee/provider-discord/apis/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
-	"k8s.io/apimachinery/pkg/types"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"net/http"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
 )
 
 const (
@@ -25,22 +27,23 @@ const (
 )
 
 // Setup adds a controller that reconciles Application managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(applicationv1alpha1.ApplicationGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(applicationv1alpha1.ApplicationGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:  mgr.GetClient(),
 			usage: resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(applicationv1alpha1.ApplicationGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&applicationv1alpha1.Application{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -59,7 +62,7 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*applicationv1alpha1.Application)
+	_, ok := mg.(*applicationv1alpha1.Application)
 	if !ok {
 		return nil, errors.New(errNotApplication)
 	}
@@ -68,31 +71,23 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	pc := &v1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	// Extract credentials from the provider config
-	credentials := discordclient.ProviderCredentials{
-		Source:                    discordclient.CredentialsSourceSecret,
-		CommonCredentialSelectors: pc.Spec.Credentials.CommonCredentialSelectors,
-	}
-	token, err := credentials.Extract(ctx, c.kube)
+	token, err := discordclient.GetConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
 	// Create Discord client
-	discordClient := discordclient.NewDiscordClient(token)
+	discordClient := discordclient.NewDiscordClient(*token)
 
-	return &external{discord: discordClient}, nil
+	return &external{discord: discordClient, endpoints: &http.Client{Timeout: 10 * time.Second}}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	discord discordclient.ApplicationClient
+	// endpoints sends the pre-flight check of the interactions endpoint URL.
+	endpoints *http.Client
 }
 
 func (e *external) Disconnect(_ context.Context) error {
@@ -138,23 +133,25 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.BotPublic = app.BotPublic
 	cr.Status.AtProvider.BotRequireCodeGrant = app.BotRequireCodeGrant
 	if app.Bot != nil {
-		if botID, ok := app.Bot["id"].(string); ok {
-			cr.Status.AtProvider.BotUserID = &botID
-		}
+		cr.Status.AtProvider.BotUserID = &app.Bot.ID
 	}
 	cr.Status.AtProvider.TermsOfServiceURL = app.TermsOfServiceURL
 	cr.Status.AtProvider.PrivacyPolicyURL = app.PrivacyPolicyURL
+	cr.Status.AtProvider.Owner = nil
 	if app.Owner != nil {
-		if ownerID, ok := app.Owner["id"].(string); ok {
-			cr.Status.AtProvider.OwnerID = &ownerID
+		cr.Status.AtProvider.OwnerID = &app.Owner.ID
+		cr.Status.AtProvider.Owner = &applicationv1alpha1.ApplicationOwner{
+			ID:         app.Owner.ID,
+			Username:   app.Owner.Username,
+			GlobalName: app.Owner.GlobalName,
 		}
 	}
 	cr.Status.AtProvider.Summary = app.Summary
 	cr.Status.AtProvider.VerifyKey = app.VerifyKey
+	cr.Status.AtProvider.Team = nil
 	if app.Team != nil {
-		if teamID, ok := app.Team["id"].(string); ok {
-			cr.Status.AtProvider.TeamID = &teamID
-		}
+		cr.Status.AtProvider.TeamID = &app.Team.ID
+		cr.Status.AtProvider.Team = observeTeam(app.Team)
 	}
 	cr.Status.AtProvider.GuildID = app.GuildID
 	cr.Status.AtProvider.PrimarySkuID = app.PrimarySkuID
@@ -167,18 +164,8 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.RoleConnectionsVerificationURL = app.RoleConnectionsVerificationURL
 	cr.Status.AtProvider.Tags = app.Tags
 	if app.InstallParams != nil {
-		if scopes, ok := app.InstallParams["scopes"].([]interface{}); ok {
-			installScopes := make([]string, len(scopes))
-			for i, scope := range scopes {
-				if scopeStr, ok := scope.(string); ok {
-					installScopes[i] = scopeStr
-				}
-			}
-			cr.Status.AtProvider.InstallParamsScopes = installScopes
-		}
-		if permissions, ok := app.InstallParams["permissions"].(string); ok {
-			cr.Status.AtProvider.InstallParamsPermissions = &permissions
-		}
+		cr.Status.AtProvider.InstallParamsScopes = app.InstallParams.Scopes
+		cr.Status.AtProvider.InstallParamsPermissions = &app.InstallParams.Permissions
 	}
 	cr.Status.AtProvider.CustomInstallURL = app.CustomInstallURL
 
@@ -191,6 +178,9 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		if cr.Spec.ForProvider.Description != nil && *cr.Spec.ForProvider.Description != app.Description {
 			needsUpdate = true
 		}
+		if interactionsEndpointChanged(cr.Spec.ForProvider.InteractionsEndpointURL, app.InteractionsEndpointURL) {
+			needsUpdate = true
+		}
 		// Can only update current application, not arbitrary applications
 	}
 
@@ -270,8 +260,25 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		req.Tags = cr.Spec.ForProvider.Tags
 	}
 
+	// Check a new interactions endpoint before Discord does, so a bad URL is
+	// reported as such rather than as a failed update
+	if u := cr.Spec.ForProvider.InteractionsEndpointURL; interactionsEndpointChanged(u, cr.Status.AtProvider.InteractionsEndpointURL) {
+		if *u != "" {
+			if err := discordclient.CheckInteractionsEndpoint(ctx, e.endpoints, *u); err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+		}
+		req.InteractionsEndpointURL = u
+	}
+
 	_, err := e.discord.ModifyCurrentApplication(ctx, req)
 	if err != nil {
+		if req.InteractionsEndpointURL != nil && discordclient.IsInteractionsEndpointRejected(err) {
+			return managed.ExternalUpdate{}, &discordclient.InteractionsEndpointRejectedError{
+				URL:    *req.InteractionsEndpointURL,
+				Reason: "Discord could not verify the endpoint: " + err.Error(),
+			}
+		}
 		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update current application")
 	}
 
@@ -288,3 +295,39 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	// We just remove our tracking of it
 	return managed.ExternalDelete{}, nil
 }
+
+// observeTeam returns the observed state of an application's team.
+func observeTeam(team *discordclient.ApplicationTeam) *applicationv1alpha1.ApplicationTeam {
+	obs := &applicationv1alpha1.ApplicationTeam{
+		ID:          team.ID,
+		Name:        team.Name,
+		OwnerUserID: team.OwnerUserID,
+	}
+	for _, m := range team.Members {
+		state := "Invited"
+		if m.MembershipState == discordclient.TeamMembershipAccepted {
+			state = "Accepted"
+		}
+		obs.Members = append(obs.Members, applicationv1alpha1.ApplicationTeamMember{
+			UserID:          m.User.ID,
+			Username:        m.User.Username,
+			Role:            m.Role,
+			MembershipState: state,
+		})
+	}
+	return obs
+}
+
+// interactionsEndpointChanged reports whether the desired interactions
+// endpoint URL differs from the observed one. An unset desired URL is not
+// managed, and an empty one means no endpoint.
+func interactionsEndpointChanged(desired, observed *string) bool {
+	if desired == nil {
+		return false
+	}
+	current := ""
+	if observed != nil {
+		current = *observed
+	}
+	return *desired != current
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationemoji
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotApplicationEmoji = "managed resource is not an ApplicationEmoji custom resource"
+)
+
+// currentApplication is the application ID that stands for the bot's own
+// application.
+const currentApplication = "@me"
+
+// Setup adds a controller that reconciles ApplicationEmoji managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(applicationv1alpha1.ApplicationEmojiGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(applicationv1alpha1.ApplicationEmojiGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(applicationv1alpha1.ApplicationEmojiGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&applicationv1alpha1.ApplicationEmoji{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*applicationv1alpha1.ApplicationEmoji)
+	if !ok {
+		return nil, errors.New(errNotApplicationEmoji)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+
+	return &external{
+		applications: svc,
+		emojis:       svc,
+		kube:         c.kube,
+		http:         &http.Client{Timeout: 10 * time.Second, CheckRedirect: checkRedirect},
+		images:       sharedImages,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// applications resolves "@me" to the bot's application ID.
+	applications discordclient.ApplicationClient
+	emojis       discordclient.ApplicationEmojiClient
+	// kube reads images declared in a Secret.
+	kube client.Client
+	// http downloads images declared by URL.
+	http *http.Client
+	// images caches downloaded images, if set.
+	images *imageCache
+}
+
+// applicationID returns the ID of the application that owns the emoji,
+// resolving "@me", which the emoji endpoints do not accept.
+func (e *external) applicationID(ctx context.Context, cr *applicationv1alpha1.ApplicationEmoji) (string, error) {
+	id := cr.Spec.ForProvider.ApplicationID
+	if id != currentApplication {
+		return id, nil
+	}
+	if cr.Status.AtProvider.ApplicationID != "" {
+		return cr.Status.AtProvider.ApplicationID, nil
+	}
+	app, err := e.applications.GetCurrentApplication(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve current application")
+	}
+	cr.Status.AtProvider.ApplicationID = app.ID
+	return app.ID, nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*applicationv1alpha1.ApplicationEmoji)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotApplicationEmoji)
+	}
+
+	// Crossplane runtime defaults external-name to metadata.name for new
+	// resources, so only an emoji ID identifies an existing emoji
+	externalName := meta.GetExternalName(cr)
+	if !snowflake.Valid(externalName) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	emoji, err := e.emojis.GetApplicationEmoji(ctx, appID, externalName)
+	if err != nil {
+		if discordclient.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get application emoji")
+	}
+	if emoji == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.ID = emoji.ID
+	cr.Status.AtProvider.ApplicationID = appID
+	cr.Status.AtProvider.Name = emoji.Name
+	cr.Status.AtProvider.Animated = emoji.Animated
+	cr.Status.AtProvider.Markdown = markdown(emoji)
+
+	image, err := e.image(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	// An emoji whose upload was not recorded is taken to show the declared
+	// image, since Discord cannot tell otherwise
+	if cr.Status.AtProvider.ImageSHA256 == "" {
+		cr.Status.AtProvider.ImageSHA256 = imageSHA256(image)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: emoji.Name == cr.Spec.ForProvider.Name && cr.Status.AtProvider.ImageSHA256 == imageSHA256(image),
+	}, nil
+}
+
+// markdown returns the message syntax that renders emoji.
+func markdown(emoji *discordclient.Emoji) string {
+	if emoji.Animated {
+		return fmt.Sprintf("<a:%s:%s>", emoji.Name, emoji.ID)
+	}
+	return fmt.Sprintf("<:%s:%s>", emoji.Name, emoji.ID)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*applicationv1alpha1.ApplicationEmoji)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotApplicationEmoji)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	image, err := e.image(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := e.upload(ctx, cr, appID, image); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+// upload creates the emoji with image and records it.
+func (e *external) upload(ctx context.Context, cr *applicationv1alpha1.ApplicationEmoji, appID, image string) error {
+	emoji, err := e.emojis.CreateApplicationEmoji(ctx, appID, &discordclient.CreateApplicationEmojiRequest{
+		Name:  cr.Spec.ForProvider.Name,
+		Image: image,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create application emoji")
+	}
+
+	meta.SetExternalName(cr, emoji.ID)
+	cr.Status.AtProvider.ImageSHA256 = imageSHA256(image)
+	return nil
+}
+
+// Update renames the emoji, or uploads it again if its image changed.
+// Discord cannot replace an emoji's image, so the emoji is deleted and
+// created again, with a new ID.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*applicationv1alpha1.ApplicationEmoji)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotApplicationEmoji)
+	}
+
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	image, err := e.image(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if imageSHA256(image) != cr.Status.AtProvider.ImageSHA256 {
+		if err := e.emojis.DeleteApplicationEmoji(ctx, appID, meta.GetExternalName(cr)); err != nil && !discordclient.IsNotFound(err) {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to delete application emoji")
+		}
+		return managed.ExternalUpdate{}, e.upload(ctx, cr, appID, image)
+	}
+
+	_, err = e.emojis.ModifyApplicationEmoji(ctx, appID, meta.GetExternalName(cr), &discordclient.ModifyApplicationEmojiRequest{
+		Name: cr.Spec.ForProvider.Name,
+	})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update application emoji")
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*applicationv1alpha1.ApplicationEmoji)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotApplicationEmoji)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	if err := e.emojis.DeleteApplicationEmoji(ctx, appID, meta.GetExternalName(cr)); err != nil {
+		if discordclient.IsNotFound(err) {
+			return managed.ExternalDelete{}, nil
+		}
+		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete application emoji")
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationemoji
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MockApplicationClient struct {
+	discordclient.ApplicationClient
+	GetCurrentApplicationFunc func(ctx context.Context) (*discordclient.DiscordApplication, error)
+}
+
+func (m *MockApplicationClient) GetCurrentApplication(ctx context.Context) (*discordclient.DiscordApplication, error) {
+	return m.GetCurrentApplicationFunc(ctx)
+}
+
+type MockApplicationEmojiClient struct {
+	discordclient.ApplicationEmojiClient
+	GetApplicationEmojiFunc    func(ctx context.Context, applicationID, emojiID string) (*discordclient.Emoji, error)
+	CreateApplicationEmojiFunc func(ctx context.Context, applicationID string, req *discordclient.CreateApplicationEmojiRequest) (*discordclient.Emoji, error)
+	DeleteApplicationEmojiFunc func(ctx context.Context, applicationID, emojiID string) error
+}
+
+func (m *MockApplicationEmojiClient) GetApplicationEmoji(ctx context.Context, applicationID, emojiID string) (*discordclient.Emoji, error) {
+	return m.GetApplicationEmojiFunc(ctx, applicationID, emojiID)
+}
+
+func (m *MockApplicationEmojiClient) CreateApplicationEmoji(ctx context.Context, applicationID string, req *discordclient.CreateApplicationEmojiRequest) (*discordclient.Emoji, error) {
+	return m.CreateApplicationEmojiFunc(ctx, applicationID, req)
+}
+
+func (m *MockApplicationEmojiClient) DeleteApplicationEmoji(ctx context.Context, applicationID, emojiID string) error {
+	return m.DeleteApplicationEmojiFunc(ctx, applicationID, emojiID)
+}
+
+func emojiResource(externalName string) *applicationv1alpha1.ApplicationEmoji {
+	return &applicationv1alpha1.ApplicationEmoji{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "wave",
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: externalName},
+		},
+		Spec: applicationv1alpha1.ApplicationEmojiSpec{
+			ForProvider: applicationv1alpha1.ApplicationEmojiParameters{
+				ApplicationID: "@me",
+				Name:          "wave",
+				Image:         "data:image/png;base64,AAAA",
+			},
+		},
+	}
+}
+
+func botApplication() *MockApplicationClient {
+	return &MockApplicationClient{
+		GetCurrentApplicationFunc: func(ctx context.Context) (*discordclient.DiscordApplication, error) {
+			return &discordclient.DiscordApplication{ID: "42"}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NotCreated", func(t *testing.T) {
+		e := &external{}
+		obs, err := e.Observe(ctx, emojiResource("wave"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("Renamed", func(t *testing.T) {
+		e := &external{
+			applications: botApplication(),
+			emojis: &MockApplicationEmojiClient{
+				GetApplicationEmojiFunc: func(ctx context.Context, applicationID, emojiID string) (*discordclient.Emoji, error) {
+					assert.Equal(t, "42", applicationID)
+					return &discordclient.Emoji{ID: emojiID, Name: "hello", Animated: true}, nil
+				},
+			},
+		}
+		cr := emojiResource("1234567890123456789")
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, "42", cr.Status.AtProvider.ApplicationID)
+		assert.Equal(t, "<a:hello:1234567890123456789>", cr.Status.AtProvider.Markdown)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		e := &external{
+			applications: botApplication(),
+			emojis: &MockApplicationEmojiClient{
+				GetApplicationEmojiFunc: func(ctx context.Context, applicationID, emojiID string) (*discordclient.Emoji, error) {
+					return nil, &discordclient.APIError{StatusCode: 404, Code: 10014, Message: "Unknown Emoji"}
+				},
+			},
+		}
+		obs, err := e.Observe(ctx, emojiResource("1234567890123456789"))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	var got *discordclient.CreateApplicationEmojiRequest
+	e := &external{
+		applications: botApplication(),
+		emojis: &MockApplicationEmojiClient{
+			CreateApplicationEmojiFunc: func(ctx context.Context, applicationID string, req *discordclient.CreateApplicationEmojiRequest) (*discordclient.Emoji, error) {
+				got = req
+				return &discordclient.Emoji{ID: "1234567890123456789", Name: req.Name}, nil
+			},
+		},
+	}
+	cr := emojiResource("wave")
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "data:image/png;base64,AAAA", got.Image)
+	assert.Equal(t, "1234567890123456789", meta.GetExternalName(cr))
+}
+
+func TestDeleteAlreadyGone(t *testing.T) {
+	e := &external{
+		applications: botApplication(),
+		emojis: &MockApplicationEmojiClient{
+			DeleteApplicationEmojiFunc: func(ctx context.Context, applicationID, emojiID string) error {
+				return &discordclient.APIError{StatusCode: 404, Code: 10014, Message: "Unknown Emoji"}
+			},
+		},
+	}
+	_, err := e.Delete(context.Background(), emojiResource("1234567890123456789"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationemoji
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxImageSize is the largest emoji image Discord accepts.
+const maxImageSize = 256 * 1024
+
+// imageRefresh is how long a downloaded image is reused before it is
+// downloaded again to tell whether it changed.
+const imageRefresh = time.Hour
+
+// An imageCache holds images downloaded by URL, so an emoji's image is not
+// downloaded on every poll.
+type imageCache struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries map[string]cachedImage
+}
+
+type cachedImage struct {
+	image   string
+	fetched time.Time
+}
+
+// sharedImages is shared by all clients, since clients are created per
+// reconcile.
+var sharedImages = newImageCache()
+
+func newImageCache() *imageCache {
+	return &imageCache{now: time.Now, entries: map[string]cachedImage{}}
+}
+
+// get returns the image downloaded from url, if it is recent enough.
+func (c *imageCache) get(url string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.entries[url]
+	if !ok || c.now().Sub(cached.fetched) >= imageRefresh {
+		return "", false
+	}
+	return cached.image, true
+}
+
+// put records the image downloaded from url, dropping expired images.
+func (c *imageCache) put(url, image string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for u, cached := range c.entries {
+		if now.Sub(cached.fetched) >= imageRefresh {
+			delete(c.entries, u)
+		}
+	}
+	c.entries[url] = cachedImage{image: image, fetched: now}
+}
+
+// image returns the declared emoji image as a data URI, reading it from the
+// Secret or URL it is declared in.
+func (e *external) image(ctx context.Context, cr *applicationv1alpha1.ApplicationEmoji) (string, error) {
+	p := cr.Spec.ForProvider
+	switch {
+	case p.ImageSecretRef != nil:
+		secret := &corev1.Secret{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: p.ImageSecretRef.Name}, secret); err != nil {
+			return "", errors.Wrapf(err, "cannot get image secret %s", p.ImageSecretRef.Name)
+		}
+		data, ok := secret.Data[p.ImageSecretRef.Key]
+		if !ok {
+			return "", errors.Errorf("secret %s has no %s key", p.ImageSecretRef.Name, p.ImageSecretRef.Key)
+		}
+		if strings.HasPrefix(string(data), "data:") {
+			return strings.TrimSpace(string(data)), nil
+		}
+		return dataURI(http.DetectContentType(data), data), nil
+	case p.ImageURL != nil:
+		if e.images == nil {
+			return e.download(ctx, *p.ImageURL)
+		}
+		if image, ok := e.images.get(*p.ImageURL); ok {
+			return image, nil
+		}
+		image, err := e.download(ctx, *p.ImageURL)
+		if err != nil {
+			return "", err
+		}
+		e.images.put(*p.ImageURL, image)
+		return image, nil
+	}
+	return p.Image, nil
+}
+
+// checkRedirect follows up to 10 redirects, refusing any that leave https
+// or lead to a private, loopback or link-local address, so an image URL
+// cannot reach into the cluster's network.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if req.URL.Scheme != "https" {
+		return errors.Errorf("refusing redirect to %s URL", req.URL.Scheme)
+	}
+
+	host := req.URL.Hostname()
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(req.Context(), host)
+		if err != nil {
+			return errors.Wrapf(err, "cannot resolve redirect host %s", host)
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+	for _, ip := range ips {
+		if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+			return errors.Errorf("refusing redirect to internal address %s", host)
+		}
+	}
+	return nil
+}
+
+// download returns the image at url as a data URI.
+func (e *external) download(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build image request")
+	}
+	resp, err := e.http.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to download image")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to download image: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to download image")
+	}
+	if len(data) > maxImageSize {
+		return "", errors.Errorf("image is larger than Discord's limit of %d KiB", maxImageSize/1024)
+	}
+	contentType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(data)
+	}
+	return dataURI(contentType, data), nil
+}
+
+func dataURI(contentType string, data []byte) string {
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data))
+}
+
+// imageSHA256 returns the hex encoded SHA-256 of an image data URI.
+func imageSHA256(image string) string {
+	sum := sha256.Sum256([]byte(image))
+	return hex.EncodeToString(sum[:])
+}
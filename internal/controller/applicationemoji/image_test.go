@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationemoji
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// pngHeader is enough of a PNG file for its content type to be detected.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newImageKube(t *testing.T, data []byte) client.Client {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "emoji", Namespace: "default"},
+		Data:       map[string][]byte{"wave.png": data},
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build()
+}
+
+func TestImage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Inline", func(t *testing.T) {
+		image, err := (&external{}).image(ctx, emojiResource("wave"))
+		require.NoError(t, err)
+		assert.Equal(t, "data:image/png;base64,AAAA", image)
+	})
+
+	t.Run("SecretFile", func(t *testing.T) {
+		cr := emojiResource("wave")
+		cr.Namespace = "default"
+		cr.Spec.ForProvider.Image = ""
+		cr.Spec.ForProvider.ImageSecretRef = &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "emoji"}, Key: "wave.png"}
+
+		image, err := (&external{kube: newImageKube(t, pngHeader)}).image(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", image)
+	})
+
+	t.Run("SecretDataURI", func(t *testing.T) {
+		cr := emojiResource("wave")
+		cr.Namespace = "default"
+		cr.Spec.ForProvider.Image = ""
+		cr.Spec.ForProvider.ImageSecretRef = &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "emoji"}, Key: "wave.png"}
+
+		image, err := (&external{kube: newImageKube(t, []byte("data:image/gif;base64,R0lG\n"))}).image(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, "data:image/gif;base64,R0lG", image)
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png; charset=binary")
+			_, _ = w.Write(pngHeader)
+		}))
+		defer srv.Close()
+
+		cr := emojiResource("wave")
+		cr.Spec.ForProvider.Image = ""
+		cr.Spec.ForProvider.ImageURL = &srv.URL
+
+		image, err := (&external{http: srv.Client()}).image(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", image)
+	})
+
+	t.Run("URLTooLarge", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(make([]byte, maxImageSize+1))
+		}))
+		defer srv.Close()
+
+		cr := emojiResource("wave")
+		cr.Spec.ForProvider.Image = ""
+		cr.Spec.ForProvider.ImageURL = &srv.URL
+
+		_, err := (&external{http: srv.Client()}).image(ctx, cr)
+		assert.ErrorContains(t, err, "larger than Discord's limit")
+	})
+}
+
+func TestImageCache(t *testing.T) {
+	ctx := context.Background()
+	downloads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		_, _ = w.Write(pngHeader)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	images := newImageCache()
+	images.now = func() time.Time { return now }
+	e := &external{http: srv.Client(), images: images}
+	cr := emojiResource("wave")
+	cr.Spec.ForProvider.Image = ""
+	cr.Spec.ForProvider.ImageURL = &srv.URL
+
+	for i := 0; i < 3; i++ {
+		_, err := e.image(ctx, cr)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, downloads)
+
+	now = now.Add(imageRefresh)
+	_, err := e.image(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, 2, downloads)
+}
+
+func TestCheckRedirect(t *testing.T) {
+	cases := map[string]struct {
+		target string
+		want   string
+	}{
+		"PlainHTTP":  {target: "http://example.com/wave.png", want: "refusing redirect to http URL"},
+		"Loopback":   {target: "https://127.0.0.1/wave.png", want: "internal address"},
+		"Private":    {target: "https://10.0.0.8/wave.png", want: "internal address"},
+		"LinkLocal":  {target: "https://169.254.169.254/latest/meta-data", want: "internal address"},
+		"PublicIPv4": {target: "https://93.184.215.14/wave.png"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.target, nil)
+			require.NoError(t, err)
+			err = checkRedirect(req, nil)
+			if tc.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestImageChanged(t *testing.T) {
+	ctx := context.Background()
+	var deleted string
+	var uploaded *discordclient.CreateApplicationEmojiRequest
+	e := &external{
+		applications: botApplication(),
+		kube:         newImageKube(t, pngHeader),
+		emojis: &MockApplicationEmojiClient{
+			GetApplicationEmojiFunc: func(ctx context.Context, applicationID, emojiID string) (*discordclient.Emoji, error) {
+				return &discordclient.Emoji{ID: emojiID, Name: "wave"}, nil
+			},
+			DeleteApplicationEmojiFunc: func(ctx context.Context, applicationID, emojiID string) error {
+				deleted = emojiID
+				return nil
+			},
+			CreateApplicationEmojiFunc: func(ctx context.Context, applicationID string, req *discordclient.CreateApplicationEmojiRequest) (*discordclient.Emoji, error) {
+				uploaded = req
+				return &discordclient.Emoji{ID: "2234567890123456789", Name: req.Name}, nil
+			},
+		},
+	}
+	cr := emojiResource("1234567890123456789")
+	cr.Namespace = "default"
+	cr.Spec.ForProvider.Image = ""
+	cr.Spec.ForProvider.ImageSecretRef = &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "emoji"}, Key: "wave.png"}
+
+	// An untracked emoji is taken to show the declared image
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, imageSHA256("data:image/png;base64,iVBORw0KGgo="), cr.Status.AtProvider.ImageSHA256)
+
+	cr.Status.AtProvider.ImageSHA256 = imageSHA256("data:image/png;base64,AAAA")
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890123456789", deleted)
+	assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", uploaded.Image)
+	assert.Equal(t, "2234567890123456789", meta.GetExternalName(cr))
+	assert.Equal(t, imageSHA256(uploaded.Image), cr.Status.AtProvider.ImageSHA256)
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogexport
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotAuditLogExport = "managed resource is not an AuditLogExport custom resource"
+)
+
+const (
+	// pageSize is the most entries Discord returns in one audit log page.
+	pageSize = 100
+
+	// maxPagesPerExport bounds the pages one reconcile exports, so a large
+	// backlog is worked through over several polls.
+	maxPagesPerExport = 10
+
+	// defaultInterval applies when spec.forProvider.interval is unset.
+	defaultInterval = time.Hour
+)
+
+// Setup adds a controller that reconciles AuditLogExport managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(guildv1alpha1.AuditLogExportGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(guildv1alpha1.AuditLogExportGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(guildv1alpha1.AuditLogExportGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&guildv1alpha1.AuditLogExport{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*guildv1alpha1.AuditLogExport)
+	if !ok {
+		return nil, errors.New(errNotAuditLogExport)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+
+	return &external{
+		discord:     svc,
+		permissions: svc,
+		kube:        c.kube,
+		http:        &http.Client{Timeout: 30 * time.Second},
+		now:         time.Now,
+	}, nil
+}
+
+// An ExternalClient exports new audit log entries to the configured sink.
+// The export always exists; it is out of date once its interval has passed.
+type external struct {
+	discord     discordclient.AuditLogClient
+	permissions discordclient.PermissionClient
+	kube        client.Client
+	http        *http.Client
+	now         func() time.Time
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func (e *external) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*guildv1alpha1.AuditLogExport)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAuditLogExport)
+	}
+
+	// The export is identified by its guild
+	if meta.GetExternalName(cr) != cr.Spec.ForProvider.GuildID {
+		meta.SetExternalName(cr, cr.Spec.ForProvider.GuildID)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !e.due(cr),
+	}, nil
+}
+
+// due reports whether the export has not caught up with the audit log within
+// its interval.
+func (e *external) due(cr *guildv1alpha1.AuditLogExport) bool {
+	last := cr.Status.AtProvider.LastExportTime
+	if last == nil {
+		return true
+	}
+	interval := defaultInterval
+	if cr.Spec.ForProvider.Interval != nil {
+		interval = cr.Spec.ForProvider.Interval.Duration
+	}
+	return !e.now().Before(last.Add(interval))
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	// Observe always reports the export as existing
+	return managed.ExternalCreation{}, nil
+}
+
+// Update writes the audit log entries newer than the cursor to the sink, a
+// page at a time. The cursor advances after each page is written, so a
+// failed write is retried from that page on the next reconcile.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*guildv1alpha1.AuditLogExport)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAuditLogExport)
+	}
+
+	guildID := cr.Spec.ForProvider.GuildID
+	if e.permissions != nil {
+		if err := discordclient.RequirePermissions(ctx, e.permissions, guildID, discordclient.PermissionViewAuditLog); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	s, err := newSink(ctx, e.kube, e.http, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	status := &cr.Status.AtProvider
+	exported := 0
+	for page := 0; page < maxPagesPerExport; page++ {
+		after := status.LastEntryID
+		if after == "" {
+			after = "0"
+		}
+		limit := pageSize
+		log, err := e.discord.GetGuildAuditLog(ctx, guildID, &discordclient.GetGuildAuditLogRequest{
+			Limit: &limit,
+			After: &after,
+		})
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to get guild audit log")
+		}
+		if len(log.Entries) == 0 {
+			break
+		}
+
+		sortEntries(log.Entries)
+		last := log.Entries[len(log.Entries)-1].ID
+		object, err := s.Write(ctx, last, &exportDocument{
+			GuildID: guildID,
+			Entries: log.Entries,
+			Users:   log.Users,
+		})
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to write audit log entries")
+		}
+
+		status.LastEntryID = last
+		status.LastObject = object
+		status.ExportedCount += int64(len(log.Entries))
+		exported += len(log.Entries)
+
+		if len(log.Entries) < pageSize {
+			break
+		}
+		if page == maxPagesPerExport-1 {
+			// More entries remain; leave the export due so the next
+			// poll continues from the cursor
+			status.LastExportCount = exported
+			return managed.ExternalUpdate{}, nil
+		}
+	}
+
+	now := metav1.NewTime(e.now())
+	status.LastExportTime = &now
+	status.LastExportCount = exported
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete stops the export. Entries already written are left in the sink.
+func (e *external) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*guildv1alpha1.AuditLogExport)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotAuditLogExport)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	return managed.ExternalDelete{}, nil
+}
+
+// sortEntries orders entries oldest first. Snowflakes grow with time, so a
+// shorter ID is older and IDs of equal length compare lexically.
+func sortEntries(entries []discordclient.AuditLogEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].ID, entries[j].ID
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+}
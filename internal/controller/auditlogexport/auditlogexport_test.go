@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const guildID = "111111111111111111"
+
+var now = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+// MockAuditLogClient serves entries with the given IDs, newest first as
+// Discord does, a page of at most the requested limit after the cursor.
+type MockAuditLogClient struct {
+	ids    []string
+	afters []string
+}
+
+func (m *MockAuditLogClient) GetGuildAuditLog(ctx context.Context, guildID string, req *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error) {
+	m.afters = append(m.afters, *req.After)
+	var page []discordclient.AuditLogEntry
+	for i := len(m.ids) - 1; i >= 0; i-- {
+		id := m.ids[i]
+		if len(id) > len(*req.After) || (len(id) == len(*req.After) && id > *req.After) {
+			page = append(page, discordclient.AuditLogEntry{ID: id, ActionType: 22})
+		}
+	}
+	if len(page) > *req.Limit {
+		page = page[len(page)-*req.Limit:]
+	}
+	return &discordclient.AuditLog{Entries: page}, nil
+}
+
+type MockPermissionClient struct {
+	discordclient.PermissionClient
+	have int64
+}
+
+func (m *MockPermissionClient) GetCurrentMemberPermissions(ctx context.Context, guildID string) (int64, error) {
+	return m.have, nil
+}
+
+// ids returns n increasing snowflakes.
+func ids(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("1%017d", i+1)
+	}
+	return out
+}
+
+func exportResource() *guildv1alpha1.AuditLogExport {
+	return &guildv1alpha1.AuditLogExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "default"},
+		Spec: guildv1alpha1.AuditLogExportSpec{
+			ForProvider: guildv1alpha1.AuditLogExportParameters{
+				GuildID: guildID,
+				Sink: guildv1alpha1.AuditLogSink{
+					ConfigMap: &guildv1alpha1.AuditLogConfigMapSink{NamePrefix: "audit"},
+				},
+			},
+		},
+	}
+}
+
+func newKube(t *testing.T) client.Client {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	return fake.NewClientBuilder().WithScheme(s).Build()
+}
+
+func TestObserve(t *testing.T) {
+	e := &external{now: func() time.Time { return now }}
+
+	t.Run("NeverExported", func(t *testing.T) {
+		cr := exportResource()
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, guildID, meta.GetExternalName(cr))
+	})
+
+	t.Run("WithinInterval", func(t *testing.T) {
+		cr := exportResource()
+		last := metav1.NewTime(now.Add(-30 * time.Minute))
+		cr.Status.AtProvider.LastExportTime = &last
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("IntervalElapsed", func(t *testing.T) {
+		cr := exportResource()
+		cr.Spec.ForProvider.Interval = &metav1.Duration{Duration: 15 * time.Minute}
+		last := metav1.NewTime(now.Add(-30 * time.Minute))
+		cr.Status.AtProvider.LastExportTime = &last
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("ExportsNewEntriesToConfigMaps", func(t *testing.T) {
+		all := ids(150)
+		discord := &MockAuditLogClient{ids: all}
+		kube := newKube(t)
+		e := &external{discord: discord, kube: kube, now: func() time.Time { return now }}
+		cr := exportResource()
+
+		_, err := e.Update(context.Background(), cr)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"0", all[99]}, discord.afters)
+		assert.Equal(t, all[149], cr.Status.AtProvider.LastEntryID)
+		assert.Equal(t, int64(150), cr.Status.AtProvider.ExportedCount)
+		assert.Equal(t, 150, cr.Status.AtProvider.LastExportCount)
+		assert.Equal(t, "audit-"+all[149], cr.Status.AtProvider.LastObject)
+		require.NotNil(t, cr.Status.AtProvider.LastExportTime)
+
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "audit-" + all[99]}, cm))
+		assert.Equal(t, "audit", cm.Labels[labelExport])
+		doc := &exportDocument{}
+		require.NoError(t, json.Unmarshal([]byte(cm.Data[entriesKey]), doc))
+		require.Len(t, doc.Entries, 100)
+		assert.Equal(t, all[0], doc.Entries[0].ID)
+		assert.Equal(t, all[99], doc.Entries[99].ID)
+
+		// A second export starts from the cursor and finds nothing new
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, all[149], discord.afters[2])
+		assert.Equal(t, int64(150), cr.Status.AtProvider.ExportedCount)
+		assert.Equal(t, 0, cr.Status.AtProvider.LastExportCount)
+	})
+
+	t.Run("BoundsPagesPerExport", func(t *testing.T) {
+		all := ids(pageSize*maxPagesPerExport + 1)
+		e := &external{discord: &MockAuditLogClient{ids: all}, kube: newKube(t), now: func() time.Time { return now }}
+		cr := exportResource()
+
+		_, err := e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, all[pageSize*maxPagesPerExport-1], cr.Status.AtProvider.LastEntryID)
+		assert.Nil(t, cr.Status.AtProvider.LastExportTime, "the export is still due")
+	})
+
+	t.Run("MissingPermission", func(t *testing.T) {
+		e := &external{
+			discord:     &MockAuditLogClient{},
+			permissions: &MockPermissionClient{have: discordclient.PermissionManageGuild},
+			kube:        newKube(t),
+			now:         func() time.Time { return now },
+		}
+		_, err := e.Update(context.Background(), exportResource())
+		missing, ok := discordclient.IsMissingPermissions(err)
+		require.True(t, ok, "%v", err)
+		assert.Equal(t, []string{"VIEW_AUDIT_LOG"}, missing.Permissions)
+	})
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := []discordclient.AuditLogEntry{{ID: "100000000000000002"}, {ID: "99999999999999999"}, {ID: "100000000000000001"}}
+	sortEntries(entries)
+	assert.Equal(t, "99999999999999999", entries[0].ID)
+	assert.Equal(t, "100000000000000002", entries[2].ID)
+}
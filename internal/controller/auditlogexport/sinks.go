@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// labelExport and labelGuild are set on exported ConfigMaps.
+	labelExport = "guild.discord.crossplane.io/audit-log-export"
+	labelGuild  = "guild.discord.crossplane.io/guild-id"
+
+	// entriesKey is the ConfigMap key that holds an export.
+	entriesKey = "entries.json"
+
+	// defaultMaxConfigMaps is how many ConfigMaps a sink keeps when the
+	// spec predates MaxConfigMaps.
+	defaultMaxConfigMaps = 100
+
+	// The keys of an S3 sink's credentials Secret.
+	keyAccessKeyID     = "accessKeyId"
+	keySecretAccessKey = "secretAccessKey"
+)
+
+// An exportDocument is the JSON written to a sink for each page of entries,
+// oldest entry first. Users holds the users the entries refer to.
+type exportDocument struct {
+	GuildID string                        `json:"guild_id"`
+	Entries []discordclient.AuditLogEntry `json:"audit_log_entries"`
+	Users   []discordclient.User          `json:"users,omitempty"`
+}
+
+// A sink stores exported entries. Write is called with the ID of the newest
+// entry in doc and returns the name of the object written, if any. Writing
+// the same page twice must not fail, since a page is written again when the
+// cursor could not be saved.
+type sink interface {
+	Write(ctx context.Context, lastEntryID string, doc *exportDocument) (string, error)
+}
+
+// newSink builds the sink configured on cr, reading any Secrets it needs
+// from cr's namespace.
+func newSink(ctx context.Context, kube client.Client, hc *http.Client, cr *guildv1alpha1.AuditLogExport) (sink, error) {
+	spec := cr.Spec.ForProvider.Sink
+	switch {
+	case spec.ConfigMap != nil:
+		keep := int(spec.ConfigMap.MaxConfigMaps)
+		if keep < 1 {
+			keep = defaultMaxConfigMaps
+		}
+		return &configMapSink{kube: kube, export: cr, prefix: spec.ConfigMap.NamePrefix, keep: keep}, nil
+	case spec.Webhook != nil:
+		ref := spec.Webhook.URLSecretRef
+		secret, err := getSecret(ctx, kube, cr.GetNamespace(), ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		url := string(secret.Data[ref.Key])
+		if url == "" {
+			return nil, errors.Errorf("secret %s has no %s key", ref.Name, ref.Key)
+		}
+		return &webhookSink{http: hc, url: url}, nil
+	case spec.S3 != nil:
+		secret, err := getSecret(ctx, kube, cr.GetNamespace(), spec.S3.CredentialsSecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		s := &s3Sink{
+			http:            hc,
+			endpoint:        spec.S3.Endpoint,
+			region:          "us-east-1",
+			bucket:          spec.S3.Bucket,
+			prefix:          cr.Spec.ForProvider.GuildID + "/",
+			accessKeyID:     string(secret.Data[keyAccessKeyID]),
+			secretAccessKey: string(secret.Data[keySecretAccessKey]),
+		}
+		if spec.S3.Region != nil {
+			s.region = *spec.S3.Region
+		}
+		if spec.S3.Prefix != nil {
+			s.prefix = *spec.S3.Prefix + s.prefix
+		}
+		if s.accessKeyID == "" || s.secretAccessKey == "" {
+			return nil, errors.Errorf("secret %s must have %s and %s keys", spec.S3.CredentialsSecretRef.Name, keyAccessKeyID, keySecretAccessKey)
+		}
+		return s, nil
+	}
+	return nil, errors.New("no audit log sink configured")
+}
+
+func getSecret(ctx context.Context, kube client.Client, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrapf(err, "cannot get secret %s", name)
+	}
+	return secret, nil
+}
+
+// A configMapSink writes each page to a ConfigMap named after its newest
+// entry, in the export's namespace. The ConfigMaps are not owned by the
+// export so they outlive it, but only the newest keep are kept.
+type configMapSink struct {
+	kube   client.Client
+	export *guildv1alpha1.AuditLogExport
+	prefix string
+	keep   int
+}
+
+func (s *configMapSink) Write(ctx context.Context, lastEntryID string, doc *exportDocument) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot encode audit log entries")
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.prefix + "-" + lastEntryID,
+			Namespace: s.export.GetNamespace(),
+			Labels: map[string]string{
+				labelExport: s.export.GetName(),
+				labelGuild:  doc.GuildID,
+			},
+		},
+		Data: map[string]string{entriesKey: string(data)},
+	}
+	if err := s.kube.Create(ctx, cm); err != nil && !kerrors.IsAlreadyExists(err) {
+		return "", errors.Wrap(err, "cannot create configmap")
+	}
+	if err := s.prune(ctx, doc.GuildID); err != nil {
+		return "", err
+	}
+	return cm.Name, nil
+}
+
+// prune deletes the export's ConfigMaps older than the newest keep.
+func (s *configMapSink) prune(ctx context.Context, guildID string) error {
+	l := &corev1.ConfigMapList{}
+	if err := s.kube.List(ctx, l, client.InNamespace(s.export.GetNamespace()), client.MatchingLabels{
+		labelExport: s.export.GetName(),
+		labelGuild:  guildID,
+	}); err != nil {
+		return errors.Wrap(err, "cannot list configmaps")
+	}
+	if len(l.Items) <= s.keep {
+		return nil
+	}
+
+	// Names end with the newest entry's snowflake, so a shorter ID is older
+	id := func(cm corev1.ConfigMap) string { return cm.Name[strings.LastIndex(cm.Name, "-")+1:] }
+	sort.Slice(l.Items, func(i, j int) bool {
+		a, b := id(l.Items[i]), id(l.Items[j])
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	for i := range l.Items[:len(l.Items)-s.keep] {
+		if err := s.kube.Delete(ctx, &l.Items[i]); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "cannot delete configmap %s", l.Items[i].Name)
+		}
+	}
+	return nil
+}
+
+// A webhookSink POSTs each page to an HTTP endpoint. Receivers should
+// deduplicate entries by ID, as a page may be delivered more than once.
+type webhookSink struct {
+	http *http.Client
+	url  string
+}
+
+func (s *webhookSink) Write(ctx context.Context, _ string, doc *exportDocument) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot encode audit log entries")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Wrap(withoutURL(err), "cannot build webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return "", errors.Wrap(withoutURL(err), "webhook request failed")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", errors.Errorf("webhook answered %d", resp.StatusCode)
+	}
+	return "", nil
+}
+
+// withoutURL drops the URL from err, since a webhook URL embeds its secret
+// and errors end up in conditions and events.
+func withoutURL(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return errors.Errorf("%s: %v", ue.Op, ue.Err)
+	}
+	return err
+}
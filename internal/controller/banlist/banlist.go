@@ -0,0 +1,348 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package banlist
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	banv1alpha1 "github.com/rossigee/provider-discord/apis/ban/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotBanList = "managed resource is not a BanList custom resource"
+
+	// fullSyncInterval is how often every ban is listed, even when the
+	// audit log could supply the changes since the last poll.
+	fullSyncInterval = 24 * time.Hour
+
+	// auditLogPageSize is the most entries Discord returns per audit log
+	// page.
+	auditLogPageSize = 100
+
+	// maxAuditLogPages bounds the audit log read in one poll. A guild with
+	// more new entries than that has its bans listed in full instead.
+	maxAuditLogPages = 10
+
+	// maxStatusBans bounds the bans and unexpected user IDs recorded in
+	// status, which must fit in a single Kubernetes object. A guild with
+	// more bans reports only their count and is listed in full every poll.
+	maxStatusBans = 1000
+)
+
+// Setup adds a controller that reconciles BanList managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(banv1alpha1.BanListGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(banv1alpha1.BanListGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(banv1alpha1.BanListGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&banv1alpha1.BanList{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*banv1alpha1.BanList)
+	if !ok {
+		return nil, errors.New(errNotBanList)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+	return &external{discord: svc, auditLog: svc, now: time.Now}, nil
+}
+
+// An ExternalClient observes the ban list of a guild. BanLists are
+// observe-only, so Create, Update and Delete never call Discord.
+type external struct {
+	discord discordclient.BanClient
+	// auditLog supplies the bans and unbans since the last poll, so the
+	// ban list need not be read in full; it is always read in full when
+	// auditLog is nil.
+	auditLog discordclient.AuditLogClient
+	now      func() time.Time
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*banv1alpha1.BanList)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBanList)
+	}
+
+	// The cursor in status belongs to the guild the external name records
+	guildID := cr.Spec.ForProvider.GuildID
+	status := &cr.Status.AtProvider
+	if meta.GetExternalName(cr) != guildID || !e.applyAuditLog(ctx, guildID, status) {
+		if err := e.listBans(ctx, guildID, cr.Spec.ForProvider.ExpectedUserIDs, status); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	} else {
+		t := newBanTally(cr.Spec.ForProvider.ExpectedUserIDs)
+		for _, ban := range status.Bans {
+			t.add(ban)
+		}
+		t.record(status)
+	}
+
+	// The ban list is identified by its guild
+	if meta.GetExternalName(cr) != guildID {
+		meta.SetExternalName(cr, guildID)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*banv1alpha1.BanList); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBanList)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(_ context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*banv1alpha1.BanList); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBanList)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if _, ok := mg.(*banv1alpha1.BanList); !ok {
+		return managed.ExternalDelete{}, errors.New(errNotBanList)
+	}
+	// Deleting a BanList never lifts bans
+	return managed.ExternalDelete{}, nil
+}
+
+// listBans reads every ban of the guild into status, comparing them with the
+// expected user IDs. The newest audit log entry is noted first, so bans and
+// unbans made while the list is read are applied by the next poll.
+func (e *external) listBans(ctx context.Context, guildID string, expected []string, status *banv1alpha1.BanListObservation) error {
+	cursor := ""
+	if e.auditLog != nil {
+		limit := 1
+		if log, err := e.auditLog.GetGuildAuditLog(ctx, guildID, &discordclient.GetGuildAuditLogRequest{Limit: &limit}); err == nil {
+			// An empty audit log has no newest entry; every later entry
+			// comes after 0
+			cursor = "0"
+			if len(log.Entries) > 0 {
+				cursor = log.Entries[0].ID
+			}
+		}
+	}
+
+	// Bans are tallied as they are read, so no more than maxStatusBans of
+	// them are held in memory however large the guild
+	t := newBanTally(expected)
+	err := e.discord.ForEachGuildBan(ctx, guildID, func(ban *discordclient.Ban) error {
+		t.add(banv1alpha1.BanEntry{
+			UserID:   ban.User.ID,
+			Username: ban.User.Username,
+			Reason:   ban.Reason,
+		})
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list guild bans")
+	}
+
+	now := metav1.NewTime(e.now())
+	t.record(status)
+	status.LastAuditLogEntryID = cursor
+	status.LastFullSyncTime = &now
+	return nil
+}
+
+// applyAuditLog brings status up to date from the ban and unban entries of
+// the audit log after its cursor, and reports whether it could. It cannot
+// when there is no cursor, status omits the bans, the bans were last listed
+// in full a day ago, or the audit log cannot be read or has more new entries
+// than a poll reads.
+func (e *external) applyAuditLog(ctx context.Context, guildID string, status *banv1alpha1.BanListObservation) bool {
+	if e.auditLog == nil || status.LastAuditLogEntryID == "" || status.BansOmitted || status.LastFullSyncTime == nil ||
+		!e.now().Before(status.LastFullSyncTime.Add(fullSyncInterval)) {
+		return false
+	}
+
+	var entries []discordclient.AuditLogEntry
+	usernames := map[string]string{}
+	after := status.LastAuditLogEntryID
+	for page := 0; ; page++ {
+		if page == maxAuditLogPages {
+			return false
+		}
+		limit := auditLogPageSize
+		log, err := e.auditLog.GetGuildAuditLog(ctx, guildID, &discordclient.GetGuildAuditLogRequest{Limit: &limit, After: &after})
+		if err != nil {
+			return false
+		}
+		for _, u := range log.Users {
+			usernames[u.ID] = u.Username
+		}
+		entries = append(entries, log.Entries...)
+		if len(log.Entries) < limit {
+			break
+		}
+		sort.Slice(log.Entries, func(i, j int) bool { return olderID(log.Entries[i].ID, log.Entries[j].ID) })
+		after = log.Entries[len(log.Entries)-1].ID
+	}
+	if len(entries) == 0 {
+		return true
+	}
+	sort.Slice(entries, func(i, j int) bool { return olderID(entries[i].ID, entries[j].ID) })
+
+	bans := make(map[string]banv1alpha1.BanEntry, len(status.Bans))
+	for _, ban := range status.Bans {
+		bans[ban.UserID] = ban
+	}
+	for _, entry := range entries {
+		switch entry.ActionType {
+		case discordclient.AuditLogActionMemberBanAdd:
+			ban := banv1alpha1.BanEntry{UserID: entry.TargetID, Username: usernames[entry.TargetID]}
+			if entry.Reason != "" {
+				reason := entry.Reason
+				ban.Reason = &reason
+			}
+			bans[entry.TargetID] = ban
+		case discordclient.AuditLogActionMemberBanRemove:
+			delete(bans, entry.TargetID)
+		}
+	}
+
+	status.Bans = make([]banv1alpha1.BanEntry, 0, len(bans))
+	for _, ban := range bans {
+		status.Bans = append(status.Bans, ban)
+	}
+	sort.Slice(status.Bans, func(i, j int) bool { return olderID(status.Bans[i].UserID, status.Bans[j].UserID) })
+	status.LastAuditLogEntryID = entries[len(entries)-1].ID
+	return true
+}
+
+// olderID reports whether snowflake a was issued before b. Snowflakes grow
+// with time, so a shorter ID is older and IDs of equal length compare
+// lexically.
+func olderID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// A banTally compares a guild's bans with the expected user IDs as they are
+// read, keeping at most maxStatusBans of the bans and unexpected user IDs.
+type banTally struct {
+	expected   []string
+	isExpected map[string]bool
+	found      map[string]bool
+	count      int
+	bans       []banv1alpha1.BanEntry
+	unexpected []string
+}
+
+func newBanTally(expected []string) *banTally {
+	t := &banTally{expected: expected, isExpected: make(map[string]bool, len(expected)), found: map[string]bool{}}
+	for _, id := range expected {
+		t.isExpected[id] = true
+	}
+	return t
+}
+
+func (t *banTally) add(ban banv1alpha1.BanEntry) {
+	t.count++
+	if t.count <= maxStatusBans {
+		t.bans = append(t.bans, ban)
+	} else {
+		t.bans = nil
+	}
+
+	// Unexpected bans are only reported when an expected set is given
+	switch {
+	case t.isExpected[ban.UserID]:
+		t.found[ban.UserID] = true
+	case len(t.expected) > 0 && len(t.unexpected) < maxStatusBans:
+		t.unexpected = append(t.unexpected, ban.UserID)
+	}
+}
+
+// record writes the tally to status, omitting the bans when there were more
+// than maxStatusBans of them.
+func (t *banTally) record(status *banv1alpha1.BanListObservation) {
+	var missing []string
+	for _, id := range t.expected {
+		if !t.found[id] {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(t.unexpected)
+
+	status.BanCount = t.count
+	status.Bans = t.bans
+	status.BansOmitted = t.count > maxStatusBans
+	status.MissingUserIDs = missing
+	status.UnexpectedUserIDs = t.unexpected
+}
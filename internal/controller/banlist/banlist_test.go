@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package banlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	banv1alpha1 "github.com/rossigee/provider-discord/apis/ban/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const guildID = "111111111111111111"
+
+var now = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+type MockBanClient struct {
+	discordclient.BanClient
+	ForEachGuildBanFunc func(ctx context.Context, guildID string, fn func(*discordclient.Ban) error) error
+}
+
+func (m *MockBanClient) ForEachGuildBan(ctx context.Context, guildID string, fn func(*discordclient.Ban) error) error {
+	return m.ForEachGuildBanFunc(ctx, guildID, fn)
+}
+
+type MockAuditLogClient struct {
+	GetGuildAuditLogFunc func(ctx context.Context, guildID string, req *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error)
+}
+
+func (m *MockAuditLogClient) GetGuildAuditLog(ctx context.Context, guildID string, req *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error) {
+	return m.GetGuildAuditLogFunc(ctx, guildID, req)
+}
+
+func listing(bans ...discordclient.Ban) *MockBanClient {
+	return &MockBanClient{ForEachGuildBanFunc: func(_ context.Context, _ string, fn func(*discordclient.Ban) error) error {
+		for i := range bans {
+			if err := fn(&bans[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+func noListing(t *testing.T) *MockBanClient {
+	return &MockBanClient{ForEachGuildBanFunc: func(context.Context, string, func(*discordclient.Ban) error) error {
+		t.Error("Expected bans to be read from the audit log, not listed")
+		return nil
+	}}
+}
+
+func banList(status banv1alpha1.BanListObservation) *banv1alpha1.BanList {
+	cr := &banv1alpha1.BanList{
+		Spec: banv1alpha1.BanListSpec{ForProvider: banv1alpha1.BanListParameters{
+			GuildID:         guildID,
+			ExpectedUserIDs: []string{"200000000000000001"},
+		}},
+		Status: banv1alpha1.BanListStatus{AtProvider: status},
+	}
+	meta.SetExternalName(cr, guildID)
+	return cr
+}
+
+func TestObserveListsBans(t *testing.T) {
+	e := &external{
+		discord: listing(discordclient.Ban{User: discordclient.DiscordUser{ID: "200000000000000001", Username: "spammer"}}),
+		auditLog: &MockAuditLogClient{GetGuildAuditLogFunc: func(_ context.Context, _ string, req *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error) {
+			assert.Nil(t, req.After)
+			assert.Equal(t, 1, *req.Limit)
+			return &discordclient.AuditLog{Entries: []discordclient.AuditLogEntry{{ID: "300000000000000009"}}}, nil
+		}},
+		now: func() time.Time { return now },
+	}
+	cr := banList(banv1alpha1.BanListObservation{})
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	status := cr.Status.AtProvider
+	assert.Equal(t, 1, status.BanCount)
+	assert.Equal(t, "300000000000000009", status.LastAuditLogEntryID)
+	assert.Equal(t, now, status.LastFullSyncTime.Time)
+	assert.Empty(t, status.MissingUserIDs)
+}
+
+func TestObserveAppliesAuditLog(t *testing.T) {
+	var afters []string
+	e := &external{
+		discord: noListing(t),
+		auditLog: &MockAuditLogClient{GetGuildAuditLogFunc: func(_ context.Context, _ string, req *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error) {
+			afters = append(afters, *req.After)
+			return &discordclient.AuditLog{
+				// Newest first, as Discord returns them
+				Entries: []discordclient.AuditLogEntry{
+					{ID: "300000000000000012", ActionType: discordclient.AuditLogActionMemberBanAdd, TargetID: "200000000000000003", Reason: "raid"},
+					{ID: "300000000000000011", ActionType: 1},
+					{ID: "300000000000000010", ActionType: discordclient.AuditLogActionMemberBanRemove, TargetID: "200000000000000001"},
+				},
+				Users: []discordclient.User{{ID: "200000000000000003", Username: "raider"}},
+			}, nil
+		}},
+		now: func() time.Time { return now },
+	}
+	synced := metav1.NewTime(now.Add(-time.Hour))
+	cr := banList(banv1alpha1.BanListObservation{
+		Bans: []banv1alpha1.BanEntry{
+			{UserID: "200000000000000001", Username: "spammer"},
+			{UserID: "200000000000000002", Username: "troll"},
+		},
+		LastAuditLogEntryID: "300000000000000009",
+		LastFullSyncTime:    &synced,
+	})
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	status := cr.Status.AtProvider
+	assert.Equal(t, []string{"300000000000000009"}, afters)
+	assert.Equal(t, 2, status.BanCount)
+	assert.Equal(t, "200000000000000002", status.Bans[0].UserID)
+	assert.Equal(t, "200000000000000003", status.Bans[1].UserID)
+	assert.Equal(t, "raider", status.Bans[1].Username)
+	assert.Equal(t, "raid", *status.Bans[1].Reason)
+	assert.Equal(t, "300000000000000012", status.LastAuditLogEntryID)
+	assert.Equal(t, synced, *status.LastFullSyncTime)
+	assert.Equal(t, []string{"200000000000000001"}, status.MissingUserIDs)
+}
+
+func TestObserveFallsBackToListing(t *testing.T) {
+	recent := metav1.NewTime(now.Add(-time.Hour))
+	stale := metav1.NewTime(now.Add(-fullSyncInterval))
+
+	cases := map[string]struct {
+		status   banv1alpha1.BanListObservation
+		auditErr error
+		cursor   string
+	}{
+		"StaleFullSync": {
+			status: banv1alpha1.BanListObservation{LastAuditLogEntryID: "300000000000000009", LastFullSyncTime: &stale},
+			cursor: "300000000000000009",
+		},
+		"AuditLogUnreadable": {
+			status:   banv1alpha1.BanListObservation{LastAuditLogEntryID: "300000000000000009", LastFullSyncTime: &recent},
+			auditErr: errors.New("Discord API error: 403 - Missing Permissions"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				discord: listing(discordclient.Ban{User: discordclient.DiscordUser{ID: "200000000000000001"}}),
+				auditLog: &MockAuditLogClient{GetGuildAuditLogFunc: func(context.Context, string, *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error) {
+					if tc.auditErr != nil {
+						return nil, tc.auditErr
+					}
+					return &discordclient.AuditLog{Entries: []discordclient.AuditLogEntry{{ID: "300000000000000009"}}}, nil
+				}},
+				now: func() time.Time { return now },
+			}
+			cr := banList(tc.status)
+
+			_, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, 1, cr.Status.AtProvider.BanCount)
+			assert.Equal(t, tc.cursor, cr.Status.AtProvider.LastAuditLogEntryID)
+			assert.Equal(t, now, cr.Status.AtProvider.LastFullSyncTime.Time)
+		})
+	}
+}
+
+func TestObserveOmitsBansOfLargeGuilds(t *testing.T) {
+	bans := make([]discordclient.Ban, maxStatusBans+1)
+	for i := range bans {
+		bans[i].User.ID = fmt.Sprintf("2%017d", i+2)
+	}
+	auditReads := 0
+	e := &external{
+		discord: listing(bans...),
+		auditLog: &MockAuditLogClient{GetGuildAuditLogFunc: func(_ context.Context, _ string, req *discordclient.GetGuildAuditLogRequest) (*discordclient.AuditLog, error) {
+			auditReads++
+			assert.Nil(t, req.After, "Expected the audit log not to be applied to omitted bans")
+			return &discordclient.AuditLog{Entries: []discordclient.AuditLogEntry{{ID: "300000000000000009"}}}, nil
+		}},
+		now: func() time.Time { return now },
+	}
+	cr := banList(banv1alpha1.BanListObservation{})
+
+	for range 2 {
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		status := cr.Status.AtProvider
+		assert.Equal(t, maxStatusBans+1, status.BanCount)
+		assert.True(t, status.BansOmitted)
+		assert.Nil(t, status.Bans)
+		assert.Equal(t, []string{"200000000000000001"}, status.MissingUserIDs)
+		assert.Len(t, status.UnexpectedUserIDs, maxStatusBans)
+	}
+	assert.Equal(t, 2, auditReads)
+}
+
+func TestObserveComparesExpectedBans(t *testing.T) {
+	reason := "spam"
+	tests := []struct {
+		name               string
+		expected           []string
+		bans               []discordclient.Ban
+		expectedMissing    []string
+		expectedUnexpected []string
+	}{
+		{
+			name:     "all expected bans present",
+			expected: []string{"200000000000000001"},
+			bans:     []discordclient.Ban{{User: discordclient.DiscordUser{ID: "200000000000000001"}, Reason: &reason}},
+		},
+		{
+			name:     "missing and unexpected bans",
+			expected: []string{"200000000000000001", "200000000000000002"},
+			bans: []discordclient.Ban{
+				{User: discordclient.DiscordUser{ID: "200000000000000003"}},
+				{User: discordclient.DiscordUser{ID: "200000000000000001"}},
+			},
+			expectedMissing:    []string{"200000000000000002"},
+			expectedUnexpected: []string{"200000000000000003"},
+		},
+		{
+			name: "no expected set reports nothing unexpected",
+			bans: []discordclient.Ban{{User: discordclient.DiscordUser{ID: "200000000000000003"}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &external{discord: listing(tc.bans...), now: func() time.Time { return now }}
+			cr := banList(banv1alpha1.BanListObservation{})
+			cr.Spec.ForProvider.ExpectedUserIDs = tc.expected
+			meta.SetExternalName(cr, "")
+
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceExists)
+			assert.True(t, obs.ResourceUpToDate)
+			assert.Equal(t, guildID, meta.GetExternalName(cr))
+
+			status := cr.Status.AtProvider
+			assert.Equal(t, len(tc.bans), status.BanCount)
+			assert.Len(t, status.Bans, len(tc.bans))
+			assert.Equal(t, tc.expectedMissing, status.MissingUserIDs)
+			assert.Equal(t, tc.expectedUnexpected, status.UnexpectedUserIDs)
+		})
+	}
+}
+
+func TestObserveListError(t *testing.T) {
+	e := &external{
+		discord: &MockBanClient{ForEachGuildBanFunc: func(context.Context, string, func(*discordclient.Ban) error) error {
+			return errors.New("missing access")
+		}},
+		now: func() time.Time { return now },
+	}
+
+	_, err := e.Observe(context.Background(), banList(banv1alpha1.BanListObservation{}))
+	assert.EqualError(t, err, "failed to list guild bans: missing access")
+}
+
+func TestDeleteKeepsBans(t *testing.T) {
+	// The external client has no Discord client to lift bans with
+	e := &external{}
+
+	_, err := e.Delete(context.Background(), banList(banv1alpha1.BanListObservation{}))
+	assert.NoError(t, err)
+}
+
+func TestOlderID(t *testing.T) {
+	assert.True(t, olderID("99999999999999999", "100000000000000000"))
+	assert.True(t, olderID("100000000000000000", "100000000000000001"))
+	assert.False(t, olderID("100000000000000001", "100000000000000000"))
+	assert.False(t, olderID("100000000000000000", "100000000000000000"))
+}
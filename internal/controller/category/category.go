@@ -0,0 +1,515 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package category
+
+import (
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/controller/channel"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotCategory = "managed resource is not a Category custom resource"
+)
+
+// Setup adds a controller that reconciles Category managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	return SetupWithClient(mgr, o, t, clients.NewDiscordClient)
+}
+
+// SetupWithClient adds a controller that reconciles Category managed resources with a custom client factory.
+func SetupWithClient(mgr ctrl.Manager, o controller.Options, t tuning.Options, newServiceFn func(token string) *clients.DiscordClient) error {
+	name := managed.ControllerName(channelv1alpha1.CategoryGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(channelv1alpha1.CategoryGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube:         mgr.GetClient(),
+			newServiceFn: newServiceFn,
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(channelv1alpha1.CategoryGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&channelv1alpha1.Category{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	newServiceFn func(token string) *clients.DiscordClient
+}
+
+// Connect produces an ExternalClient using the credentials of the Category's
+// ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*channelv1alpha1.Category)
+	if !ok {
+		return nil, errors.New(errNotCategory)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := clients.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := c.newServiceFn(*token)
+
+	// The category and its channels are all read from one guild listing
+	return &external{service: clients.NewSnapshotClient(svc), permissions: svc}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service clients.ChannelClient
+	// permissions resolves the bot's guild permissions for pre-flight
+	// checks; the checks are skipped when it is nil.
+	permissions clients.PermissionClient
+}
+
+// find returns the category and its declared channels from the guild's
+// channel listing, adopting a category of the same name when the external
+// name is not yet a channel ID. Declared channels not yet in the category
+// are matched against uncategorized channels of the same name and type, so
+// they are moved in rather than duplicated. Missing channels are nil.
+func (c *external) find(ctx context.Context, cr *channelv1alpha1.Category) (*clients.Channel, []*clients.Channel, error) {
+	channels, err := c.service.ListGuildChannels(ctx, cr.Spec.ForProvider.GuildID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list guild channels")
+	}
+
+	var category *clients.Channel
+	externalName := meta.GetExternalName(cr)
+	for i := range channels {
+		ch := &channels[i]
+		if ch.Type != clients.ChannelTypeCategory {
+			continue
+		}
+		if snowflake.Valid(externalName) {
+			if ch.ID == externalName {
+				category = ch
+				break
+			}
+		} else if ch.Name == clients.NormalizeChannelName(cr.Spec.ForProvider.Name, clients.ChannelTypeCategory) {
+			meta.SetExternalName(cr, ch.ID)
+			category = ch
+			break
+		}
+	}
+	if category == nil {
+		return nil, nil, nil
+	}
+
+	declared := make([]*clients.Channel, len(cr.Spec.ForProvider.Channels))
+	for i, want := range cr.Spec.ForProvider.Channels {
+		name := clients.NormalizeChannelName(want.Name, want.Type)
+		for j := range channels {
+			ch := &channels[j]
+			if ch.Type != want.Type || ch.Name != name {
+				continue
+			}
+			if ch.ParentID == category.ID {
+				declared[i] = ch
+				break
+			}
+			if ch.ParentID == "" && declared[i] == nil {
+				declared[i] = ch
+			}
+		}
+	}
+	return category, declared, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*channelv1alpha1.Category)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCategory)
+	}
+
+	category, declared, err := c.find(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if category == nil {
+		// Forget a deleted category so Crossplane creates a new one
+		meta.SetExternalName(cr, "")
+		cr.Status.AtProvider = channelv1alpha1.CategoryObservation{}
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = channelv1alpha1.CategoryObservation{
+		ID:       category.ID,
+		Name:     category.Name,
+		Position: category.Position,
+	}
+	upToDate := categoryUpToDate(cr.Spec.ForProvider, category)
+	order := positions(category.ID, declared)
+	for i, want := range cr.Spec.ForProvider.Channels {
+		got := declared[i]
+		if got == nil {
+			upToDate = false
+			continue
+		}
+		cr.Status.AtProvider.Channels = append(cr.Status.AtProvider.Channels, channelv1alpha1.CategoryChannelObservation{
+			ID:       got.ID,
+			Name:     got.Name,
+			Type:     got.Type,
+			Position: got.Position,
+		})
+		if !channelUpToDate(cr.Spec.ForProvider, category.ID, order[i], want, got) {
+			upToDate = false
+		}
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// categoryUpToDate reports whether the category channel itself matches the
+// desired parameters.
+func categoryUpToDate(p channelv1alpha1.CategoryParameters, category *clients.Channel) bool {
+	if clients.NormalizeChannelName(p.Name, clients.ChannelTypeCategory) != category.Name {
+		return false
+	}
+	if p.Position != nil && *p.Position != category.Position {
+		return false
+	}
+	return overwritesUpToDate(p.PermissionOverwrites, category.PermissionOverwrites)
+}
+
+// positions returns the position each declared channel should take.
+// Positions are guild-wide, so only the relative order of the declared
+// channels is managed: while they are all in the category in their declared
+// order they keep their positions, otherwise they are numbered from the
+// lowest position among them.
+func positions(categoryID string, declared []*clients.Channel) []int {
+	out := make([]int, len(declared))
+	base := -1
+	ordered := true
+	var prev *clients.Channel
+	for i, got := range declared {
+		if got == nil || got.ParentID != categoryID {
+			ordered = false
+			continue
+		}
+		out[i] = got.Position
+		if base == -1 || got.Position < base {
+			base = got.Position
+		}
+		// Discord orders channels of equal position by ID
+		if prev != nil && !before(prev, got) {
+			ordered = false
+		}
+		prev = got
+	}
+	if ordered {
+		return out
+	}
+	if base == -1 {
+		base = 0
+	}
+	for i := range out {
+		out[i] = base + i
+	}
+	return out
+}
+
+// before reports whether channel a is listed above channel b.
+func before(a, b *clients.Channel) bool {
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	if len(a.ID) != len(b.ID) {
+		return len(a.ID) < len(b.ID)
+	}
+	return a.ID < b.ID
+}
+
+// channelUpToDate reports whether the declared channel is in the category,
+// at the given position, and matches the declared fields and the category's
+// permission overwrites.
+func channelUpToDate(p channelv1alpha1.CategoryParameters, categoryID string, position int, want channelv1alpha1.CategoryChannel, got *clients.Channel) bool {
+	return got.ParentID == categoryID && got.Position == position && channelSettingsUpToDate(p, want, got)
+}
+
+// channelSettingsUpToDate reports whether the declared channel matches the
+// declared fields and the category's permission overwrites, wherever it is.
+func channelSettingsUpToDate(p channelv1alpha1.CategoryParameters, want channelv1alpha1.CategoryChannel, got *clients.Channel) bool {
+	if want.Topic != nil && (got.Topic == nil || *want.Topic != *got.Topic) {
+		return false
+	}
+	if want.NSFW != nil && *want.NSFW != got.NSFW {
+		return false
+	}
+	return overwritesUpToDate(p.PermissionOverwrites, got.PermissionOverwrites)
+}
+
+// overwritesUpToDate compares permission overwrites regardless of order.
+// Overwrites are only managed when the spec sets them.
+func overwritesUpToDate(want []channelv1alpha1.PermissionOverwrite, got []clients.PermissionOverwrite) bool {
+	if len(want) == 0 {
+		return true
+	}
+	if len(want) != len(got) {
+		return false
+	}
+	observed := make(map[string]clients.PermissionOverwrite, len(got))
+	for _, pw := range got {
+		observed[pw.ID] = pw
+	}
+	for _, pw := range want {
+		o, ok := observed[pw.ID]
+		if !ok || (o.Type == 0) != (pw.Type == "role") || !channel.OverwriteBitsMatch(pw.Allow, o.Allow) || !channel.OverwriteBitsMatch(pw.Deny, o.Deny) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPermissions verifies the bot holds the guild permissions needed to
+// manage the category and its channels before calling Discord.
+func (c *external) checkPermissions(ctx context.Context, cr *channelv1alpha1.Category) error {
+	if c.permissions == nil {
+		return nil
+	}
+	required := clients.PermissionManageChannels
+	if len(cr.Spec.ForProvider.PermissionOverwrites) > 0 {
+		required |= clients.PermissionManageRoles
+	}
+	return clients.RequirePermissions(ctx, c.permissions, cr.Spec.ForProvider.GuildID, required)
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*channelv1alpha1.Category)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCategory)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	if err := c.checkPermissions(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// The category and all of its channels count towards the guild's limit
+	if err := clients.CheckChannelCount(ctx, c.service, cr.Spec.ForProvider.GuildID, 1+len(cr.Spec.ForProvider.Channels)); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	category, err := c.service.CreateChannel(ctx, &clients.CreateChannelRequest{
+		Name:                 cr.Spec.ForProvider.Name,
+		Type:                 clients.ChannelTypeCategory,
+		GuildID:              cr.Spec.ForProvider.GuildID,
+		Position:             cr.Spec.ForProvider.Position,
+		PermissionOverwrites: channel.ToClientOverwrites(cr.Spec.ForProvider.PermissionOverwrites),
+	})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "failed to create category")
+	}
+	meta.SetExternalName(cr, category.ID)
+
+	// The category must exist before its channels can be created in it
+	if err := c.syncChannels(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*channelv1alpha1.Category)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCategory)
+	}
+
+	if err := c.checkPermissions(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{}, c.syncChannels(ctx, cr)
+}
+
+// syncChannels brings the category and then each declared channel, in
+// order, in line with the spec. Channels are moved into place together in
+// one request.
+func (c *external) syncChannels(ctx context.Context, cr *channelv1alpha1.Category) error {
+	// The moves are computed from the guild's current order, so other
+	// categories of the guild wait until they are sent
+	unlock, err := clients.LockGuild(ctx, cr.Spec.ForProvider.GuildID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	category, declared, err := c.find(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return errors.Errorf("category %s not found", meta.GetExternalName(cr))
+	}
+	overwrites := channel.ToClientOverwrites(cr.Spec.ForProvider.PermissionOverwrites)
+
+	missing := 0
+	for _, got := range declared {
+		if got == nil {
+			missing++
+		}
+	}
+	if missing > 0 {
+		if err := clients.CheckChannelCount(ctx, c.service, cr.Spec.ForProvider.GuildID, missing); err != nil {
+			return err
+		}
+	}
+
+	if !categoryUpToDate(cr.Spec.ForProvider, category) {
+		if _, err := c.service.ModifyChannel(ctx, category.ID, &clients.ModifyChannelRequest{
+			Name:                 &cr.Spec.ForProvider.Name,
+			Position:             cr.Spec.ForProvider.Position,
+			PermissionOverwrites: overwrites,
+		}); err != nil {
+			return errors.Wrap(err, "failed to update category")
+		}
+	}
+
+	order := positions(category.ID, declared)
+	var moves []clients.ChannelPosition
+	for i, want := range cr.Spec.ForProvider.Channels {
+		position := order[i]
+		got := declared[i]
+		if got == nil {
+			if _, err := c.service.CreateChannel(ctx, &clients.CreateChannelRequest{
+				Name:                 want.Name,
+				Type:                 want.Type,
+				GuildID:              cr.Spec.ForProvider.GuildID,
+				Topic:                want.Topic,
+				NSFW:                 want.NSFW,
+				Position:             &position,
+				ParentID:             &category.ID,
+				PermissionOverwrites: overwrites,
+			}); err != nil {
+				return errors.Wrapf(err, "failed to create channel %s", want.Name)
+			}
+			continue
+		}
+		if got.ParentID != category.ID || got.Position != position {
+			moves = append(moves, clients.ChannelPosition{ID: got.ID, Position: &position, ParentID: &category.ID})
+		}
+		if channelSettingsUpToDate(cr.Spec.ForProvider, want, got) {
+			continue
+		}
+		if _, err := c.service.ModifyChannel(ctx, got.ID, &clients.ModifyChannelRequest{
+			Topic:                want.Topic,
+			NSFW:                 want.NSFW,
+			PermissionOverwrites: overwrites,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update channel %s", want.Name)
+		}
+	}
+	if len(moves) > 0 {
+		if err := c.service.ModifyGuildChannelPositions(ctx, cr.Spec.ForProvider.GuildID, moves); err != nil {
+			return errors.Wrap(err, "failed to move channels")
+		}
+	}
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*channelv1alpha1.Category)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotCategory)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	category, declared, err := c.find(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	if category == nil {
+		return managed.ExternalDelete{}, nil
+	}
+
+	// Only channels in the category are deleted; a declared channel that
+	// was never moved in belongs to someone else
+	var children []*clients.Channel
+	for _, ch := range declared {
+		if ch != nil && ch.ParentID == category.ID {
+			children = append(children, ch)
+		}
+	}
+
+	// Check every channel before deleting any, so a refusal never leaves
+	// the category half deleted
+	if cr.Spec.ForProvider.AllowDelete == nil || !*cr.Spec.ForProvider.AllowDelete {
+		for _, ch := range children {
+			hasMessages, err := c.service.HasMessages(ctx, ch.ID)
+			if err != nil {
+				return managed.ExternalDelete{}, errors.Wrapf(err, "failed to check messages of channel %s", ch.Name)
+			}
+			if hasMessages {
+				return managed.ExternalDelete{}, fmt.Errorf("cannot delete channel %s with message history. Set spec.forProvider.allowDelete=true to confirm deletion has been reviewed and approved", ch.Name)
+			}
+		}
+	}
+
+	// Channels go before their category so none is left uncategorized
+	for _, ch := range children {
+		if err := c.service.DeleteChannel(ctx, ch.ID); err != nil && !clients.IsNotFound(err) {
+			return managed.ExternalDelete{}, errors.Wrapf(err, "failed to delete channel %s", ch.Name)
+		}
+	}
+	if err := c.service.DeleteChannel(ctx, category.ID); err != nil && !clients.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete category")
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	// Nothing to disconnect for Discord API client
+	return nil
+}
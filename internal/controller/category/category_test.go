@@ -0,0 +1,438 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package category
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+const (
+	guildID    = "123456789012345678"
+	categoryID = "223456789012345678"
+	chatID     = "323456789012345678"
+	logID      = "423456789012345678"
+)
+
+// MockChannelClient implements a mock Discord client for testing
+type MockChannelClient struct {
+	CreateChannelFunc     func(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error)
+	GetChannelFunc        func(ctx context.Context, channelID string) (*discordclient.Channel, error)
+	ModifyChannelFunc     func(ctx context.Context, channelID string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error)
+	DeleteChannelFunc     func(ctx context.Context, channelID string) error
+	ListGuildChannelsFunc func(ctx context.Context, guildID string) ([]discordclient.Channel, error)
+	HasMessagesFunc       func(ctx context.Context, channelID string) (bool, error)
+
+	ModifyGuildChannelPositionsFunc func(ctx context.Context, guildID string, positions []discordclient.ChannelPosition) error
+}
+
+// Ensure MockChannelClient implements ChannelClient interface
+var _ discordclient.ChannelClient = (*MockChannelClient)(nil)
+
+func (m *MockChannelClient) CreateChannel(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+	if m.CreateChannelFunc != nil {
+		return m.CreateChannelFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockChannelClient) GetChannel(ctx context.Context, channelID string) (*discordclient.Channel, error) {
+	if m.GetChannelFunc != nil {
+		return m.GetChannelFunc(ctx, channelID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockChannelClient) ModifyChannel(ctx context.Context, channelID string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+	if m.ModifyChannelFunc != nil {
+		return m.ModifyChannelFunc(ctx, channelID, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockChannelClient) DeleteChannel(ctx context.Context, channelID string) error {
+	if m.DeleteChannelFunc != nil {
+		return m.DeleteChannelFunc(ctx, channelID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockChannelClient) ListGuildChannels(ctx context.Context, guildID string) ([]discordclient.Channel, error) {
+	if m.ListGuildChannelsFunc != nil {
+		return m.ListGuildChannelsFunc(ctx, guildID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockChannelClient) ModifyGuildChannelPositions(ctx context.Context, guildID string, positions []discordclient.ChannelPosition) error {
+	if m.ModifyGuildChannelPositionsFunc != nil {
+		return m.ModifyGuildChannelPositionsFunc(ctx, guildID, positions)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockChannelClient) HasMessages(ctx context.Context, channelID string) (bool, error) {
+	if m.HasMessagesFunc != nil {
+		return m.HasMessagesFunc(ctx, channelID)
+	}
+	return false, errors.New("not implemented")
+}
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func strPtr(s string) *string { return &s }
+
+func boolPtr(b bool) *bool { return &b }
+
+func newCategory(externalName string) *channelv1alpha1.Category {
+	return &channelv1alpha1.Category{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "staff",
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: externalName},
+		},
+		Spec: channelv1alpha1.CategorySpec{
+			ForProvider: channelv1alpha1.CategoryParameters{
+				Name:    "STAFF",
+				GuildID: guildID,
+				PermissionOverwrites: []channelv1alpha1.PermissionOverwrite{
+					{ID: guildID, Type: "role", Deny: int64Ptr(1024)},
+				},
+				Channels: []channelv1alpha1.CategoryChannel{
+					{Name: "mod-chat", Topic: strPtr("Moderator discussion")},
+					{Name: "Mod Log"},
+				},
+			},
+		},
+	}
+}
+
+// syncedChannels returns a guild listing in which the category and its
+// channels match newCategory.
+func syncedChannels() []discordclient.Channel {
+	overwrites := []discordclient.PermissionOverwrite{{ID: guildID, Type: 0, Deny: "1024"}}
+	return []discordclient.Channel{
+		{ID: "999999999999999999", Type: discordclient.ChannelTypeText, Name: "general"},
+		{ID: categoryID, Type: discordclient.ChannelTypeCategory, Name: "STAFF", PermissionOverwrites: overwrites},
+		{ID: chatID, Type: discordclient.ChannelTypeText, Name: "mod-chat", ParentID: categoryID, Position: 0, Topic: strPtr("Moderator discussion"), PermissionOverwrites: overwrites},
+		{ID: logID, Type: discordclient.ChannelTypeText, Name: "mod-log", ParentID: categoryID, Position: 1, PermissionOverwrites: overwrites},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	tests := []struct {
+		name                 string
+		externalName         string
+		channels             func() []discordclient.Channel
+		expectedExists       bool
+		expectedUpToDate     bool
+		expectedExternalName string
+		expectedChannels     int
+	}{
+		{
+			name:                 "category and channels in sync",
+			externalName:         categoryID,
+			channels:             syncedChannels,
+			expectedExists:       true,
+			expectedUpToDate:     true,
+			expectedExternalName: categoryID,
+			expectedChannels:     2,
+		},
+		{
+			name:         "fields the spec leaves unset changed in Discord",
+			externalName: categoryID,
+			channels: func() []discordclient.Channel {
+				c := syncedChannels()
+				c[1].Position = 5
+				c[3].Topic, c[3].NSFW = strPtr("Set by hand"), true
+				return c
+			},
+			expectedExists:       true,
+			expectedUpToDate:     true,
+			expectedExternalName: categoryID,
+			expectedChannels:     2,
+		},
+		{
+			name:                 "adopts category by name",
+			externalName:         "staff",
+			channels:             syncedChannels,
+			expectedExists:       true,
+			expectedUpToDate:     true,
+			expectedExternalName: categoryID,
+			expectedChannels:     2,
+		},
+		{
+			name:         "channel missing",
+			externalName: categoryID,
+			channels: func() []discordclient.Channel {
+				return syncedChannels()[:3]
+			},
+			expectedExists:       true,
+			expectedUpToDate:     false,
+			expectedExternalName: categoryID,
+			expectedChannels:     1,
+		},
+		{
+			name:         "channels in order at guild-wide positions",
+			externalName: categoryID,
+			channels: func() []discordclient.Channel {
+				c := syncedChannels()
+				c[2].Position, c[3].Position = 7, 9
+				return c
+			},
+			expectedExists:       true,
+			expectedUpToDate:     true,
+			expectedExternalName: categoryID,
+			expectedChannels:     2,
+		},
+		{
+			name:         "channels out of order",
+			externalName: categoryID,
+			channels: func() []discordclient.Channel {
+				c := syncedChannels()
+				c[2].Position, c[3].Position = 1, 0
+				return c
+			},
+			expectedExists:       true,
+			expectedUpToDate:     false,
+			expectedExternalName: categoryID,
+			expectedChannels:     2,
+		},
+		{
+			name:         "channel overwrites not synced",
+			externalName: categoryID,
+			channels: func() []discordclient.Channel {
+				c := syncedChannels()
+				c[3].PermissionOverwrites = nil
+				return c
+			},
+			expectedExists:       true,
+			expectedUpToDate:     false,
+			expectedExternalName: categoryID,
+			expectedChannels:     2,
+		},
+		{
+			name:         "category deleted in Discord",
+			externalName: categoryID,
+			channels: func() []discordclient.Channel {
+				return syncedChannels()[:1]
+			},
+			expectedExists:       false,
+			expectedExternalName: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockChannelClient{
+				ListGuildChannelsFunc: func(ctx context.Context, id string) ([]discordclient.Channel, error) {
+					assert.Equal(t, guildID, id)
+					return tc.channels(), nil
+				},
+			}
+			e := &external{service: mock}
+			cr := newCategory(tc.externalName)
+
+			obs, err := e.Observe(context.Background(), cr)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExists, obs.ResourceExists)
+			assert.Equal(t, tc.expectedUpToDate, obs.ResourceUpToDate)
+			assert.Equal(t, tc.expectedExternalName, meta.GetExternalName(cr))
+			assert.Len(t, cr.Status.AtProvider.Channels, tc.expectedChannels)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	var created []*discordclient.CreateChannelRequest
+	listed := []discordclient.Channel{{ID: "999999999999999999", Type: discordclient.ChannelTypeText, Name: "general"}}
+	mock := &MockChannelClient{
+		ListGuildChannelsFunc: func(ctx context.Context, id string) ([]discordclient.Channel, error) {
+			return listed, nil
+		},
+		CreateChannelFunc: func(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+			created = append(created, req)
+			if req.Type == discordclient.ChannelTypeCategory {
+				listed = append(listed, discordclient.Channel{ID: categoryID, Type: req.Type, Name: req.Name, PermissionOverwrites: req.PermissionOverwrites})
+				return &discordclient.Channel{ID: categoryID, Type: req.Type, Name: req.Name}, nil
+			}
+			return &discordclient.Channel{ID: chatID, Type: req.Type, Name: req.Name}, nil
+		},
+	}
+	e := &external{service: mock}
+	cr := newCategory("staff")
+
+	_, err := e.Create(context.Background(), cr)
+
+	require.NoError(t, err)
+	assert.Equal(t, categoryID, meta.GetExternalName(cr))
+	require.Len(t, created, 3)
+	assert.Equal(t, discordclient.ChannelTypeCategory, created[0].Type)
+	for i, req := range created[1:] {
+		assert.Equal(t, cr.Spec.ForProvider.Channels[i].Name, req.Name)
+		require.NotNil(t, req.ParentID)
+		assert.Equal(t, categoryID, *req.ParentID)
+		require.NotNil(t, req.Position)
+		assert.Equal(t, i, *req.Position)
+		assert.Equal(t, created[0].PermissionOverwrites, req.PermissionOverwrites)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	listed := syncedChannels()
+	// mod-log exists but is uncategorized, and mod-chat lost its overwrites
+	listed[3].ParentID = ""
+	listed[2].PermissionOverwrites = nil
+
+	modified := map[string]*discordclient.ModifyChannelRequest{}
+	moved := map[string]discordclient.ChannelPosition{}
+	moves := 0
+	mock := &MockChannelClient{
+		ListGuildChannelsFunc: func(ctx context.Context, id string) ([]discordclient.Channel, error) {
+			return listed, nil
+		},
+		ModifyChannelFunc: func(ctx context.Context, id string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+			modified[id] = req
+			return &discordclient.Channel{ID: id}, nil
+		},
+		ModifyGuildChannelPositionsFunc: func(ctx context.Context, id string, positions []discordclient.ChannelPosition) error {
+			assert.Equal(t, guildID, id)
+			moves++
+			for _, p := range positions {
+				moved[p.ID] = p
+			}
+			return nil
+		},
+	}
+	e := &external{service: mock}
+
+	_, err := e.Update(context.Background(), newCategory(categoryID))
+
+	require.NoError(t, err)
+	assert.NotContains(t, modified, categoryID)
+	require.Contains(t, modified, chatID)
+	assert.Len(t, modified[chatID].PermissionOverwrites, 1)
+	assert.Nil(t, modified[chatID].Position)
+	assert.NotContains(t, modified, logID)
+	assert.Equal(t, 1, moves)
+	assert.NotContains(t, moved, chatID)
+	require.Contains(t, moved, logID)
+	assert.Equal(t, categoryID, *moved[logID].ParentID)
+	assert.Equal(t, 1, *moved[logID].Position)
+}
+
+func TestUpdateReordersChannels(t *testing.T) {
+	listed := syncedChannels()
+	listed[2].Position, listed[3].Position = 9, 7
+
+	modified := map[string]*discordclient.ModifyChannelRequest{}
+	moved := map[string]discordclient.ChannelPosition{}
+	moves := 0
+	mock := &MockChannelClient{
+		ListGuildChannelsFunc: func(ctx context.Context, id string) ([]discordclient.Channel, error) {
+			return listed, nil
+		},
+		ModifyChannelFunc: func(ctx context.Context, id string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+			modified[id] = req
+			return &discordclient.Channel{ID: id}, nil
+		},
+		ModifyGuildChannelPositionsFunc: func(ctx context.Context, id string, positions []discordclient.ChannelPosition) error {
+			assert.Equal(t, guildID, id)
+			moves++
+			for _, p := range positions {
+				moved[p.ID] = p
+			}
+			return nil
+		},
+	}
+	e := &external{service: mock}
+
+	_, err := e.Update(context.Background(), newCategory(categoryID))
+
+	require.NoError(t, err)
+	assert.Empty(t, modified)
+	// Both channels are moved in one request
+	assert.Equal(t, 1, moves)
+	require.Contains(t, moved, chatID)
+	assert.Equal(t, 7, *moved[chatID].Position)
+	require.Contains(t, moved, logID)
+	assert.Equal(t, 8, *moved[logID].Position)
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowDelete     *bool
+		hasMessages     bool
+		expectError     bool
+		expectedDeleted []string
+	}{
+		{
+			name:            "channels before category",
+			expectedDeleted: []string{chatID, logID, categoryID},
+		},
+		{
+			name:        "channel with messages",
+			hasMessages: true,
+			expectError: true,
+		},
+		{
+			name:            "channel with messages allowed",
+			allowDelete:     boolPtr(true),
+			hasMessages:     true,
+			expectedDeleted: []string{chatID, logID, categoryID},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted []string
+			mock := &MockChannelClient{
+				ListGuildChannelsFunc: func(ctx context.Context, id string) ([]discordclient.Channel, error) {
+					return syncedChannels(), nil
+				},
+				HasMessagesFunc: func(ctx context.Context, id string) (bool, error) {
+					return tc.hasMessages, nil
+				},
+				DeleteChannelFunc: func(ctx context.Context, id string) error {
+					deleted = append(deleted, id)
+					return nil
+				},
+			}
+			e := &external{service: mock}
+			cr := newCategory(categoryID)
+			cr.Spec.ForProvider.AllowDelete = tc.allowDelete
+
+			_, err := e.Delete(context.Background(), cr)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, deleted)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDeleted, deleted)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package channel
 
 import (
 	"context"
+	"fmt"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -28,12 +29,13 @@ import (
 	"github.com/pkg/errors"
 	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
 	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"regexp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -44,19 +46,26 @@ const (
 	errGetCreds     = "cannot get credentials"
 )
 
-var (
-	// Discord snowflake IDs are 18-19 digit numbers
-	discordSnowflakeRegex = regexp.MustCompile(`^\d{18,19}$`)
-)
-
 // isValidDiscordID checks if the provided string is a valid Discord snowflake ID
 func isValidDiscordID(id string) bool {
-	return discordSnowflakeRegex.MatchString(id)
+	return snowflake.Valid(id)
 }
 
-// isDiscordNotFound reports whether a Discord API error is a 404 not-found response.
-func isDiscordNotFound(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "Discord API error: 404")
+// observeDeleted handles a channel that was deleted in Discord according to its
+// recreate policy. Channels that were never observed have not been created
+// yet.
+func observeDeleted(cr *channelv1alpha1.Channel) managed.ExternalObservation {
+	if cr.Status.AtProvider.ID == "" {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+	if p := cr.Spec.ForProvider.RecreatePolicy; p != nil && *p == channelv1alpha1.RecreatePolicyMarkUnavailable {
+		cr.SetConditions(conditions.DeletedExternally(fmt.Sprintf("channel %s was deleted in Discord; clear the external name to recreate it", meta.GetExternalName(cr))))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	}
+	// Forget the deleted channel so Crossplane creates a new one
+	meta.SetExternalName(cr, "")
+	cr.Status.AtProvider.ID = ""
+	return managed.ExternalObservation{ResourceExists: false}
 }
 
 // checkChannelExistsByName checks if a channel with the same name already exists in the guild
@@ -73,7 +82,7 @@ func (c *external) checkChannelExistsByName(ctx context.Context, cr *channelv1al
 
 	// Check if any existing channel has the same name
 	for _, channel := range channels {
-		if channel.Name == cr.Spec.ForProvider.Name {
+		if channel.Name == clients.NormalizeChannelName(declaredName(cr.Spec.ForProvider), cr.Spec.ForProvider.Type) {
 			log.V(4).Info("Found existing channel by name, adopting", "name", channel.Name, "id", channel.ID)
 
 			// Set the external name to the existing channel's ID
@@ -88,21 +97,26 @@ func (c *external) checkChannelExistsByName(ctx context.Context, cr *channelv1al
 				GuildID:   channel.GuildID,
 				Position:  channel.Position,
 				ParentID:  channel.ParentID,
+				CreatedAt: clients.CreatedAt(channel.ID),
 				UpdatedAt: now,
+
+				SanitizedName: sanitizedName(cr.Spec.ForProvider),
 			}
 
 			// Since we matched by name, only position and parentID can differ
-			needsUpdate := false
-			if cr.Spec.ForProvider.Position != nil && *cr.Spec.ForProvider.Position != channel.Position {
-				needsUpdate = true
+			var changes []string
+			if p := cr.Spec.ForProvider.Position; p != nil && *p != channel.Position {
+				changes = append(changes, conditions.Change("position", channel.Position, *p))
 			}
-			if cr.Spec.ForProvider.ParentID != nil && *cr.Spec.ForProvider.ParentID != channel.ParentID {
-				needsUpdate = true
+			if p := cr.Spec.ForProvider.ParentID; p != nil && *p != channel.ParentID {
+				changes = append(changes, conditions.Change("parentId", channel.ParentID, *p))
 			}
 
 			return managed.ExternalObservation{
-				ResourceExists:   true,
-				ResourceUpToDate: !needsUpdate,
+				ResourceExists:    true,
+				ResourceUpToDate:  len(changes) == 0,
+				ConnectionDetails: connectionDetails(channel.ID),
+				Diff:              conditions.Diff(changes),
 			}, nil
 		}
 	}
@@ -116,27 +130,28 @@ func (c *external) checkChannelExistsByName(ctx context.Context, cr *channelv1al
 }
 
 // Setup adds a controller that reconciles Channel managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	return SetupWithClient(mgr, o, clients.NewDiscordClient)
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	return SetupWithClient(mgr, o, t, clients.NewDiscordClient)
 }
 
 // SetupWithClient adds a controller that reconciles Channel managed resources with a custom client factory.
-func SetupWithClient(mgr ctrl.Manager, o controller.Options, newServiceFn func(token string) *clients.DiscordClient) error {
+func SetupWithClient(mgr ctrl.Manager, o controller.Options, t tuning.Options, newServiceFn func(token string) *clients.DiscordClient) error {
 	name := managed.ControllerName(channelv1alpha1.ChannelGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(channelv1alpha1.ChannelGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:         mgr.GetClient(),
 			newServiceFn: newServiceFn,
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(channelv1alpha1.ChannelGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&channelv1alpha1.Channel{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -170,14 +185,25 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	svc := c.newServiceFn(*token)
+	snap := clients.NewSnapshotClient(svc)
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: snap, permissions: svc, guilds: snap, invites: svc, kube: c.kube}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	service clients.ChannelClient
+	// permissions resolves the bot's guild permissions for pre-flight
+	// checks; the checks are skipped when it is nil.
+	permissions clients.PermissionClient
+	// guilds resolves the guild's premium tier, from the guild snapshot, to
+	// check tier limits before calling Discord; the checks are skipped when
+	// it is nil.
+	guilds clients.GuildGetter
+	// invites lists the channel's invites when the spec asks for them to be
+	// reported; they are not reported when it is nil.
+	invites clients.InviteClient
 	kube    client.Client
 }
 
@@ -207,9 +233,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	// If we have a valid external name (Discord channel ID), try to get by ID
 	channel, err := c.service.GetChannel(ctx, externalName)
 	if err != nil {
-		if isDiscordNotFound(err) {
-			// Channel was deleted externally; let Crossplane recreate it
-			return managed.ExternalObservation{ResourceExists: false}, nil
+		// An unknown guild means the bot lost access, not that the channel is
+		// gone; recreating it would fail the same way
+		if clients.IsNotFound(err) && !clients.IsBotNotInGuild(err) {
+			return observeDeleted(cr), nil
 		}
 		// Propagate transient errors (rate-limit, 5xx, network) so Crossplane
 		// retries rather than accidentally provisioning a duplicate channel
@@ -231,7 +258,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		GuildID:   channel.GuildID,
 		Position:  channel.Position,
 		ParentID:  channel.ParentID,
+		Flags:     clients.ChannelFlagNames(channel.Flags),
+		CreatedAt: clients.CreatedAt(channel.ID),
 		UpdatedAt: now,
+
+		SanitizedName: sanitizedName(cr.Spec.ForProvider),
 	}
 	// Populate permission overwrites in status
 	if len(channel.PermissionOverwrites) > 0 {
@@ -269,68 +300,248 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		cr.Status.AtProvider.HasMessages = &hasMessages
 	}
 
+	c.setInvites(ctx, cr)
+
 	// Late initialization: populate spec fields from observed state if not set
 	lateInitialized := false
 	if cr.Spec.ForProvider.GuildID == "" && channel.GuildID != "" {
 		cr.Spec.ForProvider.GuildID = channel.GuildID
 		lateInitialized = true
 	}
-	if cr.Spec.ForProvider.Type == 0 && channel.Type != 0 {
-		cr.Spec.ForProvider.Type = channel.Type
-		lateInitialized = true
+
+	p, err := c.withPreset(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
+	changes := channelChanges(p, channel)
 
-	// Check if we need to update
-	needsUpdate := cr.Spec.ForProvider.Name != channel.Name
-	if cr.Spec.ForProvider.Position != nil && *cr.Spec.ForProvider.Position != channel.Position {
-		needsUpdate = true
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        len(changes) == 0,
+		Diff:                    conditions.Diff(changes),
+		ResourceLateInitialized: lateInitialized,
+		ConnectionDetails:       connectionDetails(channel.ID),
+	}, nil
+}
+
+// connectionDetails publishes the channel's ID and the syntax that links to
+// it in messages, for bots and notifiers that post to the channel.
+func connectionDetails(id string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		"channelId":      []byte(id),
+		"channelMention": []byte(clients.ChannelMention(id)),
 	}
-	if cr.Spec.ForProvider.ParentID != nil && *cr.Spec.ForProvider.ParentID != channel.ParentID {
-		needsUpdate = true
+}
+
+// setInvites reports the channel's active invites when the spec asks for
+// them. Like HasMessages, a failure to list them is not fatal.
+func (c *external) setInvites(ctx context.Context, cr *channelv1alpha1.Channel) {
+	if c.invites == nil || cr.Spec.ForProvider.ReportInvites == nil || !*cr.Spec.ForProvider.ReportInvites {
+		return
 	}
-	// Check if permission overwrites differ
-	if len(cr.Spec.ForProvider.PermissionOverwrites) != len(channel.PermissionOverwrites) {
-		needsUpdate = true
-	} else {
-		for i, pw := range cr.Spec.ForProvider.PermissionOverwrites {
-			if i >= len(channel.PermissionOverwrites) {
-				needsUpdate = true
-				break
+	invites, err := c.invites.GetChannelInvites(ctx, cr.Status.AtProvider.ID)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(2).Info("Failed to list channel invites", "error", err)
+		return
+	}
+	for _, inv := range invites {
+		obs := channelv1alpha1.ChannelInviteObservation{
+			Code:      inv.Code,
+			Uses:      inv.Uses,
+			MaxUses:   inv.MaxUses,
+			MaxAge:    inv.MaxAge,
+			Temporary: inv.Temporary,
+		}
+		if inv.Inviter != nil {
+			obs.InviterID = inv.Inviter.ID
+		}
+		if inv.ExpiresAt != nil {
+			if t, err := time.Parse(time.RFC3339, *inv.ExpiresAt); err == nil {
+				obs.ExpiresAt = &metav1.Time{Time: t}
 			}
+		}
+		cr.Status.AtProvider.Invites = append(cr.Status.AtProvider.Invites, obs)
+	}
+}
+
+// managedChannelFlags are the channel flags spec.forProvider.flags manages.
+// Other flags, such as PINNED on forum posts, are left as Discord reports them.
+const managedChannelFlags = clients.ChannelFlagRequireTag | clients.ChannelFlagHideMediaDownloadOptions
+
+// flagBits returns the Discord bit field for flags.
+func flagBits(flags []channelv1alpha1.ChannelFlag) int {
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = string(f)
+	}
+	return clients.ChannelFlagBits(names)
+}
+
+// isConvertible reports whether Discord can convert a channel of type from to
+// type to. Only text and announcement channels convert into each other.
+func isConvertible(from, to int) bool {
+	convertible := func(t int) bool {
+		return t == clients.ChannelTypeText || t == clients.ChannelTypeAnnouncement
+	}
+	return from != to && convertible(from) && convertible(to)
+}
+
+// declaredName returns the name the channel should have: the spec's name,
+// sanitized when the spec asks for it.
+func declaredName(p channelv1alpha1.ChannelParameters) string {
+	if p.SanitizeName != nil && *p.SanitizeName {
+		return clients.SanitizeChannelName(p.Name)
+	}
+	return p.Name
+}
+
+// sanitizedName returns the name applied in place of the declared one, or
+// "" if the declared name is applied as is.
+func sanitizedName(p channelv1alpha1.ChannelParameters) string {
+	if name := declaredName(p); name != p.Name {
+		return name
+	}
+	return ""
+}
+
+// isUpToDate reports whether the observed channel matches the desired
+// parameters. Only fields set in the spec are compared, so values changed
+// manually or by other bots on fields the spec leaves unset are not reverted.
+func isUpToDate(p channelv1alpha1.ChannelParameters, channel *clients.Channel) bool {
+	return len(channelChanges(p, channel)) == 0
+}
+
+// channelChanges describes the channel settings an update would change, from
+// their observed to their desired values.
+func channelChanges(p channelv1alpha1.ChannelParameters, channel *clients.Channel) []string {
+	var changes []string
+	if name := clients.NormalizeChannelName(declaredName(p), p.Type); name != channel.Name {
+		changes = append(changes, conditions.Change("name", channel.Name, name))
+	}
+	if isConvertible(channel.Type, p.Type) {
+		changes = append(changes, conditions.Change("type", channel.Type, p.Type))
+	}
+	if p.Position != nil && *p.Position != channel.Position {
+		changes = append(changes, conditions.Change("position", channel.Position, *p.Position))
+	}
+	if p.ParentID != nil && *p.ParentID != channel.ParentID {
+		changes = append(changes, conditions.Change("parentId", channel.ParentID, *p.ParentID))
+	}
+	if p.Topic != nil && (channel.Topic == nil || *p.Topic != *channel.Topic) {
+		topic := ""
+		if channel.Topic != nil {
+			topic = *channel.Topic
+		}
+		changes = append(changes, conditions.Change("topic", topic, *p.Topic))
+	}
+	if p.NSFW != nil && *p.NSFW != channel.NSFW {
+		changes = append(changes, conditions.Change("nsfw", channel.NSFW, *p.NSFW))
+	}
+	if p.Bitrate != nil && *p.Bitrate != channel.Bitrate {
+		changes = append(changes, conditions.Change("bitrate", channel.Bitrate, *p.Bitrate))
+	}
+	if p.UserLimit != nil && *p.UserLimit != channel.UserLimit {
+		changes = append(changes, conditions.Change("userLimit", channel.UserLimit, *p.UserLimit))
+	}
+	if p.RateLimitPerUser != nil && *p.RateLimitPerUser != channel.RateLimitPerUser {
+		changes = append(changes, conditions.Change("rateLimitPerUser", channel.RateLimitPerUser, *p.RateLimitPerUser))
+	}
+	if p.Flags != nil && flagBits(p.Flags) != channel.Flags&managedChannelFlags {
+		changes = append(changes, conditions.Change("flags", channel.Flags&managedChannelFlags, flagBits(p.Flags)))
+	}
+	// Permission overwrites are only managed when the spec sets them; an empty
+	// list leaves overwrites configured outside Crossplane alone
+	if len(p.PermissionOverwrites) > 0 && len(p.PermissionOverwrites) != len(channel.PermissionOverwrites) {
+		changes = append(changes, conditions.Change("permissionOverwrites", fmt.Sprintf("%d entries", len(channel.PermissionOverwrites)), fmt.Sprintf("%d entries", len(p.PermissionOverwrites))))
+	} else if len(p.PermissionOverwrites) > 0 {
+		for i, pw := range p.PermissionOverwrites {
 			channelPw := channel.PermissionOverwrites[i]
 			if pw.ID != channelPw.ID {
-				needsUpdate = true
+				changes = append(changes, conditions.Change(fmt.Sprintf("permissionOverwrites[%d].id", i), channelPw.ID, pw.ID))
 				break
 			}
-			// Convert string allow/deny to int64 for comparison
-			var channelAllow, channelDeny int64
-			if channelPw.Allow != "" {
-				if val, err := strconv.ParseInt(channelPw.Allow, 10, 64); err == nil {
-					channelAllow = val
-				}
-			}
-			if channelPw.Deny != "" {
-				if val, err := strconv.ParseInt(channelPw.Deny, 10, 64); err == nil {
-					channelDeny = val
-				}
-			}
-			if pw.Allow != nil && channelPw.Allow == "" ||
-				pw.Allow == nil && channelPw.Allow != "" ||
-				pw.Allow != nil && channelPw.Allow != "" && *pw.Allow != channelAllow ||
-				pw.Deny != nil && channelPw.Deny == "" ||
-				pw.Deny == nil && channelPw.Deny != "" ||
-				pw.Deny != nil && channelPw.Deny != "" && *pw.Deny != channelDeny {
-				needsUpdate = true
+			if !OverwriteBitsMatch(pw.Allow, channelPw.Allow) || !OverwriteBitsMatch(pw.Deny, channelPw.Deny) {
+				changes = append(changes, fmt.Sprintf("permissionOverwrites[%s]: allow %s deny %s -> allow %s deny %s",
+					pw.ID, bitsOrNone(channelPw.Allow), bitsOrNone(channelPw.Deny), declaredBits(pw.Allow), declaredBits(pw.Deny)))
 				break
 			}
 		}
 	}
+	return changes
+}
 
-	return managed.ExternalObservation{
-		ResourceExists:          true,
-		ResourceUpToDate:        !needsUpdate,
-		ResourceLateInitialized: lateInitialized,
-	}, nil
+// OverwriteBitsMatch reports whether a declared allow or deny bit set matches
+// the one Discord reports as a decimal string. An unset bit set on either
+// side means no permissions.
+func OverwriteBitsMatch(declared *int64, observed string) bool {
+	want := int64(0)
+	if declared != nil {
+		want = *declared
+	}
+	got, err := strconv.ParseInt(observed, 10, 64)
+	if err != nil {
+		got = 0
+	}
+	return want == got
+}
+
+// ToClientOverwrites converts declared permission overwrites to the form
+// Discord accepts.
+func ToClientOverwrites(in []channelv1alpha1.PermissionOverwrite) []clients.PermissionOverwrite {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]clients.PermissionOverwrite, len(in))
+	for i, pw := range in {
+		out[i] = clients.PermissionOverwrite{ID: pw.ID, Type: 1}
+		if pw.Type == "role" {
+			out[i].Type = 0
+		}
+		if pw.Allow != nil {
+			out[i].Allow = strconv.FormatInt(*pw.Allow, 10)
+		}
+		if pw.Deny != nil {
+			out[i].Deny = strconv.FormatInt(*pw.Deny, 10)
+		}
+	}
+	return out
+}
+
+func bitsOrNone(bits string) string {
+	if bits == "" {
+		return "none"
+	}
+	return bits
+}
+
+func declaredBits(bits *int64) string {
+	if bits == nil {
+		return "none"
+	}
+	return strconv.FormatInt(*bits, 10)
+}
+
+// checkPermissions verifies the bot holds the guild permissions needed to
+// create or update the channel before calling Discord.
+func (c *external) checkPermissions(ctx context.Context, cr *channelv1alpha1.Channel) error {
+	if c.permissions == nil {
+		return nil
+	}
+	required := clients.PermissionManageChannels
+	if len(cr.Spec.ForProvider.PermissionOverwrites) > 0 || cr.Spec.ForProvider.Preset != nil {
+		required |= clients.PermissionManageRoles
+	}
+	return clients.RequirePermissions(ctx, c.permissions, cr.Spec.ForProvider.GuildID, required)
+}
+
+// checkLimits verifies the channel's settings are within the limits of the
+// guild's premium tier, so an unboosted guild gets a clear error rather than
+// Discord's generic one.
+func (c *external) checkLimits(ctx context.Context, cr *channelv1alpha1.Channel) error {
+	if c.guilds == nil || cr.Spec.ForProvider.Bitrate == nil {
+		return nil
+	}
+	return clients.CheckBitrate(ctx, c.guilds, cr.Spec.ForProvider.GuildID, *cr.Spec.ForProvider.Bitrate)
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -341,8 +552,18 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
+	if err := c.checkPermissions(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := c.checkLimits(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := clients.CheckChannelCount(ctx, c.service, cr.Spec.ForProvider.GuildID, 1); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	req := &clients.CreateChannelRequest{
-		Name:     cr.Spec.ForProvider.Name,
+		Name:     declaredName(cr.Spec.ForProvider),
 		Type:     cr.Spec.ForProvider.Type,
 		GuildID:  cr.Spec.ForProvider.GuildID,
 		Position: cr.Spec.ForProvider.Position,
@@ -386,11 +607,27 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotChannel)
 	}
 
+	if err := c.checkPermissions(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.checkLimits(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	p, err := c.withPreset(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	name := declaredName(cr.Spec.ForProvider)
 	req := &clients.ModifyChannelRequest{
-		Name: &cr.Spec.ForProvider.Name,
+		Name: &name,
 	}
 
 	// Set optional fields for update
+	if isConvertible(cr.Status.AtProvider.Type, cr.Spec.ForProvider.Type) {
+		req.Type = &cr.Spec.ForProvider.Type
+	}
 	if cr.Spec.ForProvider.Position != nil {
 		req.Position = cr.Spec.ForProvider.Position
 	}
@@ -412,27 +649,17 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if cr.Spec.ForProvider.RateLimitPerUser != nil {
 		req.RateLimitPerUser = cr.Spec.ForProvider.RateLimitPerUser
 	}
-	if len(cr.Spec.ForProvider.PermissionOverwrites) > 0 {
-		req.PermissionOverwrites = make([]clients.PermissionOverwrite, len(cr.Spec.ForProvider.PermissionOverwrites))
-		for i, pw := range cr.Spec.ForProvider.PermissionOverwrites {
-			var pType int
-			if pw.Type == "role" {
-				pType = 0
-			} else {
-				pType = 1
-			}
-			req.PermissionOverwrites[i] = clients.PermissionOverwrite{
-				ID:   pw.ID,
-				Type: pType,
-			}
-			if pw.Allow != nil {
-				req.PermissionOverwrites[i].Allow = strconv.FormatInt(*pw.Allow, 10)
-			}
-			if pw.Deny != nil {
-				req.PermissionOverwrites[i].Deny = strconv.FormatInt(*pw.Deny, 10)
-			}
+	if p.Flags != nil {
+		// Discord replaces the whole bit field, so flags spec.forProvider.flags
+		// does not manage, such as PINNED, are sent back as they are
+		current, err := c.service.GetChannel(ctx, meta.GetExternalName(cr))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to get channel")
 		}
+		flags := current.Flags&^managedChannelFlags | flagBits(p.Flags)
+		req.Flags = &flags
 	}
+	req.PermissionOverwrites = ToClientOverwrites(p.PermissionOverwrites)
 
 	channel, err := c.service.ModifyChannel(ctx, meta.GetExternalName(cr), req)
 	if err != nil {
@@ -448,6 +675,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		GuildID:   channel.GuildID,
 		Position:  channel.Position,
 		ParentID:  channel.ParentID,
+		Flags:     clients.ChannelFlagNames(channel.Flags),
+		CreatedAt: clients.CreatedAt(meta.GetExternalName(cr)),
 		UpdatedAt: now,
 	}
 	if len(channel.PermissionOverwrites) > 0 {
@@ -497,7 +726,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	err := c.service.DeleteChannel(ctx, meta.GetExternalName(cr))
 	if err != nil {
 		// Check if the error is a 404 (channel not found), which means it's already deleted
-		if isDiscordNotFound(err) {
+		if clients.IsNotFound(err) {
 			return managed.ExternalDelete{}, nil
 		}
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete channel")
@@ -19,14 +19,19 @@ package channel
 import (
 	"context"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
 	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
+	"time"
 )
 
 // MockChannelClient implements a mock Discord client for testing
@@ -37,6 +42,8 @@ type MockChannelClient struct {
 	DeleteChannelFunc     func(ctx context.Context, channelID string) error
 	ListGuildChannelsFunc func(ctx context.Context, guildID string) ([]discordclient.Channel, error)
 	HasMessagesFunc       func(ctx context.Context, channelID string) (bool, error)
+
+	ModifyGuildChannelPositionsFunc func(ctx context.Context, guildID string, positions []discordclient.ChannelPosition) error
 }
 
 // Ensure MockChannelClient implements ChannelClient interface
@@ -77,6 +84,13 @@ func (m *MockChannelClient) ListGuildChannels(ctx context.Context, guildID strin
 	return nil, errors.New("not implemented")
 }
 
+func (m *MockChannelClient) ModifyGuildChannelPositions(ctx context.Context, guildID string, positions []discordclient.ChannelPosition) error {
+	if m.ModifyGuildChannelPositionsFunc != nil {
+		return m.ModifyGuildChannelPositionsFunc(ctx, guildID, positions)
+	}
+	return errors.New("not implemented")
+}
+
 func (m *MockChannelClient) HasMessages(ctx context.Context, channelID string) (bool, error) {
 	if m.HasMessagesFunc != nil {
 		return m.HasMessagesFunc(ctx, channelID)
@@ -182,6 +196,30 @@ func TestObserve(t *testing.T) {
 			expectedUpToDate: false,
 			expectError:      false,
 		},
+		{
+			name: "bot removed from guild",
+			channel: &channelv1alpha1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: channelID,
+					},
+				},
+				Spec: channelv1alpha1.ChannelSpec{
+					ForProvider: channelv1alpha1.ChannelParameters{
+						Name:    "test-channel",
+						Type:    0,
+						GuildID: guildID,
+					},
+				},
+			},
+			mockSetup: func(m *MockChannelClient) {
+				m.GetChannelFunc = func(ctx context.Context, channelID string) (*discordclient.Channel, error) {
+					// Must not be mistaken for a deleted channel and recreated
+					return nil, &discordclient.APIError{StatusCode: 404, Code: 10004, Message: "Unknown Guild"}
+				}
+			},
+			expectError: true,
+		},
 		{
 			name: "no external name set - channel does not exist",
 			channel: &channelv1alpha1.Channel{
@@ -271,6 +309,178 @@ func TestObserve(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedExists, obs.ResourceExists)
 				assert.Equal(t, tc.expectedUpToDate, obs.ResourceUpToDate)
+				if obs.ResourceExists {
+					assert.Equal(t, channelID, string(obs.ConnectionDetails["channelId"]))
+					assert.Equal(t, "<#"+channelID+">", string(obs.ConnectionDetails["channelMention"]))
+				}
+			}
+		})
+	}
+}
+
+func TestObserveDeleted(t *testing.T) {
+	ctx := context.Background()
+	channelID := "987654321098765432"
+	markUnavailable := channelv1alpha1.RecreatePolicyMarkUnavailable
+
+	tests := []struct {
+		name             string
+		policy           *channelv1alpha1.RecreatePolicy
+		observedID       string
+		expectedExists   bool
+		expectedExternal string
+		expectedReason   xpv1.ConditionReason
+	}{
+		{
+			name:             "recreate by default",
+			observedID:       channelID,
+			expectedExists:   false,
+			expectedExternal: "",
+		},
+		{
+			name:             "mark unavailable",
+			policy:           &markUnavailable,
+			observedID:       channelID,
+			expectedExists:   true,
+			expectedExternal: channelID,
+			expectedReason:   conditions.ReasonDeletedExternally,
+		},
+		{
+			name:             "never created",
+			policy:           &markUnavailable,
+			expectedExists:   false,
+			expectedExternal: channelID,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &channelv1alpha1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: channelID,
+					},
+				},
+				Spec: channelv1alpha1.ChannelSpec{
+					ForProvider: channelv1alpha1.ChannelParameters{
+						Name:           "test-channel",
+						GuildID:        "123456789012345678",
+						RecreatePolicy: tc.policy,
+					},
+				},
+				Status: channelv1alpha1.ChannelStatus{
+					AtProvider: channelv1alpha1.ChannelObservation{ID: tc.observedID},
+				},
+			}
+			mockClient := &MockChannelClient{
+				GetChannelFunc: func(ctx context.Context, channelID string) (*discordclient.Channel, error) {
+					return nil, &discordclient.APIError{StatusCode: 404, Code: 10003, Message: "Unknown Channel"}
+				},
+			}
+			e := &external{service: mockClient, kube: nil}
+
+			obs, err := e.Observe(ctx, cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExists, obs.ResourceExists)
+			assert.Equal(t, tc.expectedExternal, meta.GetExternalName(cr))
+			assert.Equal(t, tc.expectedReason, cr.GetCondition(xpv1.TypeReady).Reason)
+		})
+	}
+}
+
+type MockInviteClient struct {
+	GetChannelInvitesFunc func(ctx context.Context, channelID string) ([]discordclient.Invite, error)
+}
+
+func (m *MockInviteClient) CreateChannelInvite(ctx context.Context, channelID string, req *discordclient.CreateInviteRequest) (*discordclient.Invite, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetInvite(ctx context.Context, inviteCode string) (*discordclient.Invite, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) DeleteInvite(ctx context.Context, inviteCode string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetChannelInvites(ctx context.Context, channelID string) ([]discordclient.Invite, error) {
+	if m.GetChannelInvitesFunc != nil {
+		return m.GetChannelInvitesFunc(ctx, channelID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetGuildInvites(ctx context.Context, guildID string) ([]discordclient.Invite, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestSetInvites(t *testing.T) {
+	expires := "2025-07-01T12:00:00+00:00"
+	invites := []discordclient.Invite{
+		{Code: "abc", Inviter: &discordclient.User{ID: "42"}, Uses: 3, MaxUses: 10, MaxAge: 86400, ExpiresAt: &expires},
+		{Code: "def", Temporary: true},
+	}
+	report := true
+
+	tests := []struct {
+		name          string
+		reportInvites *bool
+		err           error
+		expected      []channelv1alpha1.ChannelInviteObservation
+	}{
+		{
+			name:          "invites reported",
+			reportInvites: &report,
+			expected: []channelv1alpha1.ChannelInviteObservation{
+				{
+					Code:      "abc",
+					InviterID: "42",
+					Uses:      3,
+					MaxUses:   10,
+					MaxAge:    86400,
+					ExpiresAt: &metav1.Time{Time: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)},
+				},
+				{Code: "def", Temporary: true},
+			},
+		},
+		{
+			name: "not requested",
+		},
+		{
+			name:          "listing fails",
+			reportInvites: &report,
+			err:           errors.New("Discord API error: 403 - {\"message\": \"Missing Permissions\", \"code\": 50013}"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &external{invites: &MockInviteClient{
+				GetChannelInvitesFunc: func(ctx context.Context, channelID string) ([]discordclient.Invite, error) {
+					assert.Equal(t, "987654321098765432", channelID)
+					return invites, tc.err
+				},
+			}}
+			cr := &channelv1alpha1.Channel{
+				Spec: channelv1alpha1.ChannelSpec{ForProvider: channelv1alpha1.ChannelParameters{ReportInvites: tc.reportInvites}},
+				Status: channelv1alpha1.ChannelStatus{
+					AtProvider: channelv1alpha1.ChannelObservation{ID: "987654321098765432"},
+				},
+			}
+
+			e.setInvites(context.Background(), cr)
+
+			require.Len(t, cr.Status.AtProvider.Invites, len(tc.expected))
+			for i := range tc.expected {
+				assert.Equal(t, tc.expected[i].Code, cr.Status.AtProvider.Invites[i].Code)
+				assert.Equal(t, tc.expected[i].InviterID, cr.Status.AtProvider.Invites[i].InviterID)
+				assert.Equal(t, tc.expected[i].Uses, cr.Status.AtProvider.Invites[i].Uses)
+				assert.Equal(t, tc.expected[i].Temporary, cr.Status.AtProvider.Invites[i].Temporary)
+				if tc.expected[i].ExpiresAt != nil {
+					require.NotNil(t, cr.Status.AtProvider.Invites[i].ExpiresAt)
+					assert.True(t, tc.expected[i].ExpiresAt.Equal(cr.Status.AtProvider.Invites[i].ExpiresAt))
+				}
 			}
 		})
 	}
@@ -290,6 +500,9 @@ func TestCreate(t *testing.T) {
 				GuildID: guildID,
 			}, nil
 		},
+		ListGuildChannelsFunc: func(ctx context.Context, guildID string) ([]discordclient.Channel, error) {
+			return nil, nil
+		},
 	}
 
 	channel := &channelv1alpha1.Channel{
@@ -309,6 +522,168 @@ func TestCreate(t *testing.T) {
 	assert.Equal(t, channelID, meta.GetExternalName(channel))
 }
 
+// MockPermissionClient implements a mock bot permission resolver for testing
+type MockPermissionClient struct {
+	Permissions int64
+}
+
+func (m *MockPermissionClient) GetCurrentMemberPermissions(ctx context.Context, guildID string) (int64, error) {
+	return m.Permissions, nil
+}
+
+func TestCreateSanitizedName(t *testing.T) {
+	var created string
+	mockClient := &MockChannelClient{
+		CreateChannelFunc: func(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+			created = req.Name
+			return &discordclient.Channel{ID: "987654321098765432", Name: req.Name}, nil
+		},
+		ListGuildChannelsFunc: func(ctx context.Context, guildID string) ([]discordclient.Channel, error) {
+			return nil, nil
+		},
+	}
+	sanitize := true
+	channel := &channelv1alpha1.Channel{
+		Spec: channelv1alpha1.ChannelSpec{
+			ForProvider: channelv1alpha1.ChannelParameters{Name: "Dev_Ops Alerts!", GuildID: "123456789012345678", SanitizeName: &sanitize},
+		},
+	}
+
+	e := &external{service: mockClient}
+	_, err := e.Create(context.Background(), channel)
+
+	require.NoError(t, err)
+	assert.Equal(t, "dev-ops-alerts", created)
+	assert.Equal(t, "dev-ops-alerts", sanitizedName(channel.Spec.ForProvider))
+
+	sanitize = false
+	assert.Empty(t, sanitizedName(channel.Spec.ForProvider))
+}
+
+func TestCreateMissingPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := &MockChannelClient{
+		CreateChannelFunc: func(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+			t.Error("CreateChannel should not be called when permissions are missing")
+			return nil, errors.New("unexpected call")
+		},
+	}
+
+	allow := int64(1024)
+	channel := &channelv1alpha1.Channel{
+		Spec: channelv1alpha1.ChannelSpec{
+			ForProvider: channelv1alpha1.ChannelParameters{
+				Name:    "test-channel",
+				GuildID: "123456789012345678",
+				PermissionOverwrites: []channelv1alpha1.PermissionOverwrite{
+					{ID: "111111111111111111", Type: "role", Allow: &allow},
+				},
+			},
+		},
+	}
+
+	e := &external{
+		service:     mockClient,
+		permissions: &MockPermissionClient{Permissions: discordclient.PermissionManageChannels},
+	}
+	_, err := e.Create(ctx, channel)
+
+	missing, ok := discordclient.IsMissingPermissions(err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"MANAGE_ROLES"}, missing.Permissions)
+}
+
+// MockGuildGetter returns a fixed guild for premium tier checks.
+type MockGuildGetter struct {
+	Guild *discordclient.Guild
+}
+
+func (m *MockGuildGetter) GetGuild(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+	return m.Guild, nil
+}
+
+func TestCreateGuildLimit(t *testing.T) {
+	created := false
+	mockClient := &MockChannelClient{
+		CreateChannelFunc: func(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+			created = true
+			return &discordclient.Channel{ID: "987654321098765432"}, nil
+		},
+		ListGuildChannelsFunc: func(ctx context.Context, guildID string) ([]discordclient.Channel, error) {
+			return make([]discordclient.Channel, discordclient.MaxGuildChannels), nil
+		},
+	}
+	channel := &channelv1alpha1.Channel{
+		Spec: channelv1alpha1.ChannelSpec{
+			ForProvider: channelv1alpha1.ChannelParameters{Name: "general", GuildID: "123456789012345678"},
+		},
+	}
+
+	e := &external{service: mockClient}
+	_, err := e.Create(context.Background(), channel)
+
+	assert.True(t, discordclient.IsGuildLimitReached(err))
+	assert.False(t, created)
+}
+
+func TestCreateBitrateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		bitrate     int
+		premiumTier int
+		features    []string
+		expectLimit bool
+	}{
+		{name: "within unboosted limit", bitrate: 96000},
+		{name: "above unboosted limit", bitrate: 128000, expectLimit: true},
+		{name: "within tier 1 limit", bitrate: 128000, premiumTier: 1},
+		{name: "VIP region guild", bitrate: 384000, features: []string{discordclient.GuildFeatureVIPRegions}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			created := false
+			mockClient := &MockChannelClient{
+				CreateChannelFunc: func(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+					created = true
+					return &discordclient.Channel{ID: "987654321098765432"}, nil
+				},
+				ListGuildChannelsFunc: func(ctx context.Context, guildID string) ([]discordclient.Channel, error) {
+					return nil, nil
+				},
+			}
+			bitrate := tc.bitrate
+			channel := &channelv1alpha1.Channel{
+				Spec: channelv1alpha1.ChannelSpec{
+					ForProvider: channelv1alpha1.ChannelParameters{
+						Name:    "Voice",
+						Type:    discordclient.ChannelTypeVoice,
+						GuildID: "123456789012345678",
+						Bitrate: &bitrate,
+					},
+				},
+			}
+
+			e := &external{
+				service: mockClient,
+				guilds:  &MockGuildGetter{Guild: &discordclient.Guild{PremiumTier: tc.premiumTier, Features: tc.features}},
+			}
+			_, err := e.Create(ctx, channel)
+
+			if tc.expectLimit {
+				assert.True(t, discordclient.IsPremiumLimit(err))
+				assert.False(t, created)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, created)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789012345678"   // Valid Discord snowflake ID
@@ -346,6 +721,94 @@ func TestUpdate(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpdateType(t *testing.T) {
+	channelID := "987654321098765432"
+
+	tests := []struct {
+		name     string
+		observed int
+		declared int
+		expected *int
+	}{
+		{name: "text to news", observed: 0, declared: 5, expected: intPtr(5)},
+		{name: "news to text", observed: 5, declared: 0, expected: intPtr(0)},
+		{name: "unchanged", observed: 5, declared: 5},
+		{name: "inconvertible", observed: 2, declared: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent *int
+			mockClient := &MockChannelClient{
+				ModifyChannelFunc: func(ctx context.Context, channelID string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+					sent = req.Type
+					return &discordclient.Channel{ID: channelID, Name: *req.Name, Type: tc.declared}, nil
+				},
+			}
+
+			channel := &channelv1alpha1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: channelID,
+					},
+				},
+				Spec: channelv1alpha1.ChannelSpec{
+					ForProvider: channelv1alpha1.ChannelParameters{
+						Name:    "releases",
+						Type:    tc.declared,
+						GuildID: "123456789012345678",
+					},
+				},
+			}
+			channel.Status.AtProvider.Type = tc.observed
+
+			e := &external{service: mockClient}
+			_, err := e.Update(context.Background(), channel)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, sent)
+		})
+	}
+}
+
+func TestUpdateFlags(t *testing.T) {
+	channelID := "987654321098765432"
+
+	var sent *int
+	mockClient := &MockChannelClient{
+		GetChannelFunc: func(ctx context.Context, channelID string) (*discordclient.Channel, error) {
+			return &discordclient.Channel{ID: channelID, Name: "help", Type: 15, Flags: discordclient.ChannelFlagPinned | discordclient.ChannelFlagHideMediaDownloadOptions}, nil
+		},
+		ModifyChannelFunc: func(ctx context.Context, channelID string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+			sent = req.Flags
+			return &discordclient.Channel{ID: channelID, Name: *req.Name, Type: 15, Flags: *req.Flags}, nil
+		},
+	}
+
+	channel := &channelv1alpha1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: channelID,
+			},
+		},
+		Spec: channelv1alpha1.ChannelSpec{
+			ForProvider: channelv1alpha1.ChannelParameters{
+				Name:    "help",
+				Type:    15,
+				GuildID: "123456789012345678",
+				Flags:   []channelv1alpha1.ChannelFlag{channelv1alpha1.ChannelFlagRequireTag},
+			},
+		},
+	}
+
+	e := &external{service: mockClient}
+	_, err := e.Update(context.Background(), channel)
+	require.NoError(t, err)
+	require.NotNil(t, sent)
+	// PINNED is not managed by spec and is kept
+	assert.Equal(t, discordclient.ChannelFlagPinned|discordclient.ChannelFlagRequireTag, *sent)
+	assert.Equal(t, []string{"PINNED", "REQUIRE_TAG"}, channel.Status.AtProvider.Flags)
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	channelID := "987654321098765432" // Valid Discord snowflake ID
@@ -486,3 +949,164 @@ func TestTypeAssertions(t *testing.T) {
 
 // Helper functions
 // Helper functions removed - unused
+
+func TestIsUpToDate(t *testing.T) {
+	topic := "announcements"
+	nsfw := true
+	sanitize := true
+	allow := int64(1024)
+
+	tests := []struct {
+		name     string
+		params   channelv1alpha1.ChannelParameters
+		channel  *discordclient.Channel
+		expected bool
+	}{
+		{
+			name:   "unset fields are not managed",
+			params: channelv1alpha1.ChannelParameters{Name: "general"},
+			channel: &discordclient.Channel{
+				Name:  "general",
+				Topic: &topic,
+				NSFW:  true,
+				PermissionOverwrites: []discordclient.PermissionOverwrite{
+					{ID: "111111111111111111", Type: 0, Allow: "1024"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name:     "sanitized name matches",
+			params:   channelv1alpha1.ChannelParameters{Name: "Team Voice!", Type: discordclient.ChannelTypeVoice, SanitizeName: &sanitize},
+			channel:  &discordclient.Channel{Name: "team-voice", Type: discordclient.ChannelTypeVoice},
+			expected: true,
+		},
+		{
+			name:     "unsanitized name differs",
+			params:   channelv1alpha1.ChannelParameters{Name: "Team Voice!", Type: discordclient.ChannelTypeVoice},
+			channel:  &discordclient.Channel{Name: "team-voice", Type: discordclient.ChannelTypeVoice},
+			expected: false,
+		},
+		{
+			name:     "text channel converted to news",
+			params:   channelv1alpha1.ChannelParameters{Name: "general", Type: 5},
+			channel:  &discordclient.Channel{Name: "general", Type: 0},
+			expected: false,
+		},
+		{
+			name:     "inconvertible type is left alone",
+			params:   channelv1alpha1.ChannelParameters{Name: "general", Type: 15},
+			channel:  &discordclient.Channel{Name: "general", Type: 0},
+			expected: true,
+		},
+		{
+			name:     "topic drift",
+			params:   channelv1alpha1.ChannelParameters{Name: "general", Topic: &topic},
+			channel:  &discordclient.Channel{Name: "general"},
+			expected: false,
+		},
+		{
+			name:     "nsfw drift",
+			params:   channelv1alpha1.ChannelParameters{Name: "general", NSFW: &nsfw},
+			channel:  &discordclient.Channel{Name: "general"},
+			expected: false,
+		},
+		{
+			name: "matching overwrites",
+			params: channelv1alpha1.ChannelParameters{
+				Name: "general",
+				PermissionOverwrites: []channelv1alpha1.PermissionOverwrite{
+					{ID: "111111111111111111", Type: "role", Allow: &allow},
+				},
+			},
+			channel: &discordclient.Channel{
+				Name: "general",
+				PermissionOverwrites: []discordclient.PermissionOverwrite{
+					{ID: "111111111111111111", Type: 0, Allow: "1024"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "matching flags ignore unmanaged bits",
+			params: channelv1alpha1.ChannelParameters{
+				Name:  "general",
+				Flags: []channelv1alpha1.ChannelFlag{channelv1alpha1.ChannelFlagRequireTag},
+			},
+			channel: &discordclient.Channel{
+				Name:  "general",
+				Flags: discordclient.ChannelFlagRequireTag | discordclient.ChannelFlagPinned,
+			},
+			expected: true,
+		},
+		{
+			name:     "flag drift",
+			params:   channelv1alpha1.ChannelParameters{Name: "general", Flags: []channelv1alpha1.ChannelFlag{channelv1alpha1.ChannelFlagRequireTag}},
+			channel:  &discordclient.Channel{Name: "general"},
+			expected: false,
+		},
+		{
+			name:     "empty flags clear set flags",
+			params:   channelv1alpha1.ChannelParameters{Name: "general", Flags: []channelv1alpha1.ChannelFlag{}},
+			channel:  &discordclient.Channel{Name: "general", Flags: discordclient.ChannelFlagRequireTag},
+			expected: false,
+		},
+		{
+			name:     "unset flags are not managed",
+			params:   channelv1alpha1.ChannelParameters{Name: "general"},
+			channel:  &discordclient.Channel{Name: "general", Flags: discordclient.ChannelFlagRequireTag},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isUpToDate(tc.params, tc.channel))
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestObserveDryRunReportsDiff(t *testing.T) {
+	oldTopic := "Old topic"
+	mock := &MockChannelClient{
+		GetChannelFunc: func(ctx context.Context, channelID string) (*discordclient.Channel, error) {
+			return &discordclient.Channel{ID: channelID, Name: "general", Type: 0, GuildID: "guild-123", Topic: &oldTopic}, nil
+		},
+	}
+	c := conditions.NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &external{service: mock}, nil
+	}))
+
+	topic := "New topic"
+	cr := &channelv1alpha1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "general",
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: "123456789012345678",
+				conditions.AnnotationKeyDryRun: "true",
+			},
+		},
+		Spec: channelv1alpha1.ChannelSpec{
+			ForProvider: channelv1alpha1.ChannelParameters{
+				Name:    "announcements",
+				Type:    0,
+				GuildID: "guild-123",
+				Topic:   &topic,
+			},
+		},
+	}
+
+	ec, err := c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+	obs, err := ec.Observe(context.Background(), cr)
+	require.NoError(t, err)
+
+	assert.True(t, obs.ResourceUpToDate)
+	cond := cr.GetCondition(conditions.TypeDryRun)
+	assert.Equal(t, conditions.ReasonWouldUpdate, cond.Reason)
+	assert.Equal(t, "would update the Discord resource for general: name: general -> announcements; topic: Old topic -> New topic", cond.Message)
+}
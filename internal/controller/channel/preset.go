@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	errGetRole     = "cannot get Role"
+	errRolePending = "waiting for Role %s to report its Discord ID"
+)
+
+// The permissions the announcementsReadonly preset takes from everyone and
+// gives to its roles.
+const readonlyPosting = clients.PermissionSendMessages | clients.PermissionSendMessagesInThreads |
+	clients.PermissionCreatePublicThreads | clients.PermissionCreatePrivateThreads
+
+// presetOverwrites expands a preset into permission overwrites for
+// @everyone, whose role ID is the guild's ID, and the preset's roles.
+func presetOverwrites(preset channelv1alpha1.ChannelPreset, guildID string, roleIDs []string) []channelv1alpha1.PermissionOverwrite {
+	var everyone, roles channelv1alpha1.PermissionOverwrite
+	switch preset {
+	case channelv1alpha1.ChannelPresetAnnouncementsReadonly:
+		everyone.Allow = ptr(clients.PermissionViewChannel | clients.PermissionReadMessageHistory)
+		everyone.Deny = ptr(readonlyPosting)
+		roles.Allow = ptr(readonlyPosting)
+	case channelv1alpha1.ChannelPresetStaffOnly:
+		everyone.Deny = ptr(clients.PermissionViewChannel)
+		roles.Allow = ptr(clients.PermissionViewChannel | clients.PermissionReadMessageHistory)
+	case channelv1alpha1.ChannelPresetPublic:
+		everyone.Allow = ptr(clients.PermissionViewChannel | clients.PermissionReadMessageHistory)
+		// Public channels grant their roles nothing beyond what everyone has
+		roleIDs = nil
+	default:
+		return nil
+	}
+
+	everyone.ID, everyone.Type = guildID, "role"
+	overwrites := []channelv1alpha1.PermissionOverwrite{everyone}
+	for _, id := range roleIDs {
+		if id == guildID {
+			continue
+		}
+		o := *roles.DeepCopy()
+		o.ID, o.Type = id, "role"
+		overwrites = append(overwrites, o)
+	}
+	return overwrites
+}
+
+// withPreset returns the channel's parameters with its preset expanded into
+// their permission overwrites. Overwrites declared in the spec replace the
+// preset's for the same role or member.
+func (c *external) withPreset(ctx context.Context, cr *channelv1alpha1.Channel) (channelv1alpha1.ChannelParameters, error) {
+	p := *cr.Spec.ForProvider.DeepCopy()
+	if p.Preset == nil {
+		return p, nil
+	}
+
+	roleIDs, err := c.presetRoles(ctx, cr)
+	if err != nil {
+		return p, err
+	}
+	overwrites := presetOverwrites(*p.Preset, p.GuildID, roleIDs)
+	for _, o := range p.PermissionOverwrites {
+		i := slices.IndexFunc(overwrites, func(po channelv1alpha1.PermissionOverwrite) bool { return po.ID == o.ID })
+		if i < 0 {
+			overwrites = append(overwrites, o)
+			continue
+		}
+		overwrites[i] = o
+	}
+	p.PermissionOverwrites = overwrites
+	return p, nil
+}
+
+// presetRoles returns the IDs of the preset's roles, from presetRoleIds
+// followed by the Roles named by presetRoleRefs.
+func (c *external) presetRoles(ctx context.Context, cr *channelv1alpha1.Channel) ([]string, error) {
+	ids := slices.Clone(cr.Spec.ForProvider.PresetRoleIDs)
+	for _, ref := range cr.Spec.ForProvider.PresetRoleRefs {
+		role := &rolev1alpha1.Role{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: ref.Name}, role); err != nil {
+			return nil, errors.Wrap(err, errGetRole)
+		}
+		if role.Status.AtProvider.ID == "" {
+			return nil, conditions.NewChildPendingError(fmt.Sprintf(errRolePending, ref.Name))
+		}
+		if !slices.Contains(ids, role.Status.AtProvider.ID) {
+			ids = append(ids, role.Status.AtProvider.ID)
+		}
+	}
+	return ids, nil
+}
+
+func ptr(v int64) *int64 {
+	return &v
+}
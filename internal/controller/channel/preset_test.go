@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const presetGuildID = "123456789012345678"
+
+func newPresetKube(t *testing.T) client.Client {
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	staff := &rolev1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "staff", Namespace: "default"}}
+	staff.Status.AtProvider.ID = "222222222222222222"
+	pending := &rolev1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"}}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(staff, pending).Build()
+}
+
+func presetChannel(preset channelv1alpha1.ChannelPreset, refs ...string) *channelv1alpha1.Channel {
+	cr := &channelv1alpha1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "announcements",
+			Namespace:   "default",
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: "987654321098765432"},
+		},
+		Spec: channelv1alpha1.ChannelSpec{
+			ForProvider: channelv1alpha1.ChannelParameters{
+				Name:          "announcements",
+				GuildID:       presetGuildID,
+				Preset:        &preset,
+				PresetRoleIDs: []string{"111111111111111111"},
+			},
+		},
+	}
+	for _, ref := range refs {
+		cr.Spec.ForProvider.PresetRoleRefs = append(cr.Spec.ForProvider.PresetRoleRefs, xpv1.Reference{Name: ref})
+	}
+	return cr
+}
+
+func TestPresetOverwrites(t *testing.T) {
+	view := discordclient.PermissionViewChannel | discordclient.PermissionReadMessageHistory
+
+	tests := map[string]struct {
+		preset   channelv1alpha1.ChannelPreset
+		expected []channelv1alpha1.PermissionOverwrite
+	}{
+		"AnnouncementsReadonly": {
+			preset: channelv1alpha1.ChannelPresetAnnouncementsReadonly,
+			expected: []channelv1alpha1.PermissionOverwrite{
+				{ID: presetGuildID, Type: "role", Allow: ptr(view), Deny: ptr(readonlyPosting)},
+				{ID: "111111111111111111", Type: "role", Allow: ptr(readonlyPosting)},
+			},
+		},
+		"StaffOnly": {
+			preset: channelv1alpha1.ChannelPresetStaffOnly,
+			expected: []channelv1alpha1.PermissionOverwrite{
+				{ID: presetGuildID, Type: "role", Deny: ptr(discordclient.PermissionViewChannel)},
+				{ID: "111111111111111111", Type: "role", Allow: ptr(view)},
+			},
+		},
+		"Public": {
+			preset: channelv1alpha1.ChannelPresetPublic,
+			expected: []channelv1alpha1.PermissionOverwrite{
+				{ID: presetGuildID, Type: "role", Allow: ptr(view)},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := presetOverwrites(tc.preset, presetGuildID, []string{"111111111111111111", presetGuildID})
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestWithPreset(t *testing.T) {
+	cr := presetChannel(channelv1alpha1.ChannelPresetStaffOnly, "staff")
+	deny := int64(0)
+	cr.Spec.ForProvider.PermissionOverwrites = []channelv1alpha1.PermissionOverwrite{
+		{ID: presetGuildID, Type: "role", Deny: &deny},
+		{ID: "333333333333333333", Type: "member", Allow: ptr(discordclient.PermissionViewChannel)},
+	}
+
+	e := &external{kube: newPresetKube(t)}
+	p, err := e.withPreset(context.Background(), cr)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(p.PermissionOverwrites))
+	for _, o := range p.PermissionOverwrites {
+		ids = append(ids, o.ID)
+	}
+	assert.Equal(t, []string{presetGuildID, "111111111111111111", "222222222222222222", "333333333333333333"}, ids)
+	assert.Equal(t, &deny, p.PermissionOverwrites[0].Deny, "declared overwrites replace the preset's")
+	assert.Len(t, cr.Spec.ForProvider.PermissionOverwrites, 2, "the spec is left alone")
+}
+
+func TestWithPresetRolePending(t *testing.T) {
+	e := &external{kube: newPresetKube(t)}
+	_, err := e.withPreset(context.Background(), presetChannel(channelv1alpha1.ChannelPresetStaffOnly, "pending"))
+	assert.True(t, conditions.IsChildPending(err))
+}
+
+func TestUpdatePreset(t *testing.T) {
+	var sent []discordclient.PermissionOverwrite
+	mockClient := &MockChannelClient{
+		ModifyChannelFunc: func(ctx context.Context, channelID string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+			sent = req.PermissionOverwrites
+			return &discordclient.Channel{ID: channelID, Name: *req.Name, GuildID: presetGuildID, PermissionOverwrites: req.PermissionOverwrites}, nil
+		},
+	}
+
+	e := &external{service: mockClient, kube: newPresetKube(t)}
+	cr := presetChannel(channelv1alpha1.ChannelPresetAnnouncementsReadonly, "staff")
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+
+	require.Len(t, sent, 3)
+	assert.Equal(t, discordclient.PermissionOverwrite{ID: presetGuildID, Type: 0, Allow: "66560", Deny: "377957124096"}, sent[0])
+	assert.Equal(t, "222222222222222222", sent[2].ID)
+
+	// The channel now matches the expanded preset
+	p, err := e.withPreset(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, isUpToDate(p, &discordclient.Channel{Name: "announcements", PermissionOverwrites: sent}))
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channelpins
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotChannelPins = "managed resource is not a ChannelPins custom resource"
+	errGetChannel     = "cannot get Channel"
+	errChannelPending = "waiting for Channel %s to report its Discord ID"
+	errGetMessage     = "cannot get ScheduledMessage"
+)
+
+// Setup adds a controller that reconciles ChannelPins managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(channelv1alpha1.ChannelPinsGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(channelv1alpha1.ChannelPinsGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(channelv1alpha1.ChannelPinsGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&channelv1alpha1.ChannelPins{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*channelv1alpha1.ChannelPins)
+	if !ok {
+		return nil, errors.New(errNotChannelPins)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+	return &external{pins: svc, messages: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient keeps a channel's declared messages pinned. The pins
+// themselves belong to the channel: Observe compares them with the declared
+// messages and Update pins, and for exclusive pins unpins, the difference.
+type external struct {
+	pins discordclient.PinClient
+	// messages looks up declared messages that are not pinned, so deleted
+	// ones are reported rather than pinned forever.
+	messages discordclient.MessageClient
+	kube     client.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*channelv1alpha1.ChannelPins)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotChannelPins)
+	}
+
+	channelID, err := e.resolveChannel(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	declared, unavailable, err := e.declared(ctx, cr, channelID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	pinned, err := e.pins.GetPinnedMessages(ctx, channelID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get pinned messages")
+	}
+
+	// The pins are identified by their channel
+	if meta.GetExternalName(cr) != channelID {
+		meta.SetExternalName(cr, channelID)
+	}
+
+	pinnedIDs := make([]string, 0, len(pinned))
+	for _, m := range pinned {
+		pinnedIDs = append(pinnedIDs, m.ID)
+	}
+	var missing, unexpected []string
+	for _, id := range declared {
+		if slices.Contains(pinnedIDs, id) {
+			continue
+		}
+		exists, err := e.messageExists(ctx, channelID, id)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if exists {
+			missing = append(missing, id)
+		} else {
+			unavailable = append(unavailable, id)
+		}
+	}
+	for _, id := range pinnedIDs {
+		if !slices.Contains(declared, id) {
+			unexpected = append(unexpected, id)
+		}
+	}
+
+	cr.Status.AtProvider = channelv1alpha1.ChannelPinsObservation{
+		ChannelID:             channelID,
+		PinCount:              len(pinnedIDs),
+		PinLimit:              discordclient.MaxPinnedMessages,
+		PinnedMessageIDs:      pinnedIDs,
+		MissingMessageIDs:     missing,
+		UnexpectedMessageIDs:  unexpected,
+		UnavailableMessageIDs: unavailable,
+	}
+
+	cr.SetConditions(xpv1.Available())
+	if len(unavailable) > 0 {
+		cr.SetConditions(conditions.MessagesUnavailable(fmt.Sprintf("messages %s were deleted or are not in channel %s, so they cannot be pinned", strings.Join(unavailable, ", "), channelID)))
+	} else {
+		cr.SetConditions(conditions.MessagesAvailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(missing) == 0 && (!exclusive(cr) || len(unexpected) == 0),
+	}, nil
+}
+
+func exclusive(cr *channelv1alpha1.ChannelPins) bool {
+	return cr.Spec.ForProvider.Exclusive != nil && *cr.Spec.ForProvider.Exclusive
+}
+
+// resolveChannel returns the ID of the channel, from channelId or the
+// Channel named by channelRef.
+func (e *external) resolveChannel(ctx context.Context, cr *channelv1alpha1.ChannelPins) (string, error) {
+	p := cr.Spec.ForProvider
+	if p.ChannelID != nil {
+		return *p.ChannelID, nil
+	}
+	if p.ChannelRef == nil {
+		return "", errors.New("channelId or channelRef is required")
+	}
+
+	channel := &channelv1alpha1.Channel{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: p.ChannelRef.Name}, channel); err != nil {
+		return "", errors.Wrap(err, errGetChannel)
+	}
+	if id := channel.Status.AtProvider.ID; id != "" {
+		return id, nil
+	}
+	return "", conditions.NewChildPendingError(fmt.Sprintf(errChannelPending, p.ChannelRef.Name))
+}
+
+// declared returns the IDs of the messages that must be pinned: messageIds
+// followed by the messages posted by the ScheduledMessages in messageRefs.
+// ScheduledMessages that do not exist or have not posted yet are skipped,
+// and the messages of those that posted in another channel are returned
+// separately, since Discord cannot pin them in this one.
+func (e *external) declared(ctx context.Context, cr *channelv1alpha1.ChannelPins, channelID string) (ids, elsewhere []string, err error) {
+	ids = slices.Clone(cr.Spec.ForProvider.MessageIDs)
+	for _, ref := range cr.Spec.ForProvider.MessageRefs {
+		sm := &messagev1alpha1.ScheduledMessage{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: ref.Name}, sm); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, errors.Wrap(err, errGetMessage)
+		}
+		id := sm.Status.AtProvider.MessageID
+		if id == "" || slices.Contains(ids, id) || slices.Contains(elsewhere, id) {
+			continue
+		}
+		if c := sm.Status.AtProvider.ChannelID; c != "" && c != channelID {
+			elsewhere = append(elsewhere, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, elsewhere, nil
+}
+
+// messageExists reports whether the message is in the channel. Discord
+// answers Unknown Message for deleted messages and for messages in other
+// channels alike.
+func (e *external) messageExists(ctx context.Context, channelID, messageID string) (bool, error) {
+	if _, err := e.messages.GetMessage(ctx, channelID, messageID); err != nil {
+		if discordclient.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get message %s", messageID)
+	}
+	return true, nil
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	// Observe always reports the pins as existing.
+	return managed.ExternalCreation{}, nil
+}
+
+// Update unpins undeclared messages when pins are exclusive, then pins the
+// missing ones, refusing to go past Discord's pin limit.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*channelv1alpha1.ChannelPins)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotChannelPins)
+	}
+
+	obs := cr.Status.AtProvider
+	var unpin []string
+	if exclusive(cr) {
+		unpin = obs.UnexpectedMessageIDs
+	}
+	if n := obs.PinCount - len(unpin) + len(obs.MissingMessageIDs); n > discordclient.MaxPinnedMessages {
+		return managed.ExternalUpdate{}, errors.Errorf("pinning %d messages would leave %d pinned in channel %s; Discord allows %d", len(obs.MissingMessageIDs), n, obs.ChannelID, discordclient.MaxPinnedMessages)
+	}
+
+	for _, id := range unpin {
+		if err := e.pins.UnpinMessage(ctx, obs.ChannelID, id); err != nil && !discordclient.IsNotFound(err) {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "failed to unpin message %s", id)
+		}
+	}
+	for _, id := range obs.MissingMessageIDs {
+		if err := e.pins.PinMessage(ctx, obs.ChannelID, id); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "failed to pin message %s", id)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete unpins the declared messages that are pinned.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*channelv1alpha1.ChannelPins)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotChannelPins)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	channelID := cr.Status.AtProvider.ChannelID
+	if channelID == "" {
+		return managed.ExternalDelete{}, nil
+	}
+	declared, _, err := e.declared(ctx, cr, channelID)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	for _, id := range declared {
+		if !slices.Contains(cr.Status.AtProvider.PinnedMessageIDs, id) {
+			continue
+		}
+		if err := e.pins.UnpinMessage(ctx, channelID, id); err != nil && !discordclient.IsNotFound(err) {
+			return managed.ExternalDelete{}, errors.Wrapf(err, "failed to unpin message %s", id)
+		}
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channelpins
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const channelID = "111111111111111111"
+
+type MockPinClient struct {
+	pinned   []string
+	calls    []string
+	unpinErr error
+}
+
+func (m *MockPinClient) GetPinnedMessages(ctx context.Context, channelID string) ([]discordclient.Message, error) {
+	messages := make([]discordclient.Message, 0, len(m.pinned))
+	for _, id := range m.pinned {
+		messages = append(messages, discordclient.Message{ID: id, ChannelID: channelID})
+	}
+	return messages, nil
+}
+
+func (m *MockPinClient) PinMessage(ctx context.Context, channelID, messageID string) error {
+	m.calls = append(m.calls, "pin "+messageID)
+	return nil
+}
+
+func (m *MockPinClient) UnpinMessage(ctx context.Context, channelID, messageID string) error {
+	m.calls = append(m.calls, "unpin "+messageID)
+	return m.unpinErr
+}
+
+type MockMessageClient struct {
+	discordclient.MessageClient
+	deleted []string
+}
+
+func (m *MockMessageClient) GetMessage(ctx context.Context, channelID, messageID string) (*discordclient.Message, error) {
+	if slices.Contains(m.deleted, messageID) {
+		return nil, &discordclient.APIError{StatusCode: 404, Code: 10008, Message: "Unknown Message"}
+	}
+	return &discordclient.Message{ID: messageID, ChannelID: channelID}, nil
+}
+
+func newKube(t *testing.T) client.Client {
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	posted := &messagev1alpha1.ScheduledMessage{ObjectMeta: metav1.ObjectMeta{Name: "rules", Namespace: "default"}}
+	posted.Status.AtProvider.MessageID = "3"
+	posted.Status.AtProvider.ChannelID = channelID
+	scheduled := &messagev1alpha1.ScheduledMessage{ObjectMeta: metav1.ObjectMeta{Name: "later", Namespace: "default"}}
+	elsewhere := &messagev1alpha1.ScheduledMessage{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "default"}}
+	elsewhere.Status.AtProvider.MessageID = "4"
+	elsewhere.Status.AtProvider.ChannelID = "222222222222222222"
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(posted, scheduled, elsewhere).Build()
+}
+
+func pinsResource(exclusive bool) *channelv1alpha1.ChannelPins {
+	id := channelID
+	return &channelv1alpha1.ChannelPins{
+		ObjectMeta: metav1.ObjectMeta{Name: "pins", Namespace: "default"},
+		Spec: channelv1alpha1.ChannelPinsSpec{
+			ForProvider: channelv1alpha1.ChannelPinsParameters{
+				ChannelID:   &id,
+				MessageIDs:  []string{"1", "2"},
+				MessageRefs: []xpv1.Reference{{Name: "rules"}, {Name: "later"}, {Name: "missing"}, {Name: "elsewhere"}},
+				Exclusive:   &exclusive,
+			},
+		},
+	}
+}
+
+func TestObserveAndUpdate(t *testing.T) {
+	tests := []struct {
+		name          string
+		exclusive     bool
+		expectedCalls []string
+	}{
+		{name: "pins missing messages", expectedCalls: []string{"pin 2", "pin 3"}},
+		{name: "exclusive unpins undeclared messages first", exclusive: true, expectedCalls: []string{"unpin 9", "pin 2", "pin 3"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pins := &MockPinClient{pinned: []string{"9", "1"}}
+			e := &external{pins: pins, messages: &MockMessageClient{}, kube: newKube(t)}
+			cr := pinsResource(tc.exclusive)
+
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceExists)
+			assert.False(t, obs.ResourceUpToDate)
+			assert.Equal(t, channelID, meta.GetExternalName(cr))
+			assert.Equal(t, 2, cr.Status.AtProvider.PinCount)
+			assert.Equal(t, 50, cr.Status.AtProvider.PinLimit)
+			assert.Equal(t, []string{"2", "3"}, cr.Status.AtProvider.MissingMessageIDs)
+			assert.Equal(t, []string{"9"}, cr.Status.AtProvider.UnexpectedMessageIDs)
+			assert.Equal(t, []string{"4"}, cr.Status.AtProvider.UnavailableMessageIDs)
+
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCalls, pins.calls)
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	e := &external{pins: &MockPinClient{pinned: []string{"3", "2", "1", "9"}}, messages: &MockMessageClient{}, kube: newKube(t)}
+	obs, err := e.Observe(context.Background(), pinsResource(false))
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestObserveUnavailableMessages(t *testing.T) {
+	pins := &MockPinClient{pinned: []string{"1", "3"}}
+	e := &external{pins: pins, messages: &MockMessageClient{deleted: []string{"2"}}, kube: newKube(t)}
+	cr := pinsResource(false)
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Empty(t, cr.Status.AtProvider.MissingMessageIDs)
+	assert.Equal(t, []string{"4", "2"}, cr.Status.AtProvider.UnavailableMessageIDs)
+	c := cr.GetCondition(conditions.TypeMessagesUnavailable)
+	assert.Equal(t, conditions.ReasonMessagesUnavailable, c.Reason)
+	assert.Equal(t, "messages 4, 2 were deleted or are not in channel "+channelID+", so they cannot be pinned", c.Message)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Empty(t, pins.calls)
+}
+
+func TestUpdatePinLimit(t *testing.T) {
+	pinned := make([]string, 49)
+	for i := range pinned {
+		pinned[i] = fmt.Sprintf("%d", 100+i)
+	}
+	pins := &MockPinClient{pinned: pinned}
+	e := &external{pins: pins, messages: &MockMessageClient{}, kube: newKube(t)}
+	cr := pinsResource(false)
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	_, err = e.Update(context.Background(), cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would leave 52 pinned")
+	assert.Empty(t, pins.calls)
+}
+
+func TestDelete(t *testing.T) {
+	pins := &MockPinClient{pinned: []string{"9", "1", "3"}, unpinErr: &discordclient.APIError{StatusCode: 404, Code: 10008, Message: "Unknown Message"}}
+	e := &external{pins: pins, messages: &MockMessageClient{}, kube: newKube(t)}
+	cr := pinsResource(false)
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	_, err = e.Delete(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unpin 1", "unpin 3"}, pins.calls)
+}
@@ -0,0 +1,502 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package commandset reconciles CommandSets, which replace an application's
+// commands in a single bulk overwrite.
+package commandset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotCommandSet = "managed resource is not a CommandSet custom resource"
+)
+
+// currentApplication is the application ID that stands for the bot's own
+// application.
+const currentApplication = "@me"
+
+var commandTypes = map[applicationv1alpha1.CommandType]int{
+	applicationv1alpha1.CommandTypeChatInput: discordclient.ApplicationCommandTypeChatInput,
+	applicationv1alpha1.CommandTypeUser:      discordclient.ApplicationCommandTypeUser,
+	applicationv1alpha1.CommandTypeMessage:   discordclient.ApplicationCommandTypeMessage,
+}
+
+var optionTypes = map[applicationv1alpha1.CommandOptionType]int{
+	applicationv1alpha1.CommandOptionSubCommand:      discordclient.ApplicationCommandOptionSubCommand,
+	applicationv1alpha1.CommandOptionSubCommandGroup: discordclient.ApplicationCommandOptionSubCommandGroup,
+	applicationv1alpha1.CommandOptionString:          discordclient.ApplicationCommandOptionString,
+	applicationv1alpha1.CommandOptionInteger:         discordclient.ApplicationCommandOptionInteger,
+	applicationv1alpha1.CommandOptionBoolean:         discordclient.ApplicationCommandOptionBoolean,
+	applicationv1alpha1.CommandOptionUser:            discordclient.ApplicationCommandOptionUser,
+	applicationv1alpha1.CommandOptionChannel:         discordclient.ApplicationCommandOptionChannel,
+	applicationv1alpha1.CommandOptionRole:            discordclient.ApplicationCommandOptionRole,
+	applicationv1alpha1.CommandOptionMentionable:     discordclient.ApplicationCommandOptionMentionable,
+	applicationv1alpha1.CommandOptionNumber:          discordclient.ApplicationCommandOptionNumber,
+	applicationv1alpha1.CommandOptionAttachment:      discordclient.ApplicationCommandOptionAttachment,
+}
+
+// Setup adds a controller that reconciles CommandSet managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(applicationv1alpha1.CommandSetGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(applicationv1alpha1.CommandSetGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(applicationv1alpha1.CommandSetGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&applicationv1alpha1.CommandSet{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*applicationv1alpha1.CommandSet)
+	if !ok {
+		return nil, errors.New(errNotCommandSet)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+
+	return &external{applications: svc, commands: svc}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// applications resolves "@me" to the bot's application ID.
+	applications discordclient.ApplicationClient
+	commands     discordclient.ApplicationCommandClient
+}
+
+// applicationID returns the ID of the application that owns the commands,
+// resolving "@me", which the command endpoints do not accept.
+func (e *external) applicationID(ctx context.Context, cr *applicationv1alpha1.CommandSet) (string, error) {
+	id := cr.Spec.ForProvider.ApplicationID
+	if id != currentApplication {
+		return id, nil
+	}
+	if cr.Status.AtProvider.ApplicationID != "" {
+		return cr.Status.AtProvider.ApplicationID, nil
+	}
+	app, err := e.applications.GetCurrentApplication(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve current application")
+	}
+	cr.Status.AtProvider.ApplicationID = app.ID
+	return app.ID, nil
+}
+
+// guildID returns the ID of the guild the commands are scoped to, or "" for
+// global commands.
+func guildID(cr *applicationv1alpha1.CommandSet) string {
+	if cr.Spec.ForProvider.GuildID == nil {
+		return ""
+	}
+	return *cr.Spec.ForProvider.GuildID
+}
+
+// scope returns the external name of the set: the application ID, followed
+// by the guild ID for guild commands.
+func scope(appID string, cr *applicationv1alpha1.CommandSet) string {
+	if id := guildID(cr); id != "" {
+		return appID + "/" + id
+	}
+	return appID
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*applicationv1alpha1.CommandSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCommandSet)
+	}
+
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The scope's commands only belong to the set once it has overwritten
+	// them, which records the scope as its external name
+	if meta.GetExternalName(cr) != scope(appID, cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, err := e.commands.ListApplicationCommands(ctx, appID, guildID(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired, err := commands(cr.Spec.ForProvider.Commands)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	record(cr, observed)
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: commandsUpToDate(desired, observed),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*applicationv1alpha1.CommandSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCommandSet)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	appID, err := e.overwrite(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	meta.SetExternalName(cr, scope(appID, cr))
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*applicationv1alpha1.CommandSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCommandSet)
+	}
+
+	if _, err := e.overwrite(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete deletes every command in the set's scope.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*applicationv1alpha1.CommandSet)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotCommandSet)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	if _, err := e.commands.BulkOverwriteApplicationCommands(ctx, appID, guildID(cr), nil); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+// overwrite replaces the commands in the set's scope with the declared ones
+// and returns the application's ID.
+func (e *external) overwrite(ctx context.Context, cr *applicationv1alpha1.CommandSet) (string, error) {
+	appID, err := e.applicationID(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	desired, err := commands(cr.Spec.ForProvider.Commands)
+	if err != nil {
+		return "", err
+	}
+
+	overwritten, err := e.commands.BulkOverwriteApplicationCommands(ctx, appID, guildID(cr), desired)
+	if err != nil {
+		return "", err
+	}
+	record(cr, overwritten)
+	return appID, nil
+}
+
+// record reports the commands Discord has in status.
+func record(cr *applicationv1alpha1.CommandSet, observed []discordclient.ApplicationCommand) {
+	cr.Status.AtProvider.Commands = make([]applicationv1alpha1.CommandObservation, 0, len(observed))
+	for _, c := range observed {
+		cr.Status.AtProvider.Commands = append(cr.Status.AtProvider.Commands, applicationv1alpha1.CommandObservation{
+			ID:   c.ID,
+			Name: c.Name,
+			Type: commandTypeName(c.Type),
+		})
+	}
+	cr.Status.AtProvider.CommandCount = len(observed)
+}
+
+// commandType returns the type of a command, which Discord defaults to
+// ChatInput.
+func commandType(t int) int {
+	if t == 0 {
+		return discordclient.ApplicationCommandTypeChatInput
+	}
+	return t
+}
+
+func commandTypeName(t int) applicationv1alpha1.CommandType {
+	for name, v := range commandTypes {
+		if v == commandType(t) {
+			return name
+		}
+	}
+	return ""
+}
+
+// commands converts the declared commands into the ones Discord takes.
+func commands(declared []applicationv1alpha1.Command) ([]discordclient.ApplicationCommand, error) {
+	out := make([]discordclient.ApplicationCommand, 0, len(declared))
+	for _, c := range declared {
+		cmd := discordclient.ApplicationCommand{
+			Type:                     discordclient.ApplicationCommandTypeChatInput,
+			Name:                     c.Name,
+			Description:              c.Description,
+			DefaultMemberPermissions: c.DefaultMemberPermissions,
+			NSFW:                     c.NSFW != nil && *c.NSFW,
+		}
+		if c.Type != "" {
+			cmd.Type = commandTypes[c.Type]
+		}
+		var err error
+		if cmd.NameLocalizations, cmd.DescriptionLocalizations, err = localizations(c.Localizations); err != nil {
+			return nil, errors.Wrapf(err, "command %s", c.Name)
+		}
+		for _, o := range c.Options {
+			opt, err := option(o.CommandParameter)
+			if err != nil {
+				return nil, errors.Wrapf(err, "command %s", c.Name)
+			}
+			for _, so := range o.Options {
+				sub, err := option(so.CommandParameter)
+				if err != nil {
+					return nil, errors.Wrapf(err, "command %s", c.Name)
+				}
+				for _, p := range so.Options {
+					param, err := option(p)
+					if err != nil {
+						return nil, errors.Wrapf(err, "command %s", c.Name)
+					}
+					sub.Options = append(sub.Options, param)
+				}
+				opt.Options = append(opt.Options, sub)
+			}
+			cmd.Options = append(cmd.Options, opt)
+		}
+		out = append(out, cmd)
+	}
+	return out, nil
+}
+
+// option converts a declared option, without its own options.
+func option(p applicationv1alpha1.CommandParameter) (discordclient.ApplicationCommandOption, error) {
+	o := discordclient.ApplicationCommandOption{
+		Type:         optionTypes[p.Type],
+		Name:         p.Name,
+		Description:  p.Description,
+		Required:     p.Required != nil && *p.Required,
+		ChannelTypes: p.ChannelTypes,
+		MinLength:    p.MinLength,
+		MaxLength:    p.MaxLength,
+		Autocomplete: p.Autocomplete != nil && *p.Autocomplete,
+	}
+	var err error
+	if o.NameLocalizations, o.DescriptionLocalizations, err = localizations(p.Localizations); err != nil {
+		return o, errors.Wrapf(err, "option %s", p.Name)
+	}
+	if p.MinValue != nil {
+		v := float64(*p.MinValue)
+		o.MinValue = &v
+	}
+	if p.MaxValue != nil {
+		v := float64(*p.MaxValue)
+		o.MaxValue = &v
+	}
+	for _, c := range p.Choices {
+		choice := discordclient.ApplicationCommandOptionChoice{Name: c.Name, Value: c.Value}
+		for l, name := range c.Localizations {
+			if !discordclient.IsLocale(l) {
+				return o, errors.Errorf("option %s: choice %s: unknown locale %q", p.Name, c.Name, l)
+			}
+			if choice.NameLocalizations == nil {
+				choice.NameLocalizations = map[string]string{}
+			}
+			choice.NameLocalizations[l] = name
+		}
+		switch p.Type {
+		case applicationv1alpha1.CommandOptionInteger:
+			v, err := strconv.ParseInt(c.Value, 10, 64)
+			if err != nil {
+				return o, errors.Errorf("option %s: choice %s is not an integer", p.Name, c.Name)
+			}
+			choice.Value = v
+		case applicationv1alpha1.CommandOptionNumber:
+			v, err := strconv.ParseFloat(c.Value, 64)
+			if err != nil {
+				return o, errors.Errorf("option %s: choice %s is not a number", p.Name, c.Name)
+			}
+			choice.Value = v
+		}
+		o.Choices = append(o.Choices, choice)
+	}
+	return o, nil
+}
+
+// localizations splits declared translations into the name and description
+// translations Discord takes, rejecting locales Discord does not know.
+func localizations(declared map[string]applicationv1alpha1.CommandLocalization) (names, descriptions map[string]string, err error) {
+	for l, t := range declared {
+		if !discordclient.IsLocale(l) {
+			return nil, nil, errors.Errorf("unknown locale %q", l)
+		}
+		if t.Name != nil {
+			if names == nil {
+				names = map[string]string{}
+			}
+			names[l] = *t.Name
+		}
+		if t.Description != nil {
+			if descriptions == nil {
+				descriptions = map[string]string{}
+			}
+			descriptions[l] = *t.Description
+		}
+	}
+	return names, descriptions, nil
+}
+
+// commandsUpToDate reports whether Discord has exactly the desired commands.
+// Commands are matched by type and name, and the fields Discord adds, such
+// as IDs and versions, are ignored.
+func commandsUpToDate(desired, observed []discordclient.ApplicationCommand) bool {
+	if len(desired) != len(observed) {
+		return false
+	}
+	byKey := make(map[string]discordclient.ApplicationCommand, len(observed))
+	for _, c := range observed {
+		byKey[fmt.Sprintf("%d/%s", commandType(c.Type), c.Name)] = c
+	}
+	for _, want := range desired {
+		got, ok := byKey[fmt.Sprintf("%d/%s", commandType(want.Type), want.Name)]
+		if !ok || !reflect.DeepEqual(normalize(want), normalize(got)) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize returns the fields of a command a CommandSet declares, in a form
+// where values Discord omits or echoes back differently compare equal.
+func normalize(c discordclient.ApplicationCommand) discordclient.ApplicationCommand {
+	return discordclient.ApplicationCommand{
+		Type:                     commandType(c.Type),
+		Name:                     c.Name,
+		NameLocalizations:        normalizeLocalizations(c.NameLocalizations),
+		Description:              c.Description,
+		DescriptionLocalizations: normalizeLocalizations(c.DescriptionLocalizations),
+		Options:                  normalizeOptions(c.Options),
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+		NSFW:                     c.NSFW,
+	}
+}
+
+func normalizeOptions(options []discordclient.ApplicationCommandOption) []discordclient.ApplicationCommandOption {
+	if len(options) == 0 {
+		return nil
+	}
+	out := make([]discordclient.ApplicationCommandOption, len(options))
+	for i, o := range options {
+		o.Options = normalizeOptions(o.Options)
+		o.NameLocalizations = normalizeLocalizations(o.NameLocalizations)
+		o.DescriptionLocalizations = normalizeLocalizations(o.DescriptionLocalizations)
+		if len(o.ChannelTypes) == 0 {
+			o.ChannelTypes = nil
+		}
+		if len(o.Choices) == 0 {
+			o.Choices = nil
+		} else {
+			// Discord returns integer choices as JSON numbers, so values
+			// are compared as text
+			choices := make([]discordclient.ApplicationCommandOptionChoice, len(o.Choices))
+			for j, c := range o.Choices {
+				choices[j] = discordclient.ApplicationCommandOptionChoice{
+					Name:              c.Name,
+					NameLocalizations: normalizeLocalizations(c.NameLocalizations),
+					Value:             fmt.Sprint(c.Value),
+				}
+			}
+			o.Choices = choices
+		}
+		out[i] = o
+	}
+	return out
+}
+
+// normalizeLocalizations treats the empty translations Discord returns for
+// untranslated commands like none.
+func normalizeLocalizations(l map[string]string) map[string]string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
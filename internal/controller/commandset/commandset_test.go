@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commandset
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	applicationv1alpha1 "github.com/rossigee/provider-discord/apis/application/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MockApplicationClient struct {
+	discordclient.ApplicationClient
+}
+
+func (m *MockApplicationClient) GetCurrentApplication(ctx context.Context) (*discordclient.DiscordApplication, error) {
+	return &discordclient.DiscordApplication{ID: "42"}, nil
+}
+
+// MockCommandClient keeps the commands of a single scope, as Discord does.
+type MockCommandClient struct {
+	commands   []discordclient.ApplicationCommand
+	overwrites int
+}
+
+func (m *MockCommandClient) ListApplicationCommands(ctx context.Context, applicationID, guildID string) ([]discordclient.ApplicationCommand, error) {
+	return m.commands, nil
+}
+
+func (m *MockCommandClient) BulkOverwriteApplicationCommands(ctx context.Context, applicationID, guildID string, commands []discordclient.ApplicationCommand) ([]discordclient.ApplicationCommand, error) {
+	m.overwrites++
+	m.commands = nil
+	for i, c := range commands {
+		// Discord assigns IDs and versions and echoes integer choices back
+		// as JSON numbers
+		c.ID, c.Version = strconv.Itoa(i+1), "1"
+		for j := range c.Options {
+			for k := range c.Options[j].Choices {
+				if v, ok := c.Options[j].Choices[k].Value.(int64); ok {
+					c.Options[j].Choices[k].Value = float64(v)
+				}
+			}
+		}
+		m.commands = append(m.commands, c)
+	}
+	return m.commands, nil
+}
+
+func commandSet() *applicationv1alpha1.CommandSet {
+	guildID := "7"
+	required := true
+	return &applicationv1alpha1.CommandSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "bot-commands"},
+		Spec: applicationv1alpha1.CommandSetSpec{
+			ForProvider: applicationv1alpha1.CommandSetParameters{
+				ApplicationID: "@me",
+				GuildID:       &guildID,
+				Commands: []applicationv1alpha1.Command{
+					{
+						Name:        "roll",
+						Description: "Roll a die",
+						Options: []applicationv1alpha1.CommandOption{{
+							CommandParameter: applicationv1alpha1.CommandParameter{
+								Type:        applicationv1alpha1.CommandOptionInteger,
+								Name:        "sides",
+								Description: "Number of sides",
+								Required:    &required,
+								Choices:     []applicationv1alpha1.CommandOptionChoice{{Name: "d6", Value: "6"}, {Name: "d20", Value: "20"}},
+							},
+						}},
+					},
+					{Type: applicationv1alpha1.CommandTypeUser, Name: "Report"},
+				},
+			},
+		},
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	commands := &MockCommandClient{commands: []discordclient.ApplicationCommand{{ID: "9", Type: 1, Name: "legacy"}}}
+	e := &external{applications: &MockApplicationClient{}, commands: commands}
+	cr := commandSet()
+
+	// Commands Discord already has are not the set's until it overwrites them
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+
+	_, err = e.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "42/7", meta.GetExternalName(cr))
+	assert.Equal(t, 1, commands.overwrites)
+	assert.Equal(t, 2, cr.Status.AtProvider.CommandCount)
+	assert.Equal(t, applicationv1alpha1.CommandObservation{ID: "2", Name: "Report", Type: applicationv1alpha1.CommandTypeUser}, cr.Status.AtProvider.Commands[1])
+
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// A command edited outside the set is overwritten
+	commands.commands[0].Description = "Roll dice"
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+
+	cr.Spec.ForProvider.Commands = cr.Spec.ForProvider.Commands[:1]
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Len(t, commands.commands, 1)
+
+	_, err = e.Delete(ctx, cr)
+	require.NoError(t, err)
+	assert.Empty(t, commands.commands)
+}
+
+func TestCommandsInvalidChoice(t *testing.T) {
+	cr := commandSet()
+	cr.Spec.ForProvider.Commands[0].Options[0].Choices[0].Value = "six"
+	_, err := commands(cr.Spec.ForProvider.Commands)
+	assert.ErrorContains(t, err, "command roll: option sides: choice d6 is not an integer")
+}
+
+func TestCommandsSubCommands(t *testing.T) {
+	got, err := commands([]applicationv1alpha1.Command{{
+		Name:        "config",
+		Description: "Configure the bot",
+		Options: []applicationv1alpha1.CommandOption{{
+			CommandParameter: applicationv1alpha1.CommandParameter{Type: applicationv1alpha1.CommandOptionSubCommandGroup, Name: "alerts", Description: "Alerts"},
+			Options: []applicationv1alpha1.CommandSubOption{{
+				CommandParameter: applicationv1alpha1.CommandParameter{Type: applicationv1alpha1.CommandOptionSubCommand, Name: "channel", Description: "Set the alert channel"},
+				Options: []applicationv1alpha1.CommandParameter{
+					{Type: applicationv1alpha1.CommandOptionChannel, Name: "target", Description: "Channel", ChannelTypes: []int{0}},
+				},
+			}},
+		}},
+	}})
+	require.NoError(t, err)
+
+	group := got[0].Options[0]
+	assert.Equal(t, discordclient.ApplicationCommandOptionSubCommandGroup, group.Type)
+	assert.Equal(t, discordclient.ApplicationCommandOptionSubCommand, group.Options[0].Type)
+	assert.Equal(t, discordclient.ApplicationCommandOption{
+		Type: discordclient.ApplicationCommandOptionChannel, Name: "target", Description: "Channel", ChannelTypes: []int{0},
+	}, group.Options[0].Options[0])
+}
+
+func TestCommandsLocalizations(t *testing.T) {
+	name, description := "würfeln", "Würfle einen Würfel"
+	cr := commandSet()
+	roll := &cr.Spec.ForProvider.Commands[0]
+	roll.Localizations = map[string]applicationv1alpha1.CommandLocalization{
+		"de":    {Name: &name, Description: &description},
+		"pt-BR": {Description: &description},
+	}
+	roll.Options[0].Choices[0].Localizations = map[string]string{"de": "W6"}
+
+	got, err := commands(cr.Spec.ForProvider.Commands)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"de": name}, got[0].NameLocalizations)
+	assert.Equal(t, map[string]string{"de": description, "pt-BR": description}, got[0].DescriptionLocalizations)
+	assert.Equal(t, map[string]string{"de": "W6"}, got[0].Options[0].Choices[0].NameLocalizations)
+
+	// Discord returns empty translations for commands that have none
+	observed, err := commands(cr.Spec.ForProvider.Commands)
+	require.NoError(t, err)
+	observed[1].NameLocalizations, observed[1].DescriptionLocalizations = map[string]string{}, map[string]string{}
+	assert.True(t, commandsUpToDate(got, observed))
+
+	observed[0].DescriptionLocalizations["de"] = "Würfeln"
+	assert.False(t, commandsUpToDate(got, observed))
+
+	roll.Options[0].Localizations = map[string]applicationv1alpha1.CommandLocalization{"de-DE": {Name: &name}}
+	_, err = commands(cr.Spec.ForProvider.Commands)
+	assert.ErrorContains(t, err, `command roll: option sides: unknown locale "de-DE"`)
+}
@@ -18,6 +18,7 @@ package config
 
 import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/rossigee/provider-discord/internal/tuning"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -38,7 +39,7 @@ func TestSetup(t *testing.T) {
 	}()
 
 	opts := controller.Options{}
-	err := Setup(nil, opts)
+	err := Setup(nil, opts, tuning.Default())
 
 	// We expect an error since we passed nil manager, but no panic
 	assert.Error(t, err)
@@ -18,63 +18,108 @@ package controller
 
 import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/pkg/errors"
 	"github.com/rossigee/provider-discord/internal/clients"
 	"github.com/rossigee/provider-discord/internal/controller/application"
+	"github.com/rossigee/provider-discord/internal/controller/applicationemoji"
+	"github.com/rossigee/provider-discord/internal/controller/auditlogexport"
+	"github.com/rossigee/provider-discord/internal/controller/banlist"
+	"github.com/rossigee/provider-discord/internal/controller/category"
 	"github.com/rossigee/provider-discord/internal/controller/channel"
+	"github.com/rossigee/provider-discord/internal/controller/channelpins"
+	"github.com/rossigee/provider-discord/internal/controller/commandset"
 	"github.com/rossigee/provider-discord/internal/controller/deduplication"
 	"github.com/rossigee/provider-discord/internal/controller/garbagecollection"
 	"github.com/rossigee/provider-discord/internal/controller/guild"
+	"github.com/rossigee/provider-discord/internal/controller/guildset"
 	"github.com/rossigee/provider-discord/internal/controller/integration"
+	"github.com/rossigee/provider-discord/internal/controller/integrationpolicy"
 	"github.com/rossigee/provider-discord/internal/controller/invite"
+	"github.com/rossigee/provider-discord/internal/controller/invitepolicy"
 	"github.com/rossigee/provider-discord/internal/controller/member"
 	"github.com/rossigee/provider-discord/internal/controller/role"
+	"github.com/rossigee/provider-discord/internal/controller/rolerollout"
+	"github.com/rossigee/provider-discord/internal/controller/scheduledmessage"
+	"github.com/rossigee/provider-discord/internal/controller/statusmessage"
 	"github.com/rossigee/provider-discord/internal/controller/user"
 	"github.com/rossigee/provider-discord/internal/controller/webhook"
 	"github.com/rossigee/provider-discord/internal/metrics"
+	"github.com/rossigee/provider-discord/internal/tuning"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 // Setup creates all Discord controllers with the supplied logger and adds them to
 // the supplied manager.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	return SetupWithMetrics(mgr, o, nil)
+	return SetupWithMetrics(mgr, o, tuning.Default(), nil)
 }
 
-// SetupWithMetrics creates all Discord controllers with metrics support and adds them to
+// SetupWithMetrics creates the Discord controllers t enables with metrics support and adds them to
 // the supplied manager.
-func SetupWithMetrics(mgr ctrl.Manager, o controller.Options, metricsRecorder *metrics.MetricsRecorder) error {
+func SetupWithMetrics(mgr ctrl.Manager, o controller.Options, t tuning.Options, metricsRecorder *metrics.MetricsRecorder) error {
 	// Setup all controllers using regular Setup functions
 	// The metrics will be integrated at the client level
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+	controllers := []struct {
+		kind  string
+		setup func(ctrl.Manager, controller.Options, tuning.Options) error
+	}{
 		// NOTE: ProviderConfig controller removed - crossplane-runtime handles this automatically
 		// config.Setup,
 		// v1alpha1 controllers (cluster-scoped)
-		channel.Setup,
-		guild.Setup,
-		role.Setup,
-		webhook.Setup,
-		invite.Setup,
-		member.Setup,
-		user.Setup,
-		application.Setup,
-		integration.Setup,
+		{"channel", channel.Setup},
+		{"category", category.Setup},
+		{"channelpins", channelpins.Setup},
+		{"guild", guild.Setup},
+		{"guildset", guildset.Setup},
+		{"auditlogexport", auditlogexport.Setup},
+		{"role", role.Setup},
+		{"rolerollout", rolerollout.Setup},
+		{"webhook", webhook.Setup},
+		{"invite", invite.Setup},
+		{"invitepolicy", invitepolicy.Setup},
+		{"member", member.Setup},
+		{"user", user.Setup},
+		{"application", application.Setup},
+		{"applicationemoji", applicationemoji.Setup},
+		{"commandset", commandset.Setup},
+		{"integration", integration.Setup},
+		{"integrationpolicy", integrationpolicy.Setup},
+		{"banlist", banlist.Setup},
+		{"scheduledmessage", scheduledmessage.Setup},
+		// ProviderConfig controllers
+		{"deduplication", func(mgr ctrl.Manager, _ controller.Options, _ tuning.Options) error {
+			// Watches ProviderConfig annotations
+			return deduplication.Setup(mgr)
+		}},
+		{"garbagecollection", func(mgr ctrl.Manager, _ controller.Options, _ tuning.Options) error {
+			// Autonomous cleanup management
+			return (&garbagecollection.ProviderConfigReconciler{}).SetupWithManager(mgr)
+		}},
+		{"statusmessage", func(mgr ctrl.Manager, _ controller.Options, _ tuning.Options) error {
+			// Resource health dashboard posted in Discord
+			return (&statusmessage.ProviderConfigReconciler{}).SetupWithManager(mgr)
+		}},
 		// v1beta1 controllers (namespaced) - Planned for v2 migration
 		// Will be added once v1beta1 APIs are properly generated
-	} {
-		if err := setup(mgr, o); err != nil {
-			return err
-		}
 	}
 
-	// Setup deduplication controller (watches ProviderConfig annotations)
-	if err := deduplication.Setup(mgr); err != nil {
-		return err
+	known := make(map[string]bool, len(controllers))
+	for _, c := range controllers {
+		known[c.kind] = true
+	}
+	for _, kind := range t.Kinds {
+		if !known[kind] {
+			return errors.Errorf("unknown controller kind %q", kind)
+		}
 	}
 
-	// Setup garbage collection controller (autonomous cleanup management)
-	gc := &garbagecollection.ProviderConfigReconciler{}
-	if err := gc.SetupWithManager(mgr); err != nil {
-		return err
+	for _, c := range controllers {
+		if !t.Enabled(c.kind) {
+			continue
+		}
+		if err := c.setup(mgr, t.ForKind(c.kind, o), t); err != nil {
+			return err
+		}
 	}
 
 	// Set the global metrics recorder for client use
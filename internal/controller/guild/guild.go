@@ -18,6 +18,7 @@ package guild
 
 import (
 	"context"
+	"fmt"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -27,10 +28,16 @@ import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	userv1alpha1 "github.com/rossigee/provider-discord/apis/user/v1alpha1"
 	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
 	"strings"
 	"time"
 )
@@ -40,26 +47,33 @@ const (
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
 	errGetCreds     = "cannot get credentials"
+
+	errGetOwner          = "cannot get owner User"
+	errOwnerPending      = "waiting for User %s to report its Discord ID"
+	errOwnershipTransfer = "guild is owned by %s, not %s; set allowOwnershipTransfer: true to transfer ownership"
 )
 
 // Setup adds a controller that reconciles Guild managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(guildv1alpha1.GuildGroupKind.String())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(guildv1alpha1.GuildGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
 			newServiceFn: clients.NewDiscordClient,
-		}),
+			recorder:     recorder,
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(recorder))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(guildv1alpha1.GuildGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&guildv1alpha1.Guild{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -71,6 +85,7 @@ type connector struct {
 	kube         client.Client
 	usage        resource.ModernTracker
 	newServiceFn func(token string) *clients.DiscordClient
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -95,14 +110,45 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(*token)
 
-	return &external{service: svc, kube: c.kube}, nil
+	snapshots := clients.NewSnapshotClient(svc)
+
+	return &external{service: svc, permissions: svc, invites: svc, widgets: svc, screening: svc, users: svc, templates: svc, inviteChecks: sharedInviteChecks, roles: snapshots, channels: snapshots, kube: c.kube, recorder: c.recorder}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	service clients.GuildClient
-	kube    client.Client
+	// permissions resolves the bot's guild permissions for pre-flight
+	// checks; the checks are skipped when it is nil.
+	permissions clients.PermissionClient
+	// invites manages the guild's primary invite; it is left unmanaged
+	// when nil.
+	invites clients.InviteClient
+	// inviteChecks remembers primary invites found recently, so they are
+	// not looked up on every poll; they are looked up every time when nil.
+	inviteChecks *inviteChecks
+	// widgets reads the guild's public widget for status; the widget is
+	// not reported when it is nil.
+	widgets clients.WidgetClient
+	// screening manages the guild's membership screening; it is left
+	// unmanaged when nil.
+	screening clients.MemberVerificationClient
+	// users lets the bot leave the guild when it is deleted with the Leave
+	// deletion mode.
+	users clients.UserClient
+	// templates reads the template the guild is restored from; the guild
+	// is not compared with a template when it is nil.
+	templates clients.GuildTemplateClient
+	roles     clients.RoleClient
+	channels  clients.ChannelClient
+	kube      client.Client
+	recorder  event.Recorder
+
+	// observed is the guild the last Observe fetched. The managed reconciler
+	// connects for every reconcile, so Update diffs against it rather than
+	// fetching the guild again.
+	observed *clients.Guild
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -118,12 +164,20 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		guild, err := c.service.GetGuild(ctx, meta.GetExternalName(cr))
 		if err != nil {
 			// Check if it's a 404 (guild not found)
-			if strings.Contains(err.Error(), "Discord API error: 404") {
+			if clients.IsNotFound(err) {
 				log.Info("Guild not found, marking as non-existent", "guildID", meta.GetExternalName(cr))
 				return managed.ExternalObservation{
 					ResourceExists: false,
 				}, nil
 			}
+			// A guild the bot has left answers with Missing Access rather
+			// than 404, and is gone as far as a Leave deletion goes
+			if meta.WasDeleted(cr) && leaves(cr) && clients.IsBotNotInGuild(err) {
+				log.Info("Guild left, marking as non-existent", "guildID", meta.GetExternalName(cr))
+				return managed.ExternalObservation{
+					ResourceExists: false,
+				}, nil
+			}
 			return managed.ExternalObservation{}, errors.Wrap(err, "failed to get guild by ID")
 		}
 
@@ -132,8 +186,18 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 				ResourceExists: false,
 			}, nil
 		}
-
-		// Update status with observed values
+		c.observed = guild
+
+		// Update status with observed values, keeping the primary invite
+		// we created and the checksums of images we uploaded, which only
+		// the status records
+		primaryInviteCode := cr.Status.AtProvider.PrimaryInviteCode
+		bannerChecksum := cr.Status.AtProvider.BannerChecksum
+		discoverySplashChecksum := cr.Status.AtProvider.DiscoverySplashChecksum
+		lastAppliedPlanID := ""
+		if r := cr.Status.AtProvider.TemplateRestore; r != nil {
+			lastAppliedPlanID = r.LastAppliedPlanID
+		}
 		now := &metav1.Time{Time: time.Now()}
 		cr.Status.AtProvider = guildv1alpha1.GuildObservation{
 			ID:                          guild.ID,
@@ -145,6 +209,13 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 			Features:                    guild.Features,
 			AFKTimeout:                  guild.AFKTimeout,
 			SystemChannelFlags:          guild.SystemChannelFlags,
+			MFALevel:                    guild.MFALevel,
+			PreferredLocale:             guild.PreferredLocale,
+			PremiumProgressBarEnabled:   guild.PremiumProgressBarEnabled,
+			PrimaryInviteCode:           primaryInviteCode,
+			BannerChecksum:              bannerChecksum,
+			DiscoverySplashChecksum:     discoverySplashChecksum,
+			CreatedAt:                   clients.CreatedAt(guild.ID),
 			UpdatedAt:                   now,
 		}
 
@@ -162,17 +233,57 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}
 		if guild.ApproximateMemberCount != nil {
 			cr.Status.AtProvider.MemberCount = *guild.ApproximateMemberCount
+			c.recordMemberCount(cr, memberCountCondition(cr.Spec.ForProvider, cr.Status.AtProvider.MemberCount))
+		}
+		if guild.VanityURLCode != nil {
+			cr.Status.AtProvider.VanityURLCode = *guild.VanityURLCode
+		}
+		if guild.Description != nil {
+			cr.Status.AtProvider.Description = *guild.Description
+		}
+		if guild.Banner != nil {
+			cr.Status.AtProvider.Banner = *guild.Banner
+		}
+		if guild.DiscoverySplash != nil {
+			cr.Status.AtProvider.DiscoverySplash = *guild.DiscoverySplash
+		}
+		setSlotUsage(cr, guild)
+		c.setWidget(ctx, cr)
+		inviteUpToDate := c.primaryInviteUpToDate(ctx, cr)
+		screeningUpToDate := c.membershipScreeningUpToDate(ctx, cr, guild)
+		setInviteURL(cr)
+
+		templateUpToDate, err := c.templateRestoreUpToDate(ctx, cr, lastAppliedPlanID)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+
+		owner, err := c.resolveOwner(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
 		}
 
 		cr.SetConditions(xpv1.Available())
 
+		changes := guildChanges(cr, guild)
+		if owner != "" && owner != guild.OwnerID {
+			changes = append(changes, conditions.Change("ownerId", guild.OwnerID, owner))
+		}
+		if !inviteUpToDate {
+			changes = append(changes, "primaryInvite: out of date")
+		}
+		if !screeningUpToDate {
+			changes = append(changes, "membershipScreening: out of date")
+		}
+		if !templateUpToDate {
+			changes = append(changes, "templateRestore: not applied")
+		}
+
 		return managed.ExternalObservation{
-			ResourceExists:   true,
-			ResourceUpToDate: c.isUpToDate(cr, guild),
-			ConnectionDetails: managed.ConnectionDetails{
-				"guildId":   []byte(guild.ID),
-				"guildName": []byte(guild.Name),
-			},
+			ResourceExists:    true,
+			ResourceUpToDate:  len(changes) == 0,
+			Diff:              conditions.Diff(changes),
+			ConnectionDetails: connectionDetails(cr, guild),
 		}, nil
 	}
 
@@ -182,55 +293,392 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
-func (c *external) isUpToDate(cr *guildv1alpha1.Guild, guild *clients.Guild) bool {
-	// Check if name needs to be updated
-	if cr.Spec.ForProvider.Name != guild.Name {
-		return false
+// recordMemberCount sets the MemberCountThreshold condition, emitting a
+// warning event when the member count first crosses a threshold. Guilds
+// without thresholds only get the condition cleared if it was set.
+func (c *external) recordMemberCount(cr *guildv1alpha1.Guild, cond *xpv1.Condition) {
+	previous := cr.GetCondition(conditions.TypeMemberCountThreshold)
+	if cond == nil {
+		if previous.Status == corev1.ConditionTrue {
+			cr.SetConditions(conditions.MemberCountInRange())
+		}
+		return
 	}
+	if cond.Status == corev1.ConditionTrue && (previous.Status != corev1.ConditionTrue || previous.Reason != cond.Reason) && c.recorder != nil {
+		c.recorder.Event(cr, event.Warning(event.Reason(cond.Reason), errors.New(cond.Message)))
+	}
+	cr.SetConditions(*cond)
+}
 
-	// Check if region needs to be updated
-	if cr.Spec.ForProvider.Region != nil {
-		if guild.Region == nil || *cr.Spec.ForProvider.Region != *guild.Region {
-			return false
+// memberCountCondition reports whether count is outside the member count
+// thresholds in p, or returns nil when p sets none.
+func memberCountCondition(p guildv1alpha1.GuildParameters, count int) *xpv1.Condition {
+	if p.AlertOnMemberCountBelow == nil && p.AlertOnMemberCountAbove == nil {
+		return nil
+	}
+	var cond xpv1.Condition
+	switch {
+	case p.AlertOnMemberCountBelow != nil && count < *p.AlertOnMemberCountBelow:
+		cond = conditions.MemberCountOutOfRange(conditions.ReasonMemberCountBelow,
+			fmt.Sprintf("guild has about %d members, below the threshold of %d", count, *p.AlertOnMemberCountBelow))
+	case p.AlertOnMemberCountAbove != nil && count > *p.AlertOnMemberCountAbove:
+		cond = conditions.MemberCountOutOfRange(conditions.ReasonMemberCountAbove,
+			fmt.Sprintf("guild has about %d members, above the threshold of %d", count, *p.AlertOnMemberCountAbove))
+	default:
+		cond = conditions.MemberCountInRange()
+	}
+	return &cond
+}
+
+// setSlotUsage reports how many of the guild's emoji and sticker slots are
+// used, so compositions can stop adding them before Discord refuses.
+// Emojis managed by integrations do not take up slots.
+func setSlotUsage(cr *guildv1alpha1.Guild, guild *clients.Guild) {
+	var static, animated int
+	for _, e := range guild.Emojis {
+		switch {
+		case e.Managed:
+		case e.Animated:
+			animated++
+		default:
+			static++
 		}
 	}
+	limits := clients.LimitsForGuild(guild)
 
-	// Check if verification level needs to be updated
-	if cr.Spec.ForProvider.VerificationLevel != nil {
-		if *cr.Spec.ForProvider.VerificationLevel != guild.VerificationLevel {
-			return false
+	cr.Status.AtProvider.PremiumTier = guild.PremiumTier
+	cr.Status.AtProvider.Emojis = &guildv1alpha1.SlotUsage{Used: static, Limit: limits.EmojiSlots}
+	cr.Status.AtProvider.AnimatedEmojis = &guildv1alpha1.SlotUsage{Used: animated, Limit: limits.EmojiSlots}
+	cr.Status.AtProvider.Stickers = &guildv1alpha1.SlotUsage{Used: len(guild.Stickers), Limit: limits.StickerSlots}
+}
+
+// setWidget reports the guild's public widget, which gives a lightweight
+// view of its health without privileged intents. The widget is left out of
+// the status when it is disabled or cannot be read.
+func (c *external) setWidget(ctx context.Context, cr *guildv1alpha1.Guild) {
+	if c.widgets == nil {
+		return
+	}
+	widget, err := c.widgets.GetGuildWidget(ctx, cr.Status.AtProvider.ID)
+	if err != nil {
+		if clients.ErrorCode(err) != clients.ErrorCodeWidgetDisabled {
+			ctrl.LoggerFrom(ctx).V(1).Info("Cannot read guild widget", "guildID", cr.Status.AtProvider.ID, "error", err)
 		}
+		return
+	}
+	if widget == nil {
+		return
 	}
+	cr.Status.AtProvider.Widget = &guildv1alpha1.WidgetObservation{
+		PresenceCount: widget.PresenceCount,
+		ImageURL:      clients.WidgetImageURL(cr.Status.AtProvider.ID, ""),
+	}
+	if widget.InstantInvite != nil {
+		cr.Status.AtProvider.Widget.InstantInvite = *widget.InstantInvite
+	}
+}
 
-	// Check if default message notifications needs to be updated
-	if cr.Spec.ForProvider.DefaultMessageNotifications != nil {
-		if *cr.Spec.ForProvider.DefaultMessageNotifications != guild.DefaultMessageNotifications {
-			return false
-		}
+// connectionDetails publishes the guild's identity and join links.
+func connectionDetails(cr *guildv1alpha1.Guild, guild *clients.Guild) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		"guildId":   []byte(guild.ID),
+		"guildName": []byte(guild.Name),
+	}
+	if code := cr.Status.AtProvider.VanityURLCode; code != "" {
+		cd["vanityUrl"] = []byte(clients.InviteURL(code))
 	}
+	if code := cr.Status.AtProvider.PrimaryInviteCode; code != "" {
+		cd["primaryInviteUrl"] = []byte(clients.InviteURL(code))
+	}
+	if url := cr.Status.AtProvider.InviteURL; url != "" {
+		cd["inviteUrl"] = []byte(url)
+	}
+	return cd
+}
 
-	// Check if explicit content filter needs to be updated
-	if cr.Spec.ForProvider.ExplicitContentFilter != nil {
-		if *cr.Spec.ForProvider.ExplicitContentFilter != guild.ExplicitContentFilter {
-			return false
-		}
+// setInviteURL sets the guild's canonical join link, preferring its vanity
+// URL to its primary invite.
+func setInviteURL(cr *guildv1alpha1.Guild) {
+	switch {
+	case cr.Status.AtProvider.VanityURLCode != "":
+		cr.Status.AtProvider.InviteURL = clients.InviteURL(cr.Status.AtProvider.VanityURLCode)
+	case cr.Status.AtProvider.PrimaryInviteCode != "":
+		cr.Status.AtProvider.InviteURL = clients.InviteURL(cr.Status.AtProvider.PrimaryInviteCode)
+	default:
+		cr.Status.AtProvider.InviteURL = ""
 	}
+}
 
-	// Check if AFK timeout needs to be updated
-	if cr.Spec.ForProvider.AFKTimeout != nil {
-		if *cr.Spec.ForProvider.AFKTimeout != guild.AFKTimeout {
+// membershipScreeningUpToDate reports whether the guild's membership
+// screening matches its configuration, reporting it in status. Guilds
+// without one are not checked, and a form that cannot be read is assumed to
+// be up to date.
+func (c *external) membershipScreeningUpToDate(ctx context.Context, cr *guildv1alpha1.Guild, guild *clients.Guild) bool {
+	p := cr.Spec.ForProvider.MembershipScreening
+	if c.screening == nil || p == nil {
+		return true
+	}
+	enabled := hasFeature(guild, clients.GuildFeatureMemberVerificationGate)
+	cr.Status.AtProvider.MembershipScreening = &guildv1alpha1.MembershipScreeningObservation{Enabled: enabled}
+
+	verification, err := c.screening.GetGuildMemberVerification(ctx, guild.ID)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("Cannot read membership screening", "guildID", guild.ID, "error", err)
+		return p.Enabled == enabled
+	}
+	cr.Status.AtProvider.MembershipScreening.Version = verification.Version
+	cr.Status.AtProvider.MembershipScreening.FormFieldCount = len(verification.FormFields)
+
+	if p.Enabled != enabled {
+		return false
+	}
+	if p.Description != nil && (verification.Description == nil || *p.Description != *verification.Description) {
+		return false
+	}
+	return p.FormFields == nil || formFieldsEqual(formFields(p.FormFields), verification.FormFields)
+}
+
+// updateMembershipScreening applies the configured membership screening
+// when it differs from the observed guild's.
+func (c *external) updateMembershipScreening(ctx context.Context, cr *guildv1alpha1.Guild, observed *clients.Guild) error {
+	p := cr.Spec.ForProvider.MembershipScreening
+	if c.screening == nil || p == nil || c.membershipScreeningUpToDate(ctx, cr, observed) {
+		return nil
+	}
+	if !hasFeature(observed, "COMMUNITY") {
+		return errors.New("cannot update guild: membershipScreening requires the COMMUNITY feature")
+	}
+
+	req := &clients.ModifyMemberVerificationRequest{Enabled: &p.Enabled, Description: p.Description}
+	if p.FormFields != nil {
+		req.FormFields = formFields(p.FormFields)
+	}
+	verification, err := c.screening.ModifyGuildMemberVerification(ctx, meta.GetExternalName(cr), req)
+	if err != nil {
+		return errors.Wrap(err, "failed to update membership screening")
+	}
+	cr.Status.AtProvider.MembershipScreening = &guildv1alpha1.MembershipScreeningObservation{
+		Enabled:        p.Enabled,
+		Version:        verification.Version,
+		FormFieldCount: len(verification.FormFields),
+	}
+	return nil
+}
+
+// formFields converts configured membership screening fields to Discord's.
+func formFields(fields []guildv1alpha1.MembershipScreeningField) []clients.MemberVerificationField {
+	out := make([]clients.MemberVerificationField, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, clients.MemberVerificationField{
+			FieldType: f.Type,
+			Label:     f.Label,
+			Values:    f.Rules,
+			Choices:   f.Choices,
+			Required:  f.Required == nil || *f.Required,
+		})
+	}
+	return out
+}
+
+// formFieldsEqual compares the parts of membership screening fields that
+// can be configured.
+func formFieldsEqual(desired, observed []clients.MemberVerificationField) bool {
+	if len(desired) != len(observed) {
+		return false
+	}
+	for i := range desired {
+		d, o := desired[i], observed[i]
+		if d.FieldType != o.FieldType || d.Label != o.Label || d.Required != o.Required ||
+			!slices.Equal(d.Values, o.Values) || !slices.Equal(d.Choices, o.Choices) {
 			return false
 		}
 	}
+	return true
+}
 
-	// Check if system channel flags needs to be updated
-	if cr.Spec.ForProvider.SystemChannelFlags != nil {
-		if *cr.Spec.ForProvider.SystemChannelFlags != guild.SystemChannelFlags {
-			return false
+func (c *external) isUpToDate(cr *guildv1alpha1.Guild, guild *clients.Guild) bool {
+	return len(guildChanges(cr, guild)) == 0
+}
+
+// guildChanges describes the guild settings an update would change, from
+// their observed to their desired values. Images are only reported as
+// replaced, since their data is large and Discord reports hashes of its own.
+func guildChanges(cr *guildv1alpha1.Guild, guild *clients.Guild) []string {
+	req, _ := generateModifyGuildRequest(cr.Spec.ForProvider, cr.Status.AtProvider, guild)
+	optional := func(v *string) string {
+		if v == nil {
+			return ""
 		}
+		return *v
 	}
 
-	return true
+	var changes []string
+	if req.Name != nil {
+		changes = append(changes, conditions.Change("name", guild.Name, *req.Name))
+	}
+	if req.Region != nil {
+		changes = append(changes, conditions.Change("region", optional(guild.Region), *req.Region))
+	}
+	if req.VerificationLevel != nil {
+		changes = append(changes, conditions.Change("verificationLevel", guild.VerificationLevel, *req.VerificationLevel))
+	}
+	if req.DefaultMessageNotifications != nil {
+		changes = append(changes, conditions.Change("defaultMessageNotifications", guild.DefaultMessageNotifications, *req.DefaultMessageNotifications))
+	}
+	if req.ExplicitContentFilter != nil {
+		changes = append(changes, conditions.Change("explicitContentFilter", guild.ExplicitContentFilter, *req.ExplicitContentFilter))
+	}
+	if req.AFKTimeout != nil {
+		changes = append(changes, conditions.Change("afkTimeout", guild.AFKTimeout, *req.AFKTimeout))
+	}
+	if req.SystemChannelFlags != nil {
+		changes = append(changes, conditions.Change("systemChannelFlags", guild.SystemChannelFlags, *req.SystemChannelFlags))
+	}
+	if req.PreferredLocale != nil {
+		changes = append(changes, conditions.Change("preferredLocale", guild.PreferredLocale, *req.PreferredLocale))
+	}
+	if req.PremiumProgressBarEnabled != nil {
+		changes = append(changes, conditions.Change("premiumProgressBarEnabled", guild.PremiumProgressBarEnabled, *req.PremiumProgressBarEnabled))
+	}
+	if req.Description != nil {
+		changes = append(changes, conditions.Change("description", optional(guild.Description), *req.Description))
+	}
+	if req.Banner != nil {
+		changes = append(changes, "banner: replaced")
+	}
+	if req.DiscoverySplash != nil {
+		changes = append(changes, "discoverySplash: replaced")
+	}
+	if cr.Spec.ForProvider.MFALevel != nil && *cr.Spec.ForProvider.MFALevel != guild.MFALevel {
+		changes = append(changes, conditions.Change("mfaLevel", guild.MFALevel, *cr.Spec.ForProvider.MFALevel))
+	}
+	return changes
+}
+
+// generateModifyGuildRequest compares the desired parameters with the
+// observed guild and returns a request containing only the fields that differ.
+// Optional fields left unset in the spec are not managed, so values changed in
+// Discord by hand or by other bots are left alone. Images are compared with
+// the checksums in at of the images last uploaded, since Discord only
+// reports hashes of its own.
+func generateModifyGuildRequest(spec guildv1alpha1.GuildParameters, at guildv1alpha1.GuildObservation, guild *clients.Guild) (*clients.ModifyGuildRequest, bool) {
+	req := &clients.ModifyGuildRequest{}
+	needsUpdate := false
+
+	if spec.Name != guild.Name {
+		req.Name = &spec.Name
+		needsUpdate = true
+	}
+
+	if spec.Region != nil && (guild.Region == nil || *spec.Region != *guild.Region) {
+		req.Region = spec.Region
+		needsUpdate = true
+	}
+
+	if spec.VerificationLevel != nil && *spec.VerificationLevel != guild.VerificationLevel {
+		req.VerificationLevel = spec.VerificationLevel
+		needsUpdate = true
+	}
+
+	if spec.DefaultMessageNotifications != nil && *spec.DefaultMessageNotifications != guild.DefaultMessageNotifications {
+		req.DefaultMessageNotifications = spec.DefaultMessageNotifications
+		needsUpdate = true
+	}
+
+	if spec.ExplicitContentFilter != nil && *spec.ExplicitContentFilter != guild.ExplicitContentFilter {
+		req.ExplicitContentFilter = spec.ExplicitContentFilter
+		needsUpdate = true
+	}
+
+	if spec.AFKTimeout != nil && *spec.AFKTimeout != guild.AFKTimeout {
+		req.AFKTimeout = spec.AFKTimeout
+		needsUpdate = true
+	}
+
+	if spec.SystemChannelFlags != nil && *spec.SystemChannelFlags != guild.SystemChannelFlags {
+		req.SystemChannelFlags = spec.SystemChannelFlags
+		needsUpdate = true
+	}
+
+	if spec.PreferredLocale != nil && *spec.PreferredLocale != guild.PreferredLocale {
+		req.PreferredLocale = spec.PreferredLocale
+		needsUpdate = true
+	}
+
+	if spec.PremiumProgressBarEnabled != nil && *spec.PremiumProgressBarEnabled != guild.PremiumProgressBarEnabled {
+		req.PremiumProgressBarEnabled = spec.PremiumProgressBarEnabled
+		needsUpdate = true
+	}
+
+	if spec.Description != nil && (guild.Description == nil || *spec.Description != *guild.Description) {
+		req.Description = spec.Description
+		needsUpdate = true
+	}
+
+	if spec.Banner != nil && (guild.Banner == nil || clients.ImageChecksum(*spec.Banner) != at.BannerChecksum) {
+		req.Banner = spec.Banner
+		needsUpdate = true
+	}
+
+	if spec.DiscoverySplash != nil && (guild.DiscoverySplash == nil || clients.ImageChecksum(*spec.DiscoverySplash) != at.DiscoverySplashChecksum) {
+		req.DiscoverySplash = spec.DiscoverySplash
+		needsUpdate = true
+	}
+
+	return req, needsUpdate
+}
+
+// resolveOwner returns the ID of the user who should own the guild, from
+// ownerId or the User named by ownerRef, or "" if neither is set.
+func (c *external) resolveOwner(ctx context.Context, cr *guildv1alpha1.Guild) (string, error) {
+	p := cr.Spec.ForProvider
+	if p.OwnerID != nil {
+		return *p.OwnerID, nil
+	}
+	if p.OwnerRef == nil {
+		return "", nil
+	}
+
+	user := &userv1alpha1.User{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: p.OwnerRef.Name}, user); err != nil {
+		return "", errors.Wrap(err, errGetOwner)
+	}
+	if id := user.Status.AtProvider.ID; id != "" {
+		return id, nil
+	}
+	if id := user.Spec.ForProvider.UserID; id != "@me" {
+		return id, nil
+	}
+	return "", conditions.NewChildPendingError(fmt.Sprintf(errOwnerPending, p.OwnerRef.Name))
+}
+
+// checkFeatures returns an error if req sets fields that need guild features
+// the guild lacks, rather than sending a request Discord will reject.
+func checkFeatures(req *clients.ModifyGuildRequest, guild *clients.Guild) error {
+	var missing []string
+	if req.Description != nil && !hasFeature(guild, "COMMUNITY") {
+		missing = append(missing, "description requires the COMMUNITY feature")
+	}
+	if req.Banner != nil && !hasFeature(guild, "BANNER") {
+		missing = append(missing, "banner requires the BANNER feature (server boost level 2)")
+	}
+	if req.DiscoverySplash != nil && !hasFeature(guild, "DISCOVERABLE") {
+		missing = append(missing, "discoverySplash requires the DISCOVERABLE feature")
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("cannot update guild: %s", strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+// hasFeature reports whether guild has the named feature.
+func hasFeature(guild *clients.Guild, feature string) bool {
+	for _, f := range guild.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -241,6 +689,15 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
+	// Discord is the final arbiter, so a failed pre-flight count does not
+	// block creation
+	if err := clients.CheckGuildCreateAllowed(ctx, c.service); err != nil {
+		if clients.IsGuildCreateNotAllowed(err) {
+			return managed.ExternalCreation{}, err
+		}
+		ctrl.LoggerFrom(ctx).Info("Cannot count bot guilds before creating guild", "error", err)
+	}
+
 	req := &clients.CreateGuildRequest{
 		Name: cr.Spec.ForProvider.Name,
 	}
@@ -296,50 +753,77 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotGuild)
 	}
 
-	req := &clients.ModifyGuildRequest{}
-	needsUpdate := false
-
-	// Check what fields need updating
-	if cr.Spec.ForProvider.Name != cr.Status.AtProvider.Name {
-		req.Name = &cr.Spec.ForProvider.Name
-		needsUpdate = true
+	if c.permissions != nil {
+		if err := clients.RequirePermissions(ctx, c.permissions, meta.GetExternalName(cr), clients.PermissionManageGuild); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
 	}
 
-	if cr.Spec.ForProvider.Region != nil && (cr.Status.AtProvider.Region == "" || *cr.Spec.ForProvider.Region != cr.Status.AtProvider.Region) {
-		req.Region = cr.Spec.ForProvider.Region
-		needsUpdate = true
+	// Diff against the live guild rather than status.atProvider, which may be
+	// stale or not yet populated. It is only fetched here when Update is
+	// called without Observe.
+	guild := c.observed
+	if guild == nil {
+		var err error
+		if guild, err = c.service.GetGuild(ctx, meta.GetExternalName(cr)); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to get guild")
+		}
 	}
 
-	if cr.Spec.ForProvider.VerificationLevel != nil && *cr.Spec.ForProvider.VerificationLevel != cr.Status.AtProvider.VerificationLevel {
-		req.VerificationLevel = cr.Spec.ForProvider.VerificationLevel
+	return c.update(ctx, cr, guild)
+}
+
+// update applies the differences between the desired state and the supplied
+// observation of the guild.
+func (c *external) update(ctx context.Context, cr *guildv1alpha1.Guild, observed *clients.Guild) (managed.ExternalUpdate, error) {
+	req, needsUpdate := generateModifyGuildRequest(cr.Spec.ForProvider, cr.Status.AtProvider, observed)
+
+	owner, err := c.resolveOwner(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if owner != "" && owner != observed.OwnerID {
+		if cr.Spec.ForProvider.AllowOwnershipTransfer == nil || !*cr.Spec.ForProvider.AllowOwnershipTransfer {
+			return managed.ExternalUpdate{}, errors.Errorf(errOwnershipTransfer, observed.OwnerID, owner)
+		}
+		req.OwnerID = &owner
 		needsUpdate = true
 	}
 
-	if cr.Spec.ForProvider.DefaultMessageNotifications != nil && *cr.Spec.ForProvider.DefaultMessageNotifications != cr.Status.AtProvider.DefaultMessageNotifications {
-		req.DefaultMessageNotifications = cr.Spec.ForProvider.DefaultMessageNotifications
-		needsUpdate = true
+	if needsUpdate {
+		if err := checkFeatures(req, observed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if _, err := c.service.ModifyGuild(ctx, meta.GetExternalName(cr), req); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update guild")
+		}
+		if req.Banner != nil {
+			cr.Status.AtProvider.BannerChecksum = clients.ImageChecksum(*req.Banner)
+		}
+		if req.DiscoverySplash != nil {
+			cr.Status.AtProvider.DiscoverySplashChecksum = clients.ImageChecksum(*req.DiscoverySplash)
+		}
 	}
 
-	if cr.Spec.ForProvider.ExplicitContentFilter != nil && *cr.Spec.ForProvider.ExplicitContentFilter != cr.Status.AtProvider.ExplicitContentFilter {
-		req.ExplicitContentFilter = cr.Spec.ForProvider.ExplicitContentFilter
-		needsUpdate = true
+	// MFA level has its own endpoint rather than being part of ModifyGuild
+	if cr.Spec.ForProvider.MFALevel != nil && *cr.Spec.ForProvider.MFALevel != observed.MFALevel {
+		level, err := c.service.ModifyGuildMFALevel(ctx, meta.GetExternalName(cr), *cr.Spec.ForProvider.MFALevel)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update guild MFA level")
+		}
+		cr.Status.AtProvider.MFALevel = level
 	}
 
-	if cr.Spec.ForProvider.AFKTimeout != nil && *cr.Spec.ForProvider.AFKTimeout != cr.Status.AtProvider.AFKTimeout {
-		req.AFKTimeout = cr.Spec.ForProvider.AFKTimeout
-		needsUpdate = true
+	if err := c.updatePrimaryInvite(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	if cr.Spec.ForProvider.SystemChannelFlags != nil && *cr.Spec.ForProvider.SystemChannelFlags != cr.Status.AtProvider.SystemChannelFlags {
-		req.SystemChannelFlags = cr.Spec.ForProvider.SystemChannelFlags
-		needsUpdate = true
+	if err := c.updateMembershipScreening(ctx, cr, observed); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	if needsUpdate {
-		_, err := c.service.ModifyGuild(ctx, meta.GetExternalName(cr), req)
-		if err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update guild")
-		}
+	if err := c.restoreFromTemplate(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
 	return managed.ExternalUpdate{}, nil
@@ -353,10 +837,14 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
+	if leaves(cr) {
+		return managed.ExternalDelete{}, c.leave(ctx, cr)
+	}
+
 	err := c.service.DeleteGuild(ctx, meta.GetExternalName(cr))
 	if err != nil {
 		// Check if the error is a 404 (guild not found), which means it's already deleted
-		if strings.Contains(err.Error(), "Discord API error: 404") {
+		if clients.IsNotFound(err) {
 			return managed.ExternalDelete{}, nil
 		}
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete guild")
@@ -365,6 +853,27 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, nil
 }
 
+// leaves reports whether deleting the guild makes the bot leave it.
+func leaves(cr *guildv1alpha1.Guild) bool {
+	m := cr.Spec.ForProvider.DeletionMode
+	return m != nil && *m == guildv1alpha1.DeletionModeLeave
+}
+
+// leave makes the bot leave the guild rather than deleting it. A guild the
+// bot is no longer in has already been left.
+func (c *external) leave(ctx context.Context, cr *guildv1alpha1.Guild) error {
+	if c.users == nil {
+		return errors.New("cannot leave guild: no user client")
+	}
+	if err := c.users.LeaveGuild(ctx, meta.GetExternalName(cr)); err != nil {
+		if clients.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to leave guild")
+	}
+	return nil
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	// Nothing to disconnect for Discord API client
 	return nil
@@ -19,14 +19,22 @@ package guild
 import (
 	"context"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/apis"
 	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
 	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	userv1alpha1 "github.com/rossigee/provider-discord/apis/user/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
+	"time"
 )
 
 // MockGuildClient implements a mock Discord client for testing
@@ -36,6 +44,8 @@ type MockGuildClient struct {
 	ModifyGuildFunc func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error)
 	DeleteGuildFunc func(ctx context.Context, guildID string) error
 	ListGuildsFunc  func(ctx context.Context) ([]discordclient.Guild, error)
+
+	ModifyGuildMFALevelFunc func(ctx context.Context, guildID string, level int) (int, error)
 }
 
 // Ensure MockGuildClient implements GuildClient interface
@@ -76,6 +86,13 @@ func (m *MockGuildClient) ListGuilds(ctx context.Context) ([]discordclient.Guild
 	return nil, errors.New("not implemented")
 }
 
+func (m *MockGuildClient) ModifyGuildMFALevel(ctx context.Context, guildID string, level int) (int, error) {
+	if m.ModifyGuildMFALevelFunc != nil {
+		return m.ModifyGuildMFALevelFunc(ctx, guildID, level)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func TestObserve(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789"
@@ -258,6 +275,35 @@ func TestCreate(t *testing.T) {
 	assert.Equal(t, guildID, meta.GetExternalName(guild))
 }
 
+func TestCreateGuildLimit(t *testing.T) {
+	ctx := context.Background()
+
+	created := false
+	mockClient := &MockGuildClient{
+		ListGuildsFunc: func(ctx context.Context) ([]discordclient.Guild, error) {
+			return make([]discordclient.Guild, discordclient.MaxGuildsForBotGuildCreate), nil
+		},
+		CreateGuildFunc: func(ctx context.Context, req *discordclient.CreateGuildRequest) (*discordclient.Guild, error) {
+			created = true
+			return &discordclient.Guild{ID: "123456789", Name: req.Name}, nil
+		},
+	}
+
+	guild := &guildv1alpha1.Guild{
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{Name: "Test Guild"},
+		},
+	}
+
+	e := &external{service: mockClient, kube: nil}
+	_, err := e.Create(ctx, guild)
+
+	require.Error(t, err)
+	assert.True(t, discordclient.IsGuildCreateNotAllowed(err))
+	assert.Contains(t, err.Error(), "(currently 10)")
+	assert.False(t, created)
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789"
@@ -265,6 +311,7 @@ func TestUpdate(t *testing.T) {
 	tests := []struct {
 		name         string
 		guild        *guildv1alpha1.Guild
+		observed     *discordclient.Guild
 		mockSetup    func(*MockGuildClient)
 		expectError  bool
 		expectUpdate bool
@@ -282,11 +329,9 @@ func TestUpdate(t *testing.T) {
 						Name: "Updated Guild",
 					},
 				},
-				Status: guildv1alpha1.GuildStatus{
-					AtProvider: guildv1alpha1.GuildObservation{
-						Name: "Old Guild", // Different from spec, so update needed
-					},
-				},
+			},
+			observed: &discordclient.Guild{
+				Name: "Old Guild", // Different from spec, so update needed
 			},
 			mockSetup: func(m *MockGuildClient) {
 				m.ModifyGuildFunc = func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
@@ -315,12 +360,10 @@ func TestUpdate(t *testing.T) {
 						Region: strPtr("us-west"),
 					},
 				},
-				Status: guildv1alpha1.GuildStatus{
-					AtProvider: guildv1alpha1.GuildObservation{
-						Name:   "Test Guild",
-						Region: "us-east", // Different from spec
-					},
-				},
+			},
+			observed: &discordclient.Guild{
+				Name:   "Test Guild",
+				Region: strPtr("us-east"), // Different from spec
 			},
 			mockSetup: func(m *MockGuildClient) {
 				m.ModifyGuildFunc = func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
@@ -336,6 +379,36 @@ func TestUpdate(t *testing.T) {
 			expectError:  false,
 			expectUpdate: true,
 		},
+		{
+			name: "update preferred locale",
+			guild: &guildv1alpha1.Guild{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: guildID,
+					},
+				},
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{
+						Name:            "Test Guild",
+						PreferredLocale: strPtr("de"),
+					},
+				},
+			},
+			observed: &discordclient.Guild{
+				Name:            "Test Guild",
+				PreferredLocale: "en-US",
+			},
+			mockSetup: func(m *MockGuildClient) {
+				m.ModifyGuildFunc = func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
+					assert.Nil(t, req.Name)
+					require.NotNil(t, req.PreferredLocale)
+					assert.Equal(t, "de", *req.PreferredLocale)
+					return &discordclient.Guild{ID: guildID, Name: "Test Guild", PreferredLocale: *req.PreferredLocale}, nil
+				}
+			},
+			expectError:  false,
+			expectUpdate: true,
+		},
 		{
 			name: "update multiple fields",
 			guild: &guildv1alpha1.Guild{
@@ -354,16 +427,14 @@ func TestUpdate(t *testing.T) {
 						SystemChannelFlags:          intPtr(1),
 					},
 				},
-				Status: guildv1alpha1.GuildStatus{
-					AtProvider: guildv1alpha1.GuildObservation{
-						Name:                        "Old Guild",
-						VerificationLevel:           1,
-						DefaultMessageNotifications: 0,
-						ExplicitContentFilter:       1,
-						AFKTimeout:                  300,
-						SystemChannelFlags:          0,
-					},
-				},
+			},
+			observed: &discordclient.Guild{
+				Name:                        "Old Guild",
+				VerificationLevel:           1,
+				DefaultMessageNotifications: 0,
+				ExplicitContentFilter:       1,
+				AFKTimeout:                  300,
+				SystemChannelFlags:          0,
 			},
 			mockSetup: func(m *MockGuildClient) {
 				m.ModifyGuildFunc = func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
@@ -401,20 +472,84 @@ func TestUpdate(t *testing.T) {
 						Name: "Test Guild",
 					},
 				},
+			},
+			observed: &discordclient.Guild{
+				Name: "Test Guild", // Same as spec, no update needed
+			},
+			mockSetup: func(m *MockGuildClient) {
+				// ModifyGuildFunc should not be called
+			},
+			expectError:  false,
+			expectUpdate: false,
+		},
+		{
+			name: "update MFA level",
+			guild: &guildv1alpha1.Guild{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: guildID,
+					},
+				},
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{
+						Name:     "Test Guild",
+						MFALevel: intPtr(1),
+					},
+				},
+			},
+			observed: &discordclient.Guild{
+				Name:     "Test Guild",
+				MFALevel: 0,
+			},
+			mockSetup: func(m *MockGuildClient) {
+				// Only the MFA endpoint should be called
+				m.ModifyGuildMFALevelFunc = func(ctx context.Context, gID string, level int) (int, error) {
+					assert.Equal(t, guildID, gID)
+					assert.Equal(t, 1, level)
+					return level, nil
+				}
+			},
+			expectError:  false,
+			expectUpdate: true,
+		},
+		{
+			name: "stale status does not hide drift",
+			guild: &guildv1alpha1.Guild{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: guildID,
+					},
+				},
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{
+						Name:              "Test Guild",
+						VerificationLevel: intPtr(2),
+					},
+				},
 				Status: guildv1alpha1.GuildStatus{
 					AtProvider: guildv1alpha1.GuildObservation{
-						Name: "Test Guild", // Same as spec, no update needed
+						Name:              "Test Guild",
+						VerificationLevel: 2, // Stale, matches spec
 					},
 				},
 			},
+			observed: &discordclient.Guild{
+				Name:              "Test Guild",
+				VerificationLevel: 1, // Changed in Discord since the last observation
+			},
 			mockSetup: func(m *MockGuildClient) {
-				// ModifyGuildFunc should not be called
+				m.ModifyGuildFunc = func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
+					assert.Nil(t, req.Name)
+					assert.NotNil(t, req.VerificationLevel)
+					assert.Equal(t, 2, *req.VerificationLevel)
+					return &discordclient.Guild{ID: guildID}, nil
+				}
 			},
 			expectError:  false,
-			expectUpdate: false,
+			expectUpdate: true,
 		},
 		{
-			name: "update fails",
+			name: "get guild fails",
 			guild: &guildv1alpha1.Guild{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
@@ -426,12 +561,32 @@ func TestUpdate(t *testing.T) {
 						Name: "Updated Guild",
 					},
 				},
-				Status: guildv1alpha1.GuildStatus{
-					AtProvider: guildv1alpha1.GuildObservation{
-						Name: "Old Guild",
+			},
+			mockSetup: func(m *MockGuildClient) {
+				m.GetGuildFunc = func(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+					return nil, errors.New("get failed")
+				}
+			},
+			expectError:  true,
+			expectUpdate: false,
+		},
+		{
+			name: "update fails",
+			guild: &guildv1alpha1.Guild{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: guildID,
+					},
+				},
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{
+						Name: "Updated Guild",
 					},
 				},
 			},
+			observed: &discordclient.Guild{
+				Name: "Old Guild",
+			},
 			mockSetup: func(m *MockGuildClient) {
 				m.ModifyGuildFunc = func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
 					return nil, errors.New("update failed")
@@ -445,6 +600,9 @@ func TestUpdate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockClient := &MockGuildClient{}
+			mockClient.GetGuildFunc = func(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+				return tc.observed, nil
+			}
 			tc.mockSetup(mockClient)
 
 			e := &external{service: mockClient, kube: nil}
@@ -459,6 +617,682 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateUsesObservedGuild(t *testing.T) {
+	ctx := context.Background()
+	cr := &guildv1alpha1.Guild{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: "123456789",
+			},
+		},
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{Name: "Updated Guild"},
+		},
+	}
+
+	gets := 0
+	var sent *discordclient.ModifyGuildRequest
+	e := &external{service: &MockGuildClient{
+		GetGuildFunc: func(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+			gets++
+			return &discordclient.Guild{ID: guildID, Name: "Old Guild"}, nil
+		},
+		ModifyGuildFunc: func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
+			sent = req
+			return &discordclient.Guild{ID: guildID, Name: *req.Name}, nil
+		},
+	}}
+
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	require.False(t, obs.ResourceUpToDate)
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, gets, "Update reuses the guild Observe fetched")
+	require.NotNil(t, sent)
+	assert.Equal(t, "Updated Guild", *sent.Name)
+}
+
+func TestUpdateImagesAndDescription(t *testing.T) {
+	ctx := context.Background()
+	banner := "data:image/png;base64,iVBORw0KGgo="
+
+	cr := &guildv1alpha1.Guild{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: "123456789",
+			},
+		},
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{
+				Name:        "Test Guild",
+				Description: strPtr("A test guild"),
+				Banner:      &banner,
+			},
+		},
+	}
+
+	observed := &discordclient.Guild{Name: "Test Guild"}
+	var sent *discordclient.ModifyGuildRequest
+	e := &external{service: &MockGuildClient{
+		GetGuildFunc: func(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+			return observed, nil
+		},
+		ModifyGuildFunc: func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
+			sent = req
+			return observed, nil
+		},
+	}}
+
+	// Guilds without the required features are refused before calling Discord
+	_, err := e.Update(ctx, cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "COMMUNITY")
+	assert.Contains(t, err.Error(), "BANNER")
+	assert.Nil(t, sent)
+
+	observed.Features = []string{"COMMUNITY", "BANNER"}
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	require.NotNil(t, sent)
+	assert.Equal(t, "A test guild", *sent.Description)
+	assert.Equal(t, banner, *sent.Banner)
+	assert.Equal(t, discordclient.ImageChecksum(banner), cr.Status.AtProvider.BannerChecksum)
+
+	// Once uploaded, the banner is only sent again when the declared image
+	// changes or the banner is removed in Discord
+	observed.Description = strPtr("A test guild")
+	observed.Banner = strPtr("a_1269e74af4df7417b13759eae50c83dc")
+	assert.True(t, e.isUpToDate(cr, observed))
+
+	changed := "data:image/png;base64,AAAA"
+	cr.Spec.ForProvider.Banner = &changed
+	assert.False(t, e.isUpToDate(cr, observed))
+
+	cr.Spec.ForProvider.Banner = &banner
+	observed.Banner = nil
+	assert.False(t, e.isUpToDate(cr, observed))
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	ctx := context.Background()
+	newOwner := "222222222222222222"
+
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	user := &userv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "default"}}
+	user.Status.AtProvider.ID = newOwner
+	pending := &userv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "bob", Namespace: "default"}}
+	pending.Spec.ForProvider.UserID = "@me"
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(user, pending).Build()
+
+	cr := &guildv1alpha1.Guild{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: "123456789",
+			},
+		},
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{
+				Name:     "Test Guild",
+				OwnerRef: &xpv1.Reference{Name: "alice"},
+			},
+		},
+	}
+
+	observed := &discordclient.Guild{Name: "Test Guild", OwnerID: "111111111111111111"}
+	var sent *discordclient.ModifyGuildRequest
+	e := &external{kube: kube, service: &MockGuildClient{
+		GetGuildFunc: func(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+			return observed, nil
+		},
+		ModifyGuildFunc: func(ctx context.Context, guildID string, req *discordclient.ModifyGuildRequest) (*discordclient.Guild, error) {
+			sent = req
+			return observed, nil
+		},
+	}}
+
+	// Transfers are refused unless explicitly allowed
+	_, err := e.Update(ctx, cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allowOwnershipTransfer")
+	assert.Nil(t, sent)
+
+	cr.Spec.ForProvider.AllowOwnershipTransfer = boolPtr(true)
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	require.NotNil(t, sent)
+	assert.Equal(t, newOwner, *sent.OwnerID)
+
+	// An explicit ownerId wins over ownerRef
+	cr.Spec.ForProvider.OwnerID = strPtr("333333333333333333")
+	owner, err := e.resolveOwner(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "333333333333333333", owner)
+
+	// A User that has not reported its ID yet leaves the guild pending
+	cr.Spec.ForProvider.OwnerID = nil
+	cr.Spec.ForProvider.OwnerRef.Name = "bob"
+	_, err = e.resolveOwner(ctx, cr)
+	assert.True(t, conditions.IsChildPending(err))
+}
+
+// MockInviteClient implements a mock Discord invite client for testing
+type MockInviteClient struct {
+	CreateChannelInviteFunc func(ctx context.Context, channelID string, req *discordclient.CreateInviteRequest) (*discordclient.Invite, error)
+	GetInviteFunc           func(ctx context.Context, inviteCode string) (*discordclient.Invite, error)
+	DeleteInviteFunc        func(ctx context.Context, inviteCode string) error
+	GetChannelInvitesFunc   func(ctx context.Context, channelID string) ([]discordclient.Invite, error)
+	GetGuildInvitesFunc     func(ctx context.Context, guildID string) ([]discordclient.Invite, error)
+}
+
+var _ discordclient.InviteClient = (*MockInviteClient)(nil)
+
+func (m *MockInviteClient) CreateChannelInvite(ctx context.Context, channelID string, req *discordclient.CreateInviteRequest) (*discordclient.Invite, error) {
+	if m.CreateChannelInviteFunc != nil {
+		return m.CreateChannelInviteFunc(ctx, channelID, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetInvite(ctx context.Context, inviteCode string) (*discordclient.Invite, error) {
+	if m.GetInviteFunc != nil {
+		return m.GetInviteFunc(ctx, inviteCode)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) DeleteInvite(ctx context.Context, inviteCode string) error {
+	if m.DeleteInviteFunc != nil {
+		return m.DeleteInviteFunc(ctx, inviteCode)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetChannelInvites(ctx context.Context, channelID string) ([]discordclient.Invite, error) {
+	if m.GetChannelInvitesFunc != nil {
+		return m.GetChannelInvitesFunc(ctx, channelID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetGuildInvites(ctx context.Context, guildID string) ([]discordclient.Invite, error) {
+	if m.GetGuildInvitesFunc != nil {
+		return m.GetGuildInvitesFunc(ctx, guildID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestInviteURL(t *testing.T) {
+	ctx := context.Background()
+	guildID := "123456789"
+	channelID := "555"
+	botID := "777"
+
+	tests := []struct {
+		name              string
+		vanityCode        *string
+		primaryInvite     *guildv1alpha1.PrimaryInviteParameters
+		statusCode        string
+		invites           []discordclient.Invite
+		invitesErr        error
+		expectedUpToDate  bool
+		expectedURL       string
+		expectedCreated   bool
+		expectedDeleted   string
+		expectedFinalCode string
+	}{
+		{
+			name:             "vanity URL wins",
+			vanityCode:       strPtr("gophers"),
+			primaryInvite:    &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			statusCode:       "abc",
+			invites:          []discordclient.Invite{{Code: "abc"}},
+			expectedUpToDate: true,
+			expectedURL:      "https://discord.gg/gophers",
+		},
+		{
+			name:             "primary invite in place",
+			primaryInvite:    &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			statusCode:       "abc",
+			invites:          []discordclient.Invite{{Code: "xyz"}, {Code: "abc"}},
+			expectedUpToDate: true,
+			expectedURL:      "https://discord.gg/abc",
+		},
+		{
+			name:              "primary invite not yet created",
+			primaryInvite:     &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			expectedUpToDate:  false,
+			expectedCreated:   true,
+			expectedFinalCode: "new",
+		},
+		{
+			name:          "primary invite adopted when status lost it",
+			primaryInvite: &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			invites: []discordclient.Invite{
+				{Code: "temp", Inviter: &discordclient.User{ID: botID}, MaxAge: 3600},
+				{Code: "theirs", Inviter: &discordclient.User{ID: "42"}},
+				{Code: "perm", Inviter: &discordclient.User{ID: botID}},
+			},
+			expectedUpToDate: true,
+			expectedURL:      "https://discord.gg/perm",
+		},
+		{
+			name:              "primary invite revoked in Discord",
+			primaryInvite:     &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			statusCode:        "abc",
+			invites:           []discordclient.Invite{{Code: "perm", Inviter: &discordclient.User{ID: botID}}},
+			expectedUpToDate:  false,
+			expectedURL:       "https://discord.gg/abc",
+			expectedCreated:   true,
+			expectedDeleted:   "abc",
+			expectedFinalCode: "new",
+		},
+		{
+			name:              "primary invite to another channel",
+			primaryInvite:     &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			statusCode:        "abc",
+			expectedUpToDate:  false,
+			expectedURL:       "https://discord.gg/abc",
+			expectedCreated:   true,
+			expectedDeleted:   "abc",
+			expectedFinalCode: "new",
+		},
+		{
+			name:             "primary invite unchecked while Discord is unavailable",
+			primaryInvite:    &guildv1alpha1.PrimaryInviteParameters{ChannelID: channelID},
+			statusCode:       "abc",
+			invitesErr:       errors.New("Discord API error: 503 - Service Unavailable"),
+			expectedUpToDate: true,
+			expectedURL:      "https://discord.gg/abc",
+		},
+		{
+			name:             "primary invite no longer configured",
+			statusCode:       "abc",
+			expectedUpToDate: false,
+			expectedURL:      "https://discord.gg/abc",
+			expectedDeleted:  "abc",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &guildv1alpha1.Guild{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: guildID},
+				},
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{Name: "Test Guild", PrimaryInvite: tc.primaryInvite},
+				},
+				Status: guildv1alpha1.GuildStatus{
+					AtProvider: guildv1alpha1.GuildObservation{PrimaryInviteCode: tc.statusCode},
+				},
+			}
+			observed := &discordclient.Guild{ID: guildID, Name: "Test Guild", VanityURLCode: tc.vanityCode}
+
+			created := false
+			deleted := ""
+			e := &external{
+				service: &MockGuildClient{
+					GetGuildFunc: func(ctx context.Context, id string) (*discordclient.Guild, error) {
+						return observed, nil
+					},
+				},
+				invites: &MockInviteClient{
+					GetChannelInvitesFunc: func(ctx context.Context, id string) ([]discordclient.Invite, error) {
+						assert.Equal(t, channelID, id)
+						return tc.invites, tc.invitesErr
+					},
+					CreateChannelInviteFunc: func(ctx context.Context, id string, req *discordclient.CreateInviteRequest) (*discordclient.Invite, error) {
+						assert.Equal(t, channelID, id)
+						assert.Equal(t, 0, *req.MaxAge)
+						assert.Equal(t, 0, *req.MaxUses)
+						created = true
+						return &discordclient.Invite{Code: "new"}, nil
+					},
+					DeleteInviteFunc: func(ctx context.Context, code string) error {
+						deleted = code
+						return nil
+					},
+				},
+				users: &MockUserClient{
+					GetCurrentUserFunc: func(ctx context.Context) (*discordclient.DiscordUser, error) {
+						return &discordclient.DiscordUser{ID: botID}, nil
+					},
+				},
+			}
+
+			obs, err := e.Observe(ctx, cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUpToDate, obs.ResourceUpToDate)
+			assert.Equal(t, tc.expectedURL, cr.Status.AtProvider.InviteURL)
+			assert.Equal(t, tc.expectedURL, string(obs.ConnectionDetails["inviteUrl"]))
+			if tc.expectedUpToDate {
+				return
+			}
+
+			_, err = e.update(ctx, cr, observed)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCreated, created)
+			assert.Equal(t, tc.expectedDeleted, deleted)
+			assert.Equal(t, tc.expectedFinalCode, cr.Status.AtProvider.PrimaryInviteCode)
+			if tc.expectedFinalCode != "" {
+				assert.Equal(t, "https://discord.gg/"+tc.expectedFinalCode, cr.Status.AtProvider.InviteURL)
+			}
+		})
+	}
+}
+
+func TestPrimaryInviteChecksAreRemembered(t *testing.T) {
+	ctx := context.Background()
+	checks := newInviteChecks()
+	now := time.Now()
+	checks.now = func() time.Time { return now }
+
+	listed := 0
+	e := &external{
+		invites: &MockInviteClient{
+			GetChannelInvitesFunc: func(ctx context.Context, id string) ([]discordclient.Invite, error) {
+				listed++
+				return []discordclient.Invite{{Code: "abc"}}, nil
+			},
+		},
+		inviteChecks: checks,
+	}
+	cr := &guildv1alpha1.Guild{
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{PrimaryInvite: &guildv1alpha1.PrimaryInviteParameters{ChannelID: "555"}},
+		},
+		Status: guildv1alpha1.GuildStatus{
+			AtProvider: guildv1alpha1.GuildObservation{PrimaryInviteCode: "abc"},
+		},
+	}
+
+	assert.True(t, e.primaryInviteUpToDate(ctx, cr))
+	assert.True(t, e.primaryInviteUpToDate(ctx, cr))
+	assert.Equal(t, 1, listed)
+
+	// A different channel is looked up afresh
+	cr.Spec.ForProvider.PrimaryInvite.ChannelID = "666"
+	assert.True(t, e.primaryInviteUpToDate(ctx, cr))
+	assert.Equal(t, 2, listed)
+
+	now = now.Add(primaryInviteRecheck)
+	cr.Spec.ForProvider.PrimaryInvite.ChannelID = "555"
+	assert.True(t, e.primaryInviteUpToDate(ctx, cr))
+	assert.Equal(t, 3, listed)
+}
+
+func TestSlotUsage(t *testing.T) {
+	tests := []struct {
+		name             string
+		guild            *discordclient.Guild
+		expectedEmojis   guildv1alpha1.SlotUsage
+		expectedAnimated guildv1alpha1.SlotUsage
+		expectedStickers guildv1alpha1.SlotUsage
+	}{
+		{
+			name: "unboosted guild",
+			guild: &discordclient.Guild{
+				Emojis: []discordclient.Emoji{
+					{Name: "wave"},
+					{Name: "party", Animated: true},
+					{Name: "twitch", Managed: true},
+				},
+				Stickers: make([]struct{}, 2),
+			},
+			expectedEmojis:   guildv1alpha1.SlotUsage{Used: 1, Limit: 50},
+			expectedAnimated: guildv1alpha1.SlotUsage{Used: 1, Limit: 50},
+			expectedStickers: guildv1alpha1.SlotUsage{Used: 2, Limit: 5},
+		},
+		{
+			name:             "tier 2 guild",
+			guild:            &discordclient.Guild{PremiumTier: 2},
+			expectedEmojis:   guildv1alpha1.SlotUsage{Limit: 150},
+			expectedAnimated: guildv1alpha1.SlotUsage{Limit: 150},
+			expectedStickers: guildv1alpha1.SlotUsage{Limit: 30},
+		},
+		{
+			name:             "guild with extra slots",
+			guild:            &discordclient.Guild{PremiumTier: 1, Features: []string{discordclient.GuildFeatureMoreEmoji, discordclient.GuildFeatureMoreStickers}},
+			expectedEmojis:   guildv1alpha1.SlotUsage{Limit: 200},
+			expectedAnimated: guildv1alpha1.SlotUsage{Limit: 200},
+			expectedStickers: guildv1alpha1.SlotUsage{Limit: 60},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &guildv1alpha1.Guild{}
+
+			setSlotUsage(cr, tc.guild)
+
+			assert.Equal(t, tc.guild.PremiumTier, cr.Status.AtProvider.PremiumTier)
+			assert.Equal(t, tc.expectedEmojis, *cr.Status.AtProvider.Emojis)
+			assert.Equal(t, tc.expectedAnimated, *cr.Status.AtProvider.AnimatedEmojis)
+			assert.Equal(t, tc.expectedStickers, *cr.Status.AtProvider.Stickers)
+		})
+	}
+}
+
+type MockWidgetClient struct {
+	GetGuildWidgetFunc func(ctx context.Context, guildID string) (*discordclient.GuildWidget, error)
+}
+
+func (m *MockWidgetClient) GetGuildWidget(ctx context.Context, guildID string) (*discordclient.GuildWidget, error) {
+	if m.GetGuildWidgetFunc != nil {
+		return m.GetGuildWidgetFunc(ctx, guildID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestSetWidget(t *testing.T) {
+	invite := "https://discord.com/invite/abc"
+	tests := []struct {
+		name     string
+		widget   *discordclient.GuildWidget
+		err      error
+		expected *guildv1alpha1.WidgetObservation
+	}{
+		{
+			name:   "widget enabled",
+			widget: &discordclient.GuildWidget{ID: "123", PresenceCount: 42, InstantInvite: &invite},
+			expected: &guildv1alpha1.WidgetObservation{
+				PresenceCount: 42,
+				InstantInvite: invite,
+				ImageURL:      "https://discord.com/api/v10/guilds/123/widget.png",
+			},
+		},
+		{
+			name: "widget disabled",
+			err:  errors.New(`Discord API error: 403 - {"message": "Widget Disabled", "code": 50004}`),
+		},
+		{
+			name: "widget unreadable",
+			err:  errors.New("Discord API error: 500 - oops"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &external{widgets: &MockWidgetClient{
+				GetGuildWidgetFunc: func(ctx context.Context, guildID string) (*discordclient.GuildWidget, error) {
+					assert.Equal(t, "123", guildID)
+					return tc.widget, tc.err
+				},
+			}}
+			cr := &guildv1alpha1.Guild{Status: guildv1alpha1.GuildStatus{AtProvider: guildv1alpha1.GuildObservation{ID: "123"}}}
+
+			e.setWidget(context.Background(), cr)
+
+			assert.Equal(t, tc.expected, cr.Status.AtProvider.Widget)
+		})
+	}
+}
+
+type MockMemberVerificationClient struct {
+	GetGuildMemberVerificationFunc    func(ctx context.Context, guildID string) (*discordclient.MemberVerification, error)
+	ModifyGuildMemberVerificationFunc func(ctx context.Context, guildID string, req *discordclient.ModifyMemberVerificationRequest) (*discordclient.MemberVerification, error)
+}
+
+func (m *MockMemberVerificationClient) GetGuildMemberVerification(ctx context.Context, guildID string) (*discordclient.MemberVerification, error) {
+	return m.GetGuildMemberVerificationFunc(ctx, guildID)
+}
+
+func (m *MockMemberVerificationClient) ModifyGuildMemberVerification(ctx context.Context, guildID string, req *discordclient.ModifyMemberVerificationRequest) (*discordclient.MemberVerification, error) {
+	return m.ModifyGuildMemberVerificationFunc(ctx, guildID, req)
+}
+
+func TestMembershipScreening(t *testing.T) {
+	rules := discordclient.MemberVerificationField{
+		FieldType: discordclient.MemberVerificationFieldTerms,
+		Label:     "Read and agree to the server rules",
+		Values:    []string{"Be nice"},
+		Required:  true,
+	}
+	screening := &guildv1alpha1.MembershipScreeningParameters{
+		Enabled: true,
+		FormFields: []guildv1alpha1.MembershipScreeningField{{
+			Type:  discordclient.MemberVerificationFieldTerms,
+			Label: "Read and agree to the server rules",
+			Rules: []string{"Be nice"},
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		features         []string
+		observed         []discordclient.MemberVerificationField
+		expectedUpToDate bool
+		expectedErr      string
+	}{
+		{
+			name:             "up to date",
+			features:         []string{"COMMUNITY", discordclient.GuildFeatureMemberVerificationGate},
+			observed:         []discordclient.MemberVerificationField{rules},
+			expectedUpToDate: true,
+		},
+		{
+			name:     "disabled",
+			features: []string{"COMMUNITY"},
+			observed: []discordclient.MemberVerificationField{rules},
+		},
+		{
+			name:     "rules changed",
+			features: []string{"COMMUNITY", discordclient.GuildFeatureMemberVerificationGate},
+			observed: []discordclient.MemberVerificationField{{FieldType: "TERMS", Label: rules.Label, Values: []string{"Be kind"}, Required: true}},
+		},
+		{
+			name:        "not a community",
+			expectedErr: "cannot update guild: membershipScreening requires the COMMUNITY feature",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var modified *discordclient.ModifyMemberVerificationRequest
+			e := &external{screening: &MockMemberVerificationClient{
+				GetGuildMemberVerificationFunc: func(ctx context.Context, guildID string) (*discordclient.MemberVerification, error) {
+					return &discordclient.MemberVerification{Version: "v1", FormFields: tc.observed}, nil
+				},
+				ModifyGuildMemberVerificationFunc: func(ctx context.Context, guildID string, req *discordclient.ModifyMemberVerificationRequest) (*discordclient.MemberVerification, error) {
+					modified = req
+					return &discordclient.MemberVerification{Version: "v2", FormFields: req.FormFields}, nil
+				},
+			}}
+			guild := &discordclient.Guild{ID: "123", Features: tc.features}
+			cr := &guildv1alpha1.Guild{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{meta.AnnotationKeyExternalName: "123"}},
+				Spec:       guildv1alpha1.GuildSpec{ForProvider: guildv1alpha1.GuildParameters{MembershipScreening: screening}},
+			}
+
+			assert.Equal(t, tc.expectedUpToDate, e.membershipScreeningUpToDate(context.Background(), cr, guild))
+			assert.Equal(t, len(tc.observed), cr.Status.AtProvider.MembershipScreening.FormFieldCount)
+
+			err := e.updateMembershipScreening(context.Background(), cr, guild)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectedUpToDate {
+				assert.Nil(t, modified)
+				return
+			}
+			require.NotNil(t, modified)
+			assert.True(t, *modified.Enabled)
+			assert.Equal(t, []discordclient.MemberVerificationField{rules}, modified.FormFields)
+			assert.Equal(t, "v2", cr.Status.AtProvider.MembershipScreening.Version)
+		})
+	}
+}
+
+func TestMemberCountCondition(t *testing.T) {
+	lo, hi := 100, 1000
+	tests := []struct {
+		name           string
+		params         guildv1alpha1.GuildParameters
+		count          int
+		expectedStatus corev1.ConditionStatus
+		expectedReason xpv1.ConditionReason
+	}{
+		{
+			name:  "no thresholds",
+			count: 5,
+		},
+		{
+			name:           "below",
+			params:         guildv1alpha1.GuildParameters{AlertOnMemberCountBelow: &lo, AlertOnMemberCountAbove: &hi},
+			count:          99,
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: conditions.ReasonMemberCountBelow,
+		},
+		{
+			name:           "above",
+			params:         guildv1alpha1.GuildParameters{AlertOnMemberCountAbove: &hi},
+			count:          1001,
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: conditions.ReasonMemberCountAbove,
+		},
+		{
+			name:           "in range",
+			params:         guildv1alpha1.GuildParameters{AlertOnMemberCountBelow: &lo, AlertOnMemberCountAbove: &hi},
+			count:          100,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: conditions.ReasonMemberCountInRange,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := memberCountCondition(tc.params, tc.count)
+			if tc.expectedStatus == "" {
+				assert.Nil(t, c)
+				return
+			}
+			require.NotNil(t, c)
+			assert.Equal(t, conditions.TypeMemberCountThreshold, c.Type)
+			assert.Equal(t, tc.expectedStatus, c.Status)
+			assert.Equal(t, tc.expectedReason, c.Reason)
+		})
+	}
+}
+
+func TestRecordMemberCount(t *testing.T) {
+	cr := &guildv1alpha1.Guild{}
+	e := &external{}
+
+	below := conditions.MemberCountOutOfRange(conditions.ReasonMemberCountBelow, "below")
+	e.recordMemberCount(cr, &below)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(conditions.TypeMemberCountThreshold).Status)
+
+	// Removing the thresholds clears the condition.
+	e.recordMemberCount(cr, nil)
+	assert.Equal(t, conditions.ReasonMemberCountInRange, cr.GetCondition(conditions.TypeMemberCountThreshold).Reason)
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789"
@@ -532,6 +1366,121 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+// MockUserClient implements a mock Discord user client for testing
+type MockUserClient struct {
+	discordclient.UserClient
+	LeaveGuildFunc     func(ctx context.Context, guildID string) error
+	GetCurrentUserFunc func(ctx context.Context) (*discordclient.DiscordUser, error)
+}
+
+func (m *MockUserClient) GetCurrentUser(ctx context.Context) (*discordclient.DiscordUser, error) {
+	return m.GetCurrentUserFunc(ctx)
+}
+
+func (m *MockUserClient) LeaveGuild(ctx context.Context, guildID string) error {
+	return m.LeaveGuildFunc(ctx, guildID)
+}
+
+func TestDeleteLeave(t *testing.T) {
+	ctx := context.Background()
+	guildID := "123456789"
+	leave := guildv1alpha1.DeletionModeLeave
+
+	cr := &guildv1alpha1.Guild{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: guildID,
+			},
+		},
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{
+				Name:         "Test Guild",
+				DeletionMode: &leave,
+			},
+		},
+	}
+
+	left := ""
+	e := &external{
+		service: &MockGuildClient{
+			DeleteGuildFunc: func(ctx context.Context, guildID string) error {
+				t.Error("guild must not be deleted in Leave mode")
+				return nil
+			},
+		},
+		users: &MockUserClient{
+			LeaveGuildFunc: func(ctx context.Context, id string) error {
+				left = id
+				return nil
+			},
+		},
+	}
+
+	_, err := e.Delete(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, guildID, left)
+
+	// A guild the bot already left is gone as far as it is concerned
+	e.users = &MockUserClient{
+		LeaveGuildFunc: func(ctx context.Context, id string) error {
+			return &discordclient.APIError{StatusCode: 404, Code: 10004, Message: "Unknown Guild"}
+		},
+	}
+	_, err = e.Delete(ctx, cr)
+	assert.NoError(t, err)
+
+	e.users = &MockUserClient{
+		LeaveGuildFunc: func(ctx context.Context, id string) error {
+			return errors.New("Discord API error: 500 - Internal Server Error")
+		},
+	}
+	_, err = e.Delete(ctx, cr)
+	assert.Error(t, err)
+}
+
+func TestObserveAfterLeave(t *testing.T) {
+	ctx := context.Background()
+	leave := guildv1alpha1.DeletionModeLeave
+	missingAccess := &discordclient.APIError{StatusCode: 403, Code: discordclient.ErrorCodeMissingAccess, Message: "Missing Access"}
+
+	cr := &guildv1alpha1.Guild{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: "123456789",
+			},
+		},
+		Spec: guildv1alpha1.GuildSpec{
+			ForProvider: guildv1alpha1.GuildParameters{
+				Name:         "Test Guild",
+				DeletionMode: &leave,
+			},
+		},
+	}
+	e := &external{service: &MockGuildClient{
+		GetGuildFunc: func(ctx context.Context, guildID string) (*discordclient.Guild, error) {
+			return nil, errors.Wrap(missingAccess, "failed to get guild")
+		},
+	}}
+
+	// Losing access to a guild that is not being deleted is an error
+	_, err := e.Observe(ctx, cr)
+	assert.Error(t, err)
+
+	// Once left, a guild being deleted no longer exists, so its finalizer
+	// can be removed
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+
+	// Guilds deleted outright never treat lost access as deletion
+	del := guildv1alpha1.DeletionModeDelete
+	cr.Spec.ForProvider.DeletionMode = &del
+	_, err = e.Observe(ctx, cr)
+	assert.Error(t, err)
+}
+
 func TestDisconnect(t *testing.T) {
 	e := &external{service: &MockGuildClient{}}
 	err := e.Disconnect(context.Background())
@@ -639,6 +1588,36 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "premium progress bar needs update",
+			cr: &guildv1alpha1.Guild{
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{
+						Name:                      "Test Guild",
+						PremiumProgressBarEnabled: boolPtr(true),
+					},
+				},
+			},
+			guild: &discordclient.Guild{
+				Name: "Test Guild",
+			},
+			expected: false,
+		},
+		{
+			name: "premium progress bar up to date",
+			cr: &guildv1alpha1.Guild{
+				Spec: guildv1alpha1.GuildSpec{
+					ForProvider: guildv1alpha1.GuildParameters{
+						Name:                      "Test Guild",
+						PremiumProgressBarEnabled: boolPtr(false),
+					},
+				},
+			},
+			guild: &discordclient.Guild{
+				Name: "Test Guild",
+			},
+			expected: true,
+		},
 		{
 			name: "region needs update",
 			cr: &guildv1alpha1.Guild{
@@ -671,6 +1650,10 @@ func intPtr(i int) *int {
 	return &i
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func strPtr(s string) *string {
 	return &s
 }
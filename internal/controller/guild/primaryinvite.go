@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guild
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// primaryInviteRecheck is how long a primary invite found in its channel is
+// assumed to still be there before the channel's invites are read again.
+const primaryInviteRecheck = time.Hour
+
+// inviteChecks records when primary invites were last found in their
+// channels.
+type inviteChecks struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	checked map[string]time.Time
+}
+
+// sharedInviteChecks is shared by all clients, since clients are created per
+// reconcile.
+var sharedInviteChecks = newInviteChecks()
+
+func newInviteChecks() *inviteChecks {
+	return &inviteChecks{now: time.Now, checked: map[string]time.Time{}}
+}
+
+// recent reports whether the invite was found in the channel within the
+// last primaryInviteRecheck.
+func (c *inviteChecks) recent(channelID, code string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checked, ok := c.checked[channelID+"/"+code]
+	return ok && c.now().Sub(checked) < primaryInviteRecheck
+}
+
+// record notes that the invite was found in the channel, dropping expired
+// checks.
+func (c *inviteChecks) record(channelID, code string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for key, checked := range c.checked {
+		if now.Sub(checked) >= primaryInviteRecheck {
+			delete(c.checked, key)
+		}
+	}
+	c.checked[channelID+"/"+code] = now
+}
+
+// primaryInviteUpToDate reports whether the guild has a primary invite to
+// the configured channel, or none when none is configured. The invite is
+// looked for among the channel's invites at most once per
+// primaryInviteRecheck. When status does not record one, a permanent invite
+// the bot created in the channel is adopted, so a lost status does not
+// create another. An invite that cannot be checked, for example while
+// Discord is unavailable, is assumed to still be valid.
+func (c *external) primaryInviteUpToDate(ctx context.Context, cr *guildv1alpha1.Guild) bool {
+	if c.invites == nil {
+		return true
+	}
+	code := cr.Status.AtProvider.PrimaryInviteCode
+	p := cr.Spec.ForProvider.PrimaryInvite
+	if p == nil {
+		return code == ""
+	}
+	if code != "" && c.inviteChecks.recent(p.ChannelID, code) {
+		return true
+	}
+
+	invites, err := c.invites.GetChannelInvites(ctx, p.ChannelID)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("Cannot check primary invite", "channelID", p.ChannelID, "code", code, "error", err)
+		return code != ""
+	}
+	botID := ""
+	if code == "" {
+		botID = c.botID(ctx)
+	}
+	for _, invite := range invites {
+		if invite.Code == code || isPrimaryInvite(invite, botID) {
+			cr.Status.AtProvider.PrimaryInviteCode = invite.Code
+			c.inviteChecks.record(p.ChannelID, invite.Code)
+			return true
+		}
+	}
+	return false
+}
+
+// botID returns the bot's user ID, or "" when it cannot be read.
+func (c *external) botID(ctx context.Context) string {
+	if c.users == nil {
+		return ""
+	}
+	bot, err := c.users.GetCurrentUser(ctx)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("Cannot read bot user", "error", err)
+		return ""
+	}
+	return bot.ID
+}
+
+// isPrimaryInvite reports whether the invite is a permanent invite created
+// by the bot.
+func isPrimaryInvite(invite clients.Invite, botID string) bool {
+	return botID != "" && invite.Inviter != nil && invite.Inviter.ID == botID &&
+		invite.MaxAge == 0 && invite.MaxUses == 0 && !invite.Temporary
+}
+
+// updatePrimaryInvite creates the configured primary invite, replacing one
+// that is gone or to another channel, or deletes it when it is no longer
+// configured.
+func (c *external) updatePrimaryInvite(ctx context.Context, cr *guildv1alpha1.Guild) error {
+	if c.invites == nil || c.primaryInviteUpToDate(ctx, cr) {
+		return nil
+	}
+
+	if code := cr.Status.AtProvider.PrimaryInviteCode; code != "" {
+		if err := c.invites.DeleteInvite(ctx, code); err != nil && !clients.IsNotFound(err) {
+			return errors.Wrap(err, "failed to delete primary invite")
+		}
+		cr.Status.AtProvider.PrimaryInviteCode = ""
+	}
+
+	if p := cr.Spec.ForProvider.PrimaryInvite; p != nil {
+		never := 0
+		invite, err := c.invites.CreateChannelInvite(ctx, p.ChannelID, &clients.CreateInviteRequest{MaxAge: &never, MaxUses: &never})
+		if err != nil {
+			return errors.Wrap(err, "failed to create primary invite")
+		}
+		cr.Status.AtProvider.PrimaryInviteCode = invite.Code
+		c.inviteChecks.record(p.ChannelID, invite.Code)
+	}
+	setInviteURL(cr)
+	return nil
+}
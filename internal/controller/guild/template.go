@@ -0,0 +1,361 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guild
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// everyoneTemplateRoleID is the template ID of the @everyone role, whose
+// Discord ID is the guild's.
+const everyoneTemplateRoleID = 0
+
+// A restoreStep is one change of a restore plan.
+type restoreStep struct {
+	change string
+	apply  func(ctx context.Context) error
+}
+
+// A restorePlan lists the changes that restore a guild's roles and channels
+// from a template, in the order they are made: roles, then categories, then
+// the other channels. Restoring never deletes roles or channels the
+// template does not have, and leaves positions and permission overwrites
+// alone.
+type restorePlan struct {
+	code  string
+	steps []restoreStep
+
+	// roleCreates and channelCreates count the roles and channels, categories
+	// included, the plan creates.
+	roleCreates, channelCreates int
+
+	// categories maps the template IDs of categories to the IDs of the
+	// guild's categories, including those the plan creates once they are.
+	categories map[int]string
+}
+
+// changes describes the plan's changes.
+func (p *restorePlan) changes() []string {
+	changes := make([]string, len(p.steps))
+	for i, s := range p.steps {
+		changes[i] = s.change
+	}
+	return changes
+}
+
+// id identifies the plan. It covers the template and every change, so an
+// approved plan never applies once the template or the guild has changed.
+func (p *restorePlan) id() string {
+	sum := sha256.Sum256([]byte(p.code + "\n" + strings.Join(p.changes(), "\n")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// planRestore compares the guild's roles and channels with the template.
+func (c *external) planRestore(ctx context.Context, guildID, code string) (*restorePlan, error) {
+	template, err := c.templates.GetGuildTemplate(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := c.roles.ListRoles(ctx, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list roles")
+	}
+	channels, err := c.channels.ListGuildChannels(ctx, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list channels")
+	}
+
+	p := &restorePlan{code: code, categories: map[int]string{}}
+	c.planRoles(p, guildID, template.SerializedSourceGuild.Roles, roles)
+	c.planChannels(p, guildID, template.SerializedSourceGuild.Channels, channels)
+	return p, nil
+}
+
+// planRoles plans the role changes. Roles are matched by name; roles owned
+// by bots and integrations are never matched, as they cannot be changed.
+func (c *external) planRoles(p *restorePlan, guildID string, want []clients.TemplateRole, have []clients.Role) {
+	used := map[string]bool{}
+	for _, tr := range want {
+		var match *clients.Role
+		for i := range have {
+			r := &have[i]
+			if used[r.ID] || r.Managed {
+				continue
+			}
+			if (tr.ID == everyoneTemplateRoleID && r.ID == guildID) || (tr.ID != everyoneTemplateRoleID && r.ID != guildID && r.Name == tr.Name) {
+				match = r
+				break
+			}
+		}
+
+		req := clients.ModifyRoleRequest{Permissions: &tr.Permissions}
+		if tr.ID != everyoneTemplateRoleID {
+			req.Color, req.Hoist, req.Mentionable = &tr.Color, &tr.Hoist, &tr.Mentionable
+		}
+
+		if match == nil {
+			if tr.ID == everyoneTemplateRoleID {
+				continue
+			}
+			p.roleCreates++
+			p.steps = append(p.steps, restoreStep{
+				change: fmt.Sprintf("create role %q", tr.Name),
+				apply: func(ctx context.Context) error {
+					_, err := c.roles.CreateRole(ctx, guildID, clients.CreateRoleRequest{Name: tr.Name, Permissions: req.Permissions, Color: req.Color, Hoist: req.Hoist, Mentionable: req.Mentionable})
+					return err
+				},
+			})
+			continue
+		}
+		used[match.ID] = true
+
+		var fields []string
+		if match.Permissions != tr.Permissions {
+			fields = append(fields, "permissions")
+		}
+		if tr.ID != everyoneTemplateRoleID {
+			if match.Color != tr.Color {
+				fields = append(fields, "color")
+			}
+			if match.Hoist != tr.Hoist {
+				fields = append(fields, "hoist")
+			}
+			if match.Mentionable != tr.Mentionable {
+				fields = append(fields, "mentionable")
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		id := match.ID
+		p.steps = append(p.steps, restoreStep{
+			change: fmt.Sprintf("update role %q: %s", tr.Name, strings.Join(fields, ", ")),
+			apply: func(ctx context.Context) error {
+				_, err := c.roles.ModifyRole(ctx, guildID, id, req)
+				return err
+			},
+		})
+	}
+}
+
+// planChannels plans the category changes, then those of the other
+// channels. Channels are matched by name and type.
+func (c *external) planChannels(p *restorePlan, guildID string, want []clients.TemplateChannel, have []clients.Channel) {
+	names := map[int]string{}
+	used := map[string]bool{}
+	match := func(tc clients.TemplateChannel) *clients.Channel {
+		for i := range have {
+			ch := &have[i]
+			if !used[ch.ID] && ch.Type == tc.Type && ch.Name == tc.Name {
+				used[ch.ID] = true
+				return ch
+			}
+		}
+		return nil
+	}
+
+	for _, tc := range want {
+		if tc.Type != clients.ChannelTypeCategory {
+			continue
+		}
+		names[tc.ID] = tc.Name
+		if ch := match(tc); ch != nil {
+			p.categories[tc.ID] = ch.ID
+			continue
+		}
+		p.channelCreates++
+		p.steps = append(p.steps, restoreStep{
+			change: fmt.Sprintf("create category %q", tc.Name),
+			apply: func(ctx context.Context) error {
+				ch, err := c.channels.CreateChannel(ctx, &clients.CreateChannelRequest{GuildID: guildID, Name: tc.Name, Type: tc.Type})
+				if err != nil {
+					return err
+				}
+				p.categories[tc.ID] = ch.ID
+				return nil
+			},
+		})
+	}
+
+	for _, tc := range want {
+		if tc.Type == clients.ChannelTypeCategory {
+			continue
+		}
+		voice := tc.Type == clients.ChannelTypeVoice || tc.Type == clients.ChannelTypeStage
+		in := ""
+		if tc.ParentID != nil {
+			in = fmt.Sprintf(" in %q", names[*tc.ParentID])
+		}
+		// parent resolves the channel's category once the categories the
+		// plan creates exist.
+		parent := func() *string {
+			if tc.ParentID == nil {
+				return nil
+			}
+			if id, ok := p.categories[*tc.ParentID]; ok {
+				return &id
+			}
+			return nil
+		}
+
+		ch := match(tc)
+		if ch == nil {
+			p.channelCreates++
+			p.steps = append(p.steps, restoreStep{
+				change: fmt.Sprintf("create channel %q%s", tc.Name, in),
+				apply: func(ctx context.Context) error {
+					req := &clients.CreateChannelRequest{GuildID: guildID, Name: tc.Name, Type: tc.Type, Topic: tc.Topic, NSFW: &tc.NSFW, ParentID: parent()}
+					if voice {
+						req.Bitrate, req.UserLimit = &tc.Bitrate, &tc.UserLimit
+					} else {
+						req.RateLimitPerUser = &tc.RateLimitPerUser
+					}
+					_, err := c.channels.CreateChannel(ctx, req)
+					return err
+				},
+			})
+			continue
+		}
+
+		var fields []string
+		topic := ""
+		if tc.Topic != nil {
+			topic = *tc.Topic
+		}
+		if !voice && (ch.Topic == nil && topic != "" || ch.Topic != nil && *ch.Topic != topic) {
+			fields = append(fields, "topic")
+		}
+		if ch.NSFW != tc.NSFW {
+			fields = append(fields, "nsfw")
+		}
+		if !voice && ch.RateLimitPerUser != tc.RateLimitPerUser {
+			fields = append(fields, "rateLimitPerUser")
+		}
+		if voice && ch.Bitrate != tc.Bitrate {
+			fields = append(fields, "bitrate")
+		}
+		if voice && ch.UserLimit != tc.UserLimit {
+			fields = append(fields, "userLimit")
+		}
+		if tc.ParentID != nil {
+			if id, ok := p.categories[*tc.ParentID]; !ok || id != ch.ParentID {
+				fields = append(fields, "category")
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		id := ch.ID
+		p.steps = append(p.steps, restoreStep{
+			change: fmt.Sprintf("update channel %q%s: %s", tc.Name, in, strings.Join(fields, ", ")),
+			apply: func(ctx context.Context) error {
+				req := &clients.ModifyChannelRequest{NSFW: &tc.NSFW, ParentID: parent()}
+				if voice {
+					req.Bitrate, req.UserLimit = &tc.Bitrate, &tc.UserLimit
+				} else {
+					req.Topic, req.RateLimitPerUser = &topic, &tc.RateLimitPerUser
+				}
+				_, err := c.channels.ModifyChannel(ctx, id, req)
+				return err
+			},
+		})
+	}
+}
+
+// templateRestoreUpToDate reports the plan restoring the guild from its
+// template in status, and returns false only when the plan has changes and
+// is approved. Guilds without a template are not checked.
+func (c *external) templateRestoreUpToDate(ctx context.Context, cr *guildv1alpha1.Guild, lastApplied string) (bool, error) {
+	r := cr.Spec.ForProvider.RestoreFromTemplate
+	if c.templates == nil || r == nil {
+		if cr.GetCondition(conditions.TypeTemplateDrift).Status == corev1.ConditionTrue {
+			cr.SetConditions(conditions.MatchesTemplate())
+		}
+		return true, nil
+	}
+
+	p, err := c.planRestore(ctx, cr.Status.AtProvider.ID, r.Code)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot plan restore from template")
+	}
+	obs := &guildv1alpha1.TemplateRestoreObservation{Code: r.Code, LastAppliedPlanID: lastApplied}
+	cr.Status.AtProvider.TemplateRestore = obs
+	if len(p.steps) == 0 {
+		cr.SetConditions(conditions.MatchesTemplate())
+		return true, nil
+	}
+
+	obs.PlanID, obs.Changes = p.id(), p.changes()
+	cr.SetConditions(conditions.TemplateDrifted(fmt.Sprintf("%d change(s) would restore the guild from template %s; set spec.forProvider.restoreFromTemplate.approvedPlan to %s to make them", len(p.steps), r.Code, obs.PlanID)))
+	return r.ApprovedPlan == nil || *r.ApprovedPlan != obs.PlanID, nil
+}
+
+// restoreFromTemplate applies the approved plan restoring the guild from its
+// template, if it still describes the changes to make.
+func (c *external) restoreFromTemplate(ctx context.Context, cr *guildv1alpha1.Guild) error {
+	r := cr.Spec.ForProvider.RestoreFromTemplate
+	if c.templates == nil || r == nil || r.ApprovedPlan == nil {
+		return nil
+	}
+	guildID := meta.GetExternalName(cr)
+	p, err := c.planRestore(ctx, guildID, r.Code)
+	if err != nil {
+		return errors.Wrap(err, "cannot plan restore from template")
+	}
+	if len(p.steps) == 0 || p.id() != *r.ApprovedPlan {
+		return nil
+	}
+
+	if c.permissions != nil {
+		if err := clients.RequirePermissions(ctx, c.permissions, guildID, clients.PermissionManageRoles|clients.PermissionManageChannels); err != nil {
+			return err
+		}
+	}
+	if err := clients.CheckRoleCount(ctx, c.roles, guildID, p.roleCreates); err != nil {
+		return err
+	}
+	if err := clients.CheckChannelCount(ctx, c.channels, guildID, p.channelCreates); err != nil {
+		return err
+	}
+
+	for _, s := range p.steps {
+		if err := s.apply(ctx); err != nil {
+			return errors.Wrapf(err, "cannot restore from template: %s", s.change)
+		}
+	}
+
+	if cr.Status.AtProvider.TemplateRestore == nil {
+		cr.Status.AtProvider.TemplateRestore = &guildv1alpha1.TemplateRestoreObservation{Code: r.Code}
+	}
+	cr.Status.AtProvider.TemplateRestore.LastAppliedPlanID = p.id()
+	if c.recorder != nil {
+		c.recorder.Event(cr, event.Normal("RestoredFromTemplate", fmt.Sprintf("Made %d change(s) to restore the guild from template %s", len(p.steps), r.Code)))
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guild
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MockTemplateClient struct {
+	Template *discordclient.GuildTemplate
+}
+
+func (m *MockTemplateClient) GetGuildTemplate(ctx context.Context, code string) (*discordclient.GuildTemplate, error) {
+	if m.Template == nil || m.Template.Code != code {
+		return nil, fmt.Errorf("failed to get guild template: Discord API error: 404 - Unknown Guild Template")
+	}
+	return m.Template, nil
+}
+
+// fakeGuildContent holds the roles and channels of a guild in memory.
+type fakeGuildContent struct {
+	discordclient.RoleClient
+	discordclient.ChannelClient
+
+	roles    []discordclient.Role
+	channels []discordclient.Channel
+	nextID   int
+}
+
+func (f *fakeGuildContent) id() string {
+	f.nextID++
+	return fmt.Sprintf("9%017d", f.nextID)
+}
+
+func (f *fakeGuildContent) ListRoles(ctx context.Context, guildID string) ([]discordclient.Role, error) {
+	return append([]discordclient.Role(nil), f.roles...), nil
+}
+
+func (f *fakeGuildContent) CreateRole(ctx context.Context, guildID string, req discordclient.CreateRoleRequest) (*discordclient.Role, error) {
+	r := discordclient.Role{ID: f.id(), Name: req.Name, Permissions: *req.Permissions, Color: *req.Color, Hoist: *req.Hoist, Mentionable: *req.Mentionable}
+	f.roles = append(f.roles, r)
+	return &r, nil
+}
+
+func (f *fakeGuildContent) ModifyRole(ctx context.Context, guildID, roleID string, req discordclient.ModifyRoleRequest) (*discordclient.Role, error) {
+	for i := range f.roles {
+		r := &f.roles[i]
+		if r.ID != roleID {
+			continue
+		}
+		r.Permissions = *req.Permissions
+		if req.Color != nil {
+			r.Color, r.Hoist, r.Mentionable = *req.Color, *req.Hoist, *req.Mentionable
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("unknown role %s", roleID)
+}
+
+func (f *fakeGuildContent) ListGuildChannels(ctx context.Context, guildID string) ([]discordclient.Channel, error) {
+	return append([]discordclient.Channel(nil), f.channels...), nil
+}
+
+func (f *fakeGuildContent) CreateChannel(ctx context.Context, req *discordclient.CreateChannelRequest) (*discordclient.Channel, error) {
+	ch := discordclient.Channel{ID: f.id(), GuildID: req.GuildID, Name: req.Name, Type: req.Type, Topic: req.Topic}
+	if req.ParentID != nil {
+		ch.ParentID = *req.ParentID
+	}
+	if req.NSFW != nil {
+		ch.NSFW = *req.NSFW
+	}
+	if req.RateLimitPerUser != nil {
+		ch.RateLimitPerUser = *req.RateLimitPerUser
+	}
+	f.channels = append(f.channels, ch)
+	return &ch, nil
+}
+
+func (f *fakeGuildContent) ModifyChannel(ctx context.Context, channelID string, req *discordclient.ModifyChannelRequest) (*discordclient.Channel, error) {
+	for i := range f.channels {
+		ch := &f.channels[i]
+		if ch.ID != channelID {
+			continue
+		}
+		ch.Topic = req.Topic
+		ch.NSFW = *req.NSFW
+		ch.RateLimitPerUser = *req.RateLimitPerUser
+		if req.ParentID != nil {
+			ch.ParentID = *req.ParentID
+		}
+		return ch, nil
+	}
+	return nil, fmt.Errorf("unknown channel %s", channelID)
+}
+
+const templateGuildID = "123456789012345678"
+
+func communityTemplate() *discordclient.GuildTemplate {
+	parent := 10
+	topic := "Say hello"
+	return &discordclient.GuildTemplate{
+		Code: "community",
+		SerializedSourceGuild: discordclient.TemplateGuild{
+			Roles: []discordclient.TemplateRole{
+				{ID: 0, Name: "@everyone", Permissions: "1024"},
+				{ID: 1, Name: "Moderator", Permissions: "8", Color: 3447003, Hoist: true},
+			},
+			Channels: []discordclient.TemplateChannel{
+				{ID: 10, Type: discordclient.ChannelTypeCategory, Name: "Community"},
+				{ID: 11, Type: discordclient.ChannelTypeText, Name: "welcome", Topic: &topic, ParentID: &parent},
+				{ID: 12, Type: discordclient.ChannelTypeText, Name: "general", ParentID: &parent},
+			},
+		},
+	}
+}
+
+func templateGuild(approved *string) *guildv1alpha1.Guild {
+	cr := &guildv1alpha1.Guild{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{meta.AnnotationKeyExternalName: templateGuildID}},
+		Spec: guildv1alpha1.GuildSpec{ForProvider: guildv1alpha1.GuildParameters{
+			RestoreFromTemplate: &guildv1alpha1.TemplateRestoreParameters{Code: "community", ApprovedPlan: approved},
+		}},
+	}
+	cr.Status.AtProvider.ID = templateGuildID
+	return cr
+}
+
+func TestTemplateRestore(t *testing.T) {
+	content := &fakeGuildContent{
+		roles: []discordclient.Role{
+			{ID: templateGuildID, Name: "@everyone", Permissions: "1024"},
+			{ID: "200000000000000002", Name: "Moderator", Permissions: "0", Managed: true},
+			{ID: "200000000000000001", Name: "Moderator", Permissions: "0", Color: 3447003, Hoist: true},
+		},
+		channels: []discordclient.Channel{
+			{ID: "300000000000000001", Type: discordclient.ChannelTypeText, Name: "general"},
+			{ID: "300000000000000002", Type: discordclient.ChannelTypeText, Name: "off-topic"},
+		},
+	}
+	e := &external{templates: &MockTemplateClient{Template: communityTemplate()}, roles: content, channels: content}
+
+	// The plan is reported but not applied until approved
+	cr := templateGuild(nil)
+	upToDate, err := e.templateRestoreUpToDate(context.Background(), cr, "")
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+	obs := cr.Status.AtProvider.TemplateRestore
+	require.NotNil(t, obs)
+	assert.Equal(t, []string{
+		`update role "Moderator": permissions`,
+		`create category "Community"`,
+		`create channel "welcome" in "Community"`,
+		`update channel "general" in "Community": category`,
+	}, obs.Changes)
+	assert.Len(t, obs.PlanID, 12)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(conditions.TypeTemplateDrift).Status)
+	assert.Contains(t, cr.GetCondition(conditions.TypeTemplateDrift).Message, obs.PlanID)
+
+	require.NoError(t, e.restoreFromTemplate(context.Background(), cr))
+	assert.Len(t, content.channels, 2, "an unapproved plan is not applied")
+
+	// A stale approval is not applied either
+	stale := "0123456789ab"
+	cr = templateGuild(&stale)
+	require.NoError(t, e.restoreFromTemplate(context.Background(), cr))
+	assert.Len(t, content.channels, 2)
+
+	// The approved plan is
+	cr = templateGuild(&obs.PlanID)
+	upToDate, err = e.templateRestoreUpToDate(context.Background(), cr, "")
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+	require.NoError(t, e.restoreFromTemplate(context.Background(), cr))
+	assert.Equal(t, obs.PlanID, cr.Status.AtProvider.TemplateRestore.LastAppliedPlanID)
+
+	assert.Equal(t, "0", content.roles[1].Permissions, "roles owned by integrations are left alone")
+	assert.Equal(t, "8", content.roles[2].Permissions)
+	require.Len(t, content.channels, 4, "extra channels are kept")
+	category := content.channels[2]
+	assert.Equal(t, "Community", category.Name)
+	assert.Equal(t, category.ID, content.channels[0].ParentID)
+	welcome := content.channels[3]
+	assert.Equal(t, "welcome", welcome.Name)
+	assert.Equal(t, category.ID, welcome.ParentID)
+	require.NotNil(t, welcome.Topic)
+	assert.Equal(t, "Say hello", *welcome.Topic)
+
+	// Afterwards the guild matches the template
+	upToDate, err = e.templateRestoreUpToDate(context.Background(), cr, obs.PlanID)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+	assert.Empty(t, cr.Status.AtProvider.TemplateRestore.Changes)
+	assert.Equal(t, obs.PlanID, cr.Status.AtProvider.TemplateRestore.LastAppliedPlanID)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(conditions.TypeTemplateDrift).Status)
+}
+
+func TestTemplateRestoreUnknownTemplate(t *testing.T) {
+	e := &external{templates: &MockTemplateClient{}, roles: &fakeGuildContent{}, channels: &fakeGuildContent{}}
+
+	_, err := e.templateRestoreUpToDate(context.Background(), templateGuild(nil), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot plan restore from template")
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package guildset stamps the Channel and Role templates of GuildSets into
+// every Guild they select.
+package guildset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const controllerName = "guildset"
+
+// Reconciler creates, updates and deletes the Channels and Roles of
+// GuildSets.
+type Reconciler struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder events.EventRecorder
+}
+
+// Setup adds a controller that reconciles GuildSets.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	r := &Reconciler{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorder(controllerName),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(t.ForControllerRuntime(guildv1alpha1.GuildSetGroupKind, o)).
+		For(&guildv1alpha1.GuildSet{}).
+		Owns(&channelv1alpha1.Channel{}).
+		Owns(&rolev1alpha1.Role{}).
+		Watches(&guildv1alpha1.Guild{}, handler.EnqueueRequestsFromMapFunc(r.selecting)).
+		Complete(r)
+}
+
+// selecting returns a request for each GuildSet selecting the Guild. Label
+// changes map both the old and the new Guild, so a GuildSet the Guild stops
+// matching is reconciled too.
+func (r *Reconciler) selecting(ctx context.Context, obj client.Object) []reconcile.Request {
+	l := &guildv1alpha1.GuildSetList{}
+	if err := r.client.List(ctx, l, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range l.Items {
+		sel, err := metav1.LabelSelectorAsSelector(&l.Items[i].Spec.GuildSelector)
+		if err != nil || !sel.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&l.Items[i])})
+	}
+	return reqs
+}
+
+// Reconcile stamps the GuildSet's templates into the Guilds it selects and
+// deletes the Channels and Roles it no longer wants.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	gs := &guildv1alpha1.GuildSet{}
+	if err := r.client.Get(ctx, req.NamespacedName, gs); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if gs.GetDeletionTimestamp() != nil {
+		// Garbage collection deletes the Channels and Roles it owns.
+		return ctrl.Result{}, nil
+	}
+
+	err := r.stamp(ctx, gs)
+	if err != nil {
+		gs.Status.SetConditions(xpv1.ReconcileError(err))
+		r.recorder.Eventf(gs, nil, corev1.EventTypeWarning, "CannotStamp", "", "Cannot stamp templates into guilds: %v", err)
+	} else {
+		gs.Status.SetConditions(xpv1.ReconcileSuccess())
+	}
+	if uerr := r.client.Status().Update(ctx, gs); uerr != nil {
+		return ctrl.Result{}, errors.Wrap(uerr, "cannot update GuildSet status")
+	}
+	return ctrl.Result{}, err
+}
+
+// stamp creates or updates the desired Channels and Roles, deletes the
+// undesired ones and records the outcome in the GuildSet's status.
+func (r *Reconciler) stamp(ctx context.Context, gs *guildv1alpha1.GuildSet) error {
+	sel, err := metav1.LabelSelectorAsSelector(&gs.Spec.GuildSelector)
+	if err != nil {
+		return errors.Wrap(err, "invalid guild selector")
+	}
+	guilds := &guildv1alpha1.GuildList{}
+	if err := r.client.List(ctx, guilds, client.InNamespace(gs.GetNamespace()), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return errors.Wrap(err, "cannot list guilds")
+	}
+	sort.Slice(guilds.Items, func(i, j int) bool { return guilds.Items[i].GetName() < guilds.Items[j].GetName() })
+
+	gs.Status.Guilds, gs.Status.PendingGuilds = nil, nil
+	desired := map[string]bool{}
+	// A selected guild without an ID may only have lost its status, so the
+	// resources stamped into it are kept until it is no longer selected
+	pending := map[string]bool{}
+	ambiguous := ambiguousNames(gs, guilds.Items)
+	var errs []error
+	// skip reports whether the name keyed by kind is ambiguous. An ambiguous
+	// resource is left as it is, and its error reported once.
+	skip := func(key string) bool {
+		err, ok := ambiguous[key]
+		if !ok {
+			return false
+		}
+		desired[key] = true
+		if err != nil {
+			errs = append(errs, err)
+			ambiguous[key] = nil
+		}
+		return true
+	}
+	for i := range guilds.Items {
+		g := &guilds.Items[i]
+		id := g.Status.AtProvider.ID
+		if id == "" {
+			gs.Status.PendingGuilds = append(gs.Status.PendingGuilds, g.GetName())
+			pending[g.GetName()] = true
+			continue
+		}
+		gs.Status.Guilds = append(gs.Status.Guilds, g.GetName())
+
+		for _, t := range gs.Spec.Channels {
+			ch := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{Name: childName(gs, g, t.Name), Namespace: gs.GetNamespace()}}
+			if skip(channelv1alpha1.ChannelKind + "/" + ch.GetName()) {
+				continue
+			}
+			desired[channelv1alpha1.ChannelKind+"/"+ch.GetName()] = true
+			if _, err := controllerutil.CreateOrUpdate(ctx, r.client, ch, func() error {
+				ch.Spec.ForProvider = *t.ForProvider.DeepCopy()
+				ch.Spec.ForProvider.GuildID = id
+				ch.Spec.CredentialsSecretRef = g.Spec.CredentialsSecretRef.DeepCopy()
+				return r.adopt(gs, g, ch)
+			}); err != nil {
+				errs = append(errs, errors.Wrapf(err, "cannot stamp Channel %s", ch.GetName()))
+			}
+		}
+		for _, t := range gs.Spec.Roles {
+			ro := &rolev1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: childName(gs, g, t.Name), Namespace: gs.GetNamespace()}}
+			if skip(rolev1alpha1.RoleKind + "/" + ro.GetName()) {
+				continue
+			}
+			desired[rolev1alpha1.RoleKind+"/"+ro.GetName()] = true
+			if _, err := controllerutil.CreateOrUpdate(ctx, r.client, ro, func() error {
+				ro.Spec.ForProvider = *t.ForProvider.DeepCopy()
+				ro.Spec.ForProvider.GuildID = id
+				ro.Spec.CredentialsSecretRef = g.Spec.CredentialsSecretRef.DeepCopy()
+				return r.adopt(gs, g, ro)
+			}); err != nil {
+				errs = append(errs, errors.Wrapf(err, "cannot stamp Role %s", ro.GetName()))
+			}
+		}
+	}
+
+	owned, err := r.owned(ctx, gs)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for key, obj := range owned {
+		if desired[key] || pending[obj.GetLabels()[guildv1alpha1.LabelGuild]] {
+			count++
+			continue
+		}
+		if err := r.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, errors.Wrapf(err, "cannot delete %s", key))
+			count++
+		}
+	}
+	gs.Status.ResourceCount = count
+
+	if len(gs.Status.PendingGuilds) > 0 {
+		gs.Status.SetConditions(xpv1.Creating())
+	} else {
+		gs.Status.SetConditions(xpv1.Available())
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// A stamped resource is a Channel or Role a GuildSet creates.
+type stamped interface {
+	client.Object
+	SetProviderConfigReference(*xpv1.ProviderConfigReference)
+}
+
+// adopt labels a stamped resource, points it at the Guild's ProviderConfig
+// and makes the GuildSet its controller.
+func (r *Reconciler) adopt(gs *guildv1alpha1.GuildSet, g *guildv1alpha1.Guild, obj stamped) error {
+	l := obj.GetLabels()
+	if l == nil {
+		l = map[string]string{}
+	}
+	l[guildv1alpha1.LabelGuildSet] = gs.GetName()
+	l[guildv1alpha1.LabelGuild] = g.GetName()
+	obj.SetLabels(l)
+	obj.SetProviderConfigReference(g.GetProviderConfigReference())
+	return controllerutil.SetControllerReference(gs, obj, r.scheme)
+}
+
+// owned returns the Channels and Roles the GuildSet controls, keyed by
+// kind and name.
+func (r *Reconciler) owned(ctx context.Context, gs *guildv1alpha1.GuildSet) (map[string]client.Object, error) {
+	opts := []client.ListOption{client.InNamespace(gs.GetNamespace()), client.MatchingLabels{guildv1alpha1.LabelGuildSet: gs.GetName()}}
+	owned := map[string]client.Object{}
+
+	channels := &channelv1alpha1.ChannelList{}
+	if err := r.client.List(ctx, channels, opts...); err != nil {
+		return nil, errors.Wrap(err, "cannot list channels")
+	}
+	for i := range channels.Items {
+		if metav1.IsControlledBy(&channels.Items[i], gs) {
+			owned[channelv1alpha1.ChannelKind+"/"+channels.Items[i].GetName()] = &channels.Items[i]
+		}
+	}
+
+	roles := &rolev1alpha1.RoleList{}
+	if err := r.client.List(ctx, roles, opts...); err != nil {
+		return nil, errors.Wrap(err, "cannot list roles")
+	}
+	for i := range roles.Items {
+		if metav1.IsControlledBy(&roles.Items[i], gs) {
+			owned[rolev1alpha1.RoleKind+"/"+roles.Items[i].GetName()] = &roles.Items[i]
+		}
+	}
+	return owned, nil
+}
+
+// childName is the name of the resource a template stamps into a guild.
+// Names longer than Kubernetes allows are shortened and end in a hash of
+// the GuildSet, guild and template names, which keeps them distinct.
+func childName(gs *guildv1alpha1.GuildSet, g *guildv1alpha1.Guild, template string) string {
+	name := fmt.Sprintf("%s-%s-%s", gs.GetName(), g.GetName(), template)
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(gs.GetName() + "/" + g.GetName() + "/" + template))
+	suffix := "-" + hex.EncodeToString(sum[:])[:10]
+	return strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.") + suffix
+}
+
+// ambiguousNames returns an error for each resource name, keyed by kind,
+// that more than one guild and template would be stamped as, such as
+// guild "a-b" with template "c" and guild "a" with template "b-c".
+func ambiguousNames(gs *guildv1alpha1.GuildSet, guilds []guildv1alpha1.Guild) map[string]error {
+	pairs := map[string][]string{}
+	for i := range guilds {
+		g := &guilds[i]
+		if g.Status.AtProvider.ID == "" {
+			continue
+		}
+		for _, t := range gs.Spec.Channels {
+			key := channelv1alpha1.ChannelKind + "/" + childName(gs, g, t.Name)
+			pairs[key] = append(pairs[key], fmt.Sprintf("guild %s template %s", g.GetName(), t.Name))
+		}
+		for _, t := range gs.Spec.Roles {
+			key := rolev1alpha1.RoleKind + "/" + childName(gs, g, t.Name)
+			pairs[key] = append(pairs[key], fmt.Sprintf("guild %s template %s", g.GetName(), t.Name))
+		}
+	}
+
+	ambiguous := map[string]error{}
+	for key, p := range pairs {
+		if len(p) > 1 {
+			ambiguous[key] = errors.Errorf("cannot stamp %s: it is the name for %s", key, strings.Join(p, " and "))
+		}
+	}
+	return ambiguous
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guildset
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "community"
+
+func guild(name, id string, labels map[string]string) *guildv1alpha1.Guild {
+	g := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	g.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: name + "-bot"})
+	g.Status.AtProvider.ID = id
+	return g
+}
+
+func guildSet() *guildv1alpha1.GuildSet {
+	return &guildv1alpha1.GuildSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "servers", Namespace: namespace, UID: "guildset-uid"},
+		Spec: guildv1alpha1.GuildSetSpec{
+			GuildSelector: metav1.LabelSelector{MatchLabels: map[string]string{"fleet": "community"}},
+			Channels: []guildv1alpha1.GuildSetChannel{{
+				Name:        "welcome",
+				ForProvider: channelv1alpha1.ChannelParameters{Name: "welcome", Type: 0},
+			}},
+			Roles: []guildv1alpha1.GuildSetRole{{
+				Name:        "moderator",
+				ForProvider: rolev1alpha1.RoleParameters{Name: "Moderator"},
+			}},
+		},
+	}
+}
+
+func setup(t *testing.T, objs ...client.Object) (*Reconciler, client.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, apis.AddToScheme(s))
+
+	kube := fake.NewClientBuilder().WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&guildv1alpha1.GuildSet{}).
+		Build()
+
+	return &Reconciler{client: kube, scheme: s, recorder: events.NewFakeRecorder(10)}, kube
+}
+
+func reconcileSet(t *testing.T, r *Reconciler) {
+	t.Helper()
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "servers"}})
+	require.NoError(t, err)
+}
+
+func TestReconcileStampsSelectedGuilds(t *testing.T) {
+	fleet := map[string]string{"fleet": "community"}
+	r, kube := setup(t,
+		guildSet(),
+		guild("alpha", "111111111111111111", fleet),
+		guild("beta", "", fleet),
+		guild("other", "222222222222222222", map[string]string{"fleet": "staff"}),
+	)
+
+	reconcileSet(t, r)
+
+	ch := &channelv1alpha1.Channel{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-welcome"}, ch))
+	assert.Equal(t, "111111111111111111", ch.Spec.ForProvider.GuildID)
+	assert.Equal(t, "welcome", ch.Spec.ForProvider.Name)
+	assert.Equal(t, "alpha-bot", ch.GetProviderConfigReference().Name)
+	assert.Equal(t, "servers", ch.GetLabels()[guildv1alpha1.LabelGuildSet])
+	assert.Equal(t, "alpha", ch.GetLabels()[guildv1alpha1.LabelGuild])
+	require.NotNil(t, metav1.GetControllerOf(ch))
+	assert.Equal(t, "servers", metav1.GetControllerOf(ch).Name)
+
+	ro := &rolev1alpha1.Role{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-moderator"}, ro))
+	assert.Equal(t, "111111111111111111", ro.Spec.ForProvider.GuildID)
+
+	channels := &channelv1alpha1.ChannelList{}
+	require.NoError(t, kube.List(context.Background(), channels))
+	assert.Len(t, channels.Items, 1, "only the selected guild with an ID is stamped")
+
+	gs := &guildv1alpha1.GuildSet{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers"}, gs))
+	assert.Equal(t, []string{"alpha"}, gs.Status.Guilds)
+	assert.Equal(t, []string{"beta"}, gs.Status.PendingGuilds)
+	assert.Equal(t, 2, gs.Status.ResourceCount)
+	assert.Equal(t, corev1.ConditionTrue, gs.Status.GetCondition(xpv1.TypeSynced).Status)
+	assert.Equal(t, xpv1.ReasonCreating, gs.Status.GetCondition(xpv1.TypeReady).Reason)
+}
+
+func TestReconcileUpdatesAndPrunes(t *testing.T) {
+	fleet := map[string]string{"fleet": "community"}
+	r, kube := setup(t, guildSet(), guild("alpha", "111111111111111111", fleet))
+	reconcileSet(t, r)
+
+	gs := &guildv1alpha1.GuildSet{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers"}, gs))
+	topic := "Say hello"
+	gs.Spec.Channels[0].ForProvider.Topic = &topic
+	gs.Spec.Roles = nil
+	require.NoError(t, kube.Update(context.Background(), gs))
+
+	reconcileSet(t, r)
+
+	ch := &channelv1alpha1.Channel{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-welcome"}, ch))
+	require.NotNil(t, ch.Spec.ForProvider.Topic)
+	assert.Equal(t, "Say hello", *ch.Spec.ForProvider.Topic)
+
+	roles := &rolev1alpha1.RoleList{}
+	require.NoError(t, kube.List(context.Background(), roles))
+	assert.Empty(t, roles.Items, "the removed role template is pruned")
+
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers"}, gs))
+	assert.Equal(t, 1, gs.Status.ResourceCount)
+	assert.Equal(t, corev1.ConditionTrue, gs.Status.GetCondition(xpv1.TypeReady).Status)
+}
+
+func TestReconcilePrunesUnselectedGuilds(t *testing.T) {
+	g := guild("alpha", "111111111111111111", map[string]string{"fleet": "community"})
+	r, kube := setup(t, guildSet(), g)
+	reconcileSet(t, r)
+
+	g.SetLabels(nil)
+	require.NoError(t, kube.Update(context.Background(), g))
+	reconcileSet(t, r)
+
+	channels := &channelv1alpha1.ChannelList{}
+	require.NoError(t, kube.List(context.Background(), channels))
+	assert.Empty(t, channels.Items)
+}
+
+func TestReconcileKeepsResourcesOfPendingGuilds(t *testing.T) {
+	g := guild("alpha", "111111111111111111", map[string]string{"fleet": "community"})
+	r, kube := setup(t, guildSet(), g)
+	reconcileSet(t, r)
+
+	// A restored Guild has lost its status but is still selected
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(g), g))
+	g.Status.AtProvider.ID = ""
+	require.NoError(t, kube.Update(context.Background(), g))
+	reconcileSet(t, r)
+
+	ch := &channelv1alpha1.Channel{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-welcome"}, ch))
+	gs := &guildv1alpha1.GuildSet{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers"}, gs))
+	assert.Equal(t, []string{"alpha"}, gs.Status.PendingGuilds)
+	assert.Equal(t, 2, gs.Status.ResourceCount)
+}
+
+func TestReconcileStampsGuildCredentials(t *testing.T) {
+	g := guild("alpha", "111111111111111111", map[string]string{"fleet": "community"})
+	g.Spec.CredentialsSecretRef = &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "team-bot"}, Key: "token"}
+	r, kube := setup(t, guildSet(), g)
+	reconcileSet(t, r)
+
+	ch := &channelv1alpha1.Channel{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-welcome"}, ch))
+	assert.Equal(t, g.Spec.CredentialsSecretRef, ch.Spec.CredentialsSecretRef)
+	ro := &rolev1alpha1.Role{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-moderator"}, ro))
+	assert.Equal(t, g.Spec.CredentialsSecretRef, ro.Spec.CredentialsSecretRef)
+}
+
+func TestReconcileLeavesUnownedResources(t *testing.T) {
+	existing := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{
+		Name:      "servers-alpha-welcome",
+		Namespace: namespace,
+		Labels:    map[string]string{guildv1alpha1.LabelGuildSet: "servers"},
+	}}
+	r, kube := setup(t, guildSet(), existing)
+	reconcileSet(t, r)
+
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(existing), existing))
+}
+
+func TestSelecting(t *testing.T) {
+	r, _ := setup(t, guildSet())
+
+	reqs := r.selecting(context.Background(), guild("alpha", "", map[string]string{"fleet": "community"}))
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "servers", reqs[0].Name)
+
+	assert.Empty(t, r.selecting(context.Background(), guild("other", "", map[string]string{"fleet": "staff"})))
+}
+
+func TestReconcileRejectsAmbiguousNames(t *testing.T) {
+	fleet := map[string]string{"fleet": "community"}
+	gs := guildSet()
+	gs.Spec.Channels = append(gs.Spec.Channels, guildv1alpha1.GuildSetChannel{
+		Name:        "new-welcome",
+		ForProvider: channelv1alpha1.ChannelParameters{Name: "new-welcome", Type: 0},
+	})
+	// Guild alpha's new-welcome and guild alpha-new's welcome are both
+	// servers-alpha-new-welcome
+	r, kube := setup(t, gs, guild("alpha", "111111111111111111", fleet), guild("alpha-new", "222222222222222222", fleet))
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "servers"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "guild alpha template new-welcome and guild alpha-new template welcome")
+
+	ch := &channelv1alpha1.Channel{}
+	err = kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-new-welcome"}, ch)
+	assert.True(t, kerrors.IsNotFound(err), "the ambiguous channel is not stamped")
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-welcome"}, ch))
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers-alpha-new-new-welcome"}, ch))
+
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "servers"}, gs))
+	assert.Equal(t, corev1.ConditionFalse, gs.Status.GetCondition(xpv1.TypeSynced).Status)
+}
+
+func TestChildName(t *testing.T) {
+	gs := guildSet()
+	long := strings.Repeat("a", 250)
+
+	assert.Equal(t, "servers-alpha-welcome", childName(gs, guild("alpha", "", nil), "welcome"))
+
+	a := childName(gs, guild(long+"-b", "", nil), "welcome")
+	b := childName(gs, guild(long+"-c", "", nil), "welcome")
+	assert.Len(t, a, validation.DNS1123SubdomainMaxLength)
+	assert.Empty(t, validation.IsDNS1123Subdomain(a))
+	assert.NotEqual(t, a, b, "shortened names stay distinct")
+	assert.Equal(t, a, childName(gs, guild(long+"-b", "", nil), "welcome"), "names are stable")
+}
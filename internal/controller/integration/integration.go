@@ -8,12 +8,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
-	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	integrationv1alpha1 "github.com/rossigee/provider-discord/apis/integration/v1alpha1"
-	v1alpha1 "github.com/rossigee/provider-discord/apis/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
-	"k8s.io/apimachinery/pkg/types"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,22 +25,23 @@ const (
 )
 
 // Setup adds a controller that reconciles Integration managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(integrationv1alpha1.IntegrationGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(integrationv1alpha1.IntegrationGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:  mgr.GetClient(),
 			usage: resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(integrationv1alpha1.IntegrationGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&integrationv1alpha1.Integration{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -69,40 +69,13 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	// Get provider config reference from the managed resource's ResourceSpec
-	var pcRef *xpv1.ProviderConfigReference
-
-	// Type assert to extract the ProviderConfigReference from the managed resource
-	switch mr := mg.(type) {
-	case interface {
-		GetProviderConfigReference() *xpv1.ProviderConfigReference
-	}:
-		pcRef = mr.GetProviderConfigReference()
-	default:
-		return nil, errors.New(errGetPC)
-	}
-
-	if pcRef == nil {
-		return nil, errors.New(errGetPC)
-	}
-
-	pc := &v1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: pcRef.Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	// Extract credentials from the provider config
-	credentials := discordclient.ProviderCredentials{
-		Source:                    discordclient.CredentialsSourceSecret,
-		CommonCredentialSelectors: pc.Spec.Credentials.CommonCredentialSelectors,
-	}
-	token, err := credentials.Extract(ctx, c.kube)
+	token, err := discordclient.GetConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
 	// Create Discord client
-	discordClient := discordclient.NewDiscordClient(token)
+	discordClient := discordclient.NewDiscordClient(*token)
 
 	return &external{discord: discordClient}, nil
 }
@@ -160,25 +133,17 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.ExpireBehavior = foundIntegration.ExpireBehavior
 	cr.Status.AtProvider.ExpireGracePeriod = foundIntegration.ExpireGracePeriod
 	if foundIntegration.User != nil {
-		if userID, ok := foundIntegration.User["id"].(string); ok {
-			cr.Status.AtProvider.UserID = &userID
-		}
+		cr.Status.AtProvider.UserID = &foundIntegration.User.ID
 	}
 	if foundIntegration.Account != nil {
-		if accountID, ok := foundIntegration.Account["id"].(string); ok {
-			cr.Status.AtProvider.AccountID = &accountID
-		}
-		if accountName, ok := foundIntegration.Account["name"].(string); ok {
-			cr.Status.AtProvider.AccountName = &accountName
-		}
+		cr.Status.AtProvider.AccountID = &foundIntegration.Account.ID
+		cr.Status.AtProvider.AccountName = &foundIntegration.Account.Name
 	}
 	cr.Status.AtProvider.SyncedAt = foundIntegration.SyncedAt
 	cr.Status.AtProvider.SubscriberCount = foundIntegration.SubscriberCount
 	cr.Status.AtProvider.Revoked = foundIntegration.Revoked
 	if foundIntegration.Application != nil {
-		if appID, ok := foundIntegration.Application["id"].(string); ok {
-			cr.Status.AtProvider.ApplicationID = &appID
-		}
+		cr.Status.AtProvider.ApplicationID = &foundIntegration.Application.ID
 	}
 	cr.Status.AtProvider.Scopes = foundIntegration.Scopes
 
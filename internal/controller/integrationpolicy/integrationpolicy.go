@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package integrationpolicy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	integrationv1alpha1 "github.com/rossigee/provider-discord/apis/integration/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotIntegrationPolicy = "managed resource is not an IntegrationPolicy custom resource"
+)
+
+// Setup adds a controller that reconciles IntegrationPolicy managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(integrationv1alpha1.IntegrationPolicyGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(integrationv1alpha1.IntegrationPolicyGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(integrationv1alpha1.IntegrationPolicyGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&integrationv1alpha1.IntegrationPolicy{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*integrationv1alpha1.IntegrationPolicy)
+	if !ok {
+		return nil, errors.New(errNotIntegrationPolicy)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+
+	return &external{integrations: svc, applications: svc}, nil
+}
+
+// An ExternalClient enforces an integration policy. The policy itself exists
+// only in Kubernetes: Observe finds the integrations that break it and Update
+// deletes them.
+type external struct {
+	integrations discordclient.IntegrationClient
+	// applications identifies the provider's own application, whose
+	// integration is never deleted.
+	applications discordclient.ApplicationClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*integrationv1alpha1.IntegrationPolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotIntegrationPolicy)
+	}
+
+	integrations, err := e.integrations.GetGuildIntegrations(ctx, cr.Spec.ForProvider.GuildID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "failed to list guild integrations")
+	}
+
+	app, err := e.applications.GetCurrentApplication(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get current application")
+	}
+
+	// The policy is identified by its guild
+	if meta.GetExternalName(cr) != cr.Spec.ForProvider.GuildID {
+		meta.SetExternalName(cr, cr.Spec.ForProvider.GuildID)
+	}
+
+	violating := violations(cr.Spec.ForProvider, integrations, app.ID)
+
+	cr.Status.AtProvider.IntegrationCount = len(integrations)
+	cr.Status.AtProvider.ViolatingIntegrationIDs = violating
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(violating) == 0,
+	}, nil
+}
+
+// violations returns the sorted IDs of integrations that break the policy.
+// Only bot integrations can break it, and never that of ownApplicationID.
+func violations(p integrationv1alpha1.IntegrationPolicyParameters, integrations []discordclient.GuildIntegration, ownApplicationID string) []string {
+	allowed := make(map[string]bool, len(p.AllowedApplicationIDs)+1)
+	for _, id := range p.AllowedApplicationIDs {
+		allowed[id] = true
+	}
+	allowed[ownApplicationID] = true
+
+	var ids []string
+	for _, integration := range integrations {
+		if integration.Application == nil || allowed[integration.Application.ID] {
+			continue
+		}
+		ids = append(ids, integration.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (e *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*integrationv1alpha1.IntegrationPolicy); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotIntegrationPolicy)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update deletes the integrations Observe found breaking the policy.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*integrationv1alpha1.IntegrationPolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotIntegrationPolicy)
+	}
+
+	for _, id := range cr.Status.AtProvider.ViolatingIntegrationIDs {
+		if err := e.integrations.DeleteGuildIntegration(ctx, cr.Spec.ForProvider.GuildID, id); err != nil && !discordclient.IsNotFound(err) {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "failed to delete integration %s", id)
+		}
+		cr.Status.AtProvider.DeletedCount++
+	}
+	cr.Status.AtProvider.ViolatingIntegrationIDs = nil
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if _, ok := mg.(*integrationv1alpha1.IntegrationPolicy); !ok {
+		return managed.ExternalDelete{}, errors.New(errNotIntegrationPolicy)
+	}
+	// Deleting a policy stops enforcement; it never deletes integrations
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	integrationv1alpha1 "github.com/rossigee/provider-discord/apis/integration/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockIntegrationClient struct {
+	GetGuildIntegrationsFunc   func(ctx context.Context, guildID string) ([]discordclient.GuildIntegration, error)
+	DeleteGuildIntegrationFunc func(ctx context.Context, guildID, integrationID string) error
+}
+
+func (m *MockIntegrationClient) GetGuildIntegrations(ctx context.Context, guildID string) ([]discordclient.GuildIntegration, error) {
+	return m.GetGuildIntegrationsFunc(ctx, guildID)
+}
+
+func (m *MockIntegrationClient) DeleteGuildIntegration(ctx context.Context, guildID, integrationID string) error {
+	return m.DeleteGuildIntegrationFunc(ctx, guildID, integrationID)
+}
+
+type MockApplicationClient struct {
+	discordclient.ApplicationClient
+}
+
+func (m *MockApplicationClient) GetCurrentApplication(ctx context.Context) (*discordclient.DiscordApplication, error) {
+	return &discordclient.DiscordApplication{ID: "own"}, nil
+}
+
+func TestObserveAndUpdate(t *testing.T) {
+	const guildID = "123456789012345678"
+	integrations := []discordclient.GuildIntegration{
+		{ID: "i-own", Type: "discord", Application: &discordclient.IntegrationApplication{ID: "own"}},
+		{ID: "i-approved", Type: "discord", Application: &discordclient.IntegrationApplication{ID: "approved"}},
+		{ID: "i-rogue", Type: "discord", Application: &discordclient.IntegrationApplication{ID: "rogue"}},
+		{ID: "i-gone", Type: "discord", Application: &discordclient.IntegrationApplication{ID: "gone"}},
+		{ID: "i-twitch", Type: "twitch"},
+	}
+
+	var deleted []string
+	e := &external{
+		integrations: &MockIntegrationClient{
+			GetGuildIntegrationsFunc: func(ctx context.Context, id string) ([]discordclient.GuildIntegration, error) {
+				assert.Equal(t, guildID, id)
+				return integrations, nil
+			},
+			DeleteGuildIntegrationFunc: func(ctx context.Context, gid, id string) error {
+				assert.Equal(t, guildID, gid)
+				deleted = append(deleted, id)
+				if id == "i-gone" {
+					return &discordclient.APIError{StatusCode: 404, Code: 10005, Message: "Unknown Integration"}
+				}
+				return nil
+			},
+		},
+		applications: &MockApplicationClient{},
+	}
+	cr := &integrationv1alpha1.IntegrationPolicy{Spec: integrationv1alpha1.IntegrationPolicySpec{
+		ForProvider: integrationv1alpha1.IntegrationPolicyParameters{
+			GuildID:               guildID,
+			AllowedApplicationIDs: []string{"approved"},
+		},
+	}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Equal(t, guildID, meta.GetExternalName(cr))
+	assert.Equal(t, 5, cr.Status.AtProvider.IntegrationCount)
+	assert.Equal(t, []string{"i-gone", "i-rogue"}, cr.Status.AtProvider.ViolatingIntegrationIDs)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"i-gone", "i-rogue"}, deleted)
+	assert.Equal(t, 2, cr.Status.AtProvider.DeletedCount)
+	assert.Empty(t, cr.Status.AtProvider.ViolatingIntegrationIDs)
+}
+
+func TestDeleteKeepsIntegrations(t *testing.T) {
+	e := &external{}
+	_, err := e.Delete(context.Background(), &integrationv1alpha1.IntegrationPolicy{})
+	assert.NoError(t, err)
+}
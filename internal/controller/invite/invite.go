@@ -18,6 +18,7 @@ package invite
 
 import (
 	"context"
+	"fmt"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -28,6 +29,9 @@ import (
 	"github.com/pkg/errors"
 	invitev1alpha1 "github.com/rossigee/provider-discord/apis/invite/v1alpha1"
 	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"regexp"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -42,6 +46,10 @@ const (
 	errGetCreds     = "cannot get credentials"
 )
 
+// nearExhaustionFraction is the share of an invite's uses or lifetime that
+// may remain before it is reported as near exhaustion.
+const nearExhaustionFraction = 0.1
+
 var (
 	// Discord invite codes are typically 6-12 character alphanumeric strings
 	// Examples: "abc123", "xyz789", "discord", "general"
@@ -56,22 +64,25 @@ func isValidDiscordInviteCode(code string) bool {
 }
 
 // Setup adds a controller that reconciles Invite managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(invitev1alpha1.InviteGroupKind.String())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(invitev1alpha1.InviteGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:         mgr.GetClient(),
 			newServiceFn: clients.NewDiscordClient,
-		}),
+			recorder:     recorder,
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(recorder))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(invitev1alpha1.InviteGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&invitev1alpha1.Invite{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -82,6 +93,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	newServiceFn func(token string) *clients.DiscordClient
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -106,14 +118,15 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(*token)
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, kube: c.kube, recorder: c.recorder}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service clients.InviteClient
-	kube    client.Client
+	service  clients.InviteClient
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -162,6 +175,25 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		createdAt = &metav1.Time{Time: parsedTime}
 	}
 
+	// GET /invites/{code} omits usage metadata, which is only returned when
+	// listing the channel's invites
+	uses, maxUses, maxAge, temporary := invite.Uses, invite.MaxUses, invite.MaxAge, invite.Temporary
+	if channelInvites, err := c.service.GetChannelInvites(ctx, cr.Spec.ForProvider.ChannelID); err != nil {
+		// Log but don't fail - the invite itself was observed
+		ctrl.LoggerFrom(ctx).V(2).Info("Failed to get invite metadata", "code", invite.Code, "error", err)
+	} else {
+		for _, ci := range channelInvites {
+			if ci.Code != invite.Code {
+				continue
+			}
+			uses, maxUses, maxAge, temporary = ci.Uses, ci.MaxUses, ci.MaxAge, ci.Temporary
+			if parsedTime, err := time.Parse(time.RFC3339, ci.CreatedAt); err == nil {
+				createdAt = &metav1.Time{Time: parsedTime}
+			}
+			break
+		}
+	}
+
 	// Update status with observed values
 	cr.Status.AtProvider = invitev1alpha1.InviteObservation{
 		Code:                     invite.Code,
@@ -175,16 +207,18 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		ApproximateMemberCount:   invite.ApproximateMemberCount,
 		ExpiresAt:                expiresAt,
 		CreatedAt:                createdAt,
-		Uses:                     invite.Uses,
-		MaxAge:                   invite.MaxAge,
-		MaxUses:                  invite.MaxUses,
-		Temporary:                invite.Temporary,
+		Uses:                     uses,
+		MaxAge:                   maxAge,
+		MaxUses:                  maxUses,
+		Temporary:                temporary,
 	}
 
+	c.recordExhaustion(cr, exhaustionCondition(cr.Status.AtProvider, time.Now()))
+
 	// Store invite URL in connection secret
 	connectionDetails := managed.ConnectionDetails{}
 	if invite.Code != "" {
-		inviteURL := "https://discord.gg/" + invite.Code
+		inviteURL := clients.InviteURL(invite.Code)
 		connectionDetails["url"] = []byte(inviteURL)
 	}
 
@@ -196,6 +230,46 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// recordExhaustion sets the NearExhaustion condition, emitting a warning event
+// when an invite first needs rotating.
+func (c *external) recordExhaustion(cr *invitev1alpha1.Invite, cond xpv1.Condition) {
+	previous := cr.GetCondition(conditions.TypeNearExhaustion)
+	if cond.Status == corev1.ConditionTrue && (previous.Status != corev1.ConditionTrue || previous.Reason != cond.Reason) && c.recorder != nil {
+		c.recorder.Event(cr, event.Warning(event.Reason(cond.Reason), errors.New(cond.Message)))
+	}
+	cr.SetConditions(cond)
+}
+
+// exhaustionCondition reports whether the invite has used up, or is about to
+// use up, its uses or lifetime.
+func exhaustionCondition(o invitev1alpha1.InviteObservation, now time.Time) xpv1.Condition {
+	if o.MaxUses > 0 {
+		remaining := o.MaxUses - o.Uses
+		switch {
+		case remaining <= 0:
+			return conditions.NearExhaustion(conditions.ReasonMaxUsesReached,
+				fmt.Sprintf("invite has been used %d of %d times", o.Uses, o.MaxUses))
+		case float64(remaining) <= float64(o.MaxUses)*nearExhaustionFraction:
+			return conditions.NearExhaustion(conditions.ReasonNearMaxUses,
+				fmt.Sprintf("invite has %d of %d uses left", remaining, o.MaxUses))
+		}
+	}
+
+	if o.ExpiresAt != nil {
+		left := o.ExpiresAt.Sub(now)
+		switch {
+		case left <= 0:
+			return conditions.NearExhaustion(conditions.ReasonExpired,
+				fmt.Sprintf("invite expired at %s", o.ExpiresAt.UTC().Format(time.RFC3339)))
+		case o.MaxAge > 0 && left.Seconds() <= float64(o.MaxAge)*nearExhaustionFraction:
+			return conditions.NearExhaustion(conditions.ReasonNearExpiry,
+				fmt.Sprintf("invite expires at %s", o.ExpiresAt.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	return conditions.Usable()
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*invitev1alpha1.Invite)
 	if !ok {
@@ -221,7 +295,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	// Store invite URL in connection secret
 	connectionDetails := managed.ConnectionDetails{}
 	if invite.Code != "" {
-		inviteURL := "https://discord.gg/" + invite.Code
+		inviteURL := clients.InviteURL(invite.Code)
 		connectionDetails["url"] = []byte(inviteURL)
 	}
 
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invite
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	invitev1alpha1 "github.com/rossigee/provider-discord/apis/invite/v1alpha1"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestExhaustionCondition(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time { return &metav1.Time{Time: now.Add(d)} }
+
+	tests := []struct {
+		name           string
+		observation    invitev1alpha1.InviteObservation
+		expectedStatus corev1.ConditionStatus
+		expectedReason xpv1.ConditionReason
+	}{
+		{
+			name:           "unlimited and permanent",
+			observation:    invitev1alpha1.InviteObservation{Uses: 500},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: conditions.ReasonUsable,
+		},
+		{
+			name:           "plenty of uses left",
+			observation:    invitev1alpha1.InviteObservation{Uses: 50, MaxUses: 100},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: conditions.ReasonUsable,
+		},
+		{
+			name:           "near max uses",
+			observation:    invitev1alpha1.InviteObservation{Uses: 9, MaxUses: 10},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: conditions.ReasonNearMaxUses,
+		},
+		{
+			name:           "max uses reached",
+			observation:    invitev1alpha1.InviteObservation{Uses: 10, MaxUses: 10},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: conditions.ReasonMaxUsesReached,
+		},
+		{
+			name:           "near expiry",
+			observation:    invitev1alpha1.InviteObservation{MaxAge: 86400, ExpiresAt: at(time.Hour)},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: conditions.ReasonNearExpiry,
+		},
+		{
+			name:           "expired",
+			observation:    invitev1alpha1.InviteObservation{MaxAge: 86400, ExpiresAt: at(-time.Minute)},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: conditions.ReasonExpired,
+		},
+		{
+			name:           "plenty of lifetime left",
+			observation:    invitev1alpha1.InviteObservation{MaxAge: 86400, ExpiresAt: at(12 * time.Hour)},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: conditions.ReasonUsable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := exhaustionCondition(tc.observation, now)
+			assert.Equal(t, conditions.TypeNearExhaustion, c.Type)
+			assert.Equal(t, tc.expectedStatus, c.Status)
+			assert.Equal(t, tc.expectedReason, c.Reason)
+		})
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package invitepolicy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	invitev1alpha1 "github.com/rossigee/provider-discord/apis/invite/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotInvitePolicy = "managed resource is not an InvitePolicy custom resource"
+)
+
+// Setup adds a controller that reconciles InvitePolicy managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(invitev1alpha1.InvitePolicyGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(invitev1alpha1.InvitePolicyGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube:  mgr.GetClient(),
+			grace: o.PollInterval,
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(invitev1alpha1.InvitePolicyGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&invitev1alpha1.InvitePolicy{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+	// grace is how long a new invite is kept before it may be deleted as
+	// unmanaged: the poll interval, so the Invite or Guild that created it
+	// has recorded its code.
+	grace time.Duration
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*invitev1alpha1.InvitePolicy)
+	if !ok {
+		return nil, errors.New(errNotInvitePolicy)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+	return &external{discord: svc, users: svc, kube: c.kube, grace: c.grace, now: time.Now}, nil
+}
+
+// An ExternalClient enforces an invite policy. The policy itself exists only
+// in Kubernetes: Observe finds the invites that break it and Update deletes
+// them.
+type external struct {
+	discord discordclient.InviteClient
+	// users reads the bot's user, whose invites are never deleted as
+	// unmanaged.
+	users discordclient.UserClient
+	kube  client.Client
+	grace time.Duration
+	now   func() time.Time
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*invitev1alpha1.InvitePolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotInvitePolicy)
+	}
+
+	invites, err := e.discord.GetGuildInvites(ctx, cr.Spec.ForProvider.GuildID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "failed to list guild invites")
+	}
+
+	managedCodes, err := e.managedCodes(ctx, cr.Spec.ForProvider.GuildID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The policy is identified by its guild
+	if meta.GetExternalName(cr) != cr.Spec.ForProvider.GuildID {
+		meta.SetExternalName(cr, cr.Spec.ForProvider.GuildID)
+	}
+
+	managedCount := 0
+	for _, inv := range invites {
+		if managedCodes[inv.Code] {
+			managedCount++
+		}
+	}
+	botID, err := e.botID(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	violating := violations(cr.Spec.ForProvider, invites, managedCodes, botID, e.grace, e.now())
+
+	cr.Status.AtProvider.InviteCount = len(invites)
+	cr.Status.AtProvider.ManagedCount = managedCount
+	cr.Status.AtProvider.ViolatingCodes = violating
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(violating) == 0,
+	}, nil
+}
+
+// managedCodes returns the codes of invites managed by Invite resources, and
+// the primary invites of Guild resources for the guild, in any namespace.
+func (e *external) managedCodes(ctx context.Context, guildID string) (map[string]bool, error) {
+	codes := map[string]bool{}
+
+	invites := &invitev1alpha1.InviteList{}
+	if err := e.kube.List(ctx, invites); err != nil {
+		return nil, errors.Wrap(err, "cannot list Invites")
+	}
+	for _, inv := range invites.Items {
+		if code := meta.GetExternalName(&inv); code != "" {
+			codes[code] = true
+		}
+		if code := inv.Status.AtProvider.Code; code != "" {
+			codes[code] = true
+		}
+	}
+
+	guilds := &guildv1alpha1.GuildList{}
+	if err := e.kube.List(ctx, guilds); err != nil {
+		return nil, errors.Wrap(err, "cannot list Guilds")
+	}
+	for _, g := range guilds.Items {
+		if meta.GetExternalName(&g) == guildID && g.Status.AtProvider.PrimaryInviteCode != "" {
+			codes[g.Status.AtProvider.PrimaryInviteCode] = true
+		}
+	}
+
+	return codes, nil
+}
+
+// botID returns the bot's user ID when the policy deletes unmanaged invites.
+// The invites the bot creates belong to Invite and Guild resources, so an
+// error is returned rather than risk deleting one whose code is not yet
+// recorded.
+func (e *external) botID(ctx context.Context, p invitev1alpha1.InvitePolicyParameters) (string, error) {
+	if e.users == nil || p.DeleteUnmanaged == nil || !*p.DeleteUnmanaged {
+		return "", nil
+	}
+	bot, err := e.users.GetCurrentUser(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get bot user")
+	}
+	return bot.ID, nil
+}
+
+// violations returns the sorted codes of invites that break the policy.
+// Invites created by the bot, or within grace of now, are not deleted as
+// unmanaged: they may belong to an Invite or Guild that has not recorded
+// their code yet.
+func violations(p invitev1alpha1.InvitePolicyParameters, invites []discordclient.Invite, managedCodes map[string]bool, botID string, grace time.Duration, now time.Time) []string {
+	exempt := make(map[string]bool, len(p.ExemptCodes))
+	for _, code := range p.ExemptCodes {
+		exempt[code] = true
+	}
+
+	var codes []string
+	for _, inv := range invites {
+		if exempt[inv.Code] {
+			continue
+		}
+		unmanaged := p.DeleteUnmanaged != nil && *p.DeleteUnmanaged && !managedCodes[inv.Code] &&
+			!createdBy(inv, botID) && !createdWithin(inv, grace, now)
+		if unmanaged || tooOld(p.MaxAge, inv, now) {
+			codes = append(codes, inv.Code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// createdBy reports whether inv was created by the user with the supplied ID.
+func createdBy(inv discordclient.Invite, userID string) bool {
+	return userID != "" && inv.Inviter != nil && inv.Inviter.ID == userID
+}
+
+// createdWithin reports whether inv was created less than d ago.
+func createdWithin(inv discordclient.Invite, d time.Duration, now time.Time) bool {
+	created, err := time.Parse(time.RFC3339, inv.CreatedAt)
+	return err == nil && now.Sub(created) < d
+}
+
+// tooOld reports whether inv was created longer than maxAge ago. Invites
+// with an unreadable creation time are never too old.
+func tooOld(maxAge *metav1.Duration, inv discordclient.Invite, now time.Time) bool {
+	if maxAge == nil || maxAge.Duration <= 0 {
+		return false
+	}
+	created, err := time.Parse(time.RFC3339, inv.CreatedAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(created) > maxAge.Duration
+}
+
+func (e *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*invitev1alpha1.InvitePolicy); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotInvitePolicy)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update deletes the invites Observe found breaking the policy.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*invitev1alpha1.InvitePolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotInvitePolicy)
+	}
+
+	for _, code := range cr.Status.AtProvider.ViolatingCodes {
+		if err := e.discord.DeleteInvite(ctx, code); err != nil && !discordclient.IsNotFound(err) {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "failed to delete invite %s", code)
+		}
+		cr.Status.AtProvider.DeletedCount++
+	}
+	cr.Status.AtProvider.ViolatingCodes = nil
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if _, ok := mg.(*invitev1alpha1.InvitePolicy); !ok {
+		return managed.ExternalDelete{}, errors.New(errNotInvitePolicy)
+	}
+	// Deleting a policy stops enforcement; it never deletes invites
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invitepolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/apis"
+	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
+	invitev1alpha1 "github.com/rossigee/provider-discord/apis/invite/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type MockInviteClient struct {
+	GetGuildInvitesFunc func(ctx context.Context, guildID string) ([]discordclient.Invite, error)
+	DeleteInviteFunc    func(ctx context.Context, inviteCode string) error
+}
+
+func (m *MockInviteClient) CreateChannelInvite(ctx context.Context, channelID string, req *discordclient.CreateInviteRequest) (*discordclient.Invite, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetInvite(ctx context.Context, inviteCode string) (*discordclient.Invite, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) DeleteInvite(ctx context.Context, inviteCode string) error {
+	if m.DeleteInviteFunc != nil {
+		return m.DeleteInviteFunc(ctx, inviteCode)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetChannelInvites(ctx context.Context, channelID string) ([]discordclient.Invite, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockInviteClient) GetGuildInvites(ctx context.Context, guildID string) ([]discordclient.Invite, error) {
+	if m.GetGuildInvitesFunc != nil {
+		return m.GetGuildInvitesFunc(ctx, guildID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+type MockUserClient struct {
+	discordclient.UserClient
+	GetCurrentUserFunc func(ctx context.Context) (*discordclient.DiscordUser, error)
+}
+
+func (m *MockUserClient) GetCurrentUser(ctx context.Context) (*discordclient.DiscordUser, error) {
+	return m.GetCurrentUserFunc(ctx)
+}
+
+func bot(id string) *MockUserClient {
+	return &MockUserClient{GetCurrentUserFunc: func(context.Context) (*discordclient.DiscordUser, error) {
+		return &discordclient.DiscordUser{ID: id}, nil
+	}}
+}
+
+func TestObserveAndUpdate(t *testing.T) {
+	const guildID = "123456789012345678"
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	invites := []discordclient.Invite{
+		{Code: "managed", CreatedAt: "2025-05-30T00:00:00+00:00"},
+		{Code: "primary", CreatedAt: "2025-05-30T00:00:00+00:00"},
+		{Code: "manual", CreatedAt: "2025-05-30T00:00:00+00:00"},
+		{Code: "stale", CreatedAt: "2025-01-01T00:00:00+00:00"},
+		{Code: "exempt", CreatedAt: "2025-01-01T00:00:00+00:00"},
+		{Code: "recent", CreatedAt: "2025-05-31T23:59:00+00:00"},
+		{Code: "by-bot", CreatedAt: "2025-05-30T00:00:00+00:00", Inviter: &discordclient.User{ID: "999999999999999999"}},
+	}
+
+	newKube := func(t *testing.T) client.Client {
+		s := runtime.NewScheme()
+		require.NoError(t, apis.AddToScheme(s))
+		inv := &invitev1alpha1.Invite{ObjectMeta: metav1.ObjectMeta{Name: "welcome", Namespace: "default"}}
+		meta.SetExternalName(inv, "managed")
+		g := &guildv1alpha1.Guild{ObjectMeta: metav1.ObjectMeta{Name: "guild", Namespace: "other"}}
+		meta.SetExternalName(g, guildID)
+		g.Status.AtProvider.PrimaryInviteCode = "primary"
+		return fake.NewClientBuilder().WithScheme(s).WithObjects(inv, g).Build()
+	}
+
+	yes := true
+	tests := []struct {
+		name              string
+		params            invitev1alpha1.InvitePolicyParameters
+		expectedViolating []string
+	}{
+		{
+			name:              "unmanaged invites",
+			params:            invitev1alpha1.InvitePolicyParameters{DeleteUnmanaged: &yes, ExemptCodes: []string{"exempt"}},
+			expectedViolating: []string{"manual", "stale"},
+		},
+		{
+			name:              "old invites",
+			params:            invitev1alpha1.InvitePolicyParameters{MaxAge: &metav1.Duration{Duration: 30 * 24 * time.Hour}},
+			expectedViolating: []string{"exempt", "stale"},
+		},
+		{
+			name:   "nothing enforced",
+			params: invitev1alpha1.InvitePolicyParameters{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted []string
+			e := &external{
+				discord: &MockInviteClient{
+					GetGuildInvitesFunc: func(ctx context.Context, id string) ([]discordclient.Invite, error) {
+						assert.Equal(t, guildID, id)
+						return invites, nil
+					},
+					DeleteInviteFunc: func(ctx context.Context, code string) error {
+						deleted = append(deleted, code)
+						if code == "stale" {
+							return &discordclient.APIError{StatusCode: 404, Code: 10006, Message: "Unknown Invite"}
+						}
+						return nil
+					},
+				},
+				users: bot("999999999999999999"),
+				kube:  newKube(t),
+				grace: 10 * time.Minute,
+				now:   func() time.Time { return now },
+			}
+			tc.params.GuildID = guildID
+			cr := &invitev1alpha1.InvitePolicy{Spec: invitev1alpha1.InvitePolicySpec{ForProvider: tc.params}}
+
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceExists)
+			assert.Equal(t, len(tc.expectedViolating) == 0, obs.ResourceUpToDate)
+			assert.Equal(t, guildID, meta.GetExternalName(cr))
+			assert.Equal(t, 7, cr.Status.AtProvider.InviteCount)
+			assert.Equal(t, 2, cr.Status.AtProvider.ManagedCount)
+			assert.Equal(t, tc.expectedViolating, cr.Status.AtProvider.ViolatingCodes)
+
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedViolating, deleted)
+			assert.Equal(t, len(tc.expectedViolating), cr.Status.AtProvider.DeletedCount)
+			assert.Empty(t, cr.Status.AtProvider.ViolatingCodes)
+		})
+	}
+}
+
+func TestDeleteKeepsInvites(t *testing.T) {
+	e := &external{discord: &MockInviteClient{}}
+	_, err := e.Delete(context.Background(), &invitev1alpha1.InvitePolicy{})
+	assert.NoError(t, err)
+}
+
+func TestObserveNeedsBotToDeleteUnmanaged(t *testing.T) {
+	yes := true
+	e := &external{
+		discord: &MockInviteClient{GetGuildInvitesFunc: func(context.Context, string) ([]discordclient.Invite, error) {
+			return nil, nil
+		}},
+		users: &MockUserClient{GetCurrentUserFunc: func(context.Context) (*discordclient.DiscordUser, error) {
+			return nil, errors.New("unauthorized")
+		}},
+		kube: func() client.Client {
+			s := runtime.NewScheme()
+			require.NoError(t, apis.AddToScheme(s))
+			return fake.NewClientBuilder().WithScheme(s).Build()
+		}(),
+		now: time.Now,
+	}
+	cr := &invitev1alpha1.InvitePolicy{Spec: invitev1alpha1.InvitePolicySpec{ForProvider: invitev1alpha1.InvitePolicyParameters{GuildID: "123", DeleteUnmanaged: &yes}}}
+
+	_, err := e.Observe(context.Background(), cr)
+	assert.EqualError(t, err, "failed to get bot user: unauthorized")
+}
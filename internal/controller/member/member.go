@@ -11,30 +11,45 @@ import (
 	"github.com/pkg/errors"
 	memberv1alpha1 "github.com/rossigee/provider-discord/apis/member/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+	"time"
 )
 
 const (
 	errNotMember = "managed resource is not a Member custom resource"
+
+	// maxTimeoutDuration is the longest timeout Discord accepts.
+	maxTimeoutDuration = 28 * 24 * time.Hour
+
+	// searchLimit is the number of members fetched when resolving a
+	// username. Search matches prefixes of usernames and nicknames, so
+	// several members may be returned for one username.
+	searchLimit = 100
 )
 
 // Setup adds a controller that reconciles Member managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(memberv1alpha1.MemberGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(memberv1alpha1.MemberGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube: mgr.GetClient(),
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(memberv1alpha1.MemberGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&memberv1alpha1.Member{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -67,13 +82,16 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	discordClient := discordclient.NewDiscordClient(*token)
 
-	return &external{discord: discordClient}, nil
+	return &external{discord: discordClient, permissions: discordClient}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	discord discordclient.MemberClient
+	// permissions resolves the bot's guild permissions for pre-flight
+	// checks; the checks are skipped when it is nil.
+	permissions discordclient.PermissionClient
 }
 
 func (e *external) Disconnect(_ context.Context) error {
@@ -86,14 +104,31 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotMember)
 	}
 
-	// Get external name (Discord User ID)
+	// Get external name (Discord User ID). Crossplane runtime defaults it to
+	// metadata.name, so only a snowflake identifies the user
 	userID := meta.GetExternalName(cr)
+	if !snowflake.Valid(userID) {
+		userID = ""
+	}
+	// The user ID is late initialized into the external name, so it must be
+	// persisted before the member is created or updated
+	lateInitialized := false
+	if userID == "" && (cr.Spec.ForProvider.UserID != "" || cr.Spec.ForProvider.Username != nil) {
+		id, err := e.resolveUserID(ctx, cr.Spec.ForProvider)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		meta.SetExternalName(cr, id)
+		userID = id
+		lateInitialized = true
+	}
 	if userID == "" {
 		// Check if we have an ID in status
 		if cr.Status.AtProvider.User != nil && cr.Status.AtProvider.User.ID != "" {
 			// Set external name from status
 			meta.SetExternalName(cr, cr.Status.AtProvider.User.ID)
 			userID = cr.Status.AtProvider.User.ID
+			lateInitialized = true
 		} else {
 			// No external resource exists
 			return managed.ExternalObservation{
@@ -137,6 +172,10 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.Pending = member.Pending
 	cr.Status.AtProvider.Permissions = member.Permissions
 	cr.Status.AtProvider.CommunicationDisabledUntil = member.CommunicationDisabledUntil
+	cr.Status.AtProvider.AvatarDecorationData = nil
+	if member.AvatarDecorationData != nil {
+		cr.Status.AtProvider.AvatarDecorationData = &member.AvatarDecorationData.Asset
+	}
 
 	// Check if update is needed - compare all modifiable fields
 	needsUpdate := (cr.Spec.ForProvider.Nick != nil && (member.Nick == nil || *cr.Spec.ForProvider.Nick != *member.Nick)) ||
@@ -166,8 +205,13 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if cr.Spec.ForProvider.Mute != nil && member.Mute != *cr.Spec.ForProvider.Mute {
 		needsUpdate = true
 	}
-	// Check CommunicationDisabledUntil
-	if cr.Spec.ForProvider.CommunicationDisabledUntil != nil {
+	// Check timeout - a duration-based timeout is renewed once less than half
+	// of it remains, otherwise compare the explicit timestamp
+	if cr.Spec.ForProvider.TimeoutDuration != nil {
+		if timeoutNeedsRenewal(member.CommunicationDisabledUntil, cr.Spec.ForProvider.TimeoutDuration.Duration, time.Now()) {
+			needsUpdate = true
+		}
+	} else if cr.Spec.ForProvider.CommunicationDisabledUntil != nil {
 		if member.CommunicationDisabledUntil == nil || *cr.Spec.ForProvider.CommunicationDisabledUntil != *member.CommunicationDisabledUntil {
 			needsUpdate = true
 		}
@@ -176,10 +220,45 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	return managed.ExternalObservation{
 		ResourceExists:          true,
 		ResourceUpToDate:        !needsUpdate,
-		ResourceLateInitialized: false,
+		ResourceLateInitialized: lateInitialized,
 	}, nil
 }
 
+// resolveUserID returns the ID of the user the member parameters identify,
+// searching the guild's members when only a username is given. Matching is
+// exact but case-insensitive, and a username matching more than one member
+// is refused rather than guessed.
+func (e *external) resolveUserID(ctx context.Context, p memberv1alpha1.MemberParameters) (string, error) {
+	if p.UserID != "" {
+		return p.UserID, nil
+	}
+
+	limit := searchLimit
+	members, err := e.discord.SearchGuildMembers(ctx, p.GuildID, &discordclient.SearchGuildMembersRequest{
+		Query: *p.Username,
+		Limit: &limit,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to search guild members for username %q", *p.Username)
+	}
+
+	var ids []string
+	for _, m := range members {
+		if m.User != nil && strings.EqualFold(m.User.Username, *p.Username) {
+			ids = append(ids, m.User.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", errors.Errorf("no member of guild %s has username %q", p.GuildID, *p.Username)
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Strings(ids)
+		return "", errors.Errorf("username %q matches %d members of guild %s (%s); set userId instead", *p.Username, len(ids), p.GuildID, strings.Join(ids, ", "))
+	}
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	_, ok := mg.(*memberv1alpha1.Member)
 	if !ok {
@@ -203,6 +282,12 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New("cannot update member without external name")
 	}
 
+	if e.permissions != nil {
+		if err := discordclient.RequirePermissions(ctx, e.permissions, cr.Spec.ForProvider.GuildID, requiredPermissions(cr.Spec.ForProvider)); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
 	// Build modify request
 	req := &discordclient.ModifyGuildMemberRequest{}
 
@@ -226,7 +311,13 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		req.ChannelID = cr.Spec.ForProvider.ChannelID
 	}
 
-	if cr.Spec.ForProvider.CommunicationDisabledUntil != nil {
+	if cr.Spec.ForProvider.TimeoutDuration != nil {
+		until, err := timeoutUntil(cr.Spec.ForProvider.TimeoutDuration.Duration, time.Now())
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		req.CommunicationDisabledUntil = &until
+	} else if cr.Spec.ForProvider.CommunicationDisabledUntil != nil {
 		req.CommunicationDisabledUntil = cr.Spec.ForProvider.CommunicationDisabledUntil
 	}
 
@@ -250,6 +341,14 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, nil
 	}
 
+	// A duration-based timeout is lifted rather than removing the member
+	if cr.Spec.ForProvider.TimeoutDuration != nil {
+		if err := e.discord.ClearGuildMemberTimeout(ctx, cr.Spec.ForProvider.GuildID, userID); err != nil {
+			return managed.ExternalDelete{}, errors.Wrap(err, "failed to clear member timeout")
+		}
+		return managed.ExternalDelete{}, nil
+	}
+
 	err := e.discord.RemoveGuildMember(ctx, cr.Spec.ForProvider.GuildID, userID)
 	if err != nil {
 		if err.Error() == "member not found" {
@@ -261,3 +360,50 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	return managed.ExternalDelete{}, nil
 }
+
+// requiredPermissions returns the guild permissions needed to apply the
+// fields set in the member parameters.
+func requiredPermissions(p memberv1alpha1.MemberParameters) int64 {
+	var required int64
+	if p.Nick != nil {
+		required |= discordclient.PermissionManageNicknames
+	}
+	if len(p.Roles) > 0 {
+		required |= discordclient.PermissionManageRoles
+	}
+	if p.Mute != nil {
+		required |= discordclient.PermissionMuteMembers
+	}
+	if p.Deaf != nil {
+		required |= discordclient.PermissionDeafenMembers
+	}
+	if p.ChannelID != nil {
+		required |= discordclient.PermissionMoveMembers
+	}
+	if p.TimeoutDuration != nil || p.CommunicationDisabledUntil != nil {
+		required |= discordclient.PermissionModerateMembers
+	}
+	return required
+}
+
+// timeoutUntil returns the RFC3339 timestamp at which a timeout of the given
+// duration starting now expires.
+func timeoutUntil(d time.Duration, now time.Time) (string, error) {
+	if d <= 0 || d > maxTimeoutDuration {
+		return "", errors.Errorf("timeoutDuration must be between 0 and %s, got %s", maxTimeoutDuration, d)
+	}
+	return now.Add(d).UTC().Format(time.RFC3339), nil
+}
+
+// timeoutNeedsRenewal reports whether the observed timeout has lapsed or has
+// less than half of the desired duration left.
+func timeoutNeedsRenewal(observed *string, d time.Duration, now time.Time) bool {
+	if observed == nil || *observed == "" {
+		return true
+	}
+	until, err := time.Parse(time.RFC3339, *observed)
+	if err != nil {
+		return true
+	}
+	return until.Sub(now) < d/2
+}
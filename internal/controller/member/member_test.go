@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package member
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	memberv1alpha1 "github.com/rossigee/provider-discord/apis/member/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MockMemberClient struct {
+	discordclient.MemberClient
+	GetGuildMemberFunc          func(ctx context.Context, guildID, userID string) (*discordclient.GuildMember, error)
+	SearchGuildMembersFunc      func(ctx context.Context, guildID string, req *discordclient.SearchGuildMembersRequest) ([]discordclient.GuildMember, error)
+	ClearGuildMemberTimeoutFunc func(ctx context.Context, guildID, userID string) error
+	RemoveGuildMemberFunc       func(ctx context.Context, guildID, userID string) error
+}
+
+func (m *MockMemberClient) GetGuildMember(ctx context.Context, guildID, userID string) (*discordclient.GuildMember, error) {
+	return m.GetGuildMemberFunc(ctx, guildID, userID)
+}
+
+func (m *MockMemberClient) SearchGuildMembers(ctx context.Context, guildID string, req *discordclient.SearchGuildMembersRequest) ([]discordclient.GuildMember, error) {
+	return m.SearchGuildMembersFunc(ctx, guildID, req)
+}
+
+func (m *MockMemberClient) ClearGuildMemberTimeout(ctx context.Context, guildID, userID string) error {
+	return m.ClearGuildMemberTimeoutFunc(ctx, guildID, userID)
+}
+
+func (m *MockMemberClient) RemoveGuildMember(ctx context.Context, guildID, userID string) error {
+	return m.RemoveGuildMemberFunc(ctx, guildID, userID)
+}
+
+func TestResolveUserID(t *testing.T) {
+	member := func(id, username string) discordclient.GuildMember {
+		return discordclient.GuildMember{User: &discordclient.DiscordUser{ID: id, Username: username}}
+	}
+	username := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		params      memberv1alpha1.MemberParameters
+		members     []discordclient.GuildMember
+		expectedID  string
+		expectedErr string
+	}{
+		{
+			name:       "user ID takes precedence",
+			params:     memberv1alpha1.MemberParameters{UserID: "111111111111111111", Username: username("alice")},
+			expectedID: "111111111111111111",
+		},
+		{
+			name:       "exact username among prefix matches",
+			params:     memberv1alpha1.MemberParameters{Username: username("Alice")},
+			members:    []discordclient.GuildMember{member("1", "alice"), member("2", "alice_alt")},
+			expectedID: "1",
+		},
+		{
+			name:        "no match",
+			params:      memberv1alpha1.MemberParameters{Username: username("alice")},
+			members:     []discordclient.GuildMember{member("2", "alice_alt")},
+			expectedErr: `no member of guild 123 has username "alice"`,
+		},
+		{
+			name:        "ambiguous",
+			params:      memberv1alpha1.MemberParameters{Username: username("alice")},
+			members:     []discordclient.GuildMember{member("2", "alice"), member("1", "ALICE")},
+			expectedErr: `username "alice" matches 2 members of guild 123 (1, 2); set userId instead`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &external{discord: &MockMemberClient{
+				SearchGuildMembersFunc: func(ctx context.Context, guildID string, req *discordclient.SearchGuildMembersRequest) ([]discordclient.GuildMember, error) {
+					assert.Equal(t, "123", guildID)
+					assert.Equal(t, *tc.params.Username, req.Query)
+					return tc.members, nil
+				},
+			}}
+			tc.params.GuildID = "123"
+
+			id, err := e.resolveUserID(context.Background(), tc.params)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func TestObserveLateInitializesExternalName(t *testing.T) {
+	username := "alice"
+	tests := []struct {
+		name                    string
+		externalName            string
+		status                  *memberv1alpha1.DiscordUser
+		expectedLateInitialized bool
+	}{
+		{
+			name:                    "resolved from username",
+			expectedLateInitialized: true,
+		},
+		{
+			name:                    "recovered from status",
+			externalName:            "alice-member",
+			status:                  &memberv1alpha1.DiscordUser{ID: "111111111111111111"},
+			expectedLateInitialized: true,
+		},
+		{
+			name:         "already set",
+			externalName: "111111111111111111",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &external{discord: &MockMemberClient{
+				SearchGuildMembersFunc: func(ctx context.Context, guildID string, req *discordclient.SearchGuildMembersRequest) ([]discordclient.GuildMember, error) {
+					return []discordclient.GuildMember{{User: &discordclient.DiscordUser{ID: "111111111111111111", Username: username}}}, nil
+				},
+				GetGuildMemberFunc: func(ctx context.Context, guildID, userID string) (*discordclient.GuildMember, error) {
+					assert.Equal(t, "111111111111111111", userID)
+					return &discordclient.GuildMember{User: &discordclient.DiscordUser{ID: userID, Username: username}}, nil
+				},
+			}}
+			cr := &memberv1alpha1.Member{}
+			cr.Spec.ForProvider.GuildID = "123"
+			if tc.status == nil {
+				cr.Spec.ForProvider.Username = &username
+			}
+			cr.Status.AtProvider.User = tc.status
+			if tc.externalName != "" {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceExists)
+			assert.Equal(t, tc.expectedLateInitialized, obs.ResourceLateInitialized)
+			assert.Equal(t, "111111111111111111", meta.GetExternalName(cr))
+		})
+	}
+}
+
+func TestTimeoutUntil(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name        string
+		duration    time.Duration
+		expected    string
+		expectError bool
+	}{
+		{name: "one day", duration: 24 * time.Hour, expected: "2025-01-02T11:00:00Z"},
+		{name: "longest timeout", duration: maxTimeoutDuration, expected: "2025-01-29T11:00:00Z"},
+		{name: "zero", duration: 0, expectError: true},
+		{name: "negative", duration: -time.Hour, expectError: true},
+		{name: "too long", duration: maxTimeoutDuration + time.Second, expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			until, err := timeoutUntil(tc.duration, now)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, until)
+		})
+	}
+}
+
+func TestTimeoutNeedsRenewal(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *string {
+		s := now.Add(d).Format(time.RFC3339)
+		return &s
+	}
+	invalid := "tomorrow"
+	empty := ""
+
+	tests := []struct {
+		name     string
+		observed *string
+		expected bool
+	}{
+		{name: "no timeout", observed: nil, expected: true},
+		{name: "empty timeout", observed: &empty, expected: true},
+		{name: "unparseable timeout", observed: &invalid, expected: true},
+		{name: "lapsed", observed: at(-time.Hour), expected: true},
+		{name: "less than half left", observed: at(11 * time.Hour), expected: true},
+		{name: "half left", observed: at(12 * time.Hour), expected: false},
+		{name: "fresh", observed: at(24 * time.Hour), expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, timeoutNeedsRenewal(tc.observed, 24*time.Hour, now))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name            string
+		timeout         *metav1.Duration
+		clearErr        error
+		expectedCleared bool
+		expectedRemoved bool
+		expectedErr     string
+	}{
+		{
+			name:            "timeout is lifted",
+			timeout:         &metav1.Duration{Duration: time.Hour},
+			expectedCleared: true,
+		},
+		{
+			name:            "lifting the timeout fails",
+			timeout:         &metav1.Duration{Duration: time.Hour},
+			clearErr:        errors.New("boom"),
+			expectedCleared: true,
+			expectedErr:     "failed to clear member timeout: boom",
+		},
+		{
+			name:            "member is removed",
+			expectedRemoved: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var cleared, removed bool
+			e := &external{discord: &MockMemberClient{
+				ClearGuildMemberTimeoutFunc: func(ctx context.Context, guildID, userID string) error {
+					assert.Equal(t, "123", guildID)
+					assert.Equal(t, "111111111111111111", userID)
+					cleared = true
+					return tc.clearErr
+				},
+				RemoveGuildMemberFunc: func(ctx context.Context, guildID, userID string) error {
+					removed = true
+					return nil
+				},
+			}}
+			cr := &memberv1alpha1.Member{}
+			cr.Spec.ForProvider.GuildID = "123"
+			cr.Spec.ForProvider.TimeoutDuration = tc.timeout
+			meta.SetExternalName(cr, "111111111111111111")
+
+			_, err := e.Delete(context.Background(), cr)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCleared, cleared)
+			assert.Equal(t, tc.expectedRemoved, removed)
+		})
+	}
+}
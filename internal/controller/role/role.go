@@ -2,6 +2,7 @@ package role
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
@@ -13,30 +14,40 @@ import (
 	"github.com/pkg/errors"
 	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	errNotRole = "managed resource is not a Role custom resource"
+	errNotRole          = "managed resource is not a Role custom resource"
+	errManagedRole      = "cannot modify a role managed by a bot or integration"
+	errManagedRoleDrift = "role %s is managed by a bot or integration and Discord does not allow it to be modified; update the spec to match it"
+	errCreateEveryone   = "the @everyone role of guild %s was not found; it cannot be created"
+	errDeleteInPrompt   = "cannot delete a role offered by an onboarding prompt. Remove it from the prompt and set spec.forProvider.allowDelete=true to confirm"
+	errAdoptManagedRole = "the role named %q (ID %s) is managed by a bot or integration and cannot be adopted; rename the Role or set conflictPolicy to CreateDuplicate"
+	errAdoptOwnedRole   = "the role named %q (ID %s) is already managed by Role %s and cannot be adopted; rename the Role or set conflictPolicy to CreateDuplicate"
 )
 
 // Setup adds a controller that reconciles Role managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(rolev1alpha1.RoleGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(rolev1alpha1.RoleGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube: mgr.GetClient(),
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(rolev1alpha1.RoleGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&rolev1alpha1.Role{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -69,13 +80,19 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	discordClient := discordclient.NewDiscordClient(*token)
 
-	return &external{discord: discordClient}, nil
+	return &external{discord: discordclient.NewSnapshotClient(discordClient), permissions: discordClient, kube: c.kube}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	discord discordclient.RoleClient
+	// permissions resolves the bot's guild permissions for pre-flight
+	// checks; the checks are skipped when it is nil.
+	permissions discordclient.PermissionClient
+	// kube lists the other Roles, so that a role one of them manages is not
+	// adopted; the check is skipped when it is nil.
+	kube client.Client
 }
 
 func (e *external) Disconnect(_ context.Context) error {
@@ -88,6 +105,11 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRole)
 	}
 
+	// The @everyone role always exists and shares the guild's ID
+	if isEveryone(cr) && meta.GetExternalName(cr) != cr.Spec.ForProvider.GuildID {
+		meta.SetExternalName(cr, cr.Spec.ForProvider.GuildID)
+	}
+
 	// Get external name (Discord Role ID)
 	roleID := meta.GetExternalName(cr)
 	if roleID == "" {
@@ -108,45 +130,156 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	role, err := e.discord.GetRole(ctx, cr.Spec.ForProvider.GuildID, roleID)
 	if err != nil {
 		if err.Error() == "role not found" {
-			return managed.ExternalObservation{
-				ResourceExists: false,
-			}, nil
+			return observeDeleted(cr), nil
 		}
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get role")
 	}
 
 	// Update status
 	cr.Status.AtProvider.ID = role.ID
+	cr.Status.AtProvider.CreatedAt = discordclient.CreatedAt(role.ID)
 	cr.Status.AtProvider.Managed = role.Managed
+	cr.Status.AtProvider.Flags = discordclient.RoleFlagNames(role.Flags)
 
-	// Check if update is needed
-	needsUpdate := role.Name != cr.Spec.ForProvider.Name ||
-		(cr.Spec.ForProvider.Color != nil && role.Color != *cr.Spec.ForProvider.Color)
-	if cr.Spec.ForProvider.Hoist != nil && role.Hoist != *cr.Spec.ForProvider.Hoist {
-		needsUpdate = true
-	}
-	if cr.Spec.ForProvider.Mentionable != nil && role.Mentionable != *cr.Spec.ForProvider.Mentionable {
-		needsUpdate = true
-	}
-	if cr.Spec.ForProvider.Permissions != nil && role.Permissions != *cr.Spec.ForProvider.Permissions {
-		needsUpdate = true
+	if cr.Spec.ForProvider.TrackMemberCount != nil && *cr.Spec.ForProvider.TrackMemberCount {
+		count, err := e.discord.CountRoleMembers(ctx, cr.Spec.ForProvider.GuildID, role.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, "failed to count role members")
+		}
+		cr.Status.AtProvider.MemberCount = &count
+	} else {
+		cr.Status.AtProvider.MemberCount = nil
 	}
-	if cr.Spec.ForProvider.Position != nil && role.Position != *cr.Spec.ForProvider.Position {
-		needsUpdate = true
+
+	changes := roleChanges(cr, role)
+	needsUpdate := len(changes) > 0
+
+	// Discord refuses changes to roles owned by bots and integrations, so
+	// report the drift instead of retrying an update that cannot succeed
+	switch {
+	case role.Managed && needsUpdate:
+		cr.SetConditions(conditions.ManagedRoleDrift(fmt.Sprintf(errManagedRoleDrift, role.ID)))
+		needsUpdate, changes = false, nil
+	case cr.GetCondition(conditions.TypeManagedRole).Status == corev1.ConditionTrue:
+		cr.SetConditions(conditions.ManagedRoleInSync())
 	}
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: !needsUpdate,
+		ResourceExists:    true,
+		ResourceUpToDate:  !needsUpdate,
+		Diff:              conditions.Diff(changes),
+		ConnectionDetails: connectionDetails(cr, role.ID),
 	}, nil
 }
 
+// roleChanges describes the role settings an update would change, from their
+// observed to their desired values. Only the permissions of @everyone can be
+// changed.
+func roleChanges(cr *rolev1alpha1.Role, role *discordclient.Role) []string {
+	p := cr.Spec.ForProvider
+	var changes []string
+	if p.Permissions != nil && role.Permissions != *p.Permissions {
+		changes = append(changes, conditions.Change("permissions", role.Permissions, *p.Permissions))
+	}
+	if isEveryone(cr) {
+		return changes
+	}
+	if role.Name != p.Name {
+		changes = append(changes, conditions.Change("name", role.Name, p.Name))
+	}
+	if p.Color != nil && role.Color != *p.Color {
+		changes = append(changes, conditions.Change("color", role.Color, *p.Color))
+	}
+	if p.Hoist != nil && role.Hoist != *p.Hoist {
+		changes = append(changes, conditions.Change("hoist", role.Hoist, *p.Hoist))
+	}
+	if p.Mentionable != nil && role.Mentionable != *p.Mentionable {
+		changes = append(changes, conditions.Change("mentionable", role.Mentionable, *p.Mentionable))
+	}
+	if p.Position != nil && role.Position != *p.Position {
+		changes = append(changes, conditions.Change("position", role.Position, *p.Position))
+	}
+	return changes
+}
+
+// connectionDetails publishes the role's ID and the syntax that mentions it
+// in messages, for bots and notifiers that ping the role.
+func connectionDetails(cr *rolev1alpha1.Role, id string) managed.ConnectionDetails {
+	mention := discordclient.RoleMention(id)
+	if isEveryone(cr) {
+		mention = "@everyone"
+	}
+	return managed.ConnectionDetails{
+		"roleId":      []byte(id),
+		"roleMention": []byte(mention),
+	}
+}
+
+// observeDeleted handles a role that was deleted in Discord according to its
+// recreate policy. Roles that were never observed have not been created yet.
+func observeDeleted(cr *rolev1alpha1.Role) managed.ExternalObservation {
+	if cr.Status.AtProvider.ID == "" {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+	if p := cr.Spec.ForProvider.RecreatePolicy; p != nil && *p == rolev1alpha1.RecreatePolicyMarkUnavailable {
+		cr.SetConditions(conditions.DeletedExternally(fmt.Sprintf("role %s was deleted in Discord; clear the external name to recreate it", meta.GetExternalName(cr))))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	}
+	// Forget the deleted role so Crossplane creates a new one
+	meta.SetExternalName(cr, "")
+	cr.Status.AtProvider.ID = ""
+	return managed.ExternalObservation{ResourceExists: false}
+}
+
+// isEveryone reports whether cr manages its guild's @everyone role.
+func isEveryone(cr *rolev1alpha1.Role) bool {
+	return cr.Spec.ForProvider.IsEveryone != nil && *cr.Spec.ForProvider.IsEveryone
+}
+
+// inPrompt reports whether the role was last observed with the IN_PROMPT flag.
+func inPrompt(cr *rolev1alpha1.Role) bool {
+	for _, f := range cr.Status.AtProvider.Flags {
+		if f == "IN_PROMPT" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPermissions verifies the bot holds MANAGE_ROLES in the role's guild
+// before calling Discord.
+func (e *external) checkPermissions(ctx context.Context, cr *rolev1alpha1.Role) error {
+	if e.permissions == nil {
+		return nil
+	}
+	return discordclient.RequirePermissions(ctx, e.permissions, cr.Spec.ForProvider.GuildID, discordclient.PermissionManageRoles)
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*rolev1alpha1.Role)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotRole)
 	}
 
+	if isEveryone(cr) {
+		return managed.ExternalCreation{}, errors.Errorf(errCreateEveryone, cr.Spec.ForProvider.GuildID)
+	}
+
+	if err := e.checkPermissions(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	adopted, err := e.resolveConflict(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if adopted {
+		return managed.ExternalCreation{}, nil
+	}
+	if err := discordclient.CheckRoleCount(ctx, e.discord, cr.Spec.ForProvider.GuildID, 1); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	// Create role request
 	req := discordclient.CreateRoleRequest{
 		Name:        cr.Spec.ForProvider.Name,
@@ -165,6 +298,7 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	// Set external name to the Discord role ID
 	meta.SetExternalName(cr, role.ID)
 	cr.Status.AtProvider.ID = role.ID
+	cr.Status.AtProvider.CreatedAt = discordclient.CreatedAt(role.ID)
 	cr.Status.AtProvider.Managed = role.Managed
 
 	// Handle position separately if specified
@@ -186,6 +320,88 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalCreation{}, nil
 }
 
+// resolveConflict applies the role's conflict policy to any existing role with
+// the same name, reporting whether an existing role was adopted. Roles owned
+// by a bot or integration, or managed by another Role, are never adopted.
+func (e *external) resolveConflict(ctx context.Context, cr *rolev1alpha1.Role) (bool, error) {
+	policy := rolev1alpha1.ConflictPolicyAdopt
+	if cr.Spec.ForProvider.ConflictPolicy != nil {
+		policy = *cr.Spec.ForProvider.ConflictPolicy
+	}
+	if policy == rolev1alpha1.ConflictPolicyCreateDuplicate {
+		return false, nil
+	}
+
+	roles, err := e.discord.ListRoles(ctx, cr.Spec.ForProvider.GuildID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list roles")
+	}
+
+	var refused string
+	for _, role := range roles {
+		if role.Name != cr.Spec.ForProvider.Name {
+			continue
+		}
+		if policy == rolev1alpha1.ConflictPolicyError {
+			return false, errors.Errorf("a role named %q already exists in guild %s (ID %s); set conflictPolicy to Adopt or CreateDuplicate", role.Name, cr.Spec.ForProvider.GuildID, role.ID)
+		}
+		if role.Managed {
+			refused = fmt.Sprintf(errAdoptManagedRole, role.Name, role.ID)
+			continue
+		}
+		owner, err := e.ownerOf(ctx, cr, role.ID)
+		if err != nil {
+			return false, err
+		}
+		if owner != "" {
+			refused = fmt.Sprintf(errAdoptOwnedRole, role.Name, role.ID, owner)
+			continue
+		}
+		meta.SetExternalName(cr, role.ID)
+		cr.Status.AtProvider.ID = role.ID
+		cr.Status.AtProvider.CreatedAt = discordclient.CreatedAt(role.ID)
+		cr.Status.AtProvider.Managed = role.Managed
+		cr.Status.AtProvider.Flags = discordclient.RoleFlagNames(role.Flags)
+		noAdoptionConflict(cr)
+		return true, nil
+	}
+
+	if refused != "" {
+		cr.SetConditions(conditions.AdoptionRefused(refused).WithObservedGeneration(cr.GetGeneration()))
+		return false, errors.New(refused)
+	}
+	noAdoptionConflict(cr)
+	return false, nil
+}
+
+// noAdoptionConflict clears the AdoptionRefused condition, if it was set.
+func noAdoptionConflict(cr *rolev1alpha1.Role) {
+	if cr.GetCondition(conditions.TypeAdoptionRefused).Status == corev1.ConditionTrue {
+		cr.SetConditions(conditions.NoAdoptionConflict().WithObservedGeneration(cr.GetGeneration()))
+	}
+}
+
+// ownerOf returns the namespace and name of another Role that manages the
+// role with the supplied ID in cr's guild, or "" if there is none.
+func (e *external) ownerOf(ctx context.Context, cr *rolev1alpha1.Role, roleID string) (string, error) {
+	if e.kube == nil {
+		return "", nil
+	}
+	l := &rolev1alpha1.RoleList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return "", errors.Wrap(err, "cannot list roles")
+	}
+	for _, r := range l.Items {
+		if r.GetNamespace() == cr.GetNamespace() && r.GetName() == cr.GetName() {
+			continue
+		}
+		if r.Spec.ForProvider.GuildID == cr.Spec.ForProvider.GuildID && meta.GetExternalName(&r) == roleID {
+			return r.GetNamespace() + "/" + r.GetName(), nil
+		}
+	}
+	return "", nil
+}
+
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mg.(*rolev1alpha1.Role)
 	if !ok {
@@ -197,6 +413,14 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New("external name (role ID) not set")
 	}
 
+	if cr.Status.AtProvider.Managed {
+		return managed.ExternalUpdate{}, errors.New(errManagedRole)
+	}
+
+	if err := e.checkPermissions(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	// Build update request
 	req := discordclient.ModifyRoleRequest{
 		Name:        &cr.Spec.ForProvider.Name,
@@ -206,6 +430,9 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		Position:    cr.Spec.ForProvider.Position,
 		Mentionable: cr.Spec.ForProvider.Mentionable,
 	}
+	if isEveryone(cr) {
+		req = discordclient.ModifyRoleRequest{Permissions: cr.Spec.ForProvider.Permissions}
+	}
 
 	// Update the role
 	_, err := e.discord.ModifyRole(ctx, cr.Spec.ForProvider.GuildID, roleID, req)
@@ -228,11 +455,31 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, nil
 	}
 
+	// The @everyone role exists as long as its guild does
+	if isEveryone(cr) {
+		return managed.ExternalDelete{}, nil
+	}
+
+	// Roles owned by bots and integrations are removed with them; Discord
+	// refuses to delete them, so leave them in place
+	if cr.Status.AtProvider.Managed {
+		logging.NewLogrLogger(ctrl.Log.WithName("role-controller")).WithValues(
+			"roleID", roleID,
+			"guildID", cr.Spec.ForProvider.GuildID,
+		).Info("Not deleting role managed by a bot or integration")
+		return managed.ExternalDelete{}, nil
+	}
+
+	// Block deletion of roles onboarding prompts offer unless overridden
+	if inPrompt(cr) && (cr.Spec.ForProvider.AllowDelete == nil || !*cr.Spec.ForProvider.AllowDelete) {
+		return managed.ExternalDelete{}, errors.New(errDeleteInPrompt)
+	}
+
 	// Delete the role
 	err := e.discord.DeleteRole(ctx, cr.Spec.ForProvider.GuildID, roleID)
 	if err != nil {
 		// If role is already gone, don't error
-		if err.Error() == "role not found" {
+		if err.Error() == "role not found" || discordclient.IsNotFound(err) {
 			return managed.ExternalDelete{}, nil
 		}
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete role")
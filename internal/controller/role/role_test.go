@@ -19,22 +19,36 @@ package role
 import (
 	"context"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/apis"
 	guildv1alpha1 "github.com/rossigee/provider-discord/apis/guild/v1alpha1"
 	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	apisv1alpha1 "github.com/rossigee/provider-discord/apis/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
 	"testing"
+	"time"
 )
 
 // MockDiscordClient implements a mock Discord client for testing
 type MockDiscordClient struct {
 	CreateRoleFunc func(ctx context.Context, guildID string, req discordclient.CreateRoleRequest) (*discordclient.Role, error)
 	GetRoleFunc    func(ctx context.Context, guildID, roleID string) (*discordclient.Role, error)
+	ListRolesFunc  func(ctx context.Context, guildID string) ([]discordclient.Role, error)
 	ModifyRoleFunc func(ctx context.Context, guildID, roleID string, req discordclient.ModifyRoleRequest) (*discordclient.Role, error)
 	DeleteRoleFunc func(ctx context.Context, guildID, roleID string) error
+
+	CountRoleMembersFunc func(ctx context.Context, guildID, roleID string) (int, error)
 }
 
 // Ensure MockDiscordClient implements RoleClient interface
@@ -54,6 +68,13 @@ func (m *MockDiscordClient) GetRole(ctx context.Context, guildID, roleID string)
 	return nil, errors.New("not implemented")
 }
 
+func (m *MockDiscordClient) ListRoles(ctx context.Context, guildID string) ([]discordclient.Role, error) {
+	if m.ListRolesFunc != nil {
+		return m.ListRolesFunc(ctx, guildID)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockDiscordClient) ModifyRole(ctx context.Context, guildID, roleID string, req discordclient.ModifyRoleRequest) (*discordclient.Role, error) {
 	if m.ModifyRoleFunc != nil {
 		return m.ModifyRoleFunc(ctx, guildID, roleID, req)
@@ -68,6 +89,13 @@ func (m *MockDiscordClient) DeleteRole(ctx context.Context, guildID, roleID stri
 	return errors.New("not implemented")
 }
 
+func (m *MockDiscordClient) CountRoleMembers(ctx context.Context, guildID, roleID string) (int, error) {
+	if m.CountRoleMembersFunc != nil {
+		return m.CountRoleMembersFunc(ctx, guildID, roleID)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func TestObserve(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789"
@@ -115,6 +143,38 @@ func TestObserve(t *testing.T) {
 			expectedUpToDate: true,
 			expectError:      false,
 		},
+		{
+			name: "fields the spec leaves unset changed in Discord",
+			role: &rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: roleID,
+					},
+				},
+				Spec: rolev1alpha1.RoleSpec{
+					ForProvider: rolev1alpha1.RoleParameters{
+						Name:    "Test Role",
+						GuildID: guildID,
+					},
+				},
+			},
+			mockSetup: func(m *MockDiscordClient) {
+				m.GetRoleFunc = func(ctx context.Context, gID, rID string) (*discordclient.Role, error) {
+					return &discordclient.Role{
+						ID:          roleID,
+						Name:        "Test Role",
+						Color:       255,
+						Hoist:       true,
+						Mentionable: true,
+						Permissions: "8",
+						Position:    4,
+					}, nil
+				}
+			},
+			expectedExists:   true,
+			expectedUpToDate: true,
+			expectError:      false,
+		},
 		{
 			name: "role exists but needs update",
 			role: &rolev1alpha1.Role{
@@ -208,6 +268,247 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveDeleted(t *testing.T) {
+	ctx := context.Background()
+	roleID := "987654321"
+	markUnavailable := rolev1alpha1.RecreatePolicyMarkUnavailable
+
+	tests := []struct {
+		name             string
+		policy           *rolev1alpha1.RecreatePolicy
+		observedID       string
+		expectedExists   bool
+		expectedExternal string
+		expectedReason   xpv1.ConditionReason
+	}{
+		{
+			name:             "recreate by default",
+			observedID:       roleID,
+			expectedExists:   false,
+			expectedExternal: "",
+		},
+		{
+			name:             "mark unavailable",
+			policy:           &markUnavailable,
+			observedID:       roleID,
+			expectedExists:   true,
+			expectedExternal: roleID,
+			expectedReason:   conditions.ReasonDeletedExternally,
+		},
+		{
+			name:             "never created",
+			policy:           &markUnavailable,
+			expectedExists:   false,
+			expectedExternal: roleID,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: roleID,
+					},
+				},
+				Spec: rolev1alpha1.RoleSpec{
+					ForProvider: rolev1alpha1.RoleParameters{
+						Name:           "Test Role",
+						GuildID:        "123456789",
+						RecreatePolicy: tc.policy,
+					},
+				},
+				Status: rolev1alpha1.RoleStatus{
+					AtProvider: rolev1alpha1.RoleObservation{ID: tc.observedID},
+				},
+			}
+			e := &external{discord: &MockDiscordClient{
+				GetRoleFunc: func(ctx context.Context, gID, rID string) (*discordclient.Role, error) {
+					return nil, errors.New("role not found")
+				},
+			}}
+
+			obs, err := e.Observe(ctx, cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExists, obs.ResourceExists)
+			assert.Equal(t, tc.expectedExternal, meta.GetExternalName(cr))
+			assert.Equal(t, tc.expectedReason, cr.GetCondition(xpv1.TypeReady).Reason)
+		})
+	}
+}
+
+func TestObserveMemberCount(t *testing.T) {
+	ctx := context.Background()
+	guildID := "123456789"
+	roleID := "987654321"
+
+	cr := &rolev1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: roleID,
+			},
+		},
+		Spec: rolev1alpha1.RoleSpec{
+			ForProvider: rolev1alpha1.RoleParameters{
+				Name:             "Test Role",
+				GuildID:          guildID,
+				TrackMemberCount: boolPtr(true),
+			},
+		},
+	}
+
+	mockClient := &MockDiscordClient{
+		GetRoleFunc: func(ctx context.Context, gID, rID string) (*discordclient.Role, error) {
+			return &discordclient.Role{ID: roleID, Name: "Test Role"}, nil
+		},
+		CountRoleMembersFunc: func(ctx context.Context, gID, rID string) (int, error) {
+			assert.Equal(t, guildID, gID)
+			assert.Equal(t, roleID, rID)
+			return 42, nil
+		},
+	}
+
+	e := &external{discord: mockClient}
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	require.NotNil(t, cr.Status.AtProvider.MemberCount)
+	assert.Equal(t, 42, *cr.Status.AtProvider.MemberCount)
+
+	// Disabling tracking clears the count without calling the API
+	cr.Spec.ForProvider.TrackMemberCount = boolPtr(false)
+	mockClient.CountRoleMembersFunc = nil
+	_, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Nil(t, cr.Status.AtProvider.MemberCount)
+}
+
+func TestObserveCreatedAtAndFlags(t *testing.T) {
+	roleID := "175928847299117063"
+	cr := &rolev1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: roleID,
+			},
+		},
+		Spec: rolev1alpha1.RoleSpec{
+			ForProvider: rolev1alpha1.RoleParameters{
+				Name:    "Test Role",
+				GuildID: "123456789",
+			},
+		},
+	}
+
+	e := &external{discord: &MockDiscordClient{
+		GetRoleFunc: func(ctx context.Context, gID, rID string) (*discordclient.Role, error) {
+			return &discordclient.Role{ID: roleID, Name: "Test Role", Flags: discordclient.RoleFlagInPrompt}, nil
+		},
+	}}
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"IN_PROMPT"}, cr.Status.AtProvider.Flags)
+	assert.Equal(t, roleID, string(obs.ConnectionDetails["roleId"]))
+	assert.Equal(t, "<@&"+roleID+">", string(obs.ConnectionDetails["roleMention"]))
+	require.NotNil(t, cr.Status.AtProvider.CreatedAt)
+	assert.Equal(t, time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC), cr.Status.AtProvider.CreatedAt.UTC())
+}
+
+func TestObserveManagedRole(t *testing.T) {
+	roleID := "987654321"
+	cr := &rolev1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: roleID,
+			},
+		},
+		Spec: rolev1alpha1.RoleSpec{
+			ForProvider: rolev1alpha1.RoleParameters{
+				Name:    "Renamed Bot",
+				GuildID: "123456789",
+			},
+		},
+	}
+
+	name := "Bot"
+	e := &external{discord: &MockDiscordClient{
+		GetRoleFunc: func(ctx context.Context, gID, rID string) (*discordclient.Role, error) {
+			return &discordclient.Role{ID: roleID, Name: name, Managed: true}, nil
+		},
+	}}
+
+	// Drift on a managed role is reported rather than updated
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(conditions.TypeManagedRole).Status)
+	assert.Equal(t, conditions.ReasonManagedRoleDrift, cr.GetCondition(conditions.TypeManagedRole).Reason)
+
+	_, err = e.Update(context.Background(), cr)
+	assert.Error(t, err)
+
+	// The condition clears once the spec matches the role
+	name = "Renamed Bot"
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(conditions.TypeManagedRole).Status)
+}
+
+func TestEveryoneRole(t *testing.T) {
+	ctx := context.Background()
+	guildID := "123456789"
+	perms := "1024"
+
+	cr := &rolev1alpha1.Role{
+		Spec: rolev1alpha1.RoleSpec{
+			ForProvider: rolev1alpha1.RoleParameters{
+				Name:        "@everyone",
+				GuildID:     guildID,
+				IsEveryone:  boolPtr(true),
+				Permissions: &perms,
+				Hoist:       boolPtr(true),
+			},
+		},
+	}
+
+	var sent *discordclient.ModifyRoleRequest
+	e := &external{discord: &MockDiscordClient{
+		GetRoleFunc: func(ctx context.Context, gID, rID string) (*discordclient.Role, error) {
+			assert.Equal(t, guildID, rID)
+			return &discordclient.Role{ID: guildID, Name: "@everyone", Permissions: "0"}, nil
+		},
+		ModifyRoleFunc: func(ctx context.Context, gID, rID string, req discordclient.ModifyRoleRequest) (*discordclient.Role, error) {
+			assert.Equal(t, guildID, rID)
+			sent = &req
+			return &discordclient.Role{ID: guildID, Name: "@everyone", Permissions: *req.Permissions}, nil
+		},
+		DeleteRoleFunc: func(ctx context.Context, gID, rID string) error {
+			t.Error("@everyone must not be deleted")
+			return nil
+		},
+	}}
+
+	// The role resolves to the guild ID without being created
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Equal(t, guildID, meta.GetExternalName(cr))
+	assert.Equal(t, "@everyone", string(obs.ConnectionDetails["roleMention"]))
+
+	// Only permissions are sent
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	require.NotNil(t, sent)
+	assert.Equal(t, discordclient.ModifyRoleRequest{Permissions: &perms}, *sent)
+
+	_, err = e.Create(ctx, cr)
+	assert.Error(t, err)
+
+	_, err = e.Delete(ctx, cr)
+	assert.NoError(t, err)
+}
+
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789"
@@ -227,6 +528,9 @@ func TestCreate(t *testing.T) {
 	}
 
 	mockClient := &MockDiscordClient{
+		ListRolesFunc: func(ctx context.Context, gID string) ([]discordclient.Role, error) {
+			return []discordclient.Role{{ID: guildID, Name: "@everyone"}}, nil
+		},
 		CreateRoleFunc: func(ctx context.Context, gID string, req discordclient.CreateRoleRequest) (*discordclient.Role, error) {
 			assert.Equal(t, guildID, gID)
 			assert.Equal(t, "Test Role", req.Name)
@@ -256,6 +560,117 @@ func TestCreate(t *testing.T) {
 	assert.Equal(t, roleID, role.Status.AtProvider.ID)
 }
 
+func TestCreateConflictPolicy(t *testing.T) {
+	ctx := context.Background()
+	guildID := "123456789"
+	existingID := "111111111"
+	createdID := "222222222"
+
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	other := &rolev1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "moderators",
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: existingID},
+		},
+		Spec: rolev1alpha1.RoleSpec{ForProvider: rolev1alpha1.RoleParameters{Name: "Moderators", GuildID: guildID}},
+	}
+
+	tests := []struct {
+		name            string
+		policy          *rolev1alpha1.ConflictPolicy
+		roles           []discordclient.Role
+		owners          []*rolev1alpha1.Role
+		expectedError   string
+		expectedID      string
+		expectedCreate  bool
+		expectedRefused bool
+	}{
+		{
+			name:       "adopt by default",
+			expectedID: existingID,
+		},
+		{
+			name:            "managed role is not adopted",
+			roles:           []discordclient.Role{{ID: existingID, Name: "Moderators", Managed: true}},
+			expectedError:   "is managed by a bot or integration and cannot be adopted",
+			expectedRefused: true,
+		},
+		{
+			name:            "role managed by another Role is not adopted",
+			owners:          []*rolev1alpha1.Role{other},
+			expectedError:   "is already managed by Role default/moderators",
+			expectedRefused: true,
+		},
+		{
+			name:       "adopt the role no one else manages",
+			roles:      []discordclient.Role{{ID: "333333333", Name: "Moderators", Managed: true}, {ID: existingID, Name: "Moderators"}},
+			expectedID: existingID,
+		},
+		{
+			name:          "error",
+			policy:        conflictPolicyPtr(rolev1alpha1.ConflictPolicyError),
+			expectedError: `a role named "Moderators" already exists`,
+		},
+		{
+			name:           "create duplicate",
+			policy:         conflictPolicyPtr(rolev1alpha1.ConflictPolicyCreateDuplicate),
+			expectedID:     createdID,
+			expectedCreate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mods"},
+				Spec: rolev1alpha1.RoleSpec{
+					ForProvider: rolev1alpha1.RoleParameters{
+						Name:           "Moderators",
+						GuildID:        guildID,
+						ConflictPolicy: tc.policy,
+					},
+				},
+			}
+			roles := tc.roles
+			if roles == nil {
+				roles = []discordclient.Role{{ID: existingID, Name: "Moderators"}}
+			}
+			b := fake.NewClientBuilder().WithScheme(s).WithObjects(cr.DeepCopy())
+			for _, o := range tc.owners {
+				b = b.WithObjects(o.DeepCopy())
+			}
+
+			created := false
+			e := &external{kube: b.Build(), discord: &MockDiscordClient{
+				ListRolesFunc: func(ctx context.Context, gID string) ([]discordclient.Role, error) {
+					return roles, nil
+				},
+				CreateRoleFunc: func(ctx context.Context, gID string, req discordclient.CreateRoleRequest) (*discordclient.Role, error) {
+					created = true
+					return &discordclient.Role{ID: createdID, Name: req.Name}, nil
+				},
+			}}
+
+			_, err := e.Create(ctx, cr)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Empty(t, meta.GetExternalName(cr))
+				assert.False(t, created)
+				if tc.expectedRefused {
+					assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(conditions.TypeAdoptionRefused).Status)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCreate, created)
+			assert.Equal(t, tc.expectedID, meta.GetExternalName(cr))
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.Background()
 	guildID := "123456789"
@@ -369,6 +784,81 @@ func TestDelete(t *testing.T) {
 			},
 			expectError: false, // Should not error if no external name
 		},
+		{
+			name: "managed role is left in place",
+			role: &rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: roleID,
+					},
+				},
+				Spec: rolev1alpha1.RoleSpec{
+					ForProvider: rolev1alpha1.RoleParameters{
+						GuildID: guildID,
+					},
+				},
+				Status: rolev1alpha1.RoleStatus{
+					AtProvider: rolev1alpha1.RoleObservation{ID: roleID, Managed: true},
+				},
+			},
+			mockSetup: func(m *MockDiscordClient) {
+				m.DeleteRoleFunc = func(ctx context.Context, gID, rID string) error {
+					t.Error("managed role must not be deleted")
+					return nil
+				}
+			},
+			expectError: false,
+		},
+		{
+			name: "role in onboarding prompt",
+			role: &rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: roleID,
+					},
+				},
+				Spec: rolev1alpha1.RoleSpec{
+					ForProvider: rolev1alpha1.RoleParameters{
+						GuildID: guildID,
+					},
+				},
+				Status: rolev1alpha1.RoleStatus{
+					AtProvider: rolev1alpha1.RoleObservation{ID: roleID, Flags: []string{"IN_PROMPT"}},
+				},
+			},
+			mockSetup: func(m *MockDiscordClient) {
+				m.DeleteRoleFunc = func(ctx context.Context, gID, rID string) error {
+					t.Error("role in onboarding prompt must not be deleted")
+					return nil
+				}
+			},
+			expectError: true,
+		},
+		{
+			name: "role in onboarding prompt with override",
+			role: &rolev1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName: roleID,
+					},
+				},
+				Spec: rolev1alpha1.RoleSpec{
+					ForProvider: rolev1alpha1.RoleParameters{
+						GuildID:     guildID,
+						AllowDelete: boolPtr(true),
+					},
+				},
+				Status: rolev1alpha1.RoleStatus{
+					AtProvider: rolev1alpha1.RoleObservation{ID: roleID, Flags: []string{"IN_PROMPT"}},
+				},
+			},
+			mockSetup: func(m *MockDiscordClient) {
+				m.DeleteRoleFunc = func(ctx context.Context, gID, rID string) error {
+					return nil
+				}
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -433,3 +923,73 @@ func TestTypeAssertions(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), errNotRole)
 }
+
+func conflictPolicyPtr(p rolev1alpha1.ConflictPolicy) *rolev1alpha1.ConflictPolicy {
+	return &p
+}
+
+func TestObserveReportsDiffForApproval(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	required := true
+	pc := &apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       apisv1alpha1.ProviderConfigSpec{RequiresApproval: &required},
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(pc).Build()
+
+	observed := &discordclient.Role{ID: "987654321", Name: "Mods", Color: 0xff0000, Permissions: "8"}
+	modified := 0
+	mock := &MockDiscordClient{
+		GetRoleFunc: func(ctx context.Context, guildID, roleID string) (*discordclient.Role, error) {
+			r := *observed
+			return &r, nil
+		},
+		ModifyRoleFunc: func(ctx context.Context, guildID, roleID string, req discordclient.ModifyRoleRequest) (*discordclient.Role, error) {
+			modified++
+			return observed, nil
+		},
+	}
+	c := conditions.NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &external{discord: mock}, nil
+	}), conditions.WithProviderConfig(kube))
+
+	color := 0x00ff00
+	cr := &rolev1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "moderators", Generation: 3},
+		Spec: rolev1alpha1.RoleSpec{ForProvider: rolev1alpha1.RoleParameters{
+			GuildID: "123456789",
+			Name:    "Moderators",
+			Color:   &color,
+		}},
+	}
+	cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Name: "default"})
+	meta.SetExternalName(cr, "987654321")
+
+	observe := func() managed.ExternalClient {
+		ec, err := c.Connect(ctx, cr)
+		require.NoError(t, err)
+		_, err = ec.Observe(ctx, cr)
+		require.NoError(t, err)
+		return ec
+	}
+
+	// The pending approval shows what the update would change
+	observe()
+	msg := cr.GetCondition(conditions.TypePendingApproval).Message
+	assert.Contains(t, msg, "name: Mods -> Moderators; color: 16711680 -> 65280")
+	id := msg[strings.Index(msg, conditions.AnnotationKeyApprove+"=")+len(conditions.AnnotationKeyApprove)+1:]
+	id = id[:strings.Index(id, ":")]
+
+	// Drift in Discord at the same generation is a different update, which
+	// the earlier approval does not cover
+	cr.SetAnnotations(map[string]string{conditions.AnnotationKeyApprove: id})
+	observed.Permissions = "0"
+	ec := observe()
+	assert.Equal(t, conditions.ReasonAwaitingApproval, cr.GetCondition(conditions.TypePendingApproval).Reason)
+	_, err := ec.Update(ctx, cr)
+	assert.Error(t, err)
+	assert.Zero(t, modified)
+
+}
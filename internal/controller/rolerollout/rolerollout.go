@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rolerollout
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotRoleRollout = "managed resource is not a RoleRollout custom resource"
+
+	// pageSize is the most members Discord returns per list or search.
+	pageSize = 1000
+
+	// defaultBatchSize is used when the rollout sets no batch size.
+	defaultBatchSize = 50
+
+	// maxPagesPerObserve is the most member pages an observation lists
+	// while looking for members to change.
+	maxPagesPerObserve = 5
+
+	// rescanInterval is how long a rollout with nothing left to change
+	// waits before scanning the guild again.
+	rescanInterval = time.Hour
+)
+
+// Setup adds a controller that reconciles RoleRollout managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(rolev1alpha1.RoleRolloutGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(rolev1alpha1.RoleRolloutGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(rolev1alpha1.RoleRolloutGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&rolev1alpha1.RoleRollout{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*rolev1alpha1.RoleRollout)
+	if !ok {
+		return nil, errors.New(errNotRoleRollout)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	svc := discordclient.NewDiscordClient(*token)
+
+	return &external{members: svc, permissions: svc, now: time.Now}, nil
+}
+
+// An ExternalClient rolls a role out to guild members. The rollout itself
+// exists only in Kubernetes: Observe pages through the guild's members from
+// a cursor kept in status until it finds matching members still to be
+// changed, and Update changes the next batch of them. Requests go through
+// the client's rate limiter, which waits out exhausted buckets.
+type external struct {
+	members discordclient.MemberClient
+	// permissions resolves the bot's guild permissions for pre-flight
+	// checks; the checks are skipped when it is nil.
+	permissions discordclient.PermissionClient
+	now         func() time.Time
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*rolev1alpha1.RoleRollout)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRoleRollout)
+	}
+	p := cr.Spec.ForProvider
+	status := &cr.Status.AtProvider
+
+	// The rollout is identified by the role it rolls out
+	if meta.GetExternalName(cr) != p.RoleID {
+		meta.SetExternalName(cr, p.RoleID)
+	}
+	cr.SetConditions(xpv1.Available())
+
+	// A spec change restarts the scan, since the pages already passed were
+	// matched against the old selector
+	specChanged := cr.GetCondition(xpv1.TypeSynced).ObservedGeneration != cr.GetGeneration()
+	if specChanged {
+		status.Cursor = ""
+	}
+	if status.Cursor == "" && !specChanged && status.PendingCount == 0 && status.ScannedAt != nil && e.now().Sub(status.ScannedAt.Time) < rescanInterval {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	status.NextBatch = nil
+	status.PendingCount = 0
+	for page := 0; page < maxPagesPerObserve; page++ {
+		if status.Cursor == "" {
+			status.MatchedCount = 0
+		}
+		members, last, err := e.candidates(ctx, p, status.Cursor)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+
+		matched, pending := 0, []string{}
+		for _, m := range members {
+			if m.User == nil || !matches(p.Selector, m) {
+				continue
+			}
+			matched++
+			if hasRole(m, p.RoleID) != (action(p) == rolev1alpha1.RolloutActionAdd) {
+				pending = append(pending, m.User.ID)
+			}
+		}
+
+		// A page with members to change is listed again until they are
+		// all changed
+		if len(pending) > 0 {
+			sort.Strings(pending)
+			status.PendingCount = len(pending)
+			status.NextBatch = pending[:min(len(pending), batchSize(p))]
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+		}
+
+		status.MatchedCount += matched
+		if last == "" {
+			now := metav1.NewTime(e.now())
+			status.Cursor = ""
+			status.ScannedAt = &now
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		status.Cursor = last
+	}
+
+	// More pages remain; the scan continues on the next poll
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// candidates returns the page of guild members after the cursor that the
+// selector may match, and the cursor of the next page, or "" if this page
+// is the last. A selector with a query is served by a single search.
+func (e *external) candidates(ctx context.Context, p rolev1alpha1.RoleRolloutParameters, cursor string) ([]discordclient.GuildMember, string, error) {
+	limit := pageSize
+	if p.Selector.Query != nil {
+		members, err := e.members.SearchGuildMembers(ctx, p.GuildID, &discordclient.SearchGuildMembersRequest{Query: *p.Selector.Query, Limit: &limit})
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to search guild members")
+		}
+		return members, "", nil
+	}
+
+	req := &discordclient.ListGuildMembersRequest{Limit: &limit}
+	if cursor != "" {
+		req.After = &cursor
+	}
+	members, err := e.members.ListGuildMembers(ctx, p.GuildID, req)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to list guild members")
+	}
+	if len(members) < pageSize || members[len(members)-1].User == nil {
+		return members, "", nil
+	}
+	return members, members[len(members)-1].User.ID, nil
+}
+
+// matches reports whether m meets the selector's role and join criteria;
+// the query is applied by the member search.
+func matches(s rolev1alpha1.RoleRolloutSelector, m discordclient.GuildMember) bool {
+	for _, id := range s.HasRoles {
+		if !hasRole(m, id) {
+			return false
+		}
+	}
+	if s.JoinedBefore != nil {
+		if m.JoinedAt == nil {
+			return false
+		}
+		joined, err := time.Parse(time.RFC3339, *m.JoinedAt)
+		if err != nil || !joined.Before(s.JoinedBefore.Time) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasRole(m discordclient.GuildMember, roleID string) bool {
+	for _, id := range m.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+func action(p rolev1alpha1.RoleRolloutParameters) rolev1alpha1.RolloutAction {
+	if p.Action == nil {
+		return rolev1alpha1.RolloutActionAdd
+	}
+	return *p.Action
+}
+
+func batchSize(p rolev1alpha1.RoleRolloutParameters) int {
+	if p.BatchSize == nil || *p.BatchSize < 1 {
+		return defaultBatchSize
+	}
+	return *p.BatchSize
+}
+
+func (e *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*rolev1alpha1.RoleRollout); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRoleRollout)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update changes the batch of members Observe chose. Progress made before a
+// failure is kept in status.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*rolev1alpha1.RoleRollout)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRoleRollout)
+	}
+	p := cr.Spec.ForProvider
+
+	if e.permissions != nil {
+		if err := discordclient.RequirePermissions(ctx, e.permissions, p.GuildID, discordclient.PermissionManageRoles); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	batch := cr.Status.AtProvider.NextBatch
+	for i, userID := range batch {
+		var err error
+		if action(p) == rolev1alpha1.RolloutActionAdd {
+			err = e.members.AddGuildMemberRole(ctx, p.GuildID, userID, p.RoleID)
+		} else {
+			err = e.members.RemoveGuildMemberRole(ctx, p.GuildID, userID, p.RoleID)
+		}
+		// Members who left the guild since Observe are skipped
+		if err != nil && !discordclient.IsNotFound(err) {
+			cr.Status.AtProvider.NextBatch = batch[i:]
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "failed to change role of member %s", userID)
+		}
+		if err == nil {
+			cr.Status.AtProvider.ChangedCount++
+		}
+		cr.Status.AtProvider.PendingCount--
+	}
+	cr.Status.AtProvider.NextBatch = nil
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if _, ok := mg.(*rolev1alpha1.RoleRollout); !ok {
+		return managed.ExternalDelete{}, errors.New(errNotRoleRollout)
+	}
+	// Deleting a rollout stops it; it never reverts the members it changed
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
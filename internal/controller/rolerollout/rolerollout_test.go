@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rolerollout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	rolev1alpha1 "github.com/rossigee/provider-discord/apis/role/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MockMemberClient struct {
+	discordclient.MemberClient
+	ListGuildMembersFunc      func(ctx context.Context, guildID string, req *discordclient.ListGuildMembersRequest) ([]discordclient.GuildMember, error)
+	AddGuildMemberRoleFunc    func(ctx context.Context, guildID, userID, roleID string) error
+	RemoveGuildMemberRoleFunc func(ctx context.Context, guildID, userID, roleID string) error
+}
+
+func (m *MockMemberClient) ListGuildMembers(ctx context.Context, guildID string, req *discordclient.ListGuildMembersRequest) ([]discordclient.GuildMember, error) {
+	return m.ListGuildMembersFunc(ctx, guildID, req)
+}
+
+func (m *MockMemberClient) AddGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error {
+	return m.AddGuildMemberRoleFunc(ctx, guildID, userID, roleID)
+}
+
+func (m *MockMemberClient) RemoveGuildMemberRole(ctx context.Context, guildID, userID, roleID string) error {
+	return m.RemoveGuildMemberRoleFunc(ctx, guildID, userID, roleID)
+}
+
+func member(id, joinedAt string, roles ...string) discordclient.GuildMember {
+	return discordclient.GuildMember{User: &discordclient.DiscordUser{ID: id}, JoinedAt: &joinedAt, Roles: roles}
+}
+
+// page returns the members after req's cursor, sorted by ID like Discord
+// lists them.
+func page(members []discordclient.GuildMember, req *discordclient.ListGuildMembersRequest) []discordclient.GuildMember {
+	out := []discordclient.GuildMember{}
+	for _, m := range members {
+		if (req.After == nil || m.User.ID > *req.After) && len(out) < *req.Limit {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func TestObserveAndUpdate(t *testing.T) {
+	const guildID = "123456789012345678"
+	const roleID = "verified"
+
+	// More members than fit in a page
+	var members []discordclient.GuildMember
+	for i := 0; i < pageSize; i++ {
+		members = append(members, member(fmt.Sprintf("a%04d", i), "2025-01-01T00:00:00+00:00", "member", roleID))
+	}
+	members[0].Roles = []string{"member"}
+	members = append(members,
+		member("b1", "2025-01-01T00:00:00.123000+00:00", "member"),
+		member("b2", "2025-01-01T00:00:00+00:00", "member"),
+		member("b3", "2025-01-01T00:00:00+00:00"),
+		member("b4", "2025-06-01T00:00:00+00:00", "member"),
+	)
+
+	var added []string
+	lists := 0
+	e := &external{members: &MockMemberClient{
+		ListGuildMembersFunc: func(ctx context.Context, id string, req *discordclient.ListGuildMembersRequest) ([]discordclient.GuildMember, error) {
+			assert.Equal(t, guildID, id)
+			lists++
+			return page(members, req), nil
+		},
+		AddGuildMemberRoleFunc: func(ctx context.Context, gid, userID, rid string) error {
+			assert.Equal(t, roleID, rid)
+			added = append(added, userID)
+			for i := range members {
+				if members[i].User.ID != userID {
+					continue
+				}
+				if userID == "b1" {
+					// b1 left the guild
+					members = append(members[:i], members[i+1:]...)
+					return &discordclient.APIError{StatusCode: 404, Code: 10007, Message: "Unknown Member"}
+				}
+				members[i].Roles = append(members[i].Roles, rid)
+				break
+			}
+			return nil
+		},
+	}}
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+
+	batch := 2
+	cr := &rolev1alpha1.RoleRollout{Spec: rolev1alpha1.RoleRolloutSpec{ForProvider: rolev1alpha1.RoleRolloutParameters{
+		GuildID: guildID,
+		RoleID:  roleID,
+		Selector: rolev1alpha1.RoleRolloutSelector{
+			HasRoles:     []string{"member"},
+			JoinedBefore: &metav1.Time{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		BatchSize: &batch,
+	}}}
+
+	// The first page holds a member to change
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Equal(t, 1, cr.Status.AtProvider.PendingCount)
+	assert.Equal(t, []string{"a0000"}, cr.Status.AtProvider.NextBatch)
+	assert.Empty(t, cr.Status.AtProvider.Cursor)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+
+	// The first page is done, so the scan moves on to the second
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Equal(t, pageSize, cr.Status.AtProvider.MatchedCount)
+	assert.Equal(t, "a0999", cr.Status.AtProvider.Cursor)
+	assert.Equal(t, 2, cr.Status.AtProvider.PendingCount)
+	assert.Equal(t, []string{"b1", "b2"}, cr.Status.AtProvider.NextBatch)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a0000", "b1", "b2"}, added)
+	assert.Equal(t, 2, cr.Status.AtProvider.ChangedCount)
+	assert.Equal(t, 0, cr.Status.AtProvider.PendingCount)
+	assert.Empty(t, cr.Status.AtProvider.NextBatch)
+
+	// The last page finishes the scan
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, pageSize+1, cr.Status.AtProvider.MatchedCount)
+	assert.Empty(t, cr.Status.AtProvider.Cursor)
+	require.NotNil(t, cr.Status.AtProvider.ScannedAt)
+
+	// Nothing is listed until the guild is due a rescan
+	lists = 0
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, 0, lists)
+
+	now = now.Add(rescanInterval)
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lists)
+	assert.Equal(t, pageSize+1, cr.Status.AtProvider.MatchedCount)
+}
+
+func TestObserveRestartsScanOnSpecChange(t *testing.T) {
+	var after []string
+	e := &external{now: time.Now, members: &MockMemberClient{
+		ListGuildMembersFunc: func(ctx context.Context, id string, req *discordclient.ListGuildMembersRequest) ([]discordclient.GuildMember, error) {
+			if req.After == nil {
+				after = append(after, "")
+			} else {
+				after = append(after, *req.After)
+			}
+			return nil, nil
+		},
+	}}
+	cr := &rolev1alpha1.RoleRollout{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       rolev1alpha1.RoleRolloutSpec{ForProvider: rolev1alpha1.RoleRolloutParameters{GuildID: "1", RoleID: "r"}},
+		Status:     rolev1alpha1.RoleRolloutStatus{AtProvider: rolev1alpha1.RoleRolloutObservation{Cursor: "500"}},
+	}
+	cr.SetConditions(xpv1.ReconcileSuccess().WithObservedGeneration(1))
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, after)
+}
+
+func TestUpdateKeepsProgress(t *testing.T) {
+	remove := rolev1alpha1.RolloutActionRemove
+	e := &external{members: &MockMemberClient{
+		RemoveGuildMemberRoleFunc: func(ctx context.Context, gid, userID, rid string) error {
+			if userID == "2" {
+				return errors.New("Discord API error: 500 - internal error")
+			}
+			return nil
+		},
+	}}
+	cr := &rolev1alpha1.RoleRollout{
+		Spec: rolev1alpha1.RoleRolloutSpec{ForProvider: rolev1alpha1.RoleRolloutParameters{GuildID: "1", RoleID: "r", Action: &remove}},
+		Status: rolev1alpha1.RoleRolloutStatus{AtProvider: rolev1alpha1.RoleRolloutObservation{
+			PendingCount: 3,
+			NextBatch:    []string{"1", "2", "3"},
+		}},
+	}
+
+	_, err := e.Update(context.Background(), cr)
+	require.Error(t, err)
+	assert.Equal(t, 1, cr.Status.AtProvider.ChangedCount)
+	assert.Equal(t, 2, cr.Status.AtProvider.PendingCount)
+	assert.Equal(t, []string{"2", "3"}, cr.Status.AtProvider.NextBatch)
+}
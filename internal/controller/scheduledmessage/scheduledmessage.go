@@ -0,0 +1,310 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledmessage
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNotScheduledMessage = "managed resource is not a ScheduledMessage custom resource"
+	errGetChannel          = "cannot get Channel"
+	errChannelPending      = "waiting for Channel %s to report its Discord ID"
+)
+
+// minDuePoll is the shortest delay before a message that is due is polled
+// again. A zero delay would not requeue the resource at all.
+const minDuePoll = time.Second
+
+// Setup adds a controller that reconciles ScheduledMessage managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
+	name := managed.ControllerName(messagev1alpha1.ScheduledMessageGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(messagev1alpha1.ScheduledMessageGroupVersionKind),
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
+			kube: mgr.GetClient(),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(pollUntilDue(t.PollJitter(o), time.Now)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(t.ForControllerRuntime(messagev1alpha1.ScheduledMessageGroupKind, o)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&messagev1alpha1.ScheduledMessage{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials from the
+// managed resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*messagev1alpha1.ScheduledMessage)
+	if !ok {
+		return nil, errors.New(errNotScheduledMessage)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	token, err := discordclient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get discord config")
+	}
+
+	return &external{messages: discordclient.NewDiscordClient(*token), kube: c.kube, now: time.Now}, nil
+}
+
+// An ExternalClient posts a scheduled message. A message that is not due yet
+// is reported as existing so that nothing is created until sendAt passes,
+// after which Create posts it once and Update adds its reactions.
+type external struct {
+	messages discordclient.MessageClient
+	kube     client.Client
+	now      func() time.Time
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*messagev1alpha1.ScheduledMessage)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotScheduledMessage)
+	}
+
+	// Crossplane runtime defaults external-name to metadata.name for new
+	// resources, so only a message ID shows that the message was posted
+	if id := meta.GetExternalName(cr); snowflake.Valid(id) {
+		cr.Status.AtProvider.MessageID = id
+		missing, err := e.missingReactions(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		cr.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: len(missing) == 0}, nil
+	}
+
+	sendAt := cr.Spec.ForProvider.SendAt.Time
+	if e.now().Before(sendAt) {
+		cr.SetConditions(xpv1.Unavailable().WithMessage("message is scheduled for " + sendAt.UTC().Format(time.RFC3339)))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	return managed.ExternalObservation{ResourceExists: false}, nil
+}
+
+// pollUntilDue returns a poll interval hook that jitters the poll interval
+// like managed.WithPollJitterHook, but polls a message that has not been
+// posted yet when it is due rather than up to a poll interval later.
+func pollUntilDue(jitter time.Duration, now func() time.Time) managed.PollIntervalHook {
+	return func(mg resource.Managed, pollInterval time.Duration) time.Duration {
+		d := pollInterval + time.Duration((rand.Float64()-0.5)*2*float64(jitter)) //nolint:gosec // No need for secure randomness.
+		cr, ok := mg.(*messagev1alpha1.ScheduledMessage)
+		if !ok || snowflake.Valid(meta.GetExternalName(cr)) {
+			return d
+		}
+		return min(d, max(cr.Spec.ForProvider.SendAt.Sub(now()), minDuePoll))
+	}
+}
+
+// Create posts the message, which is due.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*messagev1alpha1.ScheduledMessage)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotScheduledMessage)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	channelID, err := e.resolveChannel(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	message, err := e.messages.CreateMessage(ctx, channelID, createRequest(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, "failed to post scheduled message")
+	}
+
+	meta.SetExternalName(cr, message.ID)
+	sentAt := metav1.NewTime(e.now())
+	cr.Status.AtProvider = messagev1alpha1.ScheduledMessageObservation{
+		MessageID: message.ID,
+		ChannelID: channelID,
+		SentAt:    &sentAt,
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+// resolveChannel returns the ID of the channel to post in, from channelId or
+// the Channel named by channelRef.
+func (e *external) resolveChannel(ctx context.Context, cr *messagev1alpha1.ScheduledMessage) (string, error) {
+	p := cr.Spec.ForProvider
+	if p.ChannelID != nil {
+		return *p.ChannelID, nil
+	}
+	if p.ChannelRef == nil {
+		return "", errors.New("channelId or channelRef is required")
+	}
+
+	channel := &channelv1alpha1.Channel{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: p.ChannelRef.Name}, channel); err != nil {
+		return "", errors.Wrap(err, errGetChannel)
+	}
+	if id := channel.Status.AtProvider.ID; id != "" {
+		return id, nil
+	}
+	return "", conditions.NewChildPendingError(fmt.Sprintf(errChannelPending, p.ChannelRef.Name))
+}
+
+// createRequest builds the request that posts the message in p.
+func createRequest(p messagev1alpha1.ScheduledMessageParameters) *discordclient.CreateMessageRequest {
+	req := &discordclient.CreateMessageRequest{
+		Embeds:     discordclient.Embeds(p.Embeds),
+		Components: discordclient.Components(p.Components),
+	}
+	if p.Content != nil {
+		req.Content = *p.Content
+	}
+	return req
+}
+
+// Update adds the reactions missing from the posted message. The message
+// itself is complete and is never edited.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*messagev1alpha1.ScheduledMessage)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotScheduledMessage)
+	}
+
+	missing, err := e.missingReactions(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	for _, emoji := range missing {
+		if err := e.messages.CreateReaction(ctx, cr.Status.AtProvider.ChannelID, meta.GetExternalName(cr), emoji); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "failed to react with %s", emoji)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// missingReactions returns the reactions in the spec that the bot has not
+// made on the posted message, in the form the reactions API takes. A
+// message that was deleted has none missing.
+func (e *external) missingReactions(ctx context.Context, cr *messagev1alpha1.ScheduledMessage) ([]string, error) {
+	want := cr.Spec.ForProvider.Reactions
+	if len(want) == 0 {
+		return nil, nil
+	}
+
+	if cr.Status.AtProvider.ChannelID == "" {
+		channelID, err := e.resolveChannel(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+		cr.Status.AtProvider.ChannelID = channelID
+	}
+
+	message, err := e.messages.GetMessage(ctx, cr.Status.AtProvider.ChannelID, meta.GetExternalName(cr))
+	if err != nil {
+		if discordclient.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get scheduled message")
+	}
+
+	reacted := map[string]bool{}
+	for _, r := range message.Reactions {
+		if !r.Me {
+			continue
+		}
+		if r.Emoji.ID != "" {
+			reacted[r.Emoji.Name+":"+r.Emoji.ID] = true
+		} else {
+			reacted[r.Emoji.Name] = true
+		}
+	}
+
+	var missing []string
+	for _, emoji := range want {
+		if emoji = reactionEmoji(emoji); !reacted[emoji] {
+			missing = append(missing, emoji)
+		}
+	}
+	return missing, nil
+}
+
+// reactionEmoji returns emoji in the form the reactions API takes, turning
+// custom emoji message syntax such as <:name:id> or <a:name:id> into
+// name:id.
+func reactionEmoji(emoji string) string {
+	if !strings.HasPrefix(emoji, "<") || !strings.HasSuffix(emoji, ">") {
+		return emoji
+	}
+	emoji = strings.TrimSuffix(strings.TrimPrefix(emoji, "<"), ">")
+	return strings.TrimPrefix(strings.TrimPrefix(emoji, "a"), ":")
+}
+
+// Delete leaves any posted message in place; deleting a ScheduledMessage
+// before sendAt cancels it.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*messagev1alpha1.ScheduledMessage)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotScheduledMessage)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
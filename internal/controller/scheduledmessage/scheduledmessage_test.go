@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledmessage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	messagev1alpha1 "github.com/rossigee/provider-discord/apis/message/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type MockMessageClient struct {
+	discordclient.MessageClient
+	CreateMessageFunc  func(ctx context.Context, channelID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error)
+	GetMessageFunc     func(ctx context.Context, channelID, messageID string) (*discordclient.Message, error)
+	CreateReactionFunc func(ctx context.Context, channelID, messageID, emoji string) error
+}
+
+func (m *MockMessageClient) CreateMessage(ctx context.Context, channelID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+	return m.CreateMessageFunc(ctx, channelID, req)
+}
+
+func (m *MockMessageClient) GetMessage(ctx context.Context, channelID, messageID string) (*discordclient.Message, error) {
+	return m.GetMessageFunc(ctx, channelID, messageID)
+}
+
+func (m *MockMessageClient) CreateReaction(ctx context.Context, channelID, messageID, emoji string) error {
+	return m.CreateReactionFunc(ctx, channelID, messageID, emoji)
+}
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func messageResource(externalName string, sendAt time.Time) *messagev1alpha1.ScheduledMessage {
+	channelID := "111111111111111111"
+	content := "Launch day!"
+	return &messagev1alpha1.ScheduledMessage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "launch",
+			Namespace:   "default",
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: externalName},
+		},
+		Spec: messagev1alpha1.ScheduledMessageSpec{
+			ForProvider: messagev1alpha1.ScheduledMessageParameters{
+				ChannelID: &channelID,
+				SendAt:    metav1.NewTime(sendAt),
+				Content:   &content,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	ctx := context.Background()
+	e := &external{now: func() time.Time { return now }}
+
+	t.Run("NotDue", func(t *testing.T) {
+		cr := messageResource("launch", now.Add(time.Hour))
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(xpv1.TypeReady).Status)
+	})
+
+	t.Run("Due", func(t *testing.T) {
+		obs, err := e.Observe(ctx, messageResource("launch", now.Add(-time.Minute)))
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+
+	t.Run("Sent", func(t *testing.T) {
+		cr := messageResource("222222222222222222", now.Add(-time.Hour))
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(xpv1.TypeReady).Status)
+	})
+}
+
+func TestPollUntilDue(t *testing.T) {
+	hook := pollUntilDue(0, func() time.Time { return now })
+
+	tests := []struct {
+		name         string
+		externalName string
+		sendAt       time.Time
+		expected     time.Duration
+	}{
+		{name: "due after the next poll", externalName: "launch", sendAt: now.Add(time.Hour), expected: time.Minute},
+		{name: "due before the next poll", externalName: "launch", sendAt: now.Add(20 * time.Second), expected: 20 * time.Second},
+		{name: "overdue", externalName: "launch", sendAt: now.Add(-time.Second), expected: time.Second},
+		{name: "sent", externalName: "222222222222222222", sendAt: now.Add(20 * time.Second), expected: time.Minute},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hook(messageResource(tc.externalName, tc.sendAt), time.Minute))
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	var gotChannel string
+	var got *discordclient.CreateMessageRequest
+	e := &external{
+		now: func() time.Time { return now },
+		messages: &MockMessageClient{
+			CreateMessageFunc: func(ctx context.Context, channelID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+				gotChannel, got = channelID, req
+				return &discordclient.Message{ID: "222222222222222222", ChannelID: channelID}, nil
+			},
+		},
+	}
+	cr := messageResource("launch", now)
+	title := "Release"
+	cr.Spec.ForProvider.Embeds = []messagev1alpha1.Embed{{Title: &title, Fields: []messagev1alpha1.EmbedField{{Name: "Version", Value: "1.0"}}}}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "111111111111111111", gotChannel)
+	assert.Equal(t, "Launch day!", got.Content)
+	require.Len(t, got.Embeds, 1)
+	assert.Equal(t, "Release", got.Embeds[0].Title)
+	assert.Equal(t, "Version", got.Embeds[0].Fields[0].Name)
+	assert.Equal(t, "222222222222222222", meta.GetExternalName(cr))
+	assert.Equal(t, "222222222222222222", cr.Status.AtProvider.MessageID)
+	assert.Equal(t, now, cr.Status.AtProvider.SentAt.Time)
+}
+
+func TestReactions(t *testing.T) {
+	var added []string
+	e := &external{
+		now: func() time.Time { return now },
+		messages: &MockMessageClient{
+			GetMessageFunc: func(ctx context.Context, channelID, messageID string) (*discordclient.Message, error) {
+				assert.Equal(t, "111111111111111111", channelID)
+				return &discordclient.Message{ID: messageID, Reactions: []discordclient.Reaction{
+					{Count: 3, Me: true, Emoji: discordclient.Emoji{Name: "✅"}},
+					{Count: 1, Me: false, Emoji: discordclient.Emoji{Name: "❌"}},
+					{Count: 2, Me: true, Emoji: discordclient.Emoji{ID: "444444444444444444", Name: "party"}},
+				}}, nil
+			},
+			CreateReactionFunc: func(ctx context.Context, channelID, messageID, emoji string) error {
+				added = append(added, emoji)
+				return nil
+			},
+		},
+	}
+	cr := messageResource("222222222222222222", now.Add(-time.Hour))
+	cr.Status.AtProvider.ChannelID = "111111111111111111"
+	cr.Spec.ForProvider.Reactions = []string{"✅", "❌", "<a:party:444444444444444444>", "<:agree:555555555555555555>"}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"❌", "agree:555555555555555555"}, added)
+}
+
+func TestResolveChannel(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	ready := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{Name: "announcements", Namespace: "default"}}
+	ready.Status.AtProvider.ID = "333333333333333333"
+	pending := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"}}
+	e := &external{kube: fake.NewClientBuilder().WithScheme(s).WithObjects(ready, pending).Build()}
+
+	cr := messageResource("launch", now)
+	cr.Spec.ForProvider.ChannelID = nil
+	cr.Spec.ForProvider.ChannelRef = &xpv1.Reference{Name: "announcements"}
+	id, err := e.resolveChannel(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "333333333333333333", id)
+
+	cr.Spec.ForProvider.ChannelRef = &xpv1.Reference{Name: "new"}
+	_, err = e.resolveChannel(context.Background(), cr)
+	assert.Error(t, err)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusmessage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	discordv1alpha1 "github.com/rossigee/provider-discord/apis/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	controllerName  = "statusmessage"
+	groupSuffix     = "discord.crossplane.io"
+	defaultInterval = 5 * time.Minute
+
+	// maxFailing is how many failing resources the message names before
+	// summarizing the rest.
+	maxFailing = 10
+
+	colorHealthy = 0x57F287
+	colorFailing = 0xED4245
+)
+
+// ProviderConfigReconciler keeps the status message of ProviderConfig
+// objects with spec.statusMessage up to date.
+type ProviderConfigReconciler struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	recorder  events.EventRecorder
+	newClient func(token string) discordclient.MessageClient
+}
+
+// Health counts the managed resources using a ProviderConfig by state.
+type Health struct {
+	Total   int
+	Ready   int
+	Failing []string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.client = mgr.GetClient()
+	r.scheme = mgr.GetScheme()
+	r.recorder = mgr.GetEventRecorder(controllerName)
+	if r.newClient == nil {
+		r.newClient = func(token string) discordclient.MessageClient {
+			return discordclient.NewDiscordClient(token)
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&discordv1alpha1.ProviderConfig{}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			pc := obj.(*discordv1alpha1.ProviderConfig)
+			return pc.Spec.StatusMessage != nil
+		})).
+		Complete(r)
+}
+
+// Reconcile posts or edits the ProviderConfig's status message.
+func (r *ProviderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pc := &discordv1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if pc.Spec.StatusMessage == nil {
+		return ctrl.Result{}, nil
+	}
+
+	interval := defaultInterval
+	if pc.Spec.StatusMessage.Interval != nil && pc.Spec.StatusMessage.Interval.Duration > 0 {
+		interval = pc.Spec.StatusMessage.Interval.Duration
+	}
+
+	// Nothing is posted to Discord in dry-run mode
+	if conditions.DryRunEnabled() {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	creds := &discordclient.ProviderCredentials{
+		Source:                    discordclient.CredentialsSource(pc.Spec.Credentials.Source),
+		CommonCredentialSelectors: pc.Spec.Credentials.CommonCredentialSelectors,
+	}
+	token, err := creds.Extract(ctx, r.client)
+	if err != nil {
+		r.recorder.Eventf(pc, nil, corev1.EventTypeWarning, "StatusMessageFailed", "", "Failed to extract credentials: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	health, err := r.health(ctx, pc.GetName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	msg := &discordclient.CreateMessageRequest{Embeds: []discordclient.Embed{render(pc.GetName(), health, time.Now())}}
+	id, err := r.publish(ctx, r.newClient(token), pc.Spec.StatusMessage.ChannelID, pc.Status.StatusMessageID, msg)
+	if err != nil {
+		r.recorder.Eventf(pc, nil, corev1.EventTypeWarning, "StatusMessageFailed", "", "Failed to publish status message: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	if id != pc.Status.StatusMessageID {
+		// A message that is posted but not recorded is posted again on the
+		// next reconcile, so the ID is patched in, retrying on failure
+		orig := pc.DeepCopy()
+		pc.Status.StatusMessageID = id
+		err := retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+			return r.client.Status().Patch(ctx, pc, client.MergeFrom(orig))
+		})
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "cannot record status message ID")
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// publish edits the status message, posting a new one if there is none yet
+// or it was deleted, and returns its ID.
+func (r *ProviderConfigReconciler) publish(ctx context.Context, dc discordclient.MessageClient, channelID, messageID string, msg *discordclient.CreateMessageRequest) (string, error) {
+	if messageID != "" {
+		_, err := dc.EditMessage(ctx, channelID, messageID, msg)
+		if err == nil {
+			return messageID, nil
+		}
+		if !isUnknownMessage(err) {
+			return "", err
+		}
+	}
+
+	m, err := dc.CreateMessage(ctx, channelID, msg)
+	if err != nil {
+		return "", err
+	}
+	return m.ID, nil
+}
+
+// isUnknownMessage reports whether err is Discord saying the message does
+// not exist. A 404 for an unknown channel is not, as reposting would fail
+// the same way.
+func isUnknownMessage(err error) bool {
+	apiErr, ok := discordclient.AsAPIError(err)
+	if !ok {
+		return false
+	}
+	return apiErr.Code == discordclient.ErrorCodeUnknownMessage ||
+		apiErr.StatusCode == http.StatusNotFound && apiErr.Code == 0
+}
+
+// health counts the managed resources of this provider that use the named
+// ProviderConfig.
+func (r *ProviderConfigReconciler) health(ctx context.Context, pcName string) (Health, error) {
+	h := Health{}
+	for gvk := range r.scheme.AllKnownTypes() {
+		if !strings.HasSuffix(gvk.Group, groupSuffix) || !strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		obj, err := r.scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		l, ok := obj.(resource.ManagedList)
+		if !ok {
+			continue
+		}
+		if err := r.client.List(ctx, l.(client.ObjectList)); err != nil {
+			return Health{}, errors.Wrapf(err, "cannot list %s", gvk.Kind)
+		}
+		for _, mg := range l.GetItems() {
+			ref, ok := mg.(interface {
+				GetProviderConfigReference() *xpv1.ProviderConfigReference
+			})
+			if !ok || ref.GetProviderConfigReference() == nil || ref.GetProviderConfigReference().Name != pcName {
+				continue
+			}
+			h.Total++
+			if mg.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+				h.Ready++
+			}
+			if mg.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionFalse {
+				h.Failing = append(h.Failing, fmt.Sprintf("%s %s/%s", strings.TrimSuffix(gvk.Kind, "List"), mg.GetNamespace(), mg.GetName()))
+			}
+		}
+	}
+	sort.Strings(h.Failing)
+	return h, nil
+}
+
+// render builds the status message embed.
+func render(pcName string, h Health, now time.Time) discordclient.Embed {
+	e := discordclient.Embed{
+		Title: "provider-discord: " + pcName,
+		Color: colorHealthy,
+		Fields: []discordclient.EmbedField{
+			{Name: "Resources", Value: fmt.Sprintf("%d", h.Total), Inline: true},
+			{Name: "Ready", Value: fmt.Sprintf("%d", h.Ready), Inline: true},
+			{Name: "Failing", Value: fmt.Sprintf("%d", len(h.Failing)), Inline: true},
+		},
+		Footer: &discordclient.EmbedFooter{Text: "Updated " + now.UTC().Format(time.RFC3339)},
+	}
+	if len(h.Failing) > 0 {
+		e.Color = colorFailing
+		names := h.Failing
+		if len(names) > maxFailing {
+			names = append(names[:maxFailing:maxFailing], fmt.Sprintf("and %d more", len(h.Failing)-maxFailing))
+		}
+		e.Description = "Not synced:\n" + strings.Join(names, "\n")
+	}
+	return e
+}
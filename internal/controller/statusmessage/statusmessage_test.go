@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusmessage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-discord/apis"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	discordv1alpha1 "github.com/rossigee/provider-discord/apis/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+type MockMessageClient struct {
+	discordclient.MessageClient
+	CreateMessageFunc func(ctx context.Context, channelID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error)
+	EditMessageFunc   func(ctx context.Context, channelID, messageID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error)
+}
+
+func (m *MockMessageClient) CreateMessage(ctx context.Context, channelID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+	return m.CreateMessageFunc(ctx, channelID, req)
+}
+
+func (m *MockMessageClient) EditMessage(ctx context.Context, channelID, messageID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+	return m.EditMessageFunc(ctx, channelID, messageID, req)
+}
+
+const statusChannelID = "111111111111111111"
+
+func providerConfig(messageID string) *discordv1alpha1.ProviderConfig {
+	return &discordv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: discordv1alpha1.ProviderConfigSpec{
+			Credentials: discordv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "discord", Namespace: "crossplane-system"},
+						Key:             "token",
+					},
+				},
+			},
+			StatusMessage: &discordv1alpha1.StatusMessageSpec{
+				ChannelID: statusChannelID,
+				Interval:  &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		Status: discordv1alpha1.ProviderConfigStatus{StatusMessageID: messageID},
+	}
+}
+
+func channel(name, pcName string, synced corev1.ConditionStatus) *channelv1alpha1.Channel {
+	ch := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	ch.SetProviderConfigReference(&xpv1.ProviderConfigReference{Name: pcName})
+	ch.SetConditions(xpv1.Condition{Type: xpv1.TypeSynced, Status: synced, Reason: "Test"})
+	if synced == corev1.ConditionTrue {
+		ch.SetConditions(xpv1.Available())
+	}
+	return ch
+}
+
+func setup(t *testing.T, dc discordclient.MessageClient, objs ...client.Object) (*ProviderConfigReconciler, client.Client) {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, apis.AddToScheme(s))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "discord", Namespace: "crossplane-system"},
+		Data:       map[string][]byte{"token": []byte("test-token")},
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).
+		WithObjects(append(objs, secret)...).
+		WithStatusSubresource(&discordv1alpha1.ProviderConfig{}).
+		Build()
+
+	return &ProviderConfigReconciler{
+		client:    kube,
+		scheme:    s,
+		recorder:  events.NewFakeRecorder(10),
+		newClient: func(string) discordclient.MessageClient { return dc },
+	}, kube
+}
+
+func TestReconcilePostsStatusMessage(t *testing.T) {
+	var posted *discordclient.CreateMessageRequest
+	dc := &MockMessageClient{
+		CreateMessageFunc: func(_ context.Context, channelID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			assert.Equal(t, statusChannelID, channelID)
+			posted = req
+			return &discordclient.Message{ID: "222222222222222222"}, nil
+		},
+	}
+	r, kube := setup(t, dc,
+		providerConfig(""),
+		channel("general", "default", corev1.ConditionTrue),
+		channel("broken", "default", corev1.ConditionFalse),
+		channel("elsewhere", "other", corev1.ConditionFalse),
+	)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	require.NotNil(t, posted)
+	require.Len(t, posted.Embeds, 1)
+	e := posted.Embeds[0]
+	assert.Equal(t, colorFailing, e.Color)
+	assert.Equal(t, "2", e.Fields[0].Value)
+	assert.Equal(t, "1", e.Fields[1].Value)
+	assert.Equal(t, "1", e.Fields[2].Value)
+	assert.Contains(t, e.Description, "Channel default/broken")
+	assert.NotContains(t, e.Description, "elsewhere")
+
+	pc := &discordv1alpha1.ProviderConfig{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "default"}, pc))
+	assert.Equal(t, "222222222222222222", pc.Status.StatusMessageID)
+}
+
+func TestReconcileRetriesRecordingStatusMessageID(t *testing.T) {
+	posts := 0
+	dc := &MockMessageClient{
+		CreateMessageFunc: func(context.Context, string, *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			posts++
+			return &discordclient.Message{ID: "222222222222222222"}, nil
+		},
+	}
+	r, kube := setup(t, dc, providerConfig(""))
+	failures := 1
+	r.client = interceptor.NewClient(kube.(client.WithWatch), interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			if failures > 0 {
+				failures--
+				return fmt.Errorf("apiserver unavailable")
+			}
+			return c.Status().Patch(ctx, obj, patch, opts...)
+		},
+	})
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, posts)
+
+	pc := &discordv1alpha1.ProviderConfig{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "default"}, pc))
+	assert.Equal(t, "222222222222222222", pc.Status.StatusMessageID)
+}
+
+func TestReconcileSkipsStatusMessageInDryRun(t *testing.T) {
+	conditions.SetDryRun(true)
+	defer conditions.SetDryRun(false)
+
+	// The mock panics if the message is posted or edited
+	r, _ := setup(t, &MockMessageClient{}, providerConfig(""))
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+}
+
+func TestReconcileEditsStatusMessage(t *testing.T) {
+	edited := false
+	dc := &MockMessageClient{
+		EditMessageFunc: func(_ context.Context, _, messageID string, req *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			assert.Equal(t, "222222222222222222", messageID)
+			assert.Equal(t, colorHealthy, req.Embeds[0].Color)
+			edited = true
+			return &discordclient.Message{ID: messageID}, nil
+		},
+	}
+	r, _ := setup(t, dc, providerConfig("222222222222222222"), channel("general", "default", corev1.ConditionTrue))
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	require.NoError(t, err)
+	assert.True(t, edited)
+}
+
+func TestReconcileRepostsDeletedStatusMessage(t *testing.T) {
+	dc := &MockMessageClient{
+		EditMessageFunc: func(context.Context, string, string, *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			return nil, fmt.Errorf("failed to edit message: %w", &discordclient.APIError{StatusCode: 404, Code: discordclient.ErrorCodeUnknownMessage, Message: "Unknown Message"})
+		},
+		CreateMessageFunc: func(context.Context, string, *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			return &discordclient.Message{ID: "333333333333333333"}, nil
+		},
+	}
+	r, kube := setup(t, dc, providerConfig("222222222222222222"))
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	require.NoError(t, err)
+
+	pc := &discordv1alpha1.ProviderConfig{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "default"}, pc))
+	assert.Equal(t, "333333333333333333", pc.Status.StatusMessageID)
+}
+
+func TestReconcileKeepsStatusMessageOfUnknownChannel(t *testing.T) {
+	dc := &MockMessageClient{
+		EditMessageFunc: func(context.Context, string, string, *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			return nil, fmt.Errorf("failed to edit message: %w", &discordclient.APIError{StatusCode: 404, Code: 10003, Message: "Unknown Channel"})
+		},
+		CreateMessageFunc: func(context.Context, string, *discordclient.CreateMessageRequest) (*discordclient.Message, error) {
+			t.Error("Expected no new status message in an unknown channel")
+			return nil, nil
+		},
+	}
+	r, _ := setup(t, dc, providerConfig("222222222222222222"))
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	assert.Error(t, err)
+}
+
+func TestRenderTruncatesFailing(t *testing.T) {
+	h := Health{Total: 12}
+	for i := 0; i < 12; i++ {
+		h.Failing = append(h.Failing, "Channel default/c")
+	}
+	e := render("default", h, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	assert.Contains(t, e.Description, "and 2 more")
+	assert.Equal(t, "Updated 2025-06-01T12:00:00Z", e.Footer.Text)
+}
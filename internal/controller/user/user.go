@@ -8,12 +8,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
-	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	userv1alpha1 "github.com/rossigee/provider-discord/apis/user/v1alpha1"
-	v1alpha1 "github.com/rossigee/provider-discord/apis/v1alpha1"
 	discordclient "github.com/rossigee/provider-discord/internal/clients"
-	"k8s.io/apimachinery/pkg/types"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,22 +25,23 @@ const (
 )
 
 // Setup adds a controller that reconciles User managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(userv1alpha1.UserGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(userv1alpha1.UserGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:  mgr.GetClient(),
 			usage: resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(userv1alpha1.UserGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&userv1alpha1.User{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -69,40 +69,13 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	// Get provider config reference from the managed resource's ResourceSpec
-	var pcRef *xpv1.ProviderConfigReference
-
-	// Type assert to extract the ProviderConfigReference from the managed resource
-	switch mr := mg.(type) {
-	case interface {
-		GetProviderConfigReference() *xpv1.ProviderConfigReference
-	}:
-		pcRef = mr.GetProviderConfigReference()
-	default:
-		return nil, errors.New(errGetPC)
-	}
-
-	if pcRef == nil {
-		return nil, errors.New(errGetPC)
-	}
-
-	pc := &v1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: pcRef.Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	// Extract credentials from the provider config
-	credentials := discordclient.ProviderCredentials{
-		Source:                    discordclient.CredentialsSourceSecret,
-		CommonCredentialSelectors: pc.Spec.Credentials.CommonCredentialSelectors,
-	}
-	token, err := credentials.Extract(ctx, c.kube)
+	token, err := discordclient.GetConfig(ctx, c.kube, mg)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
 	// Create Discord client
-	discordClient := discordclient.NewDiscordClient(token)
+	discordClient := discordclient.NewDiscordClient(*token)
 
 	return &external{discord: discordClient}, nil
 }
@@ -165,12 +138,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.PremiumType = user.PremiumType
 	cr.Status.AtProvider.PublicFlags = user.PublicFlags
 	if user.AvatarDecoration != nil {
-		// Convert map to string representation if needed
-		if decorationData, exists := user.AvatarDecoration["asset"]; exists {
-			if asset, ok := decorationData.(string); ok {
-				cr.Status.AtProvider.AvatarDecorationData = &asset
-			}
-		}
+		cr.Status.AtProvider.AvatarDecorationData = &user.AvatarDecoration.Asset
 	}
 
 	// Check if update is needed (only for current user)
@@ -18,6 +18,8 @@ package webhook
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -28,11 +30,16 @@ import (
 	"github.com/pkg/errors"
 	webhookv1alpha1 "github.com/rossigee/provider-discord/apis/webhook/v1alpha1"
 	"github.com/rossigee/provider-discord/internal/clients"
+	"github.com/rossigee/provider-discord/internal/conditions"
+	"github.com/rossigee/provider-discord/internal/tuning"
+	"github.com/rossigee/provider-discord/pkg/snowflake"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"regexp"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"strings"
 	"time"
 )
 
@@ -43,33 +50,29 @@ const (
 	errGetCreds     = "cannot get credentials"
 )
 
-var (
-	// Discord snowflake IDs are 18-19 digit numbers
-	discordSnowflakeRegex = regexp.MustCompile(`^\d{18,19}$`)
-)
-
 // isValidDiscordID checks if the provided string is a valid Discord snowflake ID
 func isValidDiscordID(id string) bool {
-	return discordSnowflakeRegex.MatchString(id)
+	return snowflake.Valid(id)
 }
 
 // Setup adds a controller that reconciles Webhook managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o controller.Options, t tuning.Options) error {
 	name := managed.ControllerName(webhookv1alpha1.WebhookGroupKind.String())
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(webhookv1alpha1.WebhookGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(conditions.NewConnector(&connector{
 			kube:         mgr.GetClient(),
 			newServiceFn: clients.NewDiscordClient,
-		}),
+		}, conditions.WithProviderConfig(mgr.GetClient()))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollJitterHook(t.PollJitter(o)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(t.ForControllerRuntime(webhookv1alpha1.WebhookGroupKind, o)).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&webhookv1alpha1.Webhook{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
@@ -146,6 +149,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
+	if createdFromSpec(cr) {
+		recordAvatar(cr, webhook)
+	}
+
 	// Update status with observed values
 	now := &metav1.Time{Time: time.Now()}
 	observation := webhookv1alpha1.WebhookObservation{
@@ -154,7 +161,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		Name:      webhook.Name,
 		ChannelID: webhook.ChannelID,
 		GuildID:   webhook.GuildID,
+		CreatedAt: clients.CreatedAt(webhook.ID),
 		UpdatedAt: now,
+
+		AppliedAvatarSHA256: cr.Status.AtProvider.AppliedAvatarSHA256,
+		AppliedAvatar:       cr.Status.AtProvider.AppliedAvatar,
 	}
 
 	// Handle optional fields
@@ -176,9 +187,16 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		connectionDetails["url"] = []byte(webhook.URL)
 	}
 
-	// Check if we need to update
+	if cr.Spec.ForProvider.Verify != nil && *cr.Spec.ForProvider.Verify {
+		if err := c.verifyToken(ctx, cr, webhook); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	// Check if we need to update. The avatar is only managed when declared.
 	needsUpdate := cr.Spec.ForProvider.Name != webhook.Name ||
-		(cr.Spec.ForProvider.Avatar != nil && (webhook.Avatar == nil || *cr.Spec.ForProvider.Avatar != *webhook.Avatar))
+		cr.Spec.ForProvider.ChannelID != webhook.ChannelID ||
+		!avatarUpToDate(cr.Spec.ForProvider.Avatar, webhook.Avatar, observation)
 
 	return managed.ExternalObservation{
 		ResourceExists:    true,
@@ -187,6 +205,119 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// avatarUpToDate reports whether the webhook shows the declared avatar.
+// Discord reports an avatar as a hash of its own that cannot be computed from
+// the declared image data, so the avatar is up to date while the declared
+// image is the one last sent and Discord still reports the hash it gave that
+// image.
+func avatarUpToDate(declared, current *string, o webhookv1alpha1.WebhookObservation) bool {
+	switch {
+	case declared == nil || *declared == "":
+		return true
+	case current == nil:
+		return false
+	}
+	return o.AppliedAvatarSHA256 == avatarSHA256(*declared) && o.AppliedAvatar == *current
+}
+
+// avatarSHA256 returns the hex encoded SHA-256 of avatar image data.
+func avatarSHA256(avatar string) string {
+	sum := sha256.Sum256([]byte(avatar))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordAvatar records the avatar sent to Discord and the hash Discord gave
+// it, so later observations can tell whether it changed.
+func recordAvatar(cr *webhookv1alpha1.Webhook, webhook *clients.Webhook) {
+	if cr.Spec.ForProvider.Avatar == nil || *cr.Spec.ForProvider.Avatar == "" || webhook == nil {
+		return
+	}
+	cr.Status.AtProvider.AppliedAvatarSHA256 = avatarSHA256(*cr.Spec.ForProvider.Avatar)
+	cr.Status.AtProvider.AppliedAvatar = ""
+	if webhook.Avatar != nil {
+		cr.Status.AtProvider.AppliedAvatar = *webhook.Avatar
+	}
+}
+
+// createdFromSpec reports whether cr's webhook was created from its current
+// spec and no avatar has been recorded for it since. The avatar Create sends
+// cannot be recorded by Create itself: the managed reconciler saves the
+// external name right after Create, and that replaces the status Create set
+// with the stored one. Without this, the first Update would upload the
+// avatar again.
+func createdFromSpec(cr *webhookv1alpha1.Webhook) bool {
+	return cr.Status.AtProvider.AppliedAvatarSHA256 == "" &&
+		!meta.GetExternalCreateSucceeded(cr).IsZero() &&
+		cr.GetCondition(xpv1.TypeSynced).ObservedGeneration == cr.GetGeneration()
+}
+
+// verifyToken checks that the token consumers read from the connection
+// secret still works, falling back to the token Discord returned when none
+// has been published yet, and records the result in the TokenValid condition.
+func (c *external) verifyToken(ctx context.Context, cr *webhookv1alpha1.Webhook, webhook *clients.Webhook) error {
+	token, err := c.publishedToken(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		token = webhook.Token
+	}
+	if token == "" {
+		cr.SetConditions(conditions.TokenInvalid("no webhook token is available to verify"))
+		return nil
+	}
+
+	if _, err := c.service.GetWebhookWithToken(ctx, webhook.ID, token); err != nil {
+		if !isTokenRejected(err) {
+			return errors.Wrap(err, "failed to verify webhook token")
+		}
+		cr.SetConditions(conditions.TokenInvalid("Discord rejected the webhook token; it may have been regenerated or the webhook recreated"))
+		return nil
+	}
+
+	cr.SetConditions(conditions.TokenValid())
+	return nil
+}
+
+// publishedToken returns the webhook token currently stored in the connection
+// secret, or an empty string if none has been published.
+func (c *external) publishedToken(ctx context.Context, cr *webhookv1alpha1.Webhook) (string, error) {
+	ref := cr.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return "", nil
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cr.GetNamespace()
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "cannot get connection secret")
+	}
+	return string(secret.Data["token"]), nil
+}
+
+// isTokenRejected reports whether Discord refused a webhook token, as opposed
+// to failing for a transient reason.
+func isTokenRejected(err error) bool {
+	apiErr, ok := clients.AsAPIError(err)
+	if !ok {
+		return false
+	}
+	switch apiErr.Code {
+	case clients.ErrorCodeInvalidWebhookToken, clients.ErrorCodeUnknownWebhook:
+		return true
+	case 0:
+		return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusNotFound
+	default:
+		return false
+	}
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*webhookv1alpha1.Webhook)
 	if !ok {
@@ -233,8 +364,14 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		Name: &cr.Spec.ForProvider.Name,
 	}
 
-	// Set optional fields for update
-	if cr.Spec.ForProvider.Avatar != nil {
+	// Only send the avatar when it drifted: re-uploading an unchanged image
+	// would give it a new hash.
+	var current *string
+	if cr.Status.AtProvider.Avatar != "" {
+		current = &cr.Status.AtProvider.Avatar
+	}
+	avatarDrifted := !avatarUpToDate(cr.Spec.ForProvider.Avatar, current, cr.Status.AtProvider)
+	if avatarDrifted {
 		req.Avatar = cr.Spec.ForProvider.Avatar
 	}
 
@@ -243,10 +380,13 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		req.ChannelID = &cr.Spec.ForProvider.ChannelID
 	}
 
-	_, err := c.service.ModifyWebhook(ctx, meta.GetExternalName(cr), req)
+	webhook, err := c.service.ModifyWebhook(ctx, meta.GetExternalName(cr), req)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update webhook")
 	}
+	if avatarDrifted {
+		recordAvatar(cr, webhook)
+	}
 
 	return managed.ExternalUpdate{}, nil
 }
@@ -262,7 +402,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	err := c.service.DeleteWebhook(ctx, meta.GetExternalName(cr))
 	if err != nil {
 		// Check if the error is a 404 (webhook not found), which means it's already deleted
-		if strings.Contains(err.Error(), "Discord API error: 404") {
+		if clients.IsNotFound(err) {
 			return managed.ExternalDelete{}, nil
 		}
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete webhook")
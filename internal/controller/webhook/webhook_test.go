@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	webhookv1alpha1 "github.com/rossigee/provider-discord/apis/webhook/v1alpha1"
+	discordclient "github.com/rossigee/provider-discord/internal/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const webhookID = "123456789012345678"
+
+const avatarData = "data:image/png;base64,iVBORw0KGgo="
+
+// MockWebhookClient implements a mock Discord client for testing
+type MockWebhookClient struct {
+	discordclient.WebhookClient
+
+	GetWebhookFunc    func(ctx context.Context, webhookID string) (*discordclient.Webhook, error)
+	ModifyWebhookFunc func(ctx context.Context, webhookID string, req *discordclient.ModifyWebhookRequest) (*discordclient.Webhook, error)
+}
+
+func (m *MockWebhookClient) GetWebhook(ctx context.Context, webhookID string) (*discordclient.Webhook, error) {
+	return m.GetWebhookFunc(ctx, webhookID)
+}
+
+func (m *MockWebhookClient) ModifyWebhook(ctx context.Context, webhookID string, req *discordclient.ModifyWebhookRequest) (*discordclient.Webhook, error) {
+	return m.ModifyWebhookFunc(ctx, webhookID, req)
+}
+
+func newWebhook(avatar *string, status webhookv1alpha1.WebhookObservation) *webhookv1alpha1.Webhook {
+	cr := &webhookv1alpha1.Webhook{}
+	cr.Spec.ForProvider.Name = "alerts"
+	cr.Spec.ForProvider.ChannelID = "223456789012345678"
+	cr.Spec.ForProvider.Avatar = avatar
+	cr.Status.AtProvider = status
+	meta.SetExternalName(cr, webhookID)
+	return cr
+}
+
+func TestObserveAvatar(t *testing.T) {
+	avatar := avatarData
+	applied := webhookv1alpha1.WebhookObservation{AppliedAvatarSHA256: avatarSHA256(avatarData), AppliedAvatar: "abc"}
+
+	tests := []struct {
+		name             string
+		declared         *string
+		status           webhookv1alpha1.WebhookObservation
+		current          *string
+		created          bool
+		generation       int64
+		expectedUpToDate bool
+		expectedStatus   *webhookv1alpha1.WebhookObservation
+	}{
+		{
+			name:             "no avatar declared",
+			current:          strPtr("abc"),
+			expectedUpToDate: true,
+		},
+		{
+			name:             "declared avatar applied",
+			declared:         &avatar,
+			status:           applied,
+			current:          strPtr("abc"),
+			expectedUpToDate: true,
+		},
+		{
+			name:     "declared avatar never applied",
+			declared: &avatar,
+			current:  strPtr("abc"),
+		},
+		{
+			name:             "declared avatar sent on create",
+			declared:         &avatar,
+			current:          strPtr("abc"),
+			created:          true,
+			expectedUpToDate: true,
+			expectedStatus:   &applied,
+		},
+		{
+			name:       "declared avatar changed since create",
+			declared:   &avatar,
+			current:    strPtr("abc"),
+			created:    true,
+			generation: 2,
+		},
+		{
+			name:     "declared avatar changed",
+			declared: strPtr("data:image/png;base64,R0lGODlh"),
+			status:   applied,
+			current:  strPtr("abc"),
+		},
+		{
+			name:     "avatar changed in Discord",
+			declared: &avatar,
+			status:   applied,
+			current:  strPtr("def"),
+		},
+		{
+			name:     "avatar removed in Discord",
+			declared: &avatar,
+			status:   applied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockWebhookClient{
+				GetWebhookFunc: func(ctx context.Context, id string) (*discordclient.Webhook, error) {
+					return &discordclient.Webhook{ID: id, Name: "alerts", ChannelID: "223456789012345678", Avatar: tt.current}, nil
+				},
+			}
+			e := &external{service: mock}
+			cr := newWebhook(tt.declared, tt.status)
+			if tt.created {
+				meta.SetExternalCreateSucceeded(cr, time.Now())
+				cr.SetConditions(xpv1.ReconcileSuccess().WithObservedGeneration(1))
+				cr.SetGeneration(1)
+			}
+			if tt.generation != 0 {
+				cr.SetGeneration(tt.generation)
+			}
+			expected := tt.status
+			if tt.expectedStatus != nil {
+				expected = *tt.expectedStatus
+			}
+
+			obs, err := e.Observe(context.Background(), cr)
+
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceExists)
+			assert.Equal(t, tt.expectedUpToDate, obs.ResourceUpToDate)
+			assert.Equal(t, expected.AppliedAvatarSHA256, cr.Status.AtProvider.AppliedAvatarSHA256)
+			assert.Equal(t, expected.AppliedAvatar, cr.Status.AtProvider.AppliedAvatar)
+		})
+	}
+}
+
+func TestObserveChannelMoved(t *testing.T) {
+	mock := &MockWebhookClient{
+		GetWebhookFunc: func(ctx context.Context, id string) (*discordclient.Webhook, error) {
+			return &discordclient.Webhook{ID: id, Name: "alerts", ChannelID: "323456789012345678"}, nil
+		},
+	}
+	e := &external{service: mock}
+
+	obs, err := e.Observe(context.Background(), newWebhook(nil, webhookv1alpha1.WebhookObservation{}))
+
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate)
+}
+
+func TestUpdateAvatar(t *testing.T) {
+	t.Run("sends and records a drifted avatar", func(t *testing.T) {
+		var sent *discordclient.ModifyWebhookRequest
+		mock := &MockWebhookClient{
+			ModifyWebhookFunc: func(ctx context.Context, id string, req *discordclient.ModifyWebhookRequest) (*discordclient.Webhook, error) {
+				sent = req
+				return &discordclient.Webhook{ID: id, Name: *req.Name, Avatar: strPtr("abc")}, nil
+			},
+		}
+		e := &external{service: mock}
+		avatar := avatarData
+		cr := newWebhook(&avatar, webhookv1alpha1.WebhookObservation{Avatar: "def"})
+
+		_, err := e.Update(context.Background(), cr)
+
+		require.NoError(t, err)
+		require.NotNil(t, sent.Avatar)
+		assert.Equal(t, avatarData, *sent.Avatar)
+		assert.Equal(t, avatarSHA256(avatarData), cr.Status.AtProvider.AppliedAvatarSHA256)
+		assert.Equal(t, "abc", cr.Status.AtProvider.AppliedAvatar)
+	})
+
+	t.Run("leaves an unchanged avatar alone", func(t *testing.T) {
+		var sent *discordclient.ModifyWebhookRequest
+		mock := &MockWebhookClient{
+			ModifyWebhookFunc: func(ctx context.Context, id string, req *discordclient.ModifyWebhookRequest) (*discordclient.Webhook, error) {
+				sent = req
+				return &discordclient.Webhook{ID: id, Name: *req.Name, Avatar: strPtr("abc")}, nil
+			},
+		}
+		e := &external{service: mock}
+		avatar := avatarData
+		cr := newWebhook(&avatar, webhookv1alpha1.WebhookObservation{
+			Avatar:              "abc",
+			AppliedAvatarSHA256: avatarSHA256(avatarData),
+			AppliedAvatar:       "abc",
+		})
+		cr.Spec.ForProvider.Name = "renamed"
+
+		_, err := e.Update(context.Background(), cr)
+
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", *sent.Name)
+		assert.Nil(t, sent.Avatar)
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestIsTokenRejected(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"invalid token":      {&discordclient.APIError{StatusCode: http.StatusUnauthorized, Code: discordclient.ErrorCodeInvalidWebhookToken}, true},
+		"unknown webhook":    {&discordclient.APIError{StatusCode: http.StatusNotFound, Code: discordclient.ErrorCodeUnknownWebhook}, true},
+		"not found, no code": {&discordclient.APIError{StatusCode: http.StatusNotFound}, true},
+		"missing access":     {&discordclient.APIError{StatusCode: http.StatusForbidden, Code: discordclient.ErrorCodeMissingAccess}, false},
+		"server error":       {&discordclient.APIError{StatusCode: http.StatusBadGateway}, false},
+		"not an API error":   {errors.New("Discord API error: 401 - connection reset"), false},
+		"no error":           {nil, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isTokenRejected(tc.err))
+		})
+	}
+}
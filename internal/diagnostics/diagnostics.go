@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnostics serves the provider's optional runtime diagnostics
+// endpoint: Go's pprof profiles and expvar variables, for investigating
+// memory growth or stuck goroutines in production installs.
+package diagnostics
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// PprofPath is the path prefix of the pprof profiles.
+	PprofPath = "/debug/pprof/"
+
+	// VarsPath is the path of the expvar variables, which include the
+	// runtime's memory statistics.
+	VarsPath = "/debug/vars"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+func init() {
+	expvar.Publish("goroutines", expvar.Func(func() any {
+		return runtime.NumGoroutine()
+	}))
+}
+
+// A Server serves the diagnostics endpoint. It is unauthenticated, so it
+// should be bound to localhost and reached with kubectl port-forward.
+type Server struct {
+	addr string
+}
+
+// NewServer returns a diagnostics server listening on addr.
+func NewServer(addr string) *Server {
+	return &Server{addr: addr}
+}
+
+// NeedLeaderElection returns false so every replica can be profiled.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the diagnostics endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.Handle(VarsPath, expvar.Handler())
+	return mux
+}
+
+// Start serves the diagnostics endpoint until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	// No write timeout, since CPU profiles and traces stream for as long as
+	// their seconds parameter asks
+	srv := &http.Server{Addr: s.addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return errors.Wrap(err, "diagnostics server failed")
+	case <-ctx.Done():
+		sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(sctx)
+	}
+}
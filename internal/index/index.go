@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package index maps Discord IDs to the managed resources that represent
+// them, so that audit log entries and other events naming a Discord object
+// can be traced to its resource without listing every kind.
+package index
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+)
+
+// A Ref names a managed resource.
+type Ref struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// An Index maps Discord IDs to the managed resources observed to represent
+// them. It is safe for concurrent use.
+type Index struct {
+	mu    sync.RWMutex
+	byID  map[string]map[Ref]bool
+	byRef map[Ref]string
+}
+
+// New returns an empty Index.
+func New() *Index {
+	return &Index{byID: map[string]map[Ref]bool{}, byRef: map[Ref]string{}}
+}
+
+var global = New()
+
+// Default returns the index the controllers record their observations in.
+func Default() *Index {
+	return global
+}
+
+// RefOf returns the Ref of mg. The type name is used as the kind because
+// objects read from the cache do not carry their TypeMeta.
+func RefOf(mg resource.Managed) Ref {
+	t := reflect.TypeOf(mg)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return Ref{Kind: t.Name(), Namespace: mg.GetNamespace(), Name: mg.GetName()}
+}
+
+// IDs returns the Discord IDs an external name refers to: the name itself
+// and, for composite names such as "channelID/overwriteID", each of its
+// segments.
+func IDs(externalName string) []string {
+	if externalName == "" {
+		return nil
+	}
+	ids := []string{externalName}
+	if segments := strings.Split(externalName, "/"); len(segments) > 1 {
+		for _, s := range segments {
+			if s != "" {
+				ids = append(ids, s)
+			}
+		}
+	}
+	return ids
+}
+
+// Observe records whether the Discord resource of mg exists. Existing
+// resources are indexed under the IDs of their external name; resources
+// that do not exist, or no longer do, are forgotten.
+func (i *Index) Observe(mg resource.Managed, exists bool) {
+	ref := RefOf(mg)
+	name := meta.GetExternalName(mg)
+	if !exists || name == "" || meta.WasDeleted(mg) {
+		i.Forget(ref)
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.byRef[ref] == name {
+		return
+	}
+	i.forget(ref)
+	i.byRef[ref] = name
+	for _, id := range IDs(name) {
+		if i.byID[id] == nil {
+			i.byID[id] = map[Ref]bool{}
+		}
+		i.byID[id][ref] = true
+	}
+}
+
+// Forget removes ref from the index.
+func (i *Index) Forget(ref Ref) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.forget(ref)
+}
+
+func (i *Index) forget(ref Ref) {
+	name, ok := i.byRef[ref]
+	if !ok {
+		return
+	}
+	delete(i.byRef, ref)
+	for _, id := range IDs(name) {
+		delete(i.byID[id], ref)
+		if len(i.byID[id]) == 0 {
+			delete(i.byID, id)
+		}
+	}
+}
+
+// Lookup returns the resources indexed under id, sorted by kind, namespace
+// and name.
+func (i *Index) Lookup(id string) []Ref {
+	i.mu.RLock()
+	refs := make([]Ref, 0, len(i.byID[id]))
+	for ref := range i.byID[id] {
+		refs = append(refs, ref)
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(refs, func(a, b int) bool {
+		if refs[a].Kind != refs[b].Kind {
+			return refs[a].Kind < refs[b].Kind
+		}
+		if refs[a].Namespace != refs[b].Namespace {
+			return refs[a].Namespace < refs[b].Namespace
+		}
+		return refs[a].Name < refs[b].Name
+	})
+	return refs
+}
+
+// Len returns the number of indexed resources.
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.byRef)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	channelv1alpha1 "github.com/rossigee/provider-discord/apis/channel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func channel(name, externalName string) *channelv1alpha1.Channel {
+	ch := &channelv1alpha1.Channel{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	meta.SetExternalName(ch, externalName)
+	return ch
+}
+
+func TestObserve(t *testing.T) {
+	i := New()
+	general := Ref{Kind: "Channel", Namespace: "default", Name: "general"}
+
+	i.Observe(channel("general", "111"), true)
+	assert.Equal(t, []Ref{general}, i.Lookup("111"))
+
+	// A resource that does not exist yet is not indexed
+	i.Observe(channel("pending", "222"), false)
+	assert.Empty(t, i.Lookup("222"))
+
+	// A new external name replaces the old one
+	i.Observe(channel("general", "333"), true)
+	assert.Empty(t, i.Lookup("111"))
+	assert.Equal(t, []Ref{general}, i.Lookup("333"))
+
+	// Composite external names are indexed under each segment too
+	i.Observe(channel("overwrite", "333/444"), true)
+	assert.Equal(t, []Ref{general, {Kind: "Channel", Namespace: "default", Name: "overwrite"}}, i.Lookup("333"))
+	assert.Len(t, i.Lookup("444"), 1)
+	assert.Len(t, i.Lookup("333/444"), 1)
+
+	i.Observe(channel("general", "333"), false)
+	i.Forget(Ref{Kind: "Channel", Namespace: "default", Name: "overwrite"})
+	assert.Empty(t, i.Lookup("333"))
+	assert.Empty(t, i.Lookup("444"))
+	assert.Equal(t, 0, i.Len())
+}
+
+func TestIDs(t *testing.T) {
+	assert.Nil(t, IDs(""))
+	assert.Equal(t, []string{"111"}, IDs("111"))
+	assert.Equal(t, []string{"111/222", "111", "222"}, IDs("111/222"))
+}
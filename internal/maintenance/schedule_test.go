@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package maintenance
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "lists ranges and steps", expr: "0,30 2-4 */2 1-12/3 1-5"},
+		{name: "sunday as seven", expr: "0 3 * * 7"},
+		{name: "too few fields", expr: "0 3 * *", wantErr: true},
+		{name: "out of range", expr: "60 3 * * *", wantErr: true},
+		{name: "reversed range", expr: "0 5-3 * * *", wantErr: true},
+		{name: "bad step", expr: "*/0 * * * *", wantErr: true},
+		{name: "not a number", expr: "0 three * * *", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSchedule(tc.expr)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2025-06-04 is a Wednesday.
+	from := time.Date(2025, 6, 4, 10, 15, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{name: "next minute", expr: "* * * * *", expected: time.Date(2025, 6, 4, 10, 16, 0, 0, time.UTC)},
+		{name: "later today", expr: "0 22 * * *", expected: time.Date(2025, 6, 4, 22, 0, 0, 0, time.UTC)},
+		{name: "tomorrow", expr: "0 3 * * *", expected: time.Date(2025, 6, 5, 3, 0, 0, 0, time.UTC)},
+		{name: "next sunday", expr: "30 2 * * 0", expected: time.Date(2025, 6, 8, 2, 30, 0, 0, time.UTC)},
+		{name: "sunday as seven", expr: "30 2 * * 7", expected: time.Date(2025, 6, 8, 2, 30, 0, 0, time.UTC)},
+		{name: "next month", expr: "0 0 1 * *", expected: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or week", expr: "0 0 20 * 5", expected: time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "never", expr: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := ParseSchedule(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s.Next(from))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package maintenance
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-discord/apis/v1alpha1"
+	"time"
+	// Embed the time zone database so TimeZone works in distroless images.
+	_ "time/tzdata"
+)
+
+// MaxDuration is the longest a maintenance window may stay open.
+const MaxDuration = 7 * 24 * time.Hour
+
+// A Window is a recurring period during which mutating operations are
+// allowed.
+type Window struct {
+	Schedule *Schedule
+	Duration time.Duration
+	Location *time.Location
+}
+
+// NewWindows parses the maintenance windows of a ProviderConfig.
+func NewWindows(specs []v1alpha1.MaintenanceWindow) ([]Window, error) {
+	windows := make([]Window, 0, len(specs))
+	for _, spec := range specs {
+		s, err := ParseSchedule(spec.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		d := spec.Duration.Duration
+		if d < time.Minute || d > MaxDuration {
+			return nil, errors.Errorf("maintenance window %q duration %s must be between 1m and %s", spec.Schedule, d, MaxDuration)
+		}
+		loc := time.UTC
+		if spec.TimeZone != nil && *spec.TimeZone != "" {
+			if loc, err = time.LoadLocation(*spec.TimeZone); err != nil {
+				return nil, errors.Wrapf(err, "maintenance window %q has an invalid time zone", spec.Schedule)
+			}
+		}
+		windows = append(windows, Window{Schedule: s, Duration: d, Location: loc})
+	}
+	return windows, nil
+}
+
+// Contains reports whether t falls within an opening of w.
+func (w Window) Contains(t time.Time) bool {
+	t = t.In(w.Location)
+	// The latest opening that could still cover t is the first one after
+	// t - Duration.
+	start := w.Schedule.Next(t.Add(-w.Duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// Allowed reports whether mutating operations are allowed at t. They always
+// are when no windows are configured. Otherwise they are allowed only inside
+// a window, and next is the earliest time after t that one of them opens.
+func Allowed(windows []Window, t time.Time) (allowed bool, next time.Time) {
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true, time.Time{}
+		}
+		if at := w.Schedule.Next(t.In(w.Location)); !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	return len(windows) == 0, next
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package maintenance
+
+import (
+	"github.com/rossigee/provider-discord/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestNewWindows(t *testing.T) {
+	tz := "Mars/Olympus_Mons"
+	tests := []struct {
+		name    string
+		spec    v1alpha1.MaintenanceWindow
+		wantErr bool
+	}{
+		{name: "valid", spec: v1alpha1.MaintenanceWindow{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+		{name: "bad schedule", spec: v1alpha1.MaintenanceWindow{Schedule: "0 3 * *", Duration: metav1.Duration{Duration: time.Hour}}, wantErr: true},
+		{name: "too short", spec: v1alpha1.MaintenanceWindow{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: time.Second}}, wantErr: true},
+		{name: "too long", spec: v1alpha1.MaintenanceWindow{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: 8 * 24 * time.Hour}}, wantErr: true},
+		{name: "bad time zone", spec: v1alpha1.MaintenanceWindow{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: &tz}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWindows([]v1alpha1.MaintenanceWindow{tc.spec})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	london := "Europe/London"
+	windows, err := NewWindows([]v1alpha1.MaintenanceWindow{
+		// 03:00-05:00 London time on weekdays.
+		{Schedule: "0 3 * * 1-5", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: &london},
+		// All of Saturday, UTC.
+		{Schedule: "0 0 * * 6", Duration: metav1.Duration{Duration: 24 * time.Hour}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		at           time.Time
+		expectedOK   bool
+		expectedNext time.Time
+	}{
+		{
+			name:       "inside weekday window",
+			at:         time.Date(2025, 6, 4, 3, 30, 0, 0, time.UTC), // 04:30 BST
+			expectedOK: true,
+		},
+		{
+			name:         "after weekday window",
+			at:           time.Date(2025, 6, 4, 4, 0, 0, 0, time.UTC), // 05:00 BST
+			expectedNext: time.Date(2025, 6, 5, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "inside window spanning a day",
+			at:         time.Date(2025, 6, 7, 23, 59, 0, 0, time.UTC),
+			expectedOK: true,
+		},
+		{
+			name:         "sunday",
+			at:           time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, 6, 9, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, next := Allowed(windows, tc.at)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.True(t, tc.expectedNext.Equal(next), "expected %s, got %s", tc.expectedNext, next)
+		})
+	}
+}
+
+func TestAllowedWithoutWindows(t *testing.T) {
+	ok, next := Allowed(nil, time.Now())
+	assert.True(t, ok)
+	assert.True(t, next.IsZero())
+}
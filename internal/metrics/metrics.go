@@ -134,6 +134,44 @@ var (
 		[]string{"resource_type", "error_code", "error_type"},
 	)
 
+	// Retry metrics
+	discordAPIRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ProviderNamespace,
+			Name:      "discord_api_retries_total",
+			Help:      "Total number of retried Discord API requests",
+		},
+		[]string{"resource_type"},
+	)
+
+	retryBudgetExhausted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ProviderNamespace,
+			Name:      "retry_budget_exhausted_total",
+			Help:      "Total number of failed Discord API requests not retried because the retry budget was exhausted",
+		},
+		[]string{"resource_type"},
+	)
+
+	// Drift metrics
+	driftDetected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ProviderNamespace,
+			Name:      "drift_detected_total",
+			Help:      "Total number of changes made to a Discord resource outside Crossplane",
+		},
+		[]string{"kind", "guild_id"},
+	)
+
+	driftCorrected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ProviderNamespace,
+			Name:      "drift_corrected_total",
+			Help:      "Total number of updates that brought a drifted Discord resource back to its spec",
+		},
+		[]string{"kind", "guild_id"},
+	)
+
 	// Provider health metrics
 	providerHealth = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -157,6 +195,10 @@ func init() {
 		resourceReconciliations,
 		resourceReconciliationDuration,
 		discordAPIErrors,
+		discordAPIRetries,
+		retryBudgetExhausted,
+		driftDetected,
+		driftCorrected,
 		providerHealth,
 	)
 }
@@ -240,6 +282,29 @@ func (m *MetricsRecorder) RecordAPIError(resourceType, errorCode, errorType stri
 	)
 }
 
+// RecordRetry records a retried Discord API request
+func (m *MetricsRecorder) RecordRetry(resourceType string) {
+	discordAPIRetries.WithLabelValues(resourceType).Inc()
+}
+
+// RecordRetryBudgetExhausted records a failed Discord API request that was
+// not retried because the retry budget was exhausted
+func (m *MetricsRecorder) RecordRetryBudgetExhausted(resourceType string) {
+	retryBudgetExhausted.WithLabelValues(resourceType).Inc()
+}
+
+// RecordDriftDetected records an observation that found a resource of the
+// given kind drifted from its spec
+func (m *MetricsRecorder) RecordDriftDetected(kind, guildID string) {
+	driftDetected.WithLabelValues(kind, guildID).Inc()
+}
+
+// RecordDriftCorrected records an update that brought a drifted resource of
+// the given kind back to its spec
+func (m *MetricsRecorder) RecordDriftCorrected(kind, guildID string) {
+	driftCorrected.WithLabelValues(kind, guildID).Inc()
+}
+
 // SetProviderHealth sets the provider health status
 func (m *MetricsRecorder) SetProviderHealth(component string, healthy bool) {
 	value := 0.0
@@ -136,6 +136,25 @@ func TestMetricsRecorder_RecordAPIError(t *testing.T) {
 	assert.Equal(t, float64(1), testutil.ToFloat64(counter))
 }
 
+func TestMetricsRecorder_RecordDrift(t *testing.T) {
+	recorder := NewMetricsRecorder()
+
+	// Clear metrics before test
+	driftDetected.Reset()
+	driftCorrected.Reset()
+
+	recorder.RecordDriftDetected(ResourceChannel, "123")
+	recorder.RecordDriftDetected(ResourceChannel, "123")
+	recorder.RecordDriftCorrected(ResourceChannel, "123")
+
+	detected, err := driftDetected.GetMetricWithLabelValues(ResourceChannel, "123")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), testutil.ToFloat64(detected))
+	corrected, err := driftCorrected.GetMetricWithLabelValues(ResourceChannel, "123")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(corrected))
+}
+
 func TestMetricsRecorder_SetProviderHealth(t *testing.T) {
 	recorder := NewMetricsRecorder()
 
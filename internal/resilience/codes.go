@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Discord JSON error codes, as documented at
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json.
+const (
+	ErrorCodeGeneral                     = 0
+	ErrorCodeUnknownAccount              = 10001
+	ErrorCodeUnknownApplication          = 10002
+	ErrorCodeUnknownChannel              = 10003
+	ErrorCodeUnknownGuild                = 10004
+	ErrorCodeUnknownIntegration          = 10005
+	ErrorCodeUnknownInvite               = 10006
+	ErrorCodeUnknownMember               = 10007
+	ErrorCodeUnknownMessage              = 10008
+	ErrorCodeUnknownPermissionOverwrite  = 10009
+	ErrorCodeUnknownRole                 = 10011
+	ErrorCodeUnknownUser                 = 10013
+	ErrorCodeUnknownEmoji                = 10014
+	ErrorCodeUnknownWebhook              = 10015
+	ErrorCodeUnknownBan                  = 10026
+	ErrorCodeUnknownSticker              = 10060
+	ErrorCodeUnknownScheduledEvent       = 10070
+	ErrorCodeBotsCannotUseEndpoint       = 20001
+	ErrorCodeSlowmodeRateLimit           = 20016
+	ErrorCodeChannelWriteRateLimit       = 20028
+	ErrorCodeGuildWriteRateLimit         = 20029
+	ErrorCodeMaxGuildsReached            = 30001
+	ErrorCodeMaxPinsReached              = 30003
+	ErrorCodeMaxRolesReached             = 30005
+	ErrorCodeMaxWebhooksReached          = 30007
+	ErrorCodeMaxEmojisReached            = 30008
+	ErrorCodeMaxReactionsReached         = 30010
+	ErrorCodeMaxChannelsReached          = 30013
+	ErrorCodeMaxInvitesReached           = 30016
+	ErrorCodeMaxStickersReached          = 30039
+	ErrorCodeUnauthorized                = 40001
+	ErrorCodeMissingAccess               = 50001
+	ErrorCodeWidgetDisabled              = 50004
+	ErrorCodeCannotEditOtherUsersMessage = 50005
+	ErrorCodeCannotMessageUser           = 50007
+	ErrorCodeMissingPermissions          = 50013
+	ErrorCodeInvalidToken                = 50014
+	ErrorCodeInvalidChannelType          = 50024
+	ErrorCodeInvalidWebhookToken         = 50027
+	ErrorCodeInvalidRole                 = 50028
+	ErrorCodeInvalidFormBody             = 50035
+	ErrorCodeResourceOverloaded          = 130000
+)
+
+// An ErrorClass describes how the provider treats a Discord JSON error code.
+type ErrorClass struct {
+	// Name is Discord's description of the code.
+	Name string
+
+	// Type is the category of errors with the code.
+	Type ErrorType
+
+	// Retryable is true if a request that failed with the code may succeed
+	// when sent again unchanged.
+	Retryable bool
+}
+
+// errorClasses leaves out ErrorCodeGeneral, which says nothing beyond the
+// HTTP status it comes with.
+var errorClasses = map[int]ErrorClass{
+	ErrorCodeUnknownAccount:              {"Unknown account", ErrorTypeNotFound, false},
+	ErrorCodeUnknownApplication:          {"Unknown application", ErrorTypeNotFound, false},
+	ErrorCodeUnknownChannel:              {"Unknown channel", ErrorTypeNotFound, false},
+	ErrorCodeUnknownGuild:                {"Unknown guild", ErrorTypeNotFound, false},
+	ErrorCodeUnknownIntegration:          {"Unknown integration", ErrorTypeNotFound, false},
+	ErrorCodeUnknownInvite:               {"Unknown invite", ErrorTypeNotFound, false},
+	ErrorCodeUnknownMember:               {"Unknown member", ErrorTypeNotFound, false},
+	ErrorCodeUnknownMessage:              {"Unknown message", ErrorTypeNotFound, false},
+	ErrorCodeUnknownPermissionOverwrite:  {"Unknown permission overwrite", ErrorTypeNotFound, false},
+	ErrorCodeUnknownRole:                 {"Unknown role", ErrorTypeNotFound, false},
+	ErrorCodeUnknownUser:                 {"Unknown user", ErrorTypeNotFound, false},
+	ErrorCodeUnknownEmoji:                {"Unknown emoji", ErrorTypeNotFound, false},
+	ErrorCodeUnknownWebhook:              {"Unknown webhook", ErrorTypeNotFound, false},
+	ErrorCodeUnknownBan:                  {"Unknown ban", ErrorTypeNotFound, false},
+	ErrorCodeUnknownSticker:              {"Unknown sticker", ErrorTypeNotFound, false},
+	ErrorCodeUnknownScheduledEvent:       {"Unknown guild scheduled event", ErrorTypeNotFound, false},
+	ErrorCodeBotsCannotUseEndpoint:       {"Bots cannot use this endpoint", ErrorTypePermission, false},
+	ErrorCodeSlowmodeRateLimit:           {"This action cannot be performed due to slowmode rate limit", ErrorTypeRateLimit, true},
+	ErrorCodeChannelWriteRateLimit:       {"The channel you are writing has hit the write rate limit", ErrorTypeRateLimit, true},
+	ErrorCodeGuildWriteRateLimit:         {"The write action you are performing on the server has hit the write rate limit", ErrorTypeRateLimit, true},
+	ErrorCodeMaxGuildsReached:            {"Maximum number of guilds reached", ErrorTypePermanent, false},
+	ErrorCodeMaxPinsReached:              {"Maximum number of pins reached for the channel", ErrorTypePermanent, false},
+	ErrorCodeMaxRolesReached:             {"Maximum number of guild roles reached", ErrorTypePermanent, false},
+	ErrorCodeMaxWebhooksReached:          {"Maximum number of webhooks reached", ErrorTypePermanent, false},
+	ErrorCodeMaxEmojisReached:            {"Maximum number of emojis reached", ErrorTypePermanent, false},
+	ErrorCodeMaxReactionsReached:         {"Maximum number of reactions reached", ErrorTypePermanent, false},
+	ErrorCodeMaxChannelsReached:          {"Maximum number of guild channels reached", ErrorTypePermanent, false},
+	ErrorCodeMaxInvitesReached:           {"Maximum number of invites reached", ErrorTypePermanent, false},
+	ErrorCodeMaxStickersReached:          {"Maximum number of stickers reached", ErrorTypePermanent, false},
+	ErrorCodeUnauthorized:                {"Unauthorized", ErrorTypeAuthentication, false},
+	ErrorCodeMissingAccess:               {"Missing access", ErrorTypePermission, false},
+	ErrorCodeWidgetDisabled:              {"Widget disabled", ErrorTypePermanent, false},
+	ErrorCodeCannotEditOtherUsersMessage: {"Cannot edit a message authored by another user", ErrorTypePermission, false},
+	ErrorCodeCannotMessageUser:           {"Cannot send messages to this user", ErrorTypePermission, false},
+	ErrorCodeMissingPermissions:          {"Missing permissions", ErrorTypePermission, false},
+	ErrorCodeInvalidToken:                {"Invalid authentication token provided", ErrorTypeAuthentication, false},
+	ErrorCodeInvalidChannelType:          {"Cannot execute action on this channel type", ErrorTypePermanent, false},
+	ErrorCodeInvalidWebhookToken:         {"Invalid webhook token provided", ErrorTypeAuthentication, false},
+	ErrorCodeInvalidRole:                 {"Invalid role", ErrorTypePermanent, false},
+	ErrorCodeInvalidFormBody:             {"Invalid form body", ErrorTypePermanent, false},
+	ErrorCodeResourceOverloaded:          {"API resource is currently overloaded", ErrorTypeTemporary, true},
+}
+
+// ClassifyErrorCode returns how the provider treats the Discord JSON error
+// code, and false if the code is not one it knows.
+func ClassifyErrorCode(code int) (ErrorClass, bool) {
+	c, ok := errorClasses[code]
+	return c, ok
+}
+
+// A CodedError is an error that reports the HTTP status and Discord JSON
+// error code of a failed request, such as the Discord client's API errors.
+type CodedError interface {
+	error
+
+	// DiscordStatus returns the HTTP status of the response.
+	DiscordStatus() int
+
+	// DiscordCode returns the JSON error code in the response body, or 0
+	// if it had none.
+	DiscordCode() int
+}
+
+// ErrorCode returns the Discord JSON error code of the CodedError in err's
+// chain, and false if err carries none. Errors that only carry a message
+// formatted as "Discord API error: <status> - <body>" have their body parsed.
+func ErrorCode(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.DiscordCode(), coded.DiscordCode() != 0
+	}
+	msg := err.Error()
+	idx := strings.Index(msg, "Discord API error: ")
+	if idx < 0 {
+		return 0, false
+	}
+	_, body, ok := strings.Cut(msg[idx:], " - ")
+	if !ok {
+		return 0, false
+	}
+
+	// The body may be followed by details of the request that failed, so
+	// only its first JSON value is decoded.
+	var apiErr struct {
+		Code *int `json:"code"`
+	}
+	if err := json.NewDecoder(strings.NewReader(body)).Decode(&apiErr); err != nil || apiErr.Code == nil {
+		return 0, false
+	}
+	return *apiErr.Code, true
+}
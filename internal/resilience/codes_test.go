@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resilience
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifyErrorCode(t *testing.T) {
+	tests := map[int]struct {
+		errorType ErrorType
+		retryable bool
+	}{
+		ErrorCodeUnknownAccount:              {ErrorTypeNotFound, false},
+		ErrorCodeUnknownApplication:          {ErrorTypeNotFound, false},
+		ErrorCodeUnknownChannel:              {ErrorTypeNotFound, false},
+		ErrorCodeUnknownGuild:                {ErrorTypeNotFound, false},
+		ErrorCodeUnknownIntegration:          {ErrorTypeNotFound, false},
+		ErrorCodeUnknownInvite:               {ErrorTypeNotFound, false},
+		ErrorCodeUnknownMember:               {ErrorTypeNotFound, false},
+		ErrorCodeUnknownMessage:              {ErrorTypeNotFound, false},
+		ErrorCodeUnknownPermissionOverwrite:  {ErrorTypeNotFound, false},
+		ErrorCodeUnknownRole:                 {ErrorTypeNotFound, false},
+		ErrorCodeUnknownUser:                 {ErrorTypeNotFound, false},
+		ErrorCodeUnknownEmoji:                {ErrorTypeNotFound, false},
+		ErrorCodeUnknownWebhook:              {ErrorTypeNotFound, false},
+		ErrorCodeUnknownBan:                  {ErrorTypeNotFound, false},
+		ErrorCodeUnknownSticker:              {ErrorTypeNotFound, false},
+		ErrorCodeUnknownScheduledEvent:       {ErrorTypeNotFound, false},
+		ErrorCodeBotsCannotUseEndpoint:       {ErrorTypePermission, false},
+		ErrorCodeSlowmodeRateLimit:           {ErrorTypeRateLimit, true},
+		ErrorCodeChannelWriteRateLimit:       {ErrorTypeRateLimit, true},
+		ErrorCodeGuildWriteRateLimit:         {ErrorTypeRateLimit, true},
+		ErrorCodeMaxGuildsReached:            {ErrorTypePermanent, false},
+		ErrorCodeMaxPinsReached:              {ErrorTypePermanent, false},
+		ErrorCodeMaxRolesReached:             {ErrorTypePermanent, false},
+		ErrorCodeMaxWebhooksReached:          {ErrorTypePermanent, false},
+		ErrorCodeMaxEmojisReached:            {ErrorTypePermanent, false},
+		ErrorCodeMaxReactionsReached:         {ErrorTypePermanent, false},
+		ErrorCodeMaxChannelsReached:          {ErrorTypePermanent, false},
+		ErrorCodeMaxInvitesReached:           {ErrorTypePermanent, false},
+		ErrorCodeMaxStickersReached:          {ErrorTypePermanent, false},
+		ErrorCodeUnauthorized:                {ErrorTypeAuthentication, false},
+		ErrorCodeMissingAccess:               {ErrorTypePermission, false},
+		ErrorCodeWidgetDisabled:              {ErrorTypePermanent, false},
+		ErrorCodeCannotEditOtherUsersMessage: {ErrorTypePermission, false},
+		ErrorCodeCannotMessageUser:           {ErrorTypePermission, false},
+		ErrorCodeMissingPermissions:          {ErrorTypePermission, false},
+		ErrorCodeInvalidToken:                {ErrorTypeAuthentication, false},
+		ErrorCodeInvalidChannelType:          {ErrorTypePermanent, false},
+		ErrorCodeInvalidWebhookToken:         {ErrorTypeAuthentication, false},
+		ErrorCodeInvalidRole:                 {ErrorTypePermanent, false},
+		ErrorCodeInvalidFormBody:             {ErrorTypePermanent, false},
+		ErrorCodeResourceOverloaded:          {ErrorTypeTemporary, true},
+	}
+
+	// Every code in the table is covered here
+	assert.Len(t, errorClasses, len(tests))
+
+	for code, tc := range tests {
+		t.Run(fmt.Sprint(code), func(t *testing.T) {
+			class, ok := ClassifyErrorCode(code)
+			assert.True(t, ok)
+			assert.NotEmpty(t, class.Name)
+			assert.Equal(t, tc.errorType, class.Type)
+			assert.Equal(t, tc.retryable, class.Retryable)
+
+			// ParseDiscordError classifies client errors by the code alone
+			err := fmt.Errorf("failed to update guild: Discord API error: 400 - {\"message\": %q, \"code\": %d} (PATCH /guilds/1)", class.Name, code)
+			parsed := ParseDiscordError(err, "guild", "update")
+			assert.Equal(t, tc.errorType, parsed.ErrorType)
+			assert.Equal(t, tc.retryable, parsed.Retryable)
+			assert.Equal(t, tc.errorType == ErrorTypeRateLimit, parsed.RateLimited)
+		})
+	}
+
+	_, ok := ClassifyErrorCode(ErrorCodeGeneral)
+	assert.False(t, ok)
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code int
+		ok   bool
+	}{
+		"Nil":         {err: nil},
+		"NotAPIError": {err: errors.New("connection refused")},
+		"NoBody":      {err: errors.New("Discord API error: 502")},
+		"NotJSON":     {err: errors.New("Discord API error: 502 - bad gateway")},
+		"NoCode":      {err: errors.New("Discord API error: 429 - {\"message\": \"You are being rate limited.\"}")},
+		"ZeroCode":    {err: errors.New("Discord API error: 401 - {\"message\": \"401: Unauthorized\", \"code\": 0}"), code: 0, ok: true},
+		"WithRequest": {err: errors.New("Discord API error: 403 - {\"message\": \"Missing Permissions\", \"code\": 50013} (POST /guilds/1/roles)"), code: 50013, ok: true},
+		"Wrapped":     {err: fmt.Errorf("failed to get channel: %w", errors.New("Discord API error: 404 - {\"code\": 10003}")), code: 10003, ok: true},
+		"Coded":       {err: fmt.Errorf("failed to get channel: %w", codedError{status: 404, code: 10003}), code: 10003, ok: true},
+		"CodedNoCode": {err: codedError{status: 502}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, ok := ErrorCode(tc.err)
+			assert.Equal(t, tc.code, code)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+// codedError is a CodedError whose message does not carry its code.
+type codedError struct {
+	status, code int
+}
+
+func (e codedError) Error() string      { return "request failed" }
+func (e codedError) DiscordStatus() int { return e.status }
+func (e codedError) DiscordCode() int   { return e.code }
+
+func TestParseDiscordErrorCodedError(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		status    int
+		errorType ErrorType
+	}{
+		"ByCode":   {err: codedError{status: 403, code: ErrorCodeUnknownRole}, status: 403, errorType: ErrorTypeNotFound},
+		"ByStatus": {err: fmt.Errorf("failed to get role: %w", codedError{status: 429}), status: 429, errorType: ErrorTypeRateLimit},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			parsed := ParseDiscordError(tc.err, "role", "get")
+			assert.Equal(t, tc.status, parsed.StatusCode)
+			assert.Equal(t, tc.errorType, parsed.ErrorType)
+		})
+	}
+}
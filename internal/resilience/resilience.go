@@ -20,6 +20,7 @@ package resilience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/go-logr/logr"
 	"github.com/rossigee/provider-discord/internal/metrics"
@@ -28,6 +29,8 @@ import (
 	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -43,6 +46,10 @@ const (
 	DefaultRecoveryTimeout  = 60 * time.Second
 	DefaultSuccessThreshold = 3
 
+	// Retry budget defaults
+	DefaultRetryBudget       = 100
+	DefaultRetryBudgetWindow = time.Minute
+
 	// Discord-specific constants
 	DiscordRateLimitHeader  = "X-RateLimit-Remaining"
 	DiscordRateLimitReset   = "X-RateLimit-Reset-After"
@@ -69,6 +76,20 @@ func DefaultRetryConfig() *RetryConfig {
 	}
 }
 
+// Validate returns an error if the configuration cannot be used.
+func (c *RetryConfig) Validate() error {
+	if c.MaxRetries < 0 {
+		return errors.New("max retries must not be negative")
+	}
+	if c.BaseDelay <= 0 {
+		return errors.New("retry base delay must be positive")
+	}
+	if c.MaxDelay < c.BaseDelay {
+		return errors.New("retry max delay must not be less than the base delay")
+	}
+	return nil
+}
+
 // CircuitBreakerConfig defines configuration for circuit breaker
 type CircuitBreakerConfig struct {
 	FailureThreshold int
@@ -85,6 +106,20 @@ func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
 	}
 }
 
+// Validate returns an error if the configuration cannot be used.
+func (c *CircuitBreakerConfig) Validate() error {
+	if c.FailureThreshold < 1 {
+		return errors.New("circuit breaker failure threshold must be at least 1")
+	}
+	if c.RecoveryTimeout <= 0 {
+		return errors.New("circuit breaker recovery timeout must be positive")
+	}
+	if c.SuccessThreshold < 1 {
+		return errors.New("circuit breaker success threshold must be at least 1")
+	}
+	return nil
+}
+
 // ErrorType represents different categories of errors
 type ErrorType string
 
@@ -97,6 +132,8 @@ const (
 	ErrorTypeAuthentication ErrorType = "authentication"
 	ErrorTypePermission     ErrorType = "permission"
 	ErrorTypeNotFound       ErrorType = "not_found"
+	ErrorTypeUnavailable    ErrorType = "unavailable"
+	ErrorTypeRetryBudget    ErrorType = "retry_budget"
 )
 
 // DiscordError represents a Discord API error with retry information
@@ -109,11 +146,24 @@ type DiscordError struct {
 	Retryable    bool
 	ResourceType string
 	Operation    string
+
+	// Cause is the error of the call that led to this one, such as the
+	// failed response that could not be retried, if any.
+	Cause error
 }
 
 func (e *DiscordError) Error() string {
-	return fmt.Sprintf("Discord API error [%d]: %s (type: %s, retryable: %v)",
+	msg := fmt.Sprintf("Discord API error [%d]: %s (type: %s, retryable: %v)",
 		e.StatusCode, e.Message, e.ErrorType, e.Retryable)
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the error of the call that led to this one, if any.
+func (e *DiscordError) Unwrap() error {
+	return e.Cause
 }
 
 // IsRetryable returns whether the error should be retried
@@ -137,6 +187,7 @@ const (
 
 // CircuitBreaker implements the circuit breaker pattern for Discord API calls
 type CircuitBreaker struct {
+	mu              sync.Mutex
 	config          *CircuitBreakerConfig
 	state           CircuitState
 	failures        int
@@ -162,11 +213,16 @@ func NewCircuitBreaker(config *CircuitBreakerConfig, resourceType string) *Circu
 
 // Call executes a function with circuit breaker protection
 func (cb *CircuitBreaker) Call(ctx context.Context, operation string, fn func() error) error {
-	if !cb.canCall() {
+	cb.mu.Lock()
+	allowed := cb.canCall()
+	retryAfter := cb.config.RecoveryTimeout - time.Since(cb.lastFailureTime)
+	cb.mu.Unlock()
+	if !allowed {
 		return &DiscordError{
 			StatusCode:   503,
 			Message:      "Circuit breaker is open",
-			ErrorType:    ErrorTypeTemporary,
+			ErrorType:    ErrorTypeUnavailable,
+			RetryAfter:   retryAfter,
 			Retryable:    false,
 			ResourceType: cb.resourceType,
 			Operation:    operation,
@@ -174,11 +230,21 @@ func (cb *CircuitBreaker) Call(ctx context.Context, operation string, fn func()
 	}
 
 	err := fn()
+	cb.mu.Lock()
 	cb.recordResult(err)
+	cb.mu.Unlock()
 	return err
 }
 
-// canCall checks if the circuit breaker allows the call
+// IsCircuitOpen reports whether err was returned because a circuit breaker
+// was open, and so the call never reached Discord.
+func IsCircuitOpen(err error) bool {
+	var de *DiscordError
+	return errors.As(err, &de) && de.ErrorType == ErrorTypeUnavailable
+}
+
+// canCall checks if the circuit breaker allows the call. The caller must
+// hold cb.mu.
 func (cb *CircuitBreaker) canCall() bool {
 	switch cb.state {
 	case StateClosed:
@@ -221,6 +287,10 @@ func (cb *CircuitBreaker) recordFailure() {
 // recordSuccess records a success and potentially closes the circuit
 func (cb *CircuitBreaker) recordSuccess() {
 	cb.successes++
+	if cb.state == StateClosed {
+		// Only consecutive failures open the circuit.
+		cb.failures = 0
+	}
 
 	if cb.state == StateHalfOpen && cb.successes >= cb.config.SuccessThreshold {
 		cb.setState(StateClosed)
@@ -243,9 +313,64 @@ func (cb *CircuitBreaker) setState(newState CircuitState) {
 
 // GetState returns the current circuit breaker state
 func (cb *CircuitBreaker) GetState() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 	return cb.state
 }
 
+// A RetryBudget caps the retries spent in each window, so that calls which
+// keep failing are surfaced instead of being retried without end.
+type RetryBudget struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	start   time.Time
+	used    int
+	now     func() time.Time
+	metrics *metrics.MetricsRecorder
+}
+
+// NewRetryBudget returns a budget of limit retries per window. A limit of 0
+// leaves retries unlimited, though they are still counted.
+func NewRetryBudget(limit int, window time.Duration) *RetryBudget {
+	return &RetryBudget{
+		limit:   limit,
+		window:  window,
+		now:     time.Now,
+		metrics: metrics.GetMetricsRecorder(),
+	}
+}
+
+// Spend takes a retry of resourceType from the budget. It returns a
+// DiscordError for which IsRetryBudgetExhausted is true if none is left.
+func (b *RetryBudget) Spend(resourceType string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if now.Sub(b.start) >= b.window {
+		b.start, b.used = now, 0
+	}
+	if b.limit > 0 && b.used >= b.limit {
+		b.metrics.RecordRetryBudgetExhausted(resourceType)
+		return &DiscordError{
+			Message:      fmt.Sprintf("Retry budget of %d retries per %s is exhausted", b.limit, b.window),
+			ErrorType:    ErrorTypeRetryBudget,
+			RetryAfter:   b.window - now.Sub(b.start),
+			ResourceType: resourceType,
+		}
+	}
+	b.used++
+	b.metrics.RecordRetry(resourceType)
+	return nil
+}
+
+// IsRetryBudgetExhausted reports whether err was returned because a call
+// that failed could not be retried within the retry budget.
+func IsRetryBudgetExhausted(err error) bool {
+	var de *DiscordError
+	return errors.As(err, &de) && de.ErrorType == ErrorTypeRetryBudget
+}
+
 // ResilientClient wraps Discord API calls with retry logic and circuit breaking
 type ResilientClient struct {
 	retryConfig    *RetryConfig
@@ -378,47 +503,71 @@ func ParseDiscordError(err error, resourceType, operation string) *DiscordError
 		Operation:    operation,
 	}
 
-	// Parse HTTP errors if available
-	// This would typically involve checking response headers and status codes
-	// Implementation depends on the specific HTTP client being used
-
-	// Default retry logic based on error content
+	// Classify by the status code reported by the Discord client and the
+	// JSON error code in its body. Rate limits are told apart by status, as
+	// their bodies carry no specific code.
 	errorStr := err.Error()
+	status := 0
+	var coded CodedError
+	if errors.As(err, &coded) {
+		status = coded.DiscordStatus()
+	} else {
+		status = statusCodeFromError(errorStr)
+	}
+	if status > 0 {
+		discordErr.StatusCode = status
+	}
+	class, known := ErrorClass{}, false
+	if code, ok := ErrorCode(err); ok {
+		class, known = ClassifyErrorCode(code)
+	}
 
 	switch {
-	case containsAny(errorStr, []string{"rate limit", "too many requests"}):
+	case status == http.StatusTooManyRequests:
 		discordErr.ErrorType = ErrorTypeRateLimit
-		discordErr.RateLimited = true
 		discordErr.Retryable = true
-		discordErr.StatusCode = 429
-	case containsAny(errorStr, []string{"timeout", "connection", "network"}):
+	case known:
+		discordErr.ErrorType = class.Type
+		discordErr.Retryable = class.Retryable
+	case status == 0 && containsAny(errorStr, []string{"timeout", "connection", "network"}):
+		// Transport errors never reached Discord, so carry no status.
 		discordErr.ErrorType = ErrorTypeNetwork
 		discordErr.Retryable = true
 		discordErr.StatusCode = 0
-	case containsAny(errorStr, []string{"unauthorized", "invalid token"}):
+	case status == http.StatusUnauthorized:
 		discordErr.ErrorType = ErrorTypeAuthentication
-		discordErr.Retryable = false
-		discordErr.StatusCode = 401
-	case containsAny(errorStr, []string{"forbidden", "missing permissions"}):
+	case status == http.StatusForbidden:
 		discordErr.ErrorType = ErrorTypePermission
-		discordErr.Retryable = false
-		discordErr.StatusCode = 403
-	case containsAny(errorStr, []string{"not found"}):
+	case status == http.StatusNotFound:
 		discordErr.ErrorType = ErrorTypeNotFound
-		discordErr.Retryable = false
-		discordErr.StatusCode = 404
-	case containsAny(errorStr, []string{"internal server error", "bad gateway", "service unavailable"}):
+	case status >= http.StatusInternalServerError:
 		discordErr.ErrorType = ErrorTypeTemporary
 		discordErr.Retryable = true
-		discordErr.StatusCode = 500
 	default:
 		discordErr.ErrorType = ErrorTypeUnknown
 		discordErr.Retryable = true // Be conservative and retry unknown errors
 	}
+	discordErr.RateLimited = discordErr.ErrorType == ErrorTypeRateLimit
 
 	return discordErr
 }
 
+// statusCodeFromError extracts the HTTP status code from errors returned by the
+// Discord client, which are formatted as "Discord API error: <status> - <body>".
+// It returns 0 if the error does not carry a status code.
+func statusCodeFromError(errorStr string) int {
+	const prefix = "Discord API error: "
+	idx := strings.Index(errorStr, prefix)
+	if idx < 0 {
+		return 0
+	}
+	var status int
+	if _, err := fmt.Sscanf(errorStr[idx+len(prefix):], "%d", &status); err != nil {
+		return 0
+	}
+	return status
+}
+
 // ParseRateLimitHeaders extracts rate limit information from HTTP headers
 func ParseRateLimitHeaders(headers http.Header) (remaining int, resetAfter time.Duration, err error) {
 	// Parse remaining requests
@@ -43,6 +43,45 @@ func TestDefaultCircuitBreakerConfig(t *testing.T) {
 	assert.Equal(t, DefaultSuccessThreshold, config.SuccessThreshold)
 }
 
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultRetryConfig().Validate())
+	assert.NoError(t, (&RetryConfig{MaxRetries: 0, BaseDelay: time.Second, MaxDelay: time.Second}).Validate())
+	assert.Error(t, (&RetryConfig{MaxRetries: -1, BaseDelay: time.Second, MaxDelay: time.Second}).Validate())
+	assert.Error(t, (&RetryConfig{MaxRetries: 3, BaseDelay: 0, MaxDelay: time.Second}).Validate())
+	assert.Error(t, (&RetryConfig{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Millisecond}).Validate())
+
+	assert.NoError(t, DefaultCircuitBreakerConfig().Validate())
+	assert.Error(t, (&CircuitBreakerConfig{FailureThreshold: 0, RecoveryTimeout: time.Second, SuccessThreshold: 1}).Validate())
+	assert.Error(t, (&CircuitBreakerConfig{FailureThreshold: 1, RecoveryTimeout: 0, SuccessThreshold: 1}).Validate())
+	assert.Error(t, (&CircuitBreakerConfig{FailureThreshold: 1, RecoveryTimeout: time.Second, SuccessThreshold: 0}).Validate())
+}
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Now()
+	budget := NewRetryBudget(2, time.Minute)
+	budget.now = func() time.Time { return now }
+
+	assert.NoError(t, budget.Spend("roles"))
+	assert.NoError(t, budget.Spend("channels"))
+	err := budget.Spend("roles")
+	assert.True(t, IsRetryBudgetExhausted(err))
+	assert.False(t, IsCircuitOpen(err))
+
+	now = now.Add(30 * time.Second)
+	var de *DiscordError
+	assert.True(t, errors.As(budget.Spend("roles"), &de))
+	assert.Equal(t, 30*time.Second, de.RetryAfter)
+
+	// The budget is refilled once the window passes
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, budget.Spend("roles"))
+
+	unlimited := NewRetryBudget(0, time.Minute)
+	for range 10 {
+		assert.NoError(t, unlimited.Spend("roles"))
+	}
+}
+
 func TestDiscordError(t *testing.T) {
 	err := &DiscordError{
 		StatusCode:   429,
@@ -191,7 +230,7 @@ func TestResilientClient_RetryOnRetryableError(t *testing.T) {
 	err := client.Do(context.Background(), "test_op", func() error {
 		callCount++
 		if callCount < 3 {
-			return errors.New("rate limit exceeded") // Should trigger retry
+			return errors.New("Discord API error: 429 - {\"message\": \"You are being rate limited.\"}") // Should trigger retry
 		}
 		return nil
 	})
@@ -214,7 +253,7 @@ func TestResilientClient_NoRetryOnNonRetryableError(t *testing.T) {
 	callCount := 0
 	err := client.Do(context.Background(), "test_op", func() error {
 		callCount++
-		return errors.New("unauthorized") // Should not trigger retry
+		return errors.New("Discord API error: 401 - {\"message\": \"401: Unauthorized\", \"code\": 0}") // Should not trigger retry
 	})
 
 	assert.Error(t, err)
@@ -269,7 +308,7 @@ func TestParseDiscordError_GenericError(t *testing.T) {
 	}{
 		{
 			name:              "rate limit error",
-			errorMessage:      "rate limit exceeded",
+			errorMessage:      "Discord API error: 429 - {\"message\": \"You are being rate limited.\", \"retry_after\": 1.5, \"global\": false}",
 			expectedType:      ErrorTypeRateLimit,
 			expectedRetryable: true,
 			expectedStatus:    429,
@@ -283,30 +322,72 @@ func TestParseDiscordError_GenericError(t *testing.T) {
 		},
 		{
 			name:              "unauthorized error",
-			errorMessage:      "unauthorized access",
+			errorMessage:      "Discord API error: 401 - {\"message\": \"401: Unauthorized\", \"code\": 0}",
 			expectedType:      ErrorTypeAuthentication,
 			expectedRetryable: false,
 			expectedStatus:    401,
 		},
 		{
 			name:              "forbidden error",
-			errorMessage:      "forbidden operation",
+			errorMessage:      "Discord API error: 403 - forbidden",
 			expectedType:      ErrorTypePermission,
 			expectedRetryable: false,
 			expectedStatus:    403,
 		},
 		{
 			name:              "not found error",
-			errorMessage:      "resource not found",
+			errorMessage:      "Discord API error: 404 - {\"message\": \"404: Not Found\", \"code\": 0}",
 			expectedType:      ErrorTypeNotFound,
 			expectedRetryable: false,
 			expectedStatus:    404,
 		},
 		{
 			name:              "server error",
-			errorMessage:      "internal server error",
+			errorMessage:      "Discord API error: 500 - internal server error",
+			expectedType:      ErrorTypeTemporary,
+			expectedRetryable: true,
+			expectedStatus:    500,
+		},
+		{
+			name:              "client rate limit status",
+			errorMessage:      "failed to get channel: Discord API error: 429 - {\"message\": \"You are being blocked.\"}",
+			expectedType:      ErrorTypeRateLimit,
+			expectedRetryable: true,
+			expectedStatus:    429,
+		},
+		{
+			name:              "client missing permissions status",
+			errorMessage:      "failed to create channel: Discord API error: 403 - {\"message\": \"Missing Permissions\", \"code\": 50013}",
+			expectedType:      ErrorTypePermission,
+			expectedRetryable: false,
+			expectedStatus:    403,
+		},
+		{
+			name:              "client gateway status",
+			errorMessage:      "Discord API error: 502 - upstream connection reset",
 			expectedType:      ErrorTypeTemporary,
 			expectedRetryable: true,
+			expectedStatus:    502,
+		},
+		{
+			name:              "client error code",
+			errorMessage:      "Discord API error: 400 - {\"message\": \"Maximum number of guild channels reached (500)\", \"code\": 30013} (POST /guilds/1/channels)",
+			expectedType:      ErrorTypePermanent,
+			expectedRetryable: false,
+			expectedStatus:    400,
+		},
+		{
+			name:              "error code over status",
+			errorMessage:      "Discord API error: 404 - {\"message\": \"Unknown Channel\", \"code\": 10003}",
+			expectedType:      ErrorTypeNotFound,
+			expectedRetryable: false,
+			expectedStatus:    404,
+		},
+		{
+			name:              "message wording is ignored",
+			errorMessage:      "role not found",
+			expectedType:      ErrorTypeUnknown,
+			expectedRetryable: true,
 			expectedStatus:    500,
 		},
 		{
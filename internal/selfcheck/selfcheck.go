@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selfcheck verifies at startup that the provider's CRDs are
+// installed and that its RBAC allows what its controllers do, so a broken
+// install fails with an actionable message instead of cryptic reconcile
+// errors.
+package selfcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// groupSuffix identifies the provider's API groups.
+	groupSuffix = "discord.crossplane.io"
+
+	// providerConfigGroup holds the ProviderConfig kinds every controller
+	// needs, whichever kinds are enabled.
+	providerConfigGroup = "discord.crossplane.io"
+)
+
+// managedVerbs are the verbs the controllers use on their resources.
+var managedVerbs = []string{"get", "list", "watch", "update", "patch"}
+
+// coreAccess is the access to core resources the provider needs: Secrets
+// hold Discord tokens and events report reconcile results.
+var coreAccess = []authorizationv1.ResourceAttributes{
+	{Resource: "secrets", Verb: "get"},
+	{Resource: "secrets", Verb: "list"},
+	{Resource: "secrets", Verb: "watch"},
+	{Resource: "events", Verb: "create"},
+}
+
+// An Error lists every problem the self-check found.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("provider self-check found %d problem(s): %s", len(e.Problems), strings.Join(e.Problems, "; "))
+}
+
+// A Checker checks the CRDs and RBAC of the provider.
+type Checker struct {
+	discovery discovery.ServerResourcesInterface
+	kube      client.Client
+	scheme    *runtime.Scheme
+	enabled   func(kind string) bool
+}
+
+// New returns a Checker that checks the provider kinds registered in scheme
+// for which enabled returns true, given the lower case kind name. It looks
+// CRDs up through disc and reviews access by creating
+// SelfSubjectAccessReviews with kube.
+func New(disc discovery.ServerResourcesInterface, kube client.Client, scheme *runtime.Scheme, enabled func(kind string) bool) *Checker {
+	return &Checker{discovery: disc, kube: kube, scheme: scheme, enabled: enabled}
+}
+
+// Run returns an *Error listing every missing CRD and missing permission,
+// or another error if the checks themselves could not be made.
+func (c *Checker) Run(ctx context.Context) error {
+	var problems []string
+
+	byGroupVersion := map[schema.GroupVersion][]string{}
+	for _, gvk := range c.kinds() {
+		byGroupVersion[gvk.GroupVersion()] = append(byGroupVersion[gvk.GroupVersion()], gvk.Kind)
+	}
+	groupVersions := make([]schema.GroupVersion, 0, len(byGroupVersion))
+	for gv := range byGroupVersion {
+		groupVersions = append(groupVersions, gv)
+	}
+	sort.Slice(groupVersions, func(i, j int) bool { return groupVersions[i].String() < groupVersions[j].String() })
+
+	for _, gv := range groupVersions {
+		served, err := c.discovery.ServerResourcesForGroupVersion(gv.String())
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "cannot discover resources of %s", gv)
+		}
+		resources := map[string]metav1.APIResource{}
+		subresources := map[string]bool{}
+		if served != nil {
+			for _, r := range served.APIResources {
+				if strings.Contains(r.Name, "/") {
+					subresources[r.Name] = true
+					continue
+				}
+				resources[r.Kind] = r
+			}
+		}
+
+		for _, kind := range byGroupVersion[gv] {
+			r, ok := resources[kind]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("the CRD for %s %s is not installed; install the provider package or apply its CRDs", kind, gv))
+				continue
+			}
+			denied, err := c.denied(ctx, gv.Group, r.Name, "", managedVerbs)
+			if err != nil {
+				return err
+			}
+			if subresources[r.Name+"/status"] {
+				statusDenied, err := c.denied(ctx, gv.Group, r.Name, "status", []string{"update", "patch"})
+				if err != nil {
+					return err
+				}
+				denied = append(denied, statusDenied...)
+			}
+			if len(denied) > 0 {
+				problems = append(problems, fmt.Sprintf("the provider's service account cannot %s; grant it in the provider's ClusterRole", strings.Join(denied, ", ")))
+			}
+		}
+	}
+
+	var denied []string
+	for _, attrs := range coreAccess {
+		allowed, err := c.allowed(ctx, attrs)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			denied = append(denied, attrs.Verb+" "+attrs.Resource)
+		}
+	}
+	if len(denied) > 0 {
+		problems = append(problems, fmt.Sprintf("the provider's service account cannot %s; grant it in the provider's ClusterRole", strings.Join(denied, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return &Error{Problems: problems}
+	}
+	return nil
+}
+
+// kinds returns the provider kinds to check, excluding list kinds.
+func (c *Checker) kinds() []schema.GroupVersionKind {
+	known := c.scheme.AllKnownTypes()
+	var kinds []schema.GroupVersionKind
+	for gvk := range known {
+		if !strings.HasSuffix(gvk.Group, groupSuffix) {
+			continue
+		}
+		if item := strings.TrimSuffix(gvk.Kind, "List"); item != gvk.Kind {
+			if _, ok := known[gvk.GroupVersion().WithKind(item)]; ok {
+				continue
+			}
+		}
+		if gvk.Group != providerConfigGroup && !c.enabled(strings.ToLower(gvk.Kind)) {
+			continue
+		}
+		kinds = append(kinds, gvk)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].Kind < kinds[j].Kind })
+	return kinds
+}
+
+// denied returns "<verb> <resource>" for each verb the provider may not use
+// on the resource.
+func (c *Checker) denied(ctx context.Context, group, resource, subresource string, verbs []string) ([]string, error) {
+	name := resource + "." + group
+	if subresource != "" {
+		name = resource + "/" + subresource + "." + group
+	}
+	var denied []string
+	for _, verb := range verbs {
+		allowed, err := c.allowed(ctx, authorizationv1.ResourceAttributes{Group: group, Resource: resource, Subresource: subresource, Verb: verb})
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			denied = append(denied, verb+" "+name)
+		}
+	}
+	return denied, nil
+}
+
+func (c *Checker) allowed(ctx context.Context, attrs authorizationv1.ResourceAttributes) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attrs},
+	}
+	if err := c.kube.Create(ctx, review); err != nil {
+		return false, errors.Wrap(err, "cannot review the provider's access")
+	}
+	return review.Status.Allowed, nil
+}
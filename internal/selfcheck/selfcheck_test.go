@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/rossigee/provider-discord/apis"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s))
+	require.NoError(t, authorizationv1.AddToScheme(s))
+	return s
+}
+
+// newDiscovery serves only the given resources, keyed by group version.
+func newDiscovery(resources map[string][]metav1.APIResource) *fakediscovery.FakeDiscovery {
+	d := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	for gv, r := range resources {
+		d.Resources = append(d.Resources, &metav1.APIResourceList{GroupVersion: gv, APIResources: r})
+	}
+	return d
+}
+
+// newKube answers SelfSubjectAccessReviews with allow.
+func newKube(s *runtime.Scheme, allow func(authorizationv1.ResourceAttributes) bool) client.Client {
+	return fake.NewClientBuilder().WithScheme(s).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SelfSubjectAccessReview)
+			if !ok {
+				return errors.New("unexpected create")
+			}
+			review.Status.Allowed = allow(*review.Spec.ResourceAttributes)
+			return nil
+		},
+	}).Build()
+}
+
+func onlyChannels(kind string) bool { return kind == "channel" }
+
+var installed = map[string][]metav1.APIResource{
+	"discord.crossplane.io/v1alpha1": {
+		{Name: "providerconfigs", Kind: "ProviderConfig"},
+		{Name: "providerconfigs/status", Kind: "ProviderConfig"},
+		{Name: "providerconfigusages", Kind: "ProviderConfigUsage"},
+	},
+	"channel.discord.crossplane.io/v1alpha1": {
+		{Name: "channels", Kind: "Channel"},
+		{Name: "channels/status", Kind: "Channel"},
+	},
+}
+
+func TestRun(t *testing.T) {
+	s := newScheme(t)
+
+	cases := map[string]struct {
+		resources map[string][]metav1.APIResource
+		allow     func(authorizationv1.ResourceAttributes) bool
+		want      []string
+	}{
+		"Healthy": {
+			resources: installed,
+			allow:     func(authorizationv1.ResourceAttributes) bool { return true },
+		},
+		"MissingCRD": {
+			resources: map[string][]metav1.APIResource{
+				"discord.crossplane.io/v1alpha1": installed["discord.crossplane.io/v1alpha1"],
+			},
+			allow: func(authorizationv1.ResourceAttributes) bool { return true },
+			want: []string{
+				"the CRD for Channel channel.discord.crossplane.io/v1alpha1 is not installed; install the provider package or apply its CRDs",
+			},
+		},
+		"CannotReadSecrets": {
+			resources: installed,
+			allow:     func(a authorizationv1.ResourceAttributes) bool { return a.Resource != "secrets" },
+			want: []string{
+				"the provider's service account cannot get secrets, list secrets, watch secrets; grant it in the provider's ClusterRole",
+			},
+		},
+		"CannotUpdateStatus": {
+			resources: installed,
+			allow: func(a authorizationv1.ResourceAttributes) bool {
+				return !(a.Resource == "channels" && a.Subresource == "status")
+			},
+			want: []string{
+				"the provider's service account cannot update channels/status.channel.discord.crossplane.io, patch channels/status.channel.discord.crossplane.io; grant it in the provider's ClusterRole",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := New(newDiscovery(tc.resources), newKube(s, tc.allow), s, onlyChannels).Run(context.Background())
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var checkErr *Error
+			require.ErrorAs(t, err, &checkErr)
+			assert.Equal(t, tc.want, checkErr.Problems)
+		})
+	}
+}
+
+func TestKinds(t *testing.T) {
+	c := New(nil, nil, newScheme(t), onlyChannels)
+
+	var kinds []string
+	for _, gvk := range c.kinds() {
+		kinds = append(kinds, gvk.Kind)
+	}
+
+	assert.Contains(t, kinds, "Channel")
+	assert.Contains(t, kinds, "ProviderConfig")
+	assert.NotContains(t, kinds, "ChannelList")
+	assert.NotContains(t, kinds, "Role")
+}
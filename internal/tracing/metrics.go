@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	promexporter "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// defaultMetricExportInterval is how often metrics are pushed unless
+// OTEL_METRIC_EXPORT_INTERVAL, in milliseconds, says otherwise.
+const defaultMetricExportInterval = 60 * time.Second
+
+// InitMetrics pushes the metrics in gatherer to the OTLP endpoint when
+// OTEL_METRICS_ENABLED is true, so environments standardized on
+// OpenTelemetry need not scrape the Prometheus endpoint. Passing the
+// controller-runtime registry exports the Discord API metrics together with
+// controller-runtime's reconcile and workqueue metrics. The Prometheus
+// endpoint keeps serving the same metrics.
+func InitMetrics(serviceName string, gatherer prometheus.Gatherer) (func(context.Context), error) {
+	enabled, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "false"))
+	if !enabled {
+		return func(context.Context) {}, nil
+	}
+
+	interval := defaultMetricExportInterval
+	if ms, err := strconv.Atoi(getEnv("OTEL_METRIC_EXPORT_INTERVAL", "")); err == nil && ms > 0 {
+		interval = time.Duration(ms) * time.Millisecond
+	}
+
+	ctx := context.Background()
+
+	res, err := newResource(ctx, serviceName)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot describe OpenTelemetry resource")
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, metricExporterOptions()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create OTLP metric exporter")
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter,
+			sdkmetric.WithInterval(interval),
+			sdkmetric.WithProducer(promexporter.NewMetricProducer(promexporter.WithGatherer(gatherer))),
+		)),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(mp)
+
+	return func(ctx context.Context) {
+		_ = mp.Shutdown(ctx)
+	}, nil
+}
+
+// metricExporterOptions returns the options the OTLP exporter needs beyond
+// what it reads from the standard OTEL_EXPORTER_OTLP_* variables itself. An
+// endpoint URL is left to the exporter, which connects insecurely for
+// http:// and otherwise honours OTEL_EXPORTER_OTLP_INSECURE and
+// OTEL_EXPORTER_OTLP_CERTIFICATE. A bare host:port, including the
+// localhost:4317 default, is passed explicitly and connects insecurely
+// unless OTEL_EXPORTER_OTLP_INSECURE is false.
+func metricExporterOptions() []otlpmetricgrpc.Option {
+	endpoint, insecure, ok := metricEndpoint()
+	if !ok {
+		return nil
+	}
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	return opts
+}
+
+// metricEndpoint returns the host:port metrics are pushed to and whether to
+// connect without TLS. It returns false when the endpoint is a URL the
+// exporter reads itself.
+func metricEndpoint() (endpoint string, insecure bool, ok bool) {
+	endpoint = getEnv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"))
+	if strings.Contains(endpoint, "://") {
+		return "", false, false
+	}
+	insecure = true
+	if v, err := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_INSECURE")); err == nil {
+		insecure = v
+	}
+	return endpoint, insecure, true
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricEndpoint(t *testing.T) {
+	tests := []struct {
+		name             string
+		env              map[string]string
+		expectedEndpoint string
+		expectedInsecure bool
+		expectedOK       bool
+	}{
+		{
+			name:             "default",
+			expectedEndpoint: "localhost:4317",
+			expectedInsecure: true,
+			expectedOK:       true,
+		},
+		{
+			name:             "host and port",
+			env:              map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "collector:4317"},
+			expectedEndpoint: "collector:4317",
+			expectedInsecure: true,
+			expectedOK:       true,
+		},
+		{
+			name: "TLS",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "collector:4317",
+				"OTEL_EXPORTER_OTLP_INSECURE": "false",
+			},
+			expectedEndpoint: "collector:4317",
+			expectedOK:       true,
+		},
+		{
+			name: "metrics endpoint takes precedence",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT":         "collector:4317",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "metrics:4317",
+			},
+			expectedEndpoint: "metrics:4317",
+			expectedInsecure: true,
+			expectedOK:       true,
+		},
+		{
+			name: "URL left to the exporter",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector:4317"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "OTEL_EXPORTER_OTLP_INSECURE"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			endpoint, insecure, ok := metricEndpoint()
+			assert.Equal(t, tc.expectedEndpoint, endpoint)
+			assert.Equal(t, tc.expectedInsecure, insecure)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestInitMetrics(t *testing.T) {
+	t.Setenv("OTEL_METRICS_ENABLED", "true")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "collector:4317")
+
+	shutdown, err := InitMetrics("provider-discord", prometheus.NewRegistry())
+	require.NoError(t, err)
+	shutdown(context.Background())
+}
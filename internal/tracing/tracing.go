@@ -11,6 +11,7 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
+	"net/http"
 	"os"
 	"strconv"
 )
@@ -22,7 +23,9 @@ const (
 	operationAttr    = "crossplane.operation"
 )
 
-var tracer trace.Tracer
+// tracer delegates to the global provider, so spans started before Init are
+// no-ops rather than panics.
+var tracer = otel.Tracer(tracerName)
 var tp *sdktrace.TracerProvider
 
 func Init(serviceName string) func(context.Context) {
@@ -41,12 +44,7 @@ func Init(serviceName string) func(context.Context) {
 
 	ctx := context.Background()
 
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(getEnv("OTEL_SERVICE_NAME", serviceName)),
-			attribute.String("provider.type", "crossplane"),
-		),
-	)
+	res, err := newResource(ctx, serviceName)
 	if err != nil {
 		return func(context.Context) {}
 	}
@@ -104,6 +102,33 @@ func SpanAttrs(resourceType, resourceName, operation string) []attribute.KeyValu
 	}
 }
 
+// Inject writes the trace context of ctx to header as a W3C traceparent
+// header. Nothing is written unless tracing is enabled.
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// TraceID returns the ID of the trace ctx's span belongs to, or an empty
+// string if the span is not sampled and so cannot be looked up.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// newResource describes the provider to OTLP backends, for both traces and
+// metrics.
+func newResource(ctx context.Context, serviceName string) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(getEnv("OTEL_SERVICE_NAME", serviceName)),
+			attribute.String("provider.type", "crossplane"),
+		),
+	)
+}
+
 func getEnv(key, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
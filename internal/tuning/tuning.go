@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tuning holds the provider-wide settings that trade reconcile
+// pressure against the Discord API budget.
+package tuning
+
+import (
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Default backoff bounds, matching crossplane-runtime's controller rate
+// limiter.
+const (
+	DefaultBackoffBase = 1 * time.Second
+	DefaultBackoffMax  = 60 * time.Second
+)
+
+// DefaultPollJitterFraction is the default share of the poll interval by
+// which each resource's polls are randomly brought forward or pushed back.
+const DefaultPollJitterFraction = 0.1
+
+// Options tune how many resources the controllers reconcile at once, how
+// their polls are spread out and how they retry failed reconciles.
+type Options struct {
+	// Concurrency overrides the maximum number of concurrent reconciles for
+	// individual kinds, keyed by lower case kind name. Kinds without an
+	// entry, or with a non-positive one, use the provider-wide setting.
+	Concurrency map[string]int
+
+	// PollJitterFraction is the share of the poll interval by which each
+	// resource's polls are randomly brought forward or pushed back, so that
+	// resources created together do not all poll Discord at the same moment.
+	PollJitterFraction float64
+
+	// BackoffBase is the delay before the first retry of a failed
+	// reconcile. It doubles with each consecutive failure.
+	BackoffBase time.Duration
+
+	// BackoffMax caps the delay between retries of a failing resource.
+	BackoffMax time.Duration
+
+	// Kinds limits the controllers that are set up to these lower case kind
+	// names. All controllers are set up when it is empty. Running replica
+	// sets with disjoint kinds and different leader election IDs spreads
+	// leadership of the kinds across them.
+	Kinds []string
+}
+
+// Validate returns an error if the options cannot be used.
+func (t Options) Validate() error {
+	if t.PollJitterFraction < 0 || t.PollJitterFraction >= 1 {
+		return errors.New("poll jitter fraction must be at least 0 and less than 1")
+	}
+	if t.BackoffBase <= 0 {
+		return errors.New("backoff base must be positive")
+	}
+	if t.BackoffMax < t.BackoffBase {
+		return errors.New("backoff max must not be less than backoff base")
+	}
+	return nil
+}
+
+// Default returns the options used when none are configured.
+func Default() Options {
+	return Options{
+		PollJitterFraction: DefaultPollJitterFraction,
+		BackoffBase:        DefaultBackoffBase,
+		BackoffMax:         DefaultBackoffMax,
+	}
+}
+
+// ForKind returns o with the maximum number of concurrent reconciles
+// configured for kind, if any.
+func (t Options) ForKind(kind string, o controller.Options) controller.Options {
+	if n := t.Concurrency[kind]; n > 0 {
+		o.MaxConcurrentReconciles = n
+	}
+	return o
+}
+
+// Enabled reports whether the controller for kind should be set up.
+func (t Options) Enabled(kind string) bool {
+	if len(t.Kinds) == 0 {
+		return true
+	}
+	for _, k := range t.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// PollJitter returns the maximum jitter to apply to o's poll interval.
+func (t Options) PollJitter(o controller.Options) time.Duration {
+	return time.Duration(t.PollJitterFraction * float64(o.PollInterval))
+}
+
+// Overall retry rate of a controller, matching the bucket of
+// controller-runtime's default rate limiter. Crossplane-runtime's own limiter
+// combines the same bucket with its exponential backoff.
+const (
+	retryQPS   = 10
+	retryBurst = 100
+)
+
+// ForControllerRuntime returns the controller-runtime options for the
+// controller of gk, like o.ForControllerRuntime but with the configured
+// backoff. Retries of a single resource back off exponentially, while retries
+// of all resources together are held to an overall rate.
+func (t Options) ForControllerRuntime(gk schema.GroupKind, o controller.Options) crcontroller.Options {
+	opts := o.ForControllerRuntime()
+	opts.RateLimiter = &deferringRateLimiter{
+		TypedRateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](t.BackoffBase, t.BackoffMax),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(retryQPS), retryBurst)},
+		),
+		kind:     gk,
+		deferred: deferred,
+	}
+	return opts
+}
+
+// deferrals records resources whose retries should wait longer than their
+// backoff, because the Discord API they depend on is known to be down.
+type deferrals struct {
+	mu    sync.Mutex
+	until map[deferral]time.Time
+}
+
+// deferral identifies a resource by kind and name, since resources of
+// different kinds may share a name.
+type deferral struct {
+	kind schema.GroupKind
+	name types.NamespacedName
+}
+
+var deferred = &deferrals{until: map[deferral]time.Time{}}
+
+// DeferRetry holds back retries of the named resource of kind gk until at
+// least until, so that resources waiting on an unavailable Discord API do
+// not retry, and log, every few seconds.
+func DeferRetry(gk schema.GroupKind, name types.NamespacedName, until time.Time) {
+	deferred.mu.Lock()
+	defer deferred.mu.Unlock()
+	k := deferral{kind: gk, name: name}
+	if until.After(deferred.until[k]) {
+		deferred.until[k] = until
+	}
+}
+
+// remaining returns how long retries of the named resource of kind gk are
+// still deferred for.
+func (d *deferrals) remaining(gk schema.GroupKind, name types.NamespacedName) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	k := deferral{kind: gk, name: name}
+	until, ok := d.until[k]
+	if !ok {
+		return 0
+	}
+	left := time.Until(until)
+	if left <= 0 {
+		delete(d.until, k)
+		return 0
+	}
+	return left
+}
+
+// deferringRateLimiter waits for the longer of the wrapped rate limiter's
+// backoff and any deferral of the request's resource, which is of kind.
+type deferringRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+	kind     schema.GroupKind
+	deferred *deferrals
+}
+
+func (r *deferringRateLimiter) When(item reconcile.Request) time.Duration {
+	d := r.TypedRateLimiter.When(item)
+	if left := r.deferred.remaining(r.kind, item.NamespacedName); left > d {
+		return left
+	}
+	return d
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuning
+
+import (
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+	"time"
+)
+
+var testKind = schema.GroupKind{Group: "discord.crossplane.io", Kind: "Channel"}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     Options
+		expectError bool
+	}{
+		{name: "defaults", options: Options{BackoffBase: DefaultBackoffBase, BackoffMax: DefaultBackoffMax}},
+		{name: "zero base", options: Options{BackoffMax: time.Minute}, expectError: true},
+		{name: "max below base", options: Options{BackoffBase: time.Minute, BackoffMax: time.Second}, expectError: true},
+		{name: "jitter fraction too large", options: Options{PollJitterFraction: 1, BackoffBase: DefaultBackoffBase, BackoffMax: DefaultBackoffMax}, expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.options.Validate()
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestForControllerRuntime(t *testing.T) {
+	opts := Options{BackoffBase: 2 * time.Second, BackoffMax: 5 * time.Second}.ForControllerRuntime(testKind, controller.Options{MaxConcurrentReconciles: 7})
+	assert.Equal(t, 7, opts.MaxConcurrentReconciles)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}}
+	assert.Equal(t, 2*time.Second, opts.RateLimiter.When(req))
+	assert.Equal(t, 4*time.Second, opts.RateLimiter.When(req))
+	assert.Equal(t, 5*time.Second, opts.RateLimiter.When(req))
+}
+
+func TestForControllerRuntimeLimitsOverallRate(t *testing.T) {
+	opts := Options{BackoffBase: time.Millisecond, BackoffMax: time.Millisecond}.ForControllerRuntime(testKind, controller.Options{})
+
+	// Each resource's first retry only waits for its backoff until the
+	// burst is spent, after which retries are spread out.
+	var last time.Duration
+	for i := 0; i <= retryBurst; i++ {
+		last = opts.RateLimiter.When(reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("resource-%d", i)}})
+	}
+	assert.Greater(t, last, 10*time.Millisecond)
+}
+
+func TestDeferRetry(t *testing.T) {
+	opts := Options{BackoffBase: 2 * time.Second, BackoffMax: 5 * time.Second}.ForControllerRuntime(testKind, controller.Options{})
+	name := types.NamespacedName{Namespace: "default", Name: "deferred"}
+	req := reconcile.Request{NamespacedName: name}
+
+	DeferRetry(testKind, name, time.Now().Add(time.Minute))
+	// An earlier deferral does not shorten the existing one.
+	DeferRetry(testKind, name, time.Now().Add(time.Second))
+
+	d := opts.RateLimiter.When(req)
+	assert.Greater(t, d, 50*time.Second)
+	assert.LessOrEqual(t, d, time.Minute)
+
+	// Other resources keep their normal backoff.
+	assert.Equal(t, 2*time.Second, opts.RateLimiter.When(reconcile.Request{NamespacedName: types.NamespacedName{Name: "other"}}))
+
+	// Resources of other kinds with the same name keep their normal backoff.
+	other := Options{BackoffBase: 2 * time.Second, BackoffMax: 5 * time.Second}.ForControllerRuntime(schema.GroupKind{Group: "discord.crossplane.io", Kind: "Role"}, controller.Options{})
+	assert.Equal(t, 2*time.Second, other.RateLimiter.When(req))
+
+	// Expired deferrals fall back to the backoff.
+	DeferRetry(testKind, types.NamespacedName{Name: "expired"}, time.Now().Add(-time.Second))
+	assert.Equal(t, 2*time.Second, opts.RateLimiter.When(reconcile.Request{NamespacedName: types.NamespacedName{Name: "expired"}}))
+}
+
+func TestForKind(t *testing.T) {
+	tuning := Options{Concurrency: map[string]int{"channel": 20, "guild": 1, "role": 0}}
+
+	o := controller.Options{MaxConcurrentReconciles: 10}
+
+	assert.Equal(t, 20, tuning.ForKind("channel", o).MaxConcurrentReconciles)
+	assert.Equal(t, 1, tuning.ForKind("guild", o).MaxConcurrentReconciles)
+	assert.Equal(t, 10, tuning.ForKind("role", o).MaxConcurrentReconciles)
+	assert.Equal(t, 10, tuning.ForKind("webhook", o).MaxConcurrentReconciles)
+	assert.Equal(t, 10, o.MaxConcurrentReconciles)
+}
+
+func TestPollJitter(t *testing.T) {
+	o := controller.Options{PollInterval: time.Minute}
+
+	assert.Equal(t, 6*time.Second, Default().PollJitter(o))
+	assert.Equal(t, time.Duration(0), Options{}.PollJitter(o))
+}
+
+func TestEnabled(t *testing.T) {
+	assert.True(t, Options{}.Enabled("channel"))
+
+	tuning := Options{Kinds: []string{"guild", "auditlogexport"}}
+	assert.True(t, tuning.Enabled("guild"))
+	assert.False(t, tuning.Enabled("channel"))
+}
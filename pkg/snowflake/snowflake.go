@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package snowflake works with Discord snowflake IDs: 64-bit integers,
+// serialized as decimal strings, whose high 42 bits are the milliseconds since
+// the Discord epoch at which the object was created.
+package snowflake
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Epoch is the Discord epoch, the first second of 2015, in milliseconds
+// since the Unix epoch.
+const Epoch int64 = 1420070400000
+
+// timestampShift is the number of low bits holding the worker, process and
+// increment fields below the timestamp.
+const timestampShift = 22
+
+// MaxClockSkew is how far in the future an ID's timestamp may be before it
+// is considered invalid.
+const MaxClockSkew = time.Hour
+
+// Discord serializes snowflakes as decimal strings of 17 to 20 digits.
+const (
+	minDigits = 17
+	maxDigits = 20
+)
+
+// An ID is a Discord snowflake.
+type ID uint64
+
+// Parse parses and validates a snowflake. It returns an error unless s is a
+// 17 to 20 digit decimal number whose timestamp is not in the future.
+func Parse(s string) (ID, error) {
+	if len(s) < minDigits || len(s) > maxDigits {
+		return 0, errors.Errorf("snowflake %q must be %d to %d digits", s, minDigits, maxDigits)
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("snowflake %q is not a 64-bit unsigned decimal number", s)
+	}
+	id := ID(v)
+	if id.Time().After(time.Now().Add(MaxClockSkew)) {
+		return 0, errors.Errorf("snowflake %q has a creation time in the future", s)
+	}
+	return id, nil
+}
+
+// Valid reports whether s is a valid snowflake.
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// CreatedAt returns the creation time encoded in the snowflake s.
+func CreatedAt(s string) (time.Time, error) {
+	id, err := Parse(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return id.Time(), nil
+}
+
+// Time returns the time the object identified by id was created, in UTC.
+func (id ID) Time() time.Time {
+	return time.UnixMilli(int64(id>>timestampShift) + Epoch).UTC()
+}
+
+// String returns the decimal form of id used by the Discord API.
+func (id ID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// New returns the snowflake created at t with the supplied increment, which
+// is truncated to its 12 bits. IDs created at later times, or at the same
+// time with a higher increment, sort after earlier ones.
+func New(t time.Time, increment uint16) ID {
+	ms := t.UnixMilli() - Epoch
+	if ms < 0 {
+		ms = 0
+	}
+	return ID(uint64(ms)<<timestampShift | uint64(increment&0xfff))
+}
+
+// A Generator creates unique, increasing snowflakes, for example to give
+// test fixtures realistic IDs. It is safe for concurrent use.
+type Generator struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	last      int64
+	increment uint16
+}
+
+// NewGenerator returns a Generator that stamps IDs with the time returned by
+// now, or the current time if now is nil.
+func NewGenerator(now func() time.Time) *Generator {
+	if now == nil {
+		now = time.Now
+	}
+	return &Generator{now: now}
+}
+
+// Next returns a snowflake greater than every one previously returned by g.
+func (g *Generator) Next() ID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := g.now().UnixMilli()
+	switch {
+	case ms > g.last:
+		g.last, g.increment = ms, 0
+	case g.increment == 0xfff:
+		// The increment is exhausted for this millisecond; borrow the next.
+		g.last, g.increment = g.last+1, 0
+	default:
+		g.increment++
+	}
+	return New(time.UnixMilli(g.last), g.increment)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package snowflake
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	future := New(time.Now().Add(24*time.Hour), 0).String()
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "guild", id: "175928847299117063"},
+		{name: "nineteen digits", id: "1234567890123456789"},
+		{name: "too short", id: "1234567890", wantErr: true},
+		{name: "not a number", id: "17592884729911706x", wantErr: true},
+		{name: "overflows", id: "99999999999999999999", wantErr: true},
+		{name: "resource name", id: "general-channel", wantErr: true},
+		{name: "in the future", id: future, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.id)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.False(t, Valid(tc.id))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, Valid(tc.id))
+		})
+	}
+}
+
+func TestCreatedAt(t *testing.T) {
+	// The example from the Discord API reference.
+	at, err := CreatedAt("175928847299117063")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC), at)
+
+	_, err = CreatedAt("nope")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	id := New(at, 7)
+
+	assert.Equal(t, at, id.Time())
+	assert.True(t, Valid(id.String()))
+	assert.Less(t, id, New(at, 8))
+	assert.Less(t, New(at, 0xfff), New(at.Add(time.Millisecond), 0))
+}
+
+func TestGenerator(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	g := NewGenerator(func() time.Time { return at })
+
+	var prev ID
+	for i := 0; i < 5000; i++ {
+		id := g.Next()
+		require.Greater(t, id, prev)
+		prev = id
+	}
+	assert.Equal(t, at.Add(time.Millisecond), prev.Time())
+}
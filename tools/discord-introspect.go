@@ -10,6 +10,8 @@ import (
 	"os"
 	"sort"
 	"strings"
+
+	"github.com/rossigee/provider-discord/pkg/snowflake"
 )
 
 type Guild struct {
@@ -95,6 +97,12 @@ func main() {
 		log.Fatal("DISCORD_BOT_TOKEN environment variable not set")
 	}
 
+	if *guildFlag != "" {
+		if _, err := snowflake.Parse(*guildFlag); err != nil {
+			log.Fatalf("Invalid -guild: %v", err)
+		}
+	}
+
 	// Get all guilds the bot is a member of
 	guilds := getGuilds(token)
 	fmt.Printf("Found %d guilds\n", len(guilds))
@@ -118,7 +126,11 @@ func main() {
 	os.MkdirAll(*outputDir, 0755)
 
 	for _, guild := range guilds {
-		fmt.Printf("Processing guild: %s (%s)\n", guild.Name, guild.ID)
+		created := "unknown"
+		if at, err := snowflake.CreatedAt(guild.ID); err == nil {
+			created = at.Format("2006-01-02")
+		}
+		fmt.Printf("Processing guild: %s (%s, created %s)\n", guild.Name, guild.ID, created)
 
 		// Generate Guild CR
 		if *includeGuilds {